@@ -181,7 +181,9 @@ func (n *devnetNode) run(ctx *cli.Context) error {
 		return err
 	}
 	n.nodeCfg = nodeConf
-	n.ethCfg = enode.NewEthConfigUrfave(ctx, n.nodeCfg, logger)
+	if n.ethCfg, err = enode.NewEthConfigUrfave(ctx, n.nodeCfg, logger); err != nil {
+		return err
+	}
 
 	// These are set to prevent disk and page size churn which can be excessive
 	// when running multiple nodes
@@ -30,6 +30,8 @@ import (
 
 	"github.com/erigontech/erigon/cmd/diag/db"
 	"github.com/erigontech/erigon/cmd/diag/downloader"
+	"github.com/erigontech/erigon/cmd/diag/nat"
+	"github.com/erigontech/erigon/cmd/diag/retire"
 	"github.com/erigontech/erigon/cmd/diag/stages"
 	sinfo "github.com/erigontech/erigon/cmd/diag/sysinfo"
 	"github.com/erigontech/erigon/cmd/diag/ui"
@@ -54,6 +56,8 @@ func main() {
 		&db.Command,
 		&ui.Command,
 		&sinfo.Command,
+		&retire.Command,
+		&nat.Command,
 	}
 
 	app.Flags = []cli.Flag{}
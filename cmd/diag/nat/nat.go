@@ -0,0 +1,116 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package nat
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/urfave/cli/v2"
+
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/typesproto"
+	"github.com/erigontech/erigon/cmd/diag/flags"
+	"github.com/erigontech/erigon/cmd/diag/util"
+)
+
+var Command = cli.Command{
+	Action:    printNATReport,
+	Name:      "nat",
+	Usage:     "Print NAT traversal diagnostics: mechanisms, port mappings and reachability of the p2p ports",
+	ArgsUsage: "",
+	Flags: []cli.Flag{
+		&flags.DebugURLFlag,
+		&flags.OutputFlag,
+	},
+	Description: ``,
+}
+
+func printNATReport(cliCtx *cli.Context) error {
+	var data remote.NodesInfoReply
+	url := "http://" + cliCtx.String(flags.DebugURLFlag.Name) + flags.ApiPath + "/nodeinfo"
+	if err := util.MakeHttpGetCall(cliCtx.Context, url, &data); err != nil {
+		util.RenderError(err)
+		return nil
+	}
+
+	switch cliCtx.String(flags.OutputFlag.Name) {
+	case "json":
+		reports := make(map[string]*typesproto.NatReport, len(data.NodesInfo))
+		for _, node := range data.NodesInfo {
+			reports[node.Enode] = node.Nat
+		}
+		util.RenderJson(reports)
+	case "text":
+		for _, node := range data.NodesInfo {
+			printNodeReport(node)
+		}
+	}
+
+	return nil
+}
+
+func printNodeReport(node *typesproto.NodeInfoReply) {
+	report := node.Nat
+	if report == nil {
+		fmt.Printf("%s: no NAT diagnostics\n\n", node.Enode)
+		return
+	}
+	iface := report.Interface
+	if iface == "" {
+		iface = "none"
+	}
+	fmt.Printf("Node: %s\nNAT: %s, external IP: %s, observed by peers: %s\n\n", node.Enode, iface, orDash(report.ExternalIp), orDash(report.ObservedIp))
+
+	rows := make([]table.Row, 0, len(report.Mechanisms))
+	for _, m := range report.Mechanisms {
+		rows = append(rows, table.Row{m.Name, m.Device, m.Available, orDash(m.ExternalIp), m.SupportsMapping, (time.Duration(m.LatencyMs) * time.Millisecond).String(), m.Error})
+	}
+	util.PrintTable(fmt.Sprintf("Mechanisms (probed %s):", formatTime(report.Probed)), table.Row{"Name", "Device", "Available", "External IP", "Mapping", "Latency", "Error"}, rows, nil)
+
+	rows = make([]table.Row, 0, len(report.Mappings))
+	for _, m := range report.Mappings {
+		rows = append(rows, table.Row{m.Protocol, m.InternalPort, m.ExternalPort, m.Mapped, formatTime(m.Expires), m.Renewals, m.Error})
+	}
+	util.PrintTable("Port mappings:", table.Row{"Protocol", "Port", "External port", "Mapped", "Expires", "Renewals", "Error"}, rows, nil)
+
+	rows = make([]table.Row, 0, len(report.Reachability))
+	for _, r := range report.Reachability {
+		rows = append(rows, table.Row{r.Protocol, r.Port, r.Reachable, formatTime(r.Since), formatTime(r.LastInbound)})
+	}
+	util.PrintTable("Reachability:", table.Row{"Protocol", "Port", "Reachable", "Listening since", "Last inbound"}, rows, nil)
+
+	for _, warning := range report.Warnings {
+		fmt.Println("WARNING:", warning)
+	}
+	fmt.Println()
+}
+
+func formatTime(unix int64) string {
+	if unix == 0 {
+		return "-"
+	}
+	return time.Unix(unix, 0).Format(time.DateTime)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
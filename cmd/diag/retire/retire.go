@@ -0,0 +1,90 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package retire
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/diagnostics"
+	"github.com/erigontech/erigon/cmd/diag/flags"
+	"github.com/erigontech/erigon/cmd/diag/util"
+)
+
+var Command = cli.Command{
+	Action:    printRetireJobs,
+	Name:      "retire",
+	Aliases:   []string{"rt"},
+	Usage:     "Print progress of `snapshots retire` jobs",
+	ArgsUsage: "",
+	Flags: []cli.Flag{
+		&flags.DebugURLFlag,
+		&flags.OutputFlag,
+	},
+	Description: ``,
+}
+
+func printRetireJobs(cliCtx *cli.Context) error {
+	var data diagnostics.SyncStatistics
+	url := "http://" + cliCtx.String(flags.DebugURLFlag.Name) + flags.ApiPath + "/snapshot-sync"
+	if err := util.MakeHttpGetCall(cliCtx.Context, url, &data); err != nil {
+		util.RenderError(err)
+		return nil
+	}
+
+	jobs := data.SnapshotRetire.Jobs
+
+	switch cliCtx.String(flags.OutputFlag.Name) {
+	case "json":
+		util.RenderJson(jobs)
+	case "text":
+		rows := make([]table.Row, 0, len(jobs))
+		for _, job := range jobs {
+			rows = append(rows, getJobRow(job))
+		}
+		util.PrintTable(
+			"Retire jobs:",
+			table.Row{"Type", "Job", "Range", "Status", "Progress", "Time", "Error"},
+			rows,
+			nil,
+		)
+	}
+
+	return nil
+}
+
+func getJobRow(job diagnostics.SnapshotRetireJob) table.Row {
+	progress := "-"
+	if job.Total > 0 {
+		progress = fmt.Sprintf("%d%%", job.Processed*100/job.Total)
+	} else if job.Processed > 0 {
+		progress = fmt.Sprintf("%d", job.Processed)
+	}
+
+	return table.Row{
+		job.Type,
+		job.Name,
+		fmt.Sprintf("%d-%d", job.From, job.To),
+		job.Status,
+		progress,
+		(time.Duration(job.TimeElapsed) * time.Second).String(),
+		job.Err,
+	}
+}
@@ -93,7 +93,10 @@ func runErigon(cliCtx *cli.Context) error {
 		return err
 	}
 
-	ethCfg := node.NewEthConfigUrfave(cliCtx, nodeCfg, logger)
+	ethCfg, err := node.NewEthConfigUrfave(cliCtx, nodeCfg, logger)
+	if err != nil {
+		return err
+	}
 
 	ethNode, err := node.New(cliCtx.Context, nodeCfg, ethCfg, logger)
 	if err != nil {
@@ -0,0 +1,84 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon/core/vm"
+)
+
+var EOFInitcodeFlag = cli.BoolFlag{
+	Name:  "initcode",
+	Usage: "validate the containers as initcode instead of runtime code",
+}
+
+var eofParseCommand = cli.Command{
+	Action:    eofParseCmd,
+	Name:      "eofparse",
+	Usage:     "parses and validates EOF containers, one hex-encoded container per line",
+	ArgsUsage: "<file>",
+	Flags: []cli.Flag{
+		&EOFInitcodeFlag,
+	},
+}
+
+func eofParseCmd(ctx *cli.Context) error {
+	var in io.Reader
+	switch {
+	case len(ctx.Args().First()) > 0:
+		f, err := os.Open(ctx.Args().First())
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	case ctx.IsSet(InputFlag.Name):
+		in = strings.NewReader(ctx.String(InputFlag.Name))
+	default:
+		in = os.Stdin
+	}
+
+	isInitcode := ctx.Bool(EOFInitcodeFlag.Name)
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		code, err := hex.DecodeString(strings.TrimPrefix(line, "0x"))
+		if err != nil {
+			fmt.Printf("err: invalid hex: %v\n", err)
+			continue
+		}
+		container, err := vm.ParseAndValidateEOF(code, isInitcode)
+		if err != nil {
+			fmt.Printf("err: %v\n", err)
+			continue
+		}
+		fmt.Printf("OK %v\n", container)
+	}
+	return scanner.Err()
+}
@@ -67,6 +67,7 @@ type stEnv struct {
 	Withdrawals      []*types.Withdrawal                    `json:"withdrawals,omitempty"`
 	WithdrawalsHash  *libcommon.Hash                        `json:"withdrawalsRoot,omitempty"`
 	RequestsHash     *libcommon.Hash                        `json:"requestsHash,omitempty"`
+	ExcessBlobGas    *uint64                                `json:"currentExcessBlobGas,omitempty"`
 }
 
 type stEnvMarshaling struct {
@@ -79,6 +80,7 @@ type stEnvMarshaling struct {
 	Timestamp        math.HexOrDecimal64
 	ParentTimestamp  math.HexOrDecimal64
 	BaseFee          *math.HexOrDecimal256
+	ExcessBlobGas    *math.HexOrDecimal64
 }
 
 func MakePreState(chainRules *chain.Rules, tx kv.RwTx, sd *state3.SharedDomains, accounts types.GenesisAlloc) (state.StateReader, state.WriterWithChangeSets) {
@@ -87,6 +87,10 @@ var (
 		Usage: "`stdin` or file name of where to find the transactions to apply.",
 		Value: "txs.json",
 	}
+	InputParliaFlag = cli.StringFlag{
+		Name:  "input.parlia",
+		Usage: "`stdin` or file name of where to find the Parlia snapshots and ancestor headers, required for BSC forks.",
+	}
 	ChainIDFlag = cli.Int64Flag{
 		Name:  "state.chainid",
 		Usage: "ChainID to use",
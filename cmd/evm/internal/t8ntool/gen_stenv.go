@@ -34,6 +34,7 @@ func (s stEnv) MarshalJSON() ([]byte, error) {
 		Withdrawals      []*types.Withdrawal                 `json:"withdrawals,omitempty"`
 		WithdrawalsHash  *common.Hash                        `json:"withdrawalsRoot,omitempty"`
 		RequestsHash     *common.Hash                        `json:"requestsHash,omitempty"`
+		ExcessBlobGas    *math.HexOrDecimal64                `json:"currentExcessBlobGas,omitempty"`
 	}
 	var enc stEnv
 	enc.Coinbase = common.UnprefixedAddress(s.Coinbase)
@@ -53,6 +54,7 @@ func (s stEnv) MarshalJSON() ([]byte, error) {
 	enc.Withdrawals = s.Withdrawals
 	enc.WithdrawalsHash = s.WithdrawalsHash
 	enc.RequestsHash = s.RequestsHash
+	enc.ExcessBlobGas = (*math.HexOrDecimal64)(s.ExcessBlobGas)
 	return json.Marshal(&enc)
 }
 
@@ -76,6 +78,7 @@ func (s *stEnv) UnmarshalJSON(input []byte) error {
 		Withdrawals      []*types.Withdrawal                 `json:"withdrawals,omitempty"`
 		WithdrawalsHash  *common.Hash                        `json:"withdrawalsRoot,omitempty"`
 		RequestsHash     *common.Hash                        `json:"requestsHash,omitempty"`
+		ExcessBlobGas    *math.HexOrDecimal64                `json:"currentExcessBlobGas,omitempty"`
 	}
 	var dec stEnv
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -136,5 +139,8 @@ func (s *stEnv) UnmarshalJSON(input []byte) error {
 	if dec.RequestsHash != nil {
 		s.RequestsHash = dec.RequestsHash
 	}
+	if dec.ExcessBlobGas != nil {
+		s.ExcessBlobGas = (*uint64)(dec.ExcessBlobGas)
+	}
 	return nil
 }
@@ -0,0 +1,322 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package t8ntool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
+	libstate "github.com/erigontech/erigon-lib/state"
+
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/consensus/misc"
+	"github.com/erigontech/erigon/consensus/parlia"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/systemcontracts"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/turbo/rpchelper"
+)
+
+// diffInTurn is the difficulty of a block signed by the in-turn validator.
+var diffInTurn = big.NewInt(2)
+
+// parliaInput is the consensus state Parlia needs to run a block, which can't
+// be derived from the alloc: the validator set snapshots, at least the one of
+// the parent block, and the ancestor headers. The parent header can be left
+// out, it is then built from the env.
+type parliaInput struct {
+	Snapshots []*parlia.Snapshot `json:"snapshots"`
+	Headers   []*types.Header    `json:"headers,omitempty"`
+}
+
+func readParliaInput(file string, stdinInput *parliaInput) (*parliaInput, error) {
+	if file == "" {
+		return nil, NewError(ErrorVMConfig, fmt.Errorf("Parlia config but missing '--%s'", InputParliaFlag.Name))
+	}
+	if file == stdinSelector {
+		if stdinInput == nil {
+			return nil, NewError(ErrorVMConfig, errors.New("Parlia config but missing 'parlia' in stdin input"))
+		}
+		return stdinInput, nil
+	}
+	inFile, err := os.Open(file)
+	if err != nil {
+		return nil, NewError(ErrorIO, fmt.Errorf("failed reading parlia file: %v", err))
+	}
+	defer inFile.Close()
+	input := new(parliaInput)
+	if err := json.NewDecoder(inFile).Decode(input); err != nil {
+		return nil, NewError(ErrorJson, fmt.Errorf("failed unmarshaling parlia-file: %v", err))
+	}
+	return input, nil
+}
+
+// parliaEngine runs the Parlia engine over the block, with the system
+// transactions of the block applied by the engine one by one, as in the
+// execution stage.
+type parliaEngine struct {
+	*parlia.Parlia
+	config  *chain.Config
+	chain   *parliaChain
+	getHash func(uint64) libcommon.Hash
+	logger  log.Logger
+}
+
+func newParliaEngine(config *chain.Config, input *parliaInput, env *stEnv, getHash func(uint64) libcommon.Hash, logger log.Logger) (*parliaEngine, error) {
+	engine := parlia.New(config, memdb.New(os.TempDir(), kv.ConsensusDB), nil /* blobStore */, nil /* blockReader */, logger)
+	for _, snap := range input.Snapshots {
+		if err := engine.ImportSnapshot(snap); err != nil {
+			return nil, err
+		}
+	}
+	c, err := newParliaChain(config, input, env)
+	if err != nil {
+		return nil, err
+	}
+	return &parliaEngine{Parlia: engine, config: config, chain: c, getHash: getHash, logger: logger}, nil
+}
+
+// execute runs the block over the prestate. The state is read from and
+// committed to the shared domains, which are then flushed to tx for the dump.
+func (p *parliaEngine) execute(tx kv.RwTx, sd *libstate.SharedDomains, block *types.Block, vmConfig *vm.Config,
+	getTracer func(txIndex int, txHash libcommon.Hash) (vm.EVMLogger, error),
+) (*core.EphemeralExecResult, error) {
+	header := block.Header()
+	blockNum := header.Number.Uint64()
+	reader, writer := rpchelper.NewLatestDomainStateReader(sd), state.NewWriterV4(sd)
+	ibs := state.New(reader)
+	if err := p.initialize(header, ibs); err != nil {
+		return nil, fmt.Errorf("parlia initialize: %w", err)
+	}
+	if err := ibs.FinalizeTx(p.config.Rules(blockNum, header.Time), writer); err != nil {
+		return nil, err
+	}
+	result, err := core.ExecuteBlockEphemerallyForBSC(p.config, vmConfig, p.getHash, p, block, reader, writer, p.chain, getTracer, p.logger)
+	if err != nil {
+		return nil, err
+	}
+	root, err := sd.ComputeCommitment(context.Background(), true, blockNum, "")
+	if err != nil {
+		return nil, fmt.Errorf("ComputeCommitment: %w", err)
+	}
+	result.StateRoot = libcommon.BytesToHash(root)
+	if err := sd.Flush(context.Background(), tx); err != nil {
+		return nil, err
+	}
+	if err := rawdbv3.TxNums.Append(tx, blockNum, sd.TxNum()); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// initialize applies the state changes preceding the transactions of the block.
+func (p *parliaEngine) initialize(header *types.Header, ibs *state.IntraBlockState) error {
+	parentTime := p.chain.parent.Time
+	if !p.config.IsFeynman(header.Number.Uint64(), header.Time) {
+		systemcontracts.UpgradeBuildInSystemContract(p.config, header.Number, parentTime, header.Time, ibs, p.logger)
+	}
+	// HistoryStorageAddress is a special system contract in bsc, which can't be upgraded
+	if p.config.IsOnPrague(header.Number, parentTime, header.Time) {
+		misc.InitializeBlockHashesEip2935(ibs)
+	}
+	syscall := func(contract libcommon.Address, data []byte, ibs *state.IntraBlockState, header *types.Header, constCall bool) ([]byte, error) {
+		return core.SysCallContract(contract, data, p.config, ibs, header, p, constCall)
+	}
+	return p.Parlia.Initialize(p.config, p.chain, header, ibs, syscall, p.logger, nil)
+}
+
+// Finalize hands the system transactions of the block over to Parlia, which
+// checks each of them against the one it expects and applies it. They are
+// returned along with their receipts after the included user transactions.
+func (p *parliaEngine) Finalize(config *chain.Config, header *types.Header, ibs *state.IntraBlockState,
+	txs types.Transactions, uncles []*types.Header, receipts types.Receipts, withdrawals []*types.Withdrawal,
+	_ consensus.ChainReader, syscall consensus.SystemCall, skipReceiptsEval bool, _ consensus.SystemTxCall, _ int, logger log.Logger,
+) (types.Transactions, types.Receipts, types.FlatRequests, error) {
+	included := make(map[libcommon.Hash]struct{}, len(receipts))
+	for _, receipt := range receipts {
+		included[receipt.TxHash] = struct{}{}
+	}
+	outTxs := make(types.Transactions, 0, len(txs))
+	var systemTxs []int
+	for i, txn := range txs {
+		isSystemTx, err := p.IsSystemTransaction(txn, header)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if isSystemTx {
+			systemTxs = append(systemTxs, i)
+		} else if _, ok := included[txn.Hash()]; ok {
+			outTxs = append(outTxs, txn)
+		}
+	}
+	// Is an empty block
+	if len(txs) == 0 && p.config.IsFeynman(header.Number.Uint64(), header.Time) {
+		systemcontracts.UpgradeBuildInSystemContract(p.config, header.Number, p.chain.parent.Time, header.Time, ibs, p.logger)
+	}
+	for _, i := range systemTxs {
+		applied := false
+		systemTxCall := func(ibs *state.IntraBlockState) ([]byte, bool, error) {
+			applied = true
+			return p.applySystemTx(txs[i], i, header, ibs, &outTxs, &receipts)
+		}
+		if _, _, _, err := p.Parlia.Finalize(config, header, ibs, txs, uncles, receipts, withdrawals, p.chain, syscall, skipReceiptsEval, systemTxCall, i, logger); err != nil {
+			return nil, nil, nil, err
+		}
+		if !applied {
+			return nil, nil, nil, fmt.Errorf("system transaction %d (%s) is not expected by parlia", i, txs[i].Hash())
+		}
+	}
+	return outTxs, receipts, nil, nil
+}
+
+// applySystemTx executes a system transaction the way the execution workers
+// do: it is free, and the nonce of the validator is bumped by the engine.
+func (p *parliaEngine) applySystemTx(txn types.Transaction, txIndex int, header *types.Header, ibs *state.IntraBlockState,
+	txs *types.Transactions, receipts *types.Receipts,
+) ([]byte, bool, error) {
+	blockNum := header.Number.Uint64()
+	rules := p.config.Rules(blockNum, header.Time)
+	msg, err := txn.AsMessage(*types.MakeSigner(p.config, blockNum, header.Time), header.BaseFee, rules)
+	if err != nil {
+		return nil, false, err
+	}
+	ibs.SetTxContext(txIndex, blockNum)
+	blockContext := core.NewEVMBlockContext(header, p.getHash, p, nil /* author */, p.config)
+	if rules.IsCancun {
+		ibs.Prepare(rules, msg.From(), blockContext.Coinbase, msg.To(), vm.ActivePrecompiles(rules), msg.AccessList(), nil)
+	}
+	evm := vm.NewEVM(blockContext, core.NewEVMTxContext(msg), ibs, p.config, vm.Config{})
+	nonce, err := ibs.GetNonce(msg.From())
+	if err != nil {
+		return nil, false, err
+	}
+	if err := ibs.SetNonce(msg.From(), nonce+1); err != nil {
+		return nil, false, err
+	}
+	ret, leftOverGas, err := evm.Call(vm.AccountRef(msg.From()), *msg.To(), msg.Data(), msg.Gas(), msg.Value(), false)
+	if err != nil {
+		return nil, false, fmt.Errorf("system transaction %d: %w", txIndex, err)
+	}
+	if err := ibs.FinalizeTx(rules, state.NewNoopWriter()); err != nil {
+		return nil, false, err
+	}
+	gasUsed := msg.Gas() - leftOverGas
+	header.GasUsed += gasUsed
+
+	receipt := &types.Receipt{
+		Type:              txn.Type(),
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: header.GasUsed,
+		TxHash:            txn.Hash(),
+		GasUsed:           gasUsed,
+		BlockNumber:       new(big.Int).Set(header.Number),
+		TransactionIndex:  uint(len(*txs)),
+	}
+	receipt.Logs = ibs.GetLogs(txIndex, txn.Hash(), blockNum, libcommon.Hash{})
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	*txs = append(*txs, txn)
+	*receipts = append(*receipts, receipt)
+	return ret, true, nil
+}
+
+// parliaChain serves the parent and the ancestors of the block to the engine.
+type parliaChain struct {
+	config     *chain.Config
+	parent     *types.Header
+	parentHash libcommon.Hash // hash of the parent snapshot, a parent built from the env doesn't hash to it
+	byHash     map[libcommon.Hash]*types.Header
+	byNumber   map[uint64]*types.Header
+}
+
+// newParliaChain finds the parent of the block by its snapshot, if its header
+// isn't provided it is built from the env.
+func newParliaChain(config *chain.Config, input *parliaInput, env *stEnv) (*parliaChain, error) {
+	if env.Number == 0 {
+		return nil, NewError(ErrorVMConfig, errors.New("Parlia config but currentNumber is 0"))
+	}
+	c := &parliaChain{
+		config:   config,
+		byHash:   map[libcommon.Hash]*types.Header{},
+		byNumber: map[uint64]*types.Header{},
+	}
+	for _, h := range input.Headers {
+		c.byHash[h.Hash()] = h
+		c.byNumber[h.Number.Uint64()] = h
+	}
+	for _, snap := range input.Snapshots {
+		if snap.Number == env.Number-1 {
+			c.parentHash = snap.Hash
+		}
+	}
+	if c.parentHash == (libcommon.Hash{}) {
+		return nil, NewError(ErrorVMConfig, fmt.Errorf("no parlia snapshot of the parent block %d", env.Number-1))
+	}
+	if c.parent = c.byHash[c.parentHash]; c.parent == nil {
+		difficulty := env.ParentDifficulty
+		if difficulty == nil {
+			difficulty = diffInTurn
+		}
+		c.parent = &types.Header{
+			Number:     new(big.Int).SetUint64(env.Number - 1),
+			Time:       env.ParentTimestamp,
+			Difficulty: new(big.Int).Set(difficulty),
+			UncleHash:  env.ParentUncleHash,
+		}
+		c.byHash[c.parentHash] = c.parent
+		c.byNumber[env.Number-1] = c.parent
+	}
+	return c, nil
+}
+
+func (c *parliaChain) Config() *chain.Config                    { return c.config }
+func (c *parliaChain) CurrentHeader() *types.Header             { return c.parent }
+func (c *parliaChain) CurrentFinalizedHeader() *types.Header    { return nil }
+func (c *parliaChain) CurrentSafeHeader() *types.Header         { return nil }
+func (c *parliaChain) GetHeaderByNumber(n uint64) *types.Header { return c.byNumber[n] }
+func (c *parliaChain) GetHeaderByHash(hash libcommon.Hash) *types.Header {
+	return c.byHash[hash]
+}
+func (c *parliaChain) GetHeader(hash libcommon.Hash, number uint64) *types.Header {
+	if h := c.byHash[hash]; h != nil && h.Number.Uint64() == number {
+		return h
+	}
+	return nil
+}
+func (c *parliaChain) GetTd(hash libcommon.Hash, number uint64) *big.Int { return nil }
+func (c *parliaChain) FrozenBlocks() uint64                              { return 0 }
+func (c *parliaChain) FrozenBorBlocks() uint64                           { return 0 }
+func (c *parliaChain) GetBlock(hash libcommon.Hash, number uint64) *types.Block {
+	return nil
+}
+func (c *parliaChain) HasBlock(hash libcommon.Hash, number uint64) bool { return false }
+func (c *parliaChain) BorEventsByBlock(hash libcommon.Hash, number uint64) []rlp.RawValue {
+	return nil
+}
+func (c *parliaChain) BorStartEventId(hash libcommon.Hash, number uint64) uint64 { return 0 }
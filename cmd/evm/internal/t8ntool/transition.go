@@ -95,6 +95,8 @@ type input struct {
 	Alloc types.GenesisAlloc `json:"alloc,omitempty"`
 	Env   *stEnv             `json:"env,omitempty"`
 	Txs   []*txWithKey       `json:"txs,omitempty"`
+	// Parlia is only read for the BSC forks
+	Parlia *parliaInput `json:"parlia,omitempty"`
 }
 
 func Main(ctx *cli.Context) error {
@@ -156,10 +158,11 @@ func Main(ctx *cli.Context) error {
 
 		envStr    = ctx.String(InputEnvFlag.Name)
 		txStr     = ctx.String(InputTxsFlag.Name)
+		parliaStr = ctx.String(InputParliaFlag.Name)
 		inputData = &input{}
 	)
 	// Figure out the prestate alloc
-	if allocStr == stdinSelector || envStr == stdinSelector || txStr == stdinSelector {
+	if allocStr == stdinSelector || envStr == stdinSelector || txStr == stdinSelector || parliaStr == stdinSelector {
 		decoder := json.NewDecoder(os.Stdin)
 		decoder.Decode(inputData) //nolint:errcheck
 	}
@@ -240,13 +243,20 @@ func Main(ctx *cli.Context) error {
 		prestate.Env.Random = nil
 	}
 
-	if chainConfig.IsShanghai(prestate.Env.Number, prestate.Env.Timestamp) && prestate.Env.Withdrawals == nil {
+	isParlia := chainConfig.Parlia != nil
+	if !isParlia && chainConfig.IsShanghai(prestate.Env.Number, prestate.Env.Timestamp) && prestate.Env.Withdrawals == nil {
 		return NewError(ErrorVMConfig, errors.New("Shanghai config but missing 'withdrawals' in env section"))
 	}
 
 	isMerged := chainConfig.TerminalTotalDifficulty != nil && chainConfig.TerminalTotalDifficulty.BitLen() == 0
 	env := prestate.Env
-	if isMerged {
+	if isParlia {
+		// Parlia blocks carry no randomness, and the difficulty only tells
+		// whether the block was signed in turn
+		if env.Difficulty == nil {
+			prestate.Env.Difficulty = new(big.Int).Set(diffInTurn)
+		}
+	} else if isMerged {
 		// post-merge:
 		// - random must be supplied
 		// - difficulty must be zero
@@ -272,18 +282,6 @@ func Main(ctx *cli.Context) error {
 			env.ParentTimestamp, env.ParentDifficulty, env.ParentUncleHash)
 	}
 
-	// manufacture block from above inputs
-	header := NewHeader(prestate.Env)
-
-	var ommerHeaders = make([]*types.Header, len(prestate.Env.Ommers))
-	header.Number.Add(header.Number, big.NewInt(int64(len(prestate.Env.Ommers))))
-	for i, ommer := range prestate.Env.Ommers {
-		var ommerN big.Int
-		ommerN.SetUint64(header.Number.Uint64() - ommer.Delta)
-		ommerHeaders[i] = &types.Header{Coinbase: ommer.Address, Number: &ommerN}
-	}
-	block := types.NewBlock(header, txs, ommerHeaders, nil /* receipts */, prestate.Env.Withdrawals)
-
 	var hashError error
 	getHash := func(num uint64) libcommon.Hash {
 		if prestate.Env.BlockHashes == nil {
@@ -297,6 +295,32 @@ func Main(ctx *cli.Context) error {
 		return h
 	}
 
+	t8logger := log.New("t8ntool")
+
+	// manufacture block from above inputs
+	header := NewHeader(prestate.Env)
+	var parliaEng *parliaEngine
+	if isParlia {
+		parliaIn, err := readParliaInput(parliaStr, inputData.Parlia)
+		if err != nil {
+			return err
+		}
+		if parliaEng, err = newParliaEngine(chainConfig, parliaIn, &prestate.Env, getHash, t8logger); err != nil {
+			return err
+		}
+		// the parent is whatever the snapshot was taken at
+		header.ParentHash = parliaEng.chain.parentHash
+	}
+
+	var ommerHeaders = make([]*types.Header, len(prestate.Env.Ommers))
+	header.Number.Add(header.Number, big.NewInt(int64(len(prestate.Env.Ommers))))
+	for i, ommer := range prestate.Env.Ommers {
+		var ommerN big.Int
+		ommerN.SetUint64(header.Number.Uint64() - ommer.Delta)
+		ommerHeaders[i] = &types.Header{Coinbase: ommer.Address, Number: &ommerN}
+	}
+	block := types.NewBlock(header, txs, ommerHeaders, nil /* receipts */, prestate.Env.Withdrawals)
+
 	db, agg := temporaltest.NewTestDB(nil, datadir.New(""))
 	defer db.Close()
 	defer agg.Close()
@@ -314,13 +338,17 @@ func Main(ctx *cli.Context) error {
 	defer sd.Close()
 
 	reader, writer := MakePreState(chainConfig.Rules(0, 0), tx, sd, prestate.Pre)
-	// Merge engine can be used for pre-merge blocks as well, as it
-	// redirects to the ethash engine based on the block number
-	engine := merge.New(&ethash.FakeEthash{})
 
-	t8logger := log.New("t8ntool")
-	chainReader := consensuschain.NewReader(chainConfig, tx, nil, t8logger)
-	result, err := core.ExecuteBlockEphemerally(chainConfig, &vmConfig, getHash, engine, block, reader, writer, chainReader, getTracer, t8logger)
+	var result *core.EphemeralExecResult
+	if isParlia {
+		result, err = parliaEng.execute(tx, sd, block, &vmConfig, getTracer)
+	} else {
+		chainReader := consensuschain.NewReader(chainConfig, tx, nil, t8logger)
+		// Merge engine can be used for pre-merge blocks as well, as it
+		// redirects to the ethash engine based on the block number
+		engine := merge.New(&ethash.FakeEthash{})
+		result, err = core.ExecuteBlockEphemerally(chainConfig, &vmConfig, getHash, engine, block, reader, writer, chainReader, getTracer, t8logger)
+	}
 	if hashError != nil {
 		return NewError(ErrorMissingBlockhash, fmt.Errorf("blockhash error: %v", err))
 	}
@@ -330,11 +358,13 @@ func Main(ctx *cli.Context) error {
 	}
 
 	// state root calculation
-	root, err := CalculateStateRoot(tx)
-	if err != nil {
-		return err
+	if !isParlia {
+		root, err := CalculateStateRoot(tx)
+		if err != nil {
+			return err
+		}
+		result.StateRoot = *root
 	}
-	result.StateRoot = *root
 
 	// Dump the execution result
 	body, _ := rlp.EncodeToBytes(txs)
@@ -617,6 +647,7 @@ func NewHeader(env stEnv) *types.Header {
 	header.UncleHash = env.UncleHash
 	header.WithdrawalsHash = env.WithdrawalsHash
 	header.RequestsHash = env.RequestsHash
+	header.ExcessBlobGas = env.ExcessBlobGas
 
 	return &header
 }
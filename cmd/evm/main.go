@@ -152,6 +152,7 @@ var stateTransitionCommand = cli.Command{
 		&t8ntool.InputAllocFlag,
 		&t8ntool.InputEnvFlag,
 		&t8ntool.InputTxsFlag,
+		&t8ntool.InputParliaFlag,
 		&t8ntool.ForknameFlag,
 		&t8ntool.ChainIDFlag,
 		&t8ntool.VerbosityFlag,
@@ -187,6 +188,7 @@ func init() {
 	app.Commands = []*cli.Command{
 		&compileCommand,
 		&disasmCommand,
+		&eofParseCommand,
 		&runCommand,
 		&stateTestCommand,
 		&stateTransitionCommand,
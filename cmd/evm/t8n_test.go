@@ -110,6 +110,7 @@ func TestT8n(t *testing.T) {
 	for i, tc := range []struct {
 		base        string
 		input       t8nInput
+		args        []string // extra flags
 		output      t8nOutput
 		expExitCode int
 		expOut      string
@@ -216,11 +217,29 @@ func TestT8n(t *testing.T) {
 			expOut: "exp.json",
 			output: t8nOutput{alloc: true, result: true},
 		},
+		{ // Parlia system transactions
+			base: "./testdata/30",
+			input: t8nInput{
+				"alloc.json", "txs.json", "env.json", "Pascal",
+			},
+			args:   []string{"--input.parlia", "./testdata/30/parlia.json"},
+			expOut: "exp.json",
+			output: t8nOutput{alloc: true, result: true},
+		},
+		{ // Test exit (3) on Parlia without snapshots
+			base: "./testdata/30",
+			input: t8nInput{
+				"alloc.json", "txs.json", "env.json", "Pascal",
+			},
+			output:      t8nOutput{alloc: true, result: true},
+			expExitCode: 3,
+		},
 	} {
 
 		args := []string{"t8n"}
 		args = append(args, tc.output.get()...)
 		args = append(args, tc.input.get(tc.base)...)
+		args = append(args, tc.args...)
 		var qArgs []string // quoted args for debugging purposes
 		for _, arg := range args {
 			if len(arg) == 0 {
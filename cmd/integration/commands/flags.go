@@ -39,7 +39,9 @@ var (
 	integrityFast, integritySlow bool
 	file                         string
 	HeimdallURL                  string
-	txtrace                      bool // Whether to trace the execution (should only be used together with `block`)
+	txtrace                      bool   // Whether to trace the execution (should only be used together with `block`)
+	profileFile                  string // Where to write the opcode and precompile profile of the execution
+	profileRange                 uint64 // Number of blocks aggregated in a single profile
 	unwindTypes                  []string
 	chain                        string // Which chain to use (mainnet, sepolia, etc.)
 	outputCsvFile                string
@@ -160,6 +162,11 @@ func withTxTrace(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&txtrace, "txtrace", false, "enable tracing of transactions")
 }
 
+func withProfile(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&profileFile, "profile", "", "profile opcodes and precompiles of the executed blocks and write the result as json to the given file")
+	cmd.Flags().Uint64Var(&profileRange, "profile.range", 0, "number of blocks aggregated per profile, 0 aggregates the whole execution")
+}
+
 func withChain(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&chain, "chain", "mainnet", "pick a chain to assume (mainnet, sepolia, etc.)")
 	must(cmd.MarkFlagRequired("chain"))
@@ -171,6 +178,9 @@ func withHeimdall(cmd *cobra.Command) {
 
 func withWorkers(cmd *cobra.Command) {
 	cmd.Flags().IntVar(&syncCfg.ExecWorkerCount, "exec.workers", ethconfig.Defaults.Sync.ExecWorkerCount, "")
+	cmd.Flags().BoolVar(&syncCfg.ParallelExec, "exec.parallel", false, "execute txs of block in parallel by --exec.workers")
+	cmd.Flags().BoolVar(&syncCfg.ExecPrefetch, "exec.prefetch", false, "warm up the state of the blocks by speculatively executing them ahead of the executor")
+	cmd.Flags().Float64Var(&syncCfg.ExecPrefetchCPUBudget, "exec.prefetch.cpu-budget", ethconfig.Defaults.Sync.ExecPrefetchCPUBudget, "CPU cores the state prefetcher may use")
 }
 
 func withStartTx(cmd *cobra.Command) {
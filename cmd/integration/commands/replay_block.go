@@ -0,0 +1,224 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/stagedsync"
+	"github.com/erigontech/erigon/eth/tracers"
+	_ "github.com/erigontech/erigon/eth/tracers/js"
+	_ "github.com/erigontech/erigon/eth/tracers/native"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/replay"
+	"github.com/erigontech/erigon/turbo/rpchelper"
+)
+
+var (
+	recordTo     uint64
+	replayTracer string
+	replayConfig string
+	replayOut    string
+)
+
+func init() {
+	withDataDir(cmdRecordBlock)
+	withChain(cmdRecordBlock)
+	withBlock(cmdRecordBlock)
+	withFile(cmdRecordBlock)
+	cmdRecordBlock.Flags().Uint64Var(&recordTo, "to", 0, "record the range from --block up to this block (inclusive), defaults to --block")
+	rootCmd.AddCommand(cmdRecordBlock)
+
+	withFile(cmdReplayBlock)
+	cmdReplayBlock.Flags().StringVar(&replayTracer, "tracer", "", "name of the tracer to run every transaction with, e.g. callTracer, or a js tracer")
+	cmdReplayBlock.Flags().StringVar(&replayConfig, "tracer.config", "", "json config of the tracer")
+	cmdReplayBlock.Flags().StringVar(&replayOut, "out", "", "write the tracer results to this file instead of stdout")
+	rootCmd.AddCommand(cmdReplayBlock)
+}
+
+var cmdRecordBlock = &cobra.Command{
+	Use:     "record_block",
+	Short:   "Records the state, block hashes and headers the execution of blocks reads into --file",
+	Example: "integration record_block --datadir=<datadir> --block=<n> [--to=<m>] --file=block.rec",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := debug.SetupCobra(cmd, "integration")
+		db, err := openDB(dbCfg(kv.ChainDB, chaindata), true, logger)
+		if err != nil {
+			logger.Error("Opening DB", "error", err)
+			return
+		}
+		defer db.Close()
+
+		if err := recordBlocks(cmd.Context(), db, block, recordTo, file, logger); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error(err.Error())
+			}
+			return
+		}
+	},
+}
+
+var cmdReplayBlock = &cobra.Command{
+	Use:     "replay_block",
+	Short:   "Re-executes the blocks recorded by record_block, reading nothing but the recording",
+	Example: "integration replay_block --file=block.rec --tracer=callTracer",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := debug.SetupCobra(cmd, "integration")
+		if err := replayBlocks(file, replayTracer, replayConfig, replayOut, logger); err != nil {
+			logger.Error(err.Error())
+			return
+		}
+	},
+}
+
+func recordBlocks(ctx context.Context, db kv.TemporalRwDB, from, to uint64, path string, logger log.Logger) error {
+	if to < from {
+		to = from
+	}
+	chainConfig := fromdb.ChainConfig(db)
+	br, _ := blocksIO(db, logger)
+	engine, _ := initConsensusEngine(ctx, chainConfig, datadirCli, db, br, logger)
+
+	tx, err := db.BeginTemporalRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	txNumsReader := br.TxnumReader(ctx)
+	chainReader := stagedsync.ChainReader{Cfg: *chainConfig, Db: tx, BlockReader: br, Logger: logger}
+	rec := &replay.Recording{ChainConfig: chainConfig}
+	for n := from; n <= to; n++ {
+		b, err := br.BlockByNumber(ctx, tx, n)
+		if err != nil {
+			return err
+		}
+		if b == nil {
+			return fmt.Errorf("block %d not found", n)
+		}
+		stateReader, err := rpchelper.CreateHistoryStateReader(tx, txNumsReader, n, 0, chainConfig.ChainName)
+		if err != nil {
+			return err
+		}
+		blockRec, err := replay.RecordBlock(chainConfig, engine, chainReader, b, stateReader, logger)
+		if err != nil {
+			return fmt.Errorf("block %d: %w", n, err)
+		}
+		if blockRec.Err != "" {
+			logger.Warn("Recorded block execution failed", "block", n, "err", blockRec.Err)
+		}
+		logger.Info("Recorded block", "block", n, "accounts", len(blockRec.Reads.Accounts), "storage", len(blockRec.Reads.Storage),
+			"code", len(blockRec.Reads.Code), "blockHashes", len(blockRec.BlockHashes))
+		rec.Blocks = append(rec.Blocks, blockRec)
+	}
+	if err := replay.WriteFile(path, rec); err != nil {
+		return err
+	}
+	logger.Info("Recording written", "file", path, "blocks", len(rec.Blocks))
+	return nil
+}
+
+// traceResult is the output of a tracer for one transaction of the replay.
+type traceResult struct {
+	Block  uint64          `json:"block"`
+	TxHash libcommon.Hash  `json:"txHash"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+type txTracer struct {
+	txHash libcommon.Hash
+	tracer tracers.Tracer
+}
+
+func replayBlocks(path, tracerName, tracerConfig, out string, logger log.Logger) error {
+	rec, err := replay.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var results []traceResult
+	for _, blockRec := range rec.Blocks {
+		b := blockRec.Block
+		var txTracers []txTracer
+		var getTracer func(int, libcommon.Hash) (vm.EVMLogger, error)
+		if tracerName != "" {
+			getTracer = func(txIndex int, txHash libcommon.Hash) (vm.EVMLogger, error) {
+				t, err := tracers.New(tracerName, &tracers.Context{BlockHash: b.Hash(), TxIndex: txIndex, TxHash: txHash}, json.RawMessage(tracerConfig))
+				if err != nil {
+					return nil, err
+				}
+				txTracers = append(txTracers, txTracer{txHash: txHash, tracer: t})
+				return t, nil
+			}
+		}
+
+		res, err := replay.ReplayBlock(rec.ChainConfig, blockRec, getTracer, logger)
+		switch {
+		case err != nil && blockRec.Err == "":
+			logger.Error("Replay failed, the recorded execution did not", "block", b.NumberU64(), "err", err)
+		case err != nil:
+			logger.Info("Replay failed as recorded", "block", b.NumberU64(), "err", err, "recorded", blockRec.Err)
+		case blockRec.Err != "":
+			logger.Error("Replay succeeded, the recorded execution did not", "block", b.NumberU64(), "recorded", blockRec.Err)
+		case res.ReceiptRoot != b.ReceiptHash() || uint64(res.GasUsed) != b.GasUsed():
+			logger.Error("Replay mismatch", "block", b.NumberU64(), "receiptRoot", res.ReceiptRoot, "expectedReceiptRoot", b.ReceiptHash(),
+				"gasUsed", uint64(res.GasUsed), "expectedGasUsed", b.GasUsed())
+		default:
+			logger.Info("Replayed block", "block", b.NumberU64(), "txs", len(b.Transactions()), "gasUsed", uint64(res.GasUsed))
+		}
+
+		for _, t := range txTracers {
+			r := traceResult{Block: b.NumberU64(), TxHash: t.txHash}
+			result, err := t.tracer.GetResult()
+			if err != nil {
+				r.Error = err.Error()
+			} else {
+				r.Result = result
+			}
+			results = append(results, r)
+		}
+	}
+	if tracerName == "" {
+		return nil
+	}
+
+	w := os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
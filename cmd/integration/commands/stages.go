@@ -19,6 +19,7 @@ package commands
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -66,6 +67,7 @@ import (
 	"github.com/erigontech/erigon/eth/integrity"
 	"github.com/erigontech/erigon/eth/stagedsync"
 	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/eth/tracers/profiler"
 	"github.com/erigontech/erigon/ethdb/prune"
 	"github.com/erigontech/erigon/migrations"
 	"github.com/erigontech/erigon/node/nodecfg"
@@ -510,6 +512,7 @@ func init() {
 	withPruneTo(cmdStageExec)
 	withBatchSize(cmdStageExec)
 	withTxTrace(cmdStageExec)
+	withProfile(cmdStageExec)
 	withChain(cmdStageExec)
 	withHeimdall(cmdStageExec)
 	withWorkers(cmdStageExec)
@@ -887,6 +890,12 @@ func stageExec(db kv.TemporalRwDB, ctx context.Context, logger log.Logger) error
 		vmConfig.Tracer = nil
 		vmConfig.Debug = true
 	}
+	if profileFile != "" {
+		prof := profiler.New(profileRange)
+		vmConfig.Tracer = prof.Logger()
+		vmConfig.Debug = true
+		defer writeProfile(prof, profileFile, logger)
+	}
 
 	var batchSize datasize.ByteSize
 	must(batchSize.UnmarshalText([]byte(batchSizeStr)))
@@ -1024,6 +1033,20 @@ func stageExec(db kv.TemporalRwDB, ctx context.Context, logger log.Logger) error
 	return nil
 }
 
+// writeProfile writes the profiles collected during the execution to a json file.
+func writeProfile(prof *profiler.Profiler, fileName string, logger log.Logger) {
+	profiles := prof.Profiles()
+	data, err := json.MarshalIndent(profiles, "", "  ")
+	if err == nil {
+		err = os.WriteFile(fileName, data, 0644)
+	}
+	if err != nil {
+		logger.Error("Writing execution profile", "file", fileName, "err", err)
+		return
+	}
+	logger.Info("Execution profile written", "file", fileName, "ranges", len(profiles))
+}
+
 func stageCustomTrace(db kv.TemporalRwDB, ctx context.Context, logger log.Logger) error {
 	dirs := datadir.New(datadirCli)
 	if err := datadir.ApplyMigrations(dirs); err != nil {
@@ -1426,7 +1449,7 @@ func newSync(ctx context.Context, db kv.TemporalRwDB, miningConfig *params.Minin
 	borSn.DownloadComplete() // mark as ready
 	blockRetire := freezeblocks.NewBlockRetire(estimate.CompressSnapshot.Workers(), dirs, blockReader, blockWriter, db, blobStore, heimdallStore, bridgeStore, chainConfig, &cfg, notifications.Events, blockSnapBuildSema, logger)
 
-	stageList := stages2.NewDefaultStages(context.Background(), db, snapDb, blobStore, p2p.Config{}, &cfg, sentryControlServer, notifications, nil, blockReader, blockRetire, nil, nil,
+	stageList := stages2.NewDefaultStages(context.Background(), db, snapDb, blobStore, p2p.Config{}, &cfg, sentryControlServer, notifications, nil, nil, blockReader, blockRetire, nil, nil,
 		engine, heimdallClient, heimdallStore, bridgeStore, recents, signatures, logger)
 	sync := stagedsync.New(cfg.Sync, stageList, stagedsync.DefaultUnwindOrder, stagedsync.DefaultPruneOrder, logger, stages.ModeApplyingBlocks)
 
@@ -36,6 +36,30 @@ type NodeAddr struct {
 	IPv6 NodeAddr1
 }
 
+// BscHistory is the history advertised by a BSC node in the `bsc` ENR entry.
+type BscHistory struct {
+	Chain       string
+	HistoryFrom uint64
+	BlobsFrom   uint64
+}
+
+// EthStatus is the fork ID and the BSC status extension a node sent in the eth handshake.
+type EthStatus struct {
+	ForkHash string // hex without 0x prefix
+	ForkNext uint64
+
+	HasUpgradeStatus       bool // the node sent the BSC UpgradeStatus message
+	DisablePeerTxBroadcast bool
+}
+
+// NodeEthStatus is a node enumerated by EnumerateEthStatuses.
+type NodeEthStatus struct {
+	NetworkID uint
+	ClientID  *string
+	IP        net.IP
+	Status    *EthStatus // nil if the node did not report a fork ID
+}
+
 type HandshakeError struct {
 	StringCode string
 	Time       time.Time
@@ -69,6 +93,12 @@ type DB interface {
 
 	UpdateForkCompatibility(ctx context.Context, id NodeID, isCompatFork bool) error
 
+	UpdateBscHistory(ctx context.Context, id NodeID, history BscHistory) error
+	FindBscHistory(ctx context.Context, id NodeID) (*BscHistory, error)
+
+	UpdateEthStatus(ctx context.Context, id NodeID, status EthStatus) error
+	FindEthStatus(ctx context.Context, id NodeID) (*EthStatus, error)
+
 	UpdateNeighborBucketKeys(ctx context.Context, id NodeID, keys []string) error
 	FindNeighborBucketKeys(ctx context.Context, id NodeID) ([]string, error)
 
@@ -90,4 +120,6 @@ type DB interface {
 	CountClientsWithNetworkID(ctx context.Context, clientIDPrefix string, maxPingTries uint) (uint, error)
 	CountClientsWithHandshakeTransientError(ctx context.Context, clientIDPrefix string, maxPingTries uint) (uint, error)
 	EnumerateClientIDs(ctx context.Context, maxPingTries uint, networkID uint, enumFunc func(clientID *string)) error
+	// EnumerateEthStatuses enumerates live nodes of all networks which completed the handshake.
+	EnumerateEthStatuses(ctx context.Context, maxPingTries uint, enumFunc func(node NodeEthStatus)) error
 }
@@ -214,6 +214,44 @@ func (db DBRetrier) UpdateForkCompatibility(ctx context.Context, id NodeID, isCo
 	return err
 }
 
+func (db DBRetrier) UpdateBscHistory(ctx context.Context, id NodeID, history BscHistory) error {
+	_, err := db.retry(ctx, "UpdateBscHistory", func(ctx context.Context) (interface{}, error) {
+		return nil, db.db.UpdateBscHistory(ctx, id, history)
+	})
+	return err
+}
+
+func (db DBRetrier) FindBscHistory(ctx context.Context, id NodeID) (*BscHistory, error) {
+	resultAny, err := db.retry(ctx, "FindBscHistory", func(ctx context.Context) (interface{}, error) {
+		return db.db.FindBscHistory(ctx, id)
+	})
+
+	if resultAny == nil {
+		return nil, err
+	}
+	result := resultAny.(*BscHistory)
+	return result, err
+}
+
+func (db DBRetrier) UpdateEthStatus(ctx context.Context, id NodeID, status EthStatus) error {
+	_, err := db.retry(ctx, "UpdateEthStatus", func(ctx context.Context) (interface{}, error) {
+		return nil, db.db.UpdateEthStatus(ctx, id, status)
+	})
+	return err
+}
+
+func (db DBRetrier) FindEthStatus(ctx context.Context, id NodeID) (*EthStatus, error) {
+	resultAny, err := db.retry(ctx, "FindEthStatus", func(ctx context.Context) (interface{}, error) {
+		return db.db.FindEthStatus(ctx, id)
+	})
+
+	if resultAny == nil {
+		return nil, err
+	}
+	result := resultAny.(*EthStatus)
+	return result, err
+}
+
 func (db DBRetrier) UpdateNeighborBucketKeys(ctx context.Context, id NodeID, keys []string) error {
 	_, err := db.retry(ctx, "UpdateNeighborBucketKeys", func(ctx context.Context) (interface{}, error) {
 		return nil, db.db.UpdateNeighborBucketKeys(ctx, id, keys)
@@ -73,6 +73,23 @@ CREATE TABLE IF NOT EXISTS handshake_errors (
     updated INTEGER NOT NULL
 );
 
+CREATE TABLE IF NOT EXISTS bsc_history (
+    id TEXT PRIMARY KEY,
+    chain TEXT NOT NULL,
+    history_from INTEGER NOT NULL,
+    blobs_from INTEGER NOT NULL,
+    updated INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS eth_status (
+    id TEXT PRIMARY KEY,
+    fork_hash TEXT NOT NULL,
+    fork_next INTEGER NOT NULL,
+    upgrade_status INTEGER NOT NULL,
+    disable_tx_broadcast INTEGER NOT NULL,
+    updated INTEGER NOT NULL
+);
+
 CREATE TABLE IF NOT EXISTS sentry_candidates_intake (
     id INTEGER PRIMARY KEY,
     last_event_time INTEGER NOT NULL
@@ -86,6 +103,8 @@ CREATE INDEX IF NOT EXISTS idx_nodes_compat_fork ON nodes (compat_fork);
 CREATE INDEX IF NOT EXISTS idx_nodes_network_id ON nodes (network_id);
 CREATE INDEX IF NOT EXISTS idx_nodes_handshake_retry_time ON nodes (handshake_retry_time);
 CREATE INDEX IF NOT EXISTS idx_handshake_errors_id ON handshake_errors (id);
+CREATE INDEX IF NOT EXISTS idx_bsc_history_chain ON bsc_history (chain);
+CREATE INDEX IF NOT EXISTS idx_eth_status_fork ON eth_status (fork_hash, fork_next);
 `
 
 	sqlUpsertNodeAddr = `
@@ -212,6 +231,46 @@ UPDATE nodes SET handshake_retry_time = ? WHERE id IN (123)
 
 	sqlUpdateForkCompatibility = `
 UPDATE nodes SET compat_fork = ?, compat_fork_updated = ? WHERE id = ?
+`
+
+	sqlUpdateBscHistory = `
+INSERT INTO bsc_history(
+	id,
+	chain,
+	history_from,
+	blobs_from,
+	updated
+) VALUES (?, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+	chain = excluded.chain,
+	history_from = excluded.history_from,
+	blobs_from = excluded.blobs_from,
+	updated = excluded.updated
+`
+
+	sqlFindBscHistory = `
+SELECT chain, history_from, blobs_from FROM bsc_history WHERE id = ?
+`
+
+	sqlUpdateEthStatus = `
+INSERT INTO eth_status(
+	id,
+	fork_hash,
+	fork_next,
+	upgrade_status,
+	disable_tx_broadcast,
+	updated
+) VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+	fork_hash = excluded.fork_hash,
+	fork_next = excluded.fork_next,
+	upgrade_status = excluded.upgrade_status,
+	disable_tx_broadcast = excluded.disable_tx_broadcast,
+	updated = excluded.updated
+`
+
+	sqlFindEthStatus = `
+SELECT fork_hash, fork_next, upgrade_status, disable_tx_broadcast FROM eth_status WHERE id = ?
 `
 
 	sqlUpdateNeighborBucketKeys = `
@@ -302,6 +361,15 @@ WHERE (ping_try < ?)
     AND ((network_id = ?) OR (network_id IS NULL))
     AND ((compat_fork == TRUE) OR (compat_fork IS NULL))
 `
+
+	sqlEnumerateEthStatuses = `
+SELECT nodes.network_id, nodes.client_id, nodes.ip,
+	eth_status.fork_hash, eth_status.fork_next, eth_status.upgrade_status, eth_status.disable_tx_broadcast
+FROM nodes
+LEFT JOIN eth_status ON eth_status.id = nodes.id
+WHERE (nodes.ping_try < ?)
+    AND (nodes.network_id IS NOT NULL)
+`
 )
 
 func NewDBSQLite(filePath string) (*DBSQLite, error) {
@@ -702,6 +770,52 @@ func (db *DBSQLite) UpdateForkCompatibility(ctx context.Context, id NodeID, isCo
 	return nil
 }
 
+func (db *DBSQLite) UpdateBscHistory(ctx context.Context, id NodeID, history BscHistory) error {
+	updated := time.Now().Unix()
+
+	_, err := db.db.ExecContext(ctx, sqlUpdateBscHistory, id, history.Chain, history.HistoryFrom, history.BlobsFrom, updated)
+	if err != nil {
+		return fmt.Errorf("UpdateBscHistory failed to update a node: %w", err)
+	}
+	return nil
+}
+
+func (db *DBSQLite) FindBscHistory(ctx context.Context, id NodeID) (*BscHistory, error) {
+	row := db.db.QueryRowContext(ctx, sqlFindBscHistory, id)
+	var history BscHistory
+	err := row.Scan(&history.Chain, &history.HistoryFrom, &history.BlobsFrom)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("FindBscHistory failed: %w", err)
+	}
+	return &history, nil
+}
+
+func (db *DBSQLite) UpdateEthStatus(ctx context.Context, id NodeID, status EthStatus) error {
+	updated := time.Now().Unix()
+
+	_, err := db.db.ExecContext(ctx, sqlUpdateEthStatus, id, status.ForkHash, status.ForkNext, status.HasUpgradeStatus, status.DisablePeerTxBroadcast, updated)
+	if err != nil {
+		return fmt.Errorf("UpdateEthStatus failed to update a node: %w", err)
+	}
+	return nil
+}
+
+func (db *DBSQLite) FindEthStatus(ctx context.Context, id NodeID) (*EthStatus, error) {
+	row := db.db.QueryRowContext(ctx, sqlFindEthStatus, id)
+	var status EthStatus
+	err := row.Scan(&status.ForkHash, &status.ForkNext, &status.HasUpgradeStatus, &status.DisablePeerTxBroadcast)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("FindEthStatus failed: %w", err)
+	}
+	return &status, nil
+}
+
 func (db *DBSQLite) UpdateNeighborBucketKeys(ctx context.Context, id NodeID, keys []string) error {
 	keysStr := strings.Join(keys, ",")
 
@@ -937,6 +1051,54 @@ func (db *DBSQLite) EnumerateClientIDs(
 	return nil
 }
 
+func (db *DBSQLite) EnumerateEthStatuses(
+	ctx context.Context,
+	maxPingTries uint,
+	enumFunc func(node NodeEthStatus),
+) error {
+	cursor, err := db.db.QueryContext(ctx, sqlEnumerateEthStatuses, maxPingTries)
+	if err != nil {
+		return fmt.Errorf("EnumerateEthStatuses failed to query: %w", err)
+	}
+	defer func() {
+		_ = cursor.Close()
+	}()
+
+	for cursor.Next() {
+		var node NodeEthStatus
+		var clientID sql.NullString
+		var ip sql.NullString
+		var forkHash sql.NullString
+		var forkNext sql.NullInt64
+		var upgradeStatus sql.NullBool
+		var disableTxBroadcast sql.NullBool
+		err := cursor.Scan(&node.NetworkID, &clientID, &ip, &forkHash, &forkNext, &upgradeStatus, &disableTxBroadcast)
+		if err != nil {
+			return fmt.Errorf("EnumerateEthStatuses failed to read data: %w", err)
+		}
+		if clientID.Valid {
+			node.ClientID = &clientID.String
+		}
+		if ip.Valid {
+			node.IP = net.ParseIP(ip.String)
+		}
+		if forkHash.Valid {
+			node.Status = &EthStatus{
+				ForkHash:               forkHash.String,
+				ForkNext:               uint64(forkNext.Int64),
+				HasUpgradeStatus:       upgradeStatus.Bool,
+				DisablePeerTxBroadcast: disableTxBroadcast.Bool,
+			}
+		}
+		enumFunc(node)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("EnumerateEthStatuses failed to iterate: %w", err)
+	}
+	return nil
+}
+
 func stringsToAny(strValues []NodeID) []interface{} {
 	values := make([]interface{}, 0, len(strValues))
 	for _, value := range strValues {
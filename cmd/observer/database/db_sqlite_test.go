@@ -55,3 +55,64 @@ func TestDBSQLiteInsertAndFind(t *testing.T) {
 	assert.Equal(t, addr.PortDisc, candidate.PortDisc)
 	assert.Equal(t, addr.PortRLPx, candidate.PortRLPx)
 }
+
+func TestDBSQLiteBscHistory(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewDBSQLite(filepath.Join(t.TempDir(), "observer.sqlite"))
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	var id NodeID = "ba85011c70bcc5c04d8607d3a0ed29aa6179c092cbdda10d5d32684fb33ed01bd94f588ca8f91ac48318087dcb02eaf36773a7a453f0eedd6742af668097b29c"
+	history, err := db.FindBscHistory(ctx, id)
+	require.Nil(t, err)
+	assert.Nil(t, history)
+
+	require.Nil(t, db.UpdateBscHistory(ctx, id, BscHistory{Chain: "bsc", HistoryFrom: 0, BlobsFrom: 1 << 20}))
+	require.Nil(t, db.UpdateBscHistory(ctx, id, BscHistory{Chain: "bsc", HistoryFrom: 1 << 16, BlobsFrom: 1 << 21}))
+
+	history, err = db.FindBscHistory(ctx, id)
+	require.Nil(t, err)
+	assert.Equal(t, &BscHistory{Chain: "bsc", HistoryFrom: 1 << 16, BlobsFrom: 1 << 21}, history)
+}
+
+func TestDBSQLiteEthStatus(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewDBSQLite(filepath.Join(t.TempDir(), "observer.sqlite"))
+	require.Nil(t, err)
+	defer func() { _ = db.Close() }()
+
+	var id NodeID = "ba85011c70bcc5c04d8607d3a0ed29aa6179c092cbdda10d5d32684fb33ed01bd94f588ca8f91ac48318087dcb02eaf36773a7a453f0eedd6742af668097b29c"
+	var otherID NodeID = "2b0e5f48a8d6dfe3ce4ce4efad7b0ac1b1a3e2c9d7c1ab2bd0d25fe4bdc4d2c12bfbe1d8e8d0ac8e3bd3edb5b42d3b3bd5f98b8bd6ee7a0ed7c7c0c2fd30cd8f"
+	status, err := db.FindEthStatus(ctx, id)
+	require.Nil(t, err)
+	assert.Nil(t, status)
+
+	expected := EthStatus{ForkHash: "d2a1d1b1", ForkNext: 1_739_000_000, HasUpgradeStatus: true, DisablePeerTxBroadcast: true}
+	require.Nil(t, db.UpdateEthStatus(ctx, id, EthStatus{ForkHash: "c1a2b3d4"}))
+	require.Nil(t, db.UpdateEthStatus(ctx, id, expected))
+
+	status, err = db.FindEthStatus(ctx, id)
+	require.Nil(t, err)
+	assert.Equal(t, &expected, status)
+
+	addr := NodeAddr{NodeAddr1: NodeAddr1{IP: net.ParseIP("10.0.1.16"), PortRLPx: 30303}}
+	for _, nodeID := range []NodeID{id, otherID} {
+		require.Nil(t, db.UpsertNodeAddr(ctx, nodeID, addr))
+		require.Nil(t, db.UpdateNetworkID(ctx, nodeID, 56))
+	}
+	require.Nil(t, db.UpdateClientID(ctx, id, "Geth/v1.5.0"))
+
+	var nodes []NodeEthStatus
+	require.Nil(t, db.EnumerateEthStatuses(ctx, 3, func(node NodeEthStatus) { nodes = append(nodes, node) }))
+	require.Len(t, nodes, 2)
+	for _, node := range nodes {
+		assert.Equal(t, uint(56), node.NetworkID)
+		assert.True(t, addr.IP.Equal(node.IP))
+		if node.ClientID != nil {
+			assert.Equal(t, "Geth/v1.5.0", *node.ClientID)
+			assert.Equal(t, &expected, node.Status)
+		} else {
+			assert.Nil(t, node.Status)
+		}
+	}
+}
@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -105,6 +107,10 @@ func reportWithFlags(ctx context.Context, flags reports.CommandFlags) error {
 		return nil
 	}
 
+	if flags.ForkReadiness {
+		return forkReadinessReport(ctx, db, flags, networkID)
+	}
+
 	statusReport, err := reports.CreateStatusReport(ctx, db, flags.MaxPingTries, networkID)
 	if err != nil {
 		return err
@@ -119,6 +125,33 @@ func reportWithFlags(ctx context.Context, flags reports.CommandFlags) error {
 	return nil
 }
 
+func forkReadinessReport(ctx context.Context, db database.DB, flags reports.CommandFlags, networkID uint) error {
+	var geoIP *reports.GeoIP
+	if flags.GeoIPPath != "" {
+		var err error
+		if geoIP, err = reports.LoadGeoIP(flags.GeoIPPath); err != nil {
+			return err
+		}
+	}
+
+	report, err := reports.CreateForkReadinessReport(ctx, db, flags.Chain, flags.ClientsLimit, flags.MaxPingTries, networkID, geoIP, time.Now())
+	if err != nil {
+		return err
+	}
+
+	switch flags.Format {
+	case "text":
+		fmt.Println(report)
+		return nil
+	case "json":
+		return report.WriteJSON(os.Stdout)
+	case "csv":
+		return report.WriteCSV(os.Stdout)
+	default:
+		return fmt.Errorf("unknown report format: %s", flags.Format)
+	}
+}
+
 func main() {
 	ctx, cancel := common.RootContext()
 	defer cancel()
@@ -16,7 +16,10 @@
 
 package observer
 
-import "strings"
+import (
+	"strings"
+	"unicode"
+)
 
 func clientNameBlacklist() []string {
 	return []string{
@@ -147,3 +150,26 @@ func NameFromClientID(clientID string) string {
 	parts := strings.SplitN(clientID, "/", 2)
 	return parts[0]
 }
+
+// VersionFromClientID returns the client version without build details,
+// e.g. "v1.5.7" for "Geth/v1.5.7-stable-fe5a9d6c/linux-amd64/go1.23.7",
+// or an empty string if there is no version.
+func VersionFromClientID(clientID string) string {
+	parts := strings.Split(clientID, "/")
+	for _, part := range parts[1:] {
+		if (len(part) < 2) || (part[0] != 'v') || !unicode.IsDigit(rune(part[1])) {
+			continue
+		}
+		part, _, _ = strings.Cut(part, "+")
+		segments := strings.Split(part, "-")
+		version := segments[0]
+		// keep pre-release tags like "beta", skip commits and dates
+		for _, segment := range segments[1:] {
+			if (segment != "stable") && (strings.IndexFunc(segment, unicode.IsDigit) < 0) {
+				version += "-" + segment
+			}
+		}
+		return version
+	}
+	return ""
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package observer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionFromClientID(t *testing.T) {
+	tests := []struct {
+		clientID string
+		want     string
+	}{
+		{"Geth/v1.5.7-stable-fe5a9d6c/linux-amd64/go1.23.7", "v1.5.7"},
+		{"Geth/v1.4.16-beta-bd0a2e34-20241126/linux-amd64/go1.21.13", "v1.4.16-beta"},
+		{"erigon/v1.3.0-alpha5-a1b2c3d4/linux-amd64/go1.22.2", "v1.3.0"}, // "alpha5" is indistinguishable from a commit
+		{"Nethermind/v1.25.4+20b10b35/linux-x64/dotnet8.0.2", "v1.25.4"},
+		{"Geth/node-1/v1.4.5-stable/linux-amd64/go1.21.6", "v1.4.5"},
+		{"observer/v3.0.0/linux-amd64/go1.22.2", "v3.0.0"},
+		{"Geth/linux-amd64/go1.21.6", ""},
+		{"v1.0.0", ""},
+		{"Geth/v/linux", ""},
+		{"", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.clientID, func(t *testing.T) {
+			assert.Equal(t, test.want, VersionFromClientID(test.clientID))
+		})
+	}
+}
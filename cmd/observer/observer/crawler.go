@@ -486,6 +486,18 @@ func (crawler *Crawler) saveInterrogationResult(
 		}
 	}
 
+	if (result != nil) && (result.BscHistory != nil) {
+		history := database.BscHistory{
+			Chain:       result.BscHistory.Chain,
+			HistoryFrom: result.BscHistory.HistoryFrom,
+			BlobsFrom:   result.BscHistory.BlobsFrom,
+		}
+		dbErr := crawler.db.UpdateBscHistory(ctx, id, history)
+		if dbErr != nil {
+			return dbErr
+		}
+	}
+
 	if clientID != nil {
 		dbErr := crawler.db.UpdateClientID(ctx, id, *clientID)
 		if dbErr != nil {
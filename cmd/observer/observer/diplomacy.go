@@ -252,6 +252,13 @@ func (diplomacy *Diplomacy) saveDiplomatResult(
 		}
 	}
 
+	if result.EthStatus != nil {
+		dbErr := diplomacy.db.UpdateEthStatus(ctx, id, *result.EthStatus)
+		if dbErr != nil {
+			return dbErr
+		}
+	}
+
 	if result.HandshakeErr != nil {
 		dbErr := diplomacy.db.InsertHandshakeError(ctx, id, result.HandshakeErr.StringCode())
 		if dbErr != nil {
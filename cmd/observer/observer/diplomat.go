@@ -19,6 +19,7 @@ package observer
 import (
 	"context"
 	"crypto/ecdsa"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -44,6 +45,7 @@ type DiplomatResult struct {
 	ClientID        *string
 	NetworkID       *uint64
 	EthVersion      *uint32
+	EthStatus       *database.EthStatus
 	HandshakeErr    *HandshakeError
 	HasTransientErr bool
 }
@@ -99,6 +101,17 @@ func (diplomat *Diplomat) Run(ctx context.Context) DiplomatResult {
 		result.EthVersion = &status.ProtocolVersion
 		diplomat.log.Debug("Got eth version", "ethVersion", *result.EthVersion)
 	}
+	if (status != nil) && (status.ForkID != nil) {
+		result.EthStatus = &database.EthStatus{
+			ForkHash: hex.EncodeToString(status.ForkID.Hash[:]),
+			ForkNext: status.ForkID.Next,
+		}
+		if status.UpgradeStatus != nil {
+			result.EthStatus.HasUpgradeStatus = true
+			result.EthStatus.DisablePeerTxBroadcast = status.UpgradeStatus.DisablePeerTxBroadcast
+		}
+		diplomat.log.Debug("Got fork ID", "forkID", *status.ForkID, "upgradeStatus", result.EthStatus.HasUpgradeStatus)
+	}
 
 	return result
 }
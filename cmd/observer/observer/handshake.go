@@ -44,6 +44,9 @@ const (
 	RLPxMessageIDPong       = 3
 )
 
+// bscProtocolName is the capability advertised by BSC geth nodes.
+const bscProtocolName = "bsc"
+
 // HelloMessage is the RLPx Hello message.
 // (same as protoHandshake in p2p/peer.go)
 // https://github.com/ethereum/devp2p/blob/master/rlpx.md#hello-0x00
@@ -67,8 +70,9 @@ type StatusMessage struct {
 	TD              *big.Int
 	Head            libcommon.Hash
 	Genesis         libcommon.Hash
-	ForkID          *forkid.ID     `rlp:"-"` // parsed from Rest if exists in v64+
-	Rest            []rlp.RawValue `rlp:"tail"`
+	ForkID          *forkid.ID                  `rlp:"-"` // parsed from Rest if exists in v64+
+	UpgradeStatus   *eth.UpgradeStatusExtension `rlp:"-"` // from the BSC UpgradeStatus message, nil if not sent
+	Rest            []rlp.RawValue              `rlp:"tail"`
 }
 
 type HandshakeErrorID string
@@ -211,9 +215,64 @@ func Handshake(
 		statusMessage.ForkID = &forkID
 	}
 
+	// BSC nodes only send UpgradeStatus after they got our Status.
+	// Echo their own Status, it is compatible by definition.
+	// Other nodes don't send it, so don't wait for it.
+	if supportsUpgradeStatus(&helloMessage, &statusMessage) {
+		statusMessage.UpgradeStatus = exchangeUpgradeStatus(conn, &statusMessage)
+	}
+
 	return &helloMessage, &statusMessage, nil
 }
 
+// supportsUpgradeStatus reports if the peer is a BSC node that expects UpgradeStatus after Status:
+// it either advertises the bsc protocol or its Status is for a Parlia chain.
+func supportsUpgradeStatus(helloMessage *HelloMessage, statusMessage *StatusMessage) bool {
+	if statusMessage.ProtocolVersion < direct.ETH68 {
+		return false
+	}
+	for _, c := range helloMessage.Caps {
+		if c.Name == bscProtocolName {
+			return true
+		}
+	}
+	config := params.ChainConfigByGenesisHash(statusMessage.Genesis)
+	return (config != nil) && (config.Parlia != nil)
+}
+
+func exchangeUpgradeStatus(conn *rlpx.Conn, statusMessage *StatusMessage) *eth.UpgradeStatusExtension {
+	statusData, err := rlp.EncodeToBytes(statusMessage)
+	if err != nil {
+		return nil
+	}
+	// observer is not interested in transactions
+	ourExtension, err := (&eth.UpgradeStatusExtension{DisablePeerTxBroadcast: true}).Encode()
+	if err != nil {
+		return nil
+	}
+	upgradeStatusData, err := rlp.EncodeToBytes(&eth.UpgradeStatusPacket{Extension: ourExtension})
+	if err != nil {
+		return nil
+	}
+	go func() {
+		if _, err := conn.Write(16+eth.StatusMsg, statusData); err == nil {
+			_, _ = conn.Write(16+eth.UpgradeStatusMsg, upgradeStatusData)
+		}
+	}()
+
+	var upgradeStatus eth.UpgradeStatusPacket
+	if err := readMessage(conn, 16+eth.UpgradeStatusMsg, HandshakeErrorIDStatusDecode, &upgradeStatus); err != nil {
+		return nil
+	}
+	var extension eth.UpgradeStatusExtension
+	if upgradeStatus.Extension != nil {
+		if err := rlp.DecodeBytes(*upgradeStatus.Extension, &extension); err != nil {
+			return nil
+		}
+	}
+	return &extension
+}
+
 func readMessage(conn *rlpx.Conn, expectedMessageID uint64, decodeError HandshakeErrorID, message interface{}) *HandshakeError {
 	messageID, data, _, err := conn.Read()
 	if err != nil {
@@ -25,6 +25,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/direct"
+	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/params"
 )
@@ -51,3 +52,24 @@ func TestHandshake(t *testing.T) {
 	assert.Equal(t, uint32(direct.ETH67), status.ProtocolVersion)
 	assert.Equal(t, uint64(1), status.NetworkID)
 }
+
+func TestSupportsUpgradeStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		hello  HelloMessage
+		status StatusMessage
+		want   bool
+	}{
+		{"BSC genesis", HelloMessage{}, StatusMessage{ProtocolVersion: 68, Genesis: params.BSCGenesisHash}, true},
+		{"Chapel genesis", HelloMessage{}, StatusMessage{ProtocolVersion: 68, Genesis: params.ChapelGenesisHash}, true},
+		{"bsc capability", HelloMessage{Caps: []p2p.Cap{{Name: "eth", Version: 68}, {Name: "bsc", Version: 1}}}, StatusMessage{ProtocolVersion: 68}, true},
+		{"eth/67", HelloMessage{Caps: []p2p.Cap{{Name: "bsc", Version: 1}}}, StatusMessage{ProtocolVersion: 67, Genesis: params.BSCGenesisHash}, false},
+		{"mainnet genesis", HelloMessage{Caps: []p2p.Cap{{Name: "eth", Version: 68}}}, StatusMessage{ProtocolVersion: 68, Genesis: params.MainnetGenesisHash}, false},
+		{"unknown genesis", HelloMessage{}, StatusMessage{ProtocolVersion: 68}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, supportsUpgradeStatus(&test.hello, &test.status))
+		})
+	}
+}
@@ -60,6 +60,7 @@ type Interrogator struct {
 type InterrogationResult struct {
 	Node               *enode.Node
 	IsCompatFork       *bool
+	BscHistory         *eth.BscENREntry
 	HandshakeResult    *DiplomatResult
 	HandshakeRetryTime *time.Time
 	KeygenKeys         []*ecdsa.PublicKey
@@ -125,6 +126,7 @@ func (interrogator *Interrogator) Run(ctx context.Context) (*InterrogationResult
 
 	// request ENR
 	var forkID *forkid.ID
+	var bscHistory *eth.BscENREntry
 	var enr *enode.Node
 	if (handshakeResult == nil) || (handshakeResult.ClientID == nil) || isENRRequestSupportedByClientID(*handshakeResult.ClientID) {
 		enr, err = interrogator.transport.RequestENR(interrogator.node)
@@ -140,6 +142,10 @@ func (interrogator *Interrogator) Run(ctx context.Context) (*InterrogationResult
 		if forkID == nil {
 			interrogator.log.Debug("Got ENR, but it doesn't contain a ForkID")
 		}
+		bscHistory, err = eth.LoadENRBscEntry(enr.Record())
+		if err != nil {
+			return nil, NewInterrogationError(InterrogationErrorENRDecode, err)
+		}
 	}
 
 	// filter by fork ID
@@ -187,6 +193,7 @@ func (interrogator *Interrogator) Run(ctx context.Context) (*InterrogationResult
 	result := InterrogationResult{
 		interrogator.node,
 		isCompatFork,
+		bscHistory,
 		handshakeResult,
 		handshakeRetryTime,
 		keys,
@@ -34,6 +34,10 @@ type CommandFlags struct {
 
 	SentryCandidates bool
 	ErigonLogPath    string
+
+	ForkReadiness bool
+	Format        string
+	GeoIPPath     string
 }
 
 type Command struct {
@@ -57,6 +61,9 @@ func NewCommand() *Command {
 	instance.withEstimate()
 	instance.withSentryCandidates()
 	instance.withErigonLogPath()
+	instance.withForkReadiness()
+	instance.withFormat()
+	instance.withGeoIPPath()
 
 	return &instance
 }
@@ -114,6 +121,31 @@ func (command *Command) withErigonLogPath() {
 	command.command.Flags().StringVar(&command.flags.ErigonLogPath, flag.Name, flag.Value, flag.Usage)
 }
 
+func (command *Command) withForkReadiness() {
+	flag := cli.BoolFlag{
+		Name:  "fork-readiness",
+		Usage: "Count nodes ready for the next scheduled fork by fork ID, client version, upgrade status and country",
+	}
+	command.command.Flags().BoolVar(&command.flags.ForkReadiness, flag.Name, false, flag.Usage)
+}
+
+func (command *Command) withFormat() {
+	flag := cli.StringFlag{
+		Name:  "format",
+		Usage: "Fork readiness report format: text, json or csv",
+		Value: "text",
+	}
+	command.command.Flags().StringVar(&command.flags.Format, flag.Name, flag.Value, flag.Usage)
+}
+
+func (command *Command) withGeoIPPath() {
+	flag := cli.StringFlag{
+		Name:  "geoip",
+		Usage: "CSV file with 'first IP,last IP,country code' rows for the fork readiness report, e.g. DB-IP IP to Country Lite",
+	}
+	command.command.Flags().StringVar(&command.flags.GeoIPPath, flag.Name, flag.Value, flag.Usage)
+}
+
 func (command *Command) RawCommand() *cobra.Command {
 	return &command.command
 }
@@ -0,0 +1,324 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package reports
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/erigontech/erigon/cmd/observer/database"
+	"github.com/erigontech/erigon/cmd/observer/observer"
+	"github.com/erigontech/erigon/core/forkid"
+	"github.com/erigontech/erigon/params"
+)
+
+type ForkReadiness string
+
+const (
+	ForkReady        ForkReadiness = "ready"        // the node has the fork scheduled or passed it
+	ForkNotReady     ForkReadiness = "not-ready"    // the node has no further forks scheduled
+	ForkSyncing      ForkReadiness = "syncing"      // the node is behind an earlier fork, readiness is unknown
+	ForkIncompatible ForkReadiness = "incompatible" // the fork ID doesn't match the local fork schedule
+	ForkUnknown      ForkReadiness = "unknown"      // the node didn't report a fork ID
+)
+
+// forkSchedule is the local fork schedule of a chain.
+type forkSchedule struct {
+	forks  []uint64 // block numbers followed by timestamps
+	hashes []string // hashes[i] is the fork ID hash after the first i forks
+	target int      // index of the fork to check the readiness for
+}
+
+// newForkSchedule targets the first time-based fork after now, or the last fork if all have passed.
+// Block-based forks are assumed to have passed.
+func newForkSchedule(chain string, now time.Time) (*forkSchedule, error) {
+	chainConfig := params.ChainConfigByChainName(chain)
+	genesisHash := params.GenesisHashByChainName(chain)
+	if (chainConfig == nil) || (genesisHash == nil) {
+		return nil, fmt.Errorf("unknown chain %s", chain)
+	}
+
+	// same as in the crawler
+	genesisTime := uint64(0)
+
+	heightForks, timeForks := forkid.GatherForks(chainConfig, genesisTime)
+	forks := append(slices.Clone(heightForks), timeForks...)
+	if len(forks) == 0 {
+		return nil, fmt.Errorf("chain %s has no forks", chain)
+	}
+
+	schedule := forkSchedule{
+		forks:  forks,
+		target: len(forks) - 1,
+	}
+	for i := 0; i <= len(forks); i++ {
+		// all forks are treated as passed block-based forks to get the checksum of the first i
+		id := forkid.NewIDFromForks(forks[:i], nil, *genesisHash, math.MaxUint64, math.MaxUint64)
+		schedule.hashes = append(schedule.hashes, hex.EncodeToString(id.Hash[:]))
+	}
+	for i, fork := range timeForks {
+		if fork > uint64(now.Unix()) {
+			schedule.target = len(heightForks) + i
+			break
+		}
+	}
+	return &schedule, nil
+}
+
+func (schedule *forkSchedule) readiness(status *database.EthStatus) ForkReadiness {
+	if status == nil {
+		return ForkUnknown
+	}
+	i := slices.Index(schedule.hashes, status.ForkHash)
+	switch {
+	case i < 0:
+		return ForkIncompatible
+	case i > schedule.target:
+		return ForkReady
+	case status.ForkNext == 0:
+		return ForkNotReady
+	case status.ForkNext != schedule.forks[i]:
+		return ForkIncompatible
+	case i == schedule.target:
+		return ForkReady
+	default:
+		return ForkSyncing
+	}
+}
+
+// ForkReadinessEntry is a number of nodes sharing the same fork ID, client version, country and status extension.
+type ForkReadinessEntry struct {
+	ForkHash               string        `json:"forkHash,omitempty"`
+	ForkNext               uint64        `json:"forkNext,omitempty"`
+	Readiness              ForkReadiness `json:"readiness"`
+	Client                 string        `json:"client"`
+	Version                string        `json:"version"`
+	Country                string        `json:"country,omitempty"`
+	UpgradeStatus          bool          `json:"upgradeStatus"` // the node sent the BSC UpgradeStatus message
+	DisablePeerTxBroadcast bool          `json:"disablePeerTxBroadcast"`
+	Count                  uint          `json:"count"`
+}
+
+type ForkReadinessReport struct {
+	NetworkID  uint                   `json:"networkId"`
+	Fork       uint64                 `json:"fork"`     // block number or timestamp of the checked fork
+	ForkHash   string                 `json:"forkHash"` // fork ID hash after the checked fork
+	Total      uint                   `json:"total"`
+	Readiness  map[ForkReadiness]uint `json:"readiness"`
+	ReadyRatio float64                `json:"readyRatio"` // ready nodes among the ones which reported a fork ID
+	Networks   map[uint]uint          `json:"networks"`   // handshaked nodes of all networks
+	Entries    []ForkReadinessEntry   `json:"entries"`    // sorted by count
+
+	limit uint
+}
+
+func CreateForkReadinessReport(
+	ctx context.Context,
+	db database.DB,
+	chain string,
+	limit uint,
+	maxPingTries uint,
+	networkID uint,
+	geoIP *GeoIP,
+	now time.Time,
+) (*ForkReadinessReport, error) {
+	schedule, err := newForkSchedule(chain, now)
+	if err != nil {
+		return nil, err
+	}
+
+	report := ForkReadinessReport{
+		NetworkID: networkID,
+		Fork:      schedule.forks[schedule.target],
+		ForkHash:  schedule.hashes[schedule.target+1],
+		Readiness: make(map[ForkReadiness]uint),
+		Networks:  make(map[uint]uint),
+		limit:     limit,
+	}
+
+	entries := make(map[ForkReadinessEntry]uint)
+	enumFunc := func(node database.NodeEthStatus) {
+		report.Networks[node.NetworkID]++
+		if node.NetworkID != networkID {
+			return
+		}
+		var entry ForkReadinessEntry
+		if node.ClientID != nil {
+			if observer.IsClientIDBlacklisted(*node.ClientID) {
+				return
+			}
+			entry.Client = observer.NameFromClientID(*node.ClientID)
+			entry.Version = observer.VersionFromClientID(*node.ClientID)
+		}
+		if node.Status != nil {
+			entry.ForkHash = node.Status.ForkHash
+			entry.ForkNext = node.Status.ForkNext
+			entry.UpgradeStatus = node.Status.HasUpgradeStatus
+			entry.DisablePeerTxBroadcast = node.Status.DisablePeerTxBroadcast
+		}
+		entry.Readiness = schedule.readiness(node.Status)
+		entry.Country = geoIP.Country(node.IP)
+		entries[entry]++
+	}
+	if err := db.EnumerateEthStatuses(ctx, maxPingTries, enumFunc); err != nil {
+		return nil, err
+	}
+
+	for entry, count := range entries {
+		entry.Count = count
+		report.Entries = append(report.Entries, entry)
+		report.Readiness[entry.Readiness] += count
+		report.Total += count
+	}
+	sort.Slice(report.Entries, func(i, j int) bool {
+		return report.Entries[i].Count > report.Entries[j].Count
+	})
+
+	if reported := report.Total - report.Readiness[ForkUnknown]; reported > 0 {
+		report.ReadyRatio = float64(report.Readiness[ForkReady]) / float64(reported)
+	}
+	return &report, nil
+}
+
+// group sums up entry counts by key, and counts the ready nodes.
+func (report *ForkReadinessReport) group(key func(entry ForkReadinessEntry) string) (counts, ready map[string]uint) {
+	counts = make(map[string]uint)
+	ready = make(map[string]uint)
+	for _, entry := range report.Entries {
+		k := key(entry)
+		counts[k] += entry.Count
+		if entry.Readiness == ForkReady {
+			ready[k] += entry.Count
+		}
+	}
+	return counts, ready
+}
+
+func (report *ForkReadinessReport) writeGroup(builder *strings.Builder, title string, key func(entry ForkReadinessEntry) string) {
+	counts, ready := report.group(key)
+	builder.WriteString(title + " (total, ready)")
+	builder.WriteRune('\n')
+	for i := uint(0); i < report.limit; i++ {
+		name, count := takeMapMaxValue(counts)
+		if count == 0 {
+			break
+		}
+		builder.WriteString(fmt.Sprintf("%6d %6d %s", count, ready[name], name))
+		builder.WriteRune('\n')
+	}
+	if others := sumMapValues(counts); others > 0 {
+		builder.WriteString(fmt.Sprintf("%6d %6s ...", others, ""))
+		builder.WriteRune('\n')
+	}
+}
+
+func (report *ForkReadinessReport) String() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("fork readiness of network %d for fork %d (%s):", report.NetworkID, report.Fork, report.ForkHash))
+	builder.WriteRune('\n')
+	for _, readiness := range []ForkReadiness{ForkReady, ForkNotReady, ForkSyncing, ForkIncompatible, ForkUnknown} {
+		builder.WriteString(fmt.Sprintf("%6d %s", report.Readiness[readiness], readiness))
+		builder.WriteRune('\n')
+	}
+	builder.WriteString(fmt.Sprintf("%6d total", report.Total))
+	builder.WriteRune('\n')
+	builder.WriteString(fmt.Sprintf("%5.1f%% ready", 100*report.ReadyRatio))
+	builder.WriteRune('\n')
+
+	report.writeGroup(&builder, "fork IDs:", func(entry ForkReadinessEntry) string {
+		if entry.ForkHash == "" {
+			return "unknown"
+		}
+		return fmt.Sprintf("%s/%d", entry.ForkHash, entry.ForkNext)
+	})
+	report.writeGroup(&builder, "clients:", func(entry ForkReadinessEntry) string {
+		return entry.Client + "/" + entry.Version
+	})
+	report.writeGroup(&builder, "upgrade status:", func(entry ForkReadinessEntry) string {
+		switch {
+		case !entry.UpgradeStatus:
+			return "none"
+		case entry.DisablePeerTxBroadcast:
+			return "tx broadcast disabled"
+		default:
+			return "tx broadcast enabled"
+		}
+	})
+	report.writeGroup(&builder, "countries:", func(entry ForkReadinessEntry) string {
+		if entry.Country == "" {
+			return "unknown"
+		}
+		return entry.Country
+	})
+	return builder.String()
+}
+
+func (report *ForkReadinessReport) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// WriteCSV writes the report entries, one row per entry.
+func (report *ForkReadinessReport) WriteCSV(writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.Write([]string{
+		"network_id",
+		"fork_hash",
+		"fork_next",
+		"readiness",
+		"client",
+		"version",
+		"country",
+		"upgrade_status",
+		"disable_peer_tx_broadcast",
+		"count",
+	})
+	if err != nil {
+		return err
+	}
+	networkID := strconv.FormatUint(uint64(report.NetworkID), 10)
+	for _, entry := range report.Entries {
+		err := csvWriter.Write([]string{
+			networkID,
+			entry.ForkHash,
+			strconv.FormatUint(entry.ForkNext, 10),
+			string(entry.Readiness),
+			entry.Client,
+			entry.Version,
+			entry.Country,
+			strconv.FormatBool(entry.UpgradeStatus),
+			strconv.FormatBool(entry.DisablePeerTxBroadcast),
+			strconv.FormatUint(uint64(entry.Count), 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package reports
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain/networkname"
+	"github.com/erigontech/erigon/cmd/observer/database"
+	"github.com/erigontech/erigon/core/forkid"
+	"github.com/erigontech/erigon/params"
+)
+
+func TestNewForkSchedule(t *testing.T) {
+	heightForks, timeForks := forkid.GatherForks(params.MainnetChainConfig, 0)
+	require.NotEmpty(t, timeForks)
+	lastTimeFork := timeForks[len(timeForks)-1]
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		target     int
+		targetFork uint64
+	}{
+		{"before the first time fork", time.Unix(int64(timeForks[0])-1, 0), len(heightForks), timeForks[0]},
+		{"at the first time fork", time.Unix(int64(timeForks[0]), 0), len(heightForks) + 1, timeForks[1]},
+		{"before the last time fork", time.Unix(int64(lastTimeFork)-1, 0), len(heightForks) + len(timeForks) - 1, lastTimeFork},
+		{"after all forks", time.Unix(int64(lastTimeFork)+1, 0), len(heightForks) + len(timeForks) - 1, lastTimeFork},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := newForkSchedule(networkname.Mainnet, test.now)
+			require.NoError(t, err)
+			assert.Len(t, schedule.forks, len(heightForks)+len(timeForks))
+			assert.Len(t, schedule.hashes, len(schedule.forks)+1)
+			assert.Equal(t, test.target, schedule.target)
+			assert.Equal(t, test.targetFork, schedule.forks[schedule.target])
+
+			// well known mainnet fork IDs: genesis and Homestead
+			assert.Equal(t, "fc64ec04", schedule.hashes[0])
+			assert.Equal(t, "97c2c34c", schedule.hashes[1])
+		})
+	}
+
+	_, err := newForkSchedule("unknown", time.Now())
+	assert.Error(t, err)
+}
+
+func TestForkScheduleReadiness(t *testing.T) {
+	schedule := forkSchedule{
+		forks:  []uint64{10, 20, 30},
+		hashes: []string{"00000000", "11111111", "22222222", "33333333"},
+		target: 1, // fork 20
+	}
+
+	tests := []struct {
+		name   string
+		status *database.EthStatus
+		want   ForkReadiness
+	}{
+		{"no status", nil, ForkUnknown},
+		{"unknown fork hash", &database.EthStatus{ForkHash: "ffffffff"}, ForkIncompatible},
+		{"passed the target", &database.EthStatus{ForkHash: "22222222", ForkNext: 30}, ForkReady},
+		{"passed all forks", &database.EthStatus{ForkHash: "33333333"}, ForkReady},
+		{"target scheduled", &database.EthStatus{ForkHash: "11111111", ForkNext: 20}, ForkReady},
+		{"target not scheduled", &database.EthStatus{ForkHash: "11111111"}, ForkNotReady},
+		{"other fork scheduled instead of the target", &database.EthStatus{ForkHash: "11111111", ForkNext: 25}, ForkIncompatible},
+		{"behind an earlier fork", &database.EthStatus{ForkHash: "00000000", ForkNext: 10}, ForkSyncing},
+		{"no forks scheduled before the target", &database.EthStatus{ForkHash: "00000000"}, ForkNotReady},
+		{"other fork scheduled before the target", &database.EthStatus{ForkHash: "00000000", ForkNext: 11}, ForkIncompatible},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, schedule.readiness(test.status))
+		})
+	}
+}
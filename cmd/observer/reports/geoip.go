@@ -0,0 +1,96 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package reports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+)
+
+type geoIPRange struct {
+	first   net.IP
+	last    net.IP
+	country string
+}
+
+// GeoIP maps IP addresses to country codes.
+// It is loaded from a CSV file with "first IP,last IP,country code" rows,
+// e.g. the DB-IP "IP to Country Lite" database.
+type GeoIP struct {
+	ranges []geoIPRange // sorted by first
+}
+
+func LoadGeoIP(filePath string) (*GeoIP, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the GeoIP database: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	return ParseGeoIP(file)
+}
+
+func ParseGeoIP(reader io.Reader) (*GeoIP, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.ReuseRecord = true
+
+	var geoIP GeoIP
+	for line := 1; ; line++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the GeoIP database: %w", err)
+		}
+		if len(record) < 3 {
+			return nil, fmt.Errorf("GeoIP database line %d: expected 3 fields, got %d", line, len(record))
+		}
+		first := net.ParseIP(record[0])
+		last := net.ParseIP(record[1])
+		if (first == nil) || (last == nil) {
+			return nil, fmt.Errorf("GeoIP database line %d: bad IP range %s-%s", line, record[0], record[1])
+		}
+		geoIP.ranges = append(geoIP.ranges, geoIPRange{first.To16(), last.To16(), record[2]})
+	}
+
+	sort.Slice(geoIP.ranges, func(i, j int) bool {
+		return bytes.Compare(geoIP.ranges[i].first, geoIP.ranges[j].first) < 0
+	})
+	return &geoIP, nil
+}
+
+// Country returns the country code of the IP, or an empty string if unknown.
+func (geoIP *GeoIP) Country(ip net.IP) string {
+	if (geoIP == nil) || (ip == nil) {
+		return ""
+	}
+	ip = ip.To16()
+	i := sort.Search(len(geoIP.ranges), func(i int) bool {
+		return bytes.Compare(geoIP.ranges[i].first, ip) > 0
+	}) - 1
+	if (i >= 0) && (bytes.Compare(ip, geoIP.ranges[i].last) <= 0) {
+		return geoIP.ranges[i].country
+	}
+	return ""
+}
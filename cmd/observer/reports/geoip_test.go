@@ -0,0 +1,78 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package reports
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoIPCountry(t *testing.T) {
+	// unsorted, with IPv6 and extra fields
+	const data = `10.0.1.0,10.0.1.255,DE
+2001:db8::,2001:db8::ffff,JP,extra
+10.0.0.0,10.0.0.255,US
+`
+	geoIP, err := ParseGeoIP(strings.NewReader(data))
+	require.NoError(t, err)
+
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{"10.0.0.0", "US"},
+		{"10.0.0.42", "US"},
+		{"10.0.0.255", "US"},
+		{"10.0.1.0", "DE"},
+		{"10.0.1.255", "DE"},
+		{"10.0.2.0", ""},
+		{"9.255.255.255", ""},
+		{"2001:db8::1", "JP"},
+		{"2001:db8::1:0", ""},
+		{"::ffff:10.0.0.1", "US"},
+	}
+	for _, test := range tests {
+		t.Run(test.ip, func(t *testing.T) {
+			assert.Equal(t, test.want, geoIP.Country(net.ParseIP(test.ip)))
+		})
+	}
+
+	assert.Equal(t, "", geoIP.Country(nil))
+	assert.Equal(t, "", (*GeoIP)(nil).Country(net.ParseIP("10.0.0.1")))
+}
+
+func TestParseGeoIPErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"missing country", "10.0.0.0,10.0.0.255\n"},
+		{"bad first IP", "10.0.0,10.0.0.255,US\n"},
+		{"bad last IP", "10.0.0.0,host,US\n"},
+		{"bad quoting", "10.0.0.0,10.0.0.255,\"US\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseGeoIP(strings.NewReader(test.data))
+			assert.Error(t, err)
+		})
+	}
+}
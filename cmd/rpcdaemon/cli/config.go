@@ -49,6 +49,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/paths"
 	"github.com/erigontech/erigon-lib/config3"
 	"github.com/erigontech/erigon-lib/direct"
+	"github.com/erigontech/erigon-lib/downloader/objstore"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
 	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
@@ -106,8 +107,9 @@ var rootCmd = &cobra.Command{
 }
 
 var (
-	stateCacheStr string
-	polygonSync   bool
+	stateCacheStr      string
+	snapRemoteCacheStr string
+	polygonSync        bool
 )
 
 type HeimdallReader interface {
@@ -140,6 +142,8 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	rootCmd.PersistentFlags().BoolVar(&cfg.TraceCompatibility, "trace.compat", false, "Bug for bug compatibility with OE for trace_ routines")
 	rootCmd.PersistentFlags().StringVar(&cfg.TxPoolApiAddr, "txpool.api.addr", "", "txpool api network address, for example: 127.0.0.1:9090 (default: use value of --private.api.addr)")
 
+	rootCmd.PersistentFlags().StringVar(&cfg.Snap.RemoteURL, utils.SnapRemoteFlag.Name, "", utils.SnapRemoteFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&snapRemoteCacheStr, utils.SnapRemoteDiskCacheFlag.Name, utils.SnapRemoteDiskCacheFlag.Value, utils.SnapRemoteDiskCacheFlag.Usage)
 	rootCmd.PersistentFlags().StringVar(&stateCacheStr, "state.cache", "0MB", "Amount of data to store in StateCache (enabled if no --datadir set). Set 0 to disable StateCache. Defaults to 0MB RAM")
 	rootCmd.PersistentFlags().BoolVar(&cfg.GRPCServerEnabled, "grpc", false, "Enable GRPC server")
 	rootCmd.PersistentFlags().StringVar(&cfg.GRPCListenAddress, "grpc.addr", nodecfg.DefaultGRPCHost, "GRPC server listening interface")
@@ -190,6 +194,7 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	rootCmd.PersistentFlags().Uint64Var(&cfg.OtsMaxPageSize, utils.OtsSearchMaxCapFlag.Name, utils.OtsSearchMaxCapFlag.Value, utils.OtsSearchMaxCapFlag.Usage)
 	rootCmd.PersistentFlags().DurationVar(&cfg.RPCSlowLogThreshold, utils.RPCSlowFlag.Name, utils.RPCSlowFlag.Value, utils.RPCSlowFlag.Usage)
 	rootCmd.PersistentFlags().IntVar(&cfg.WebsocketSubscribeLogsChannelSize, utils.WSSubscribeLogsChannelSize.Name, utils.WSSubscribeLogsChannelSize.Value, utils.WSSubscribeLogsChannelSize.Usage)
+	rootCmd.PersistentFlags().StringVar(&cfg.StatelessVerifyUrl, "stateless.verify.url", "", "Verify every new block by executing it statelessly against the witness served by this full node (eth_getWitness)")
 
 	if err := rootCmd.MarkPersistentFlagFilename("rpc.accessList", "json"); err != nil {
 		panic(err)
@@ -210,6 +215,10 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 			return fmt.Errorf("state.cache value of %v is not valid", stateCacheStr)
 		}
 
+		if err = cfg.Snap.RemoteDiskCache.UnmarshalText([]byte(snapRemoteCacheStr)); err != nil {
+			return fmt.Errorf("%s value of %v is not valid", utils.SnapRemoteDiskCacheFlag.Name, snapRemoteCacheStr)
+		}
+
 		cfg.WithDatadir = cfg.DataDir != ""
 		if cfg.WithDatadir {
 			if cfg.DataDir == "" {
@@ -447,6 +456,11 @@ func RemoteServices(ctx context.Context, cfg *httpcfg.HttpCfg, logger log.Logger
 		// not the case we'll need to adjust the defaults of the --no-downlaoder
 		// flag to the faulse by default
 		cfg.Snap.NoDownloader = true
+		if cfg.Snap.RemoteURL != "" {
+			if _, err := objstore.Setup(ctx, cfg.Snap.RemoteURL, cfg.Snap.RemoteDiskCache, cfg.Dirs.Snap, logger); err != nil {
+				return nil, nil, nil, nil, nil, nil, nil, ff, nil, nil, err
+			}
+		}
 		allSnapshots = freezeblocks.NewRoSnapshots(cfg.Snap, cfg.Dirs.Snap, 0, logger)
 		allBorSnapshots = heimdall.NewRoSnapshots(cfg.Snap, cfg.Dirs.Snap, 0, logger)
 		allBscSnapshots = freezeblocks.NewBscRoSnapshots(cfg.Snap, cfg.Dirs.Snap, 0, logger)
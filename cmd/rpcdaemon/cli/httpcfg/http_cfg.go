@@ -107,4 +107,6 @@ type HttpCfg struct {
 	OtsMaxPageSize uint64
 
 	RPCSlowLogThreshold time.Duration
+
+	StatelessVerifyUrl string // Full node serving eth_getWitness, the blocks are verified statelessly against its witnesses if set
 }
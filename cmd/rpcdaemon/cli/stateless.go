@@ -0,0 +1,68 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/cli/httpcfg"
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/core/rawdb"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/services"
+	"github.com/erigontech/erigon/turbo/stateless"
+)
+
+// StartStatelessVerifier verifies in the background every block appearing in
+// db by executing it against the witness served by cfg.StatelessVerifyUrl, it
+// is a no-op unless the url is set.
+func StartStatelessVerifier(ctx context.Context, cfg *httpcfg.HttpCfg, db kv.RoDB, blockReader services.FullBlockReader, engine consensus.EngineReader, logger log.Logger) error {
+	if cfg.StatelessVerifyUrl == "" {
+		return nil
+	}
+
+	var cc *chain.Config
+	if err := db.View(ctx, func(tx kv.Tx) error {
+		genesisHash, err := rawdb.ReadCanonicalHash(tx, 0)
+		if err != nil {
+			return err
+		}
+		cc, err = rawdb.ReadChainConfig(tx, genesisHash)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	client, err := rpc.DialContext(ctx, cfg.StatelessVerifyUrl, logger)
+	if err != nil {
+		return err
+	}
+	verifier := stateless.NewVerifier(cc, engine, stateless.NewDBChain(db, cc, blockReader, logger), stateless.NewRPCWitnessSource(client), time.Second, logger)
+	go func() {
+		defer client.Close()
+		if err := verifier.Run(ctx, 0, 0); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Error("[stateless] Verifier stopped", "err", err)
+		}
+	}()
+	return nil
+}
@@ -58,6 +58,10 @@ func main() {
 
 		apiList := jsonrpc.APIList(db, backend, txPool, mining, ff, stateCache, blockReader, cfg, engine, logger, bridgeReader, heimdallReader)
 		rpc.PreAllocateRPCMetricLabels(apiList)
+		if err := cli.StartStatelessVerifier(ctx, cfg, db, blockReader, engine, logger); err != nil {
+			logger.Error("Could not start stateless verifier", "err", err)
+			return nil
+		}
 		if err := cli.StartRpcServer(ctx, cfg, apiList, logger); err != nil {
 			logger.Error(err.Error())
 			return nil
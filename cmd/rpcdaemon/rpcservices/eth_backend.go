@@ -23,7 +23,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"sync/atomic"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
@@ -35,6 +37,7 @@ import (
 	"github.com/erigontech/erigon-lib/downloader/snaptype"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/rawdbv3"
 	"github.com/erigontech/erigon-lib/rlp"
@@ -43,6 +46,7 @@ import (
 	"github.com/erigontech/erigon/eth/ethconfig"
 	"github.com/erigontech/erigon/ethdb/privateapi"
 	"github.com/erigontech/erigon/p2p"
+	"github.com/erigontech/erigon/p2p/nat"
 	"github.com/erigontech/erigon/polygon/heimdall"
 	"github.com/erigontech/erigon/turbo/services"
 	"github.com/erigontech/erigon/turbo/snapshotsync"
@@ -433,12 +437,66 @@ func (back *RemoteBackend) NodeInfo(ctx context.Context, limit uint32) ([]p2p.No
 				Listener:  int(node.Ports.Listener),
 			},
 			Protocols: protocols,
+			NAT:       natReportFromProto(node.Nat),
 		})
 	}
 
 	return ret, nil
 }
 
+func fromUnixTime(t int64) time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(t, 0)
+}
+
+func natReportFromProto(report *typesproto.NatReport) *nat.Report {
+	if report == nil {
+		return nil
+	}
+	ret := &nat.Report{
+		Interface:  report.Interface,
+		ExternalIP: net.ParseIP(report.ExternalIp),
+		ObservedIP: net.ParseIP(report.ObservedIp),
+		Checked:    fromUnixTime(report.Checked),
+		Probed:     fromUnixTime(report.Probed),
+		Warnings:   report.Warnings,
+	}
+	for _, m := range report.Mechanisms {
+		ret.Mechanisms = append(ret.Mechanisms, nat.MechanismStatus{
+			Name:            m.Name,
+			Device:          m.Device,
+			Available:       m.Available,
+			ExternalIP:      net.ParseIP(m.ExternalIp),
+			SupportsMapping: m.SupportsMapping,
+			Latency:         time.Duration(m.LatencyMs) * time.Millisecond,
+			Error:           m.Error,
+		})
+	}
+	for _, m := range report.Mappings {
+		ret.Mappings = append(ret.Mappings, nat.MappingStatus{
+			Protocol:     m.Protocol,
+			ExternalPort: int(m.ExternalPort),
+			InternalPort: int(m.InternalPort),
+			Mapped:       m.Mapped,
+			Expires:      fromUnixTime(m.Expires),
+			Renewals:     m.Renewals,
+			Error:        m.Error,
+		})
+	}
+	for _, r := range report.Reachability {
+		ret.Reachability = append(ret.Reachability, nat.Reachability{
+			Protocol:    r.Protocol,
+			Port:        int(r.Port),
+			Reachable:   r.Reachable,
+			Since:       fromUnixTime(r.Since),
+			LastInbound: fromUnixTime(r.LastInbound),
+		})
+	}
+	return ret
+}
+
 func (back *RemoteBackend) AddPeer(ctx context.Context, request *remote.AddPeerRequest) (*remote.AddPeerReply, error) {
 	result, err := back.remoteEthBackend.AddPeer(ctx, request)
 	if err != nil {
@@ -447,6 +505,53 @@ func (back *RemoteBackend) AddPeer(ctx context.Context, request *remote.AddPeerR
 	return result, nil
 }
 
+func (back *RemoteBackend) RemovePeer(ctx context.Context, request *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	result, err := back.remoteEthBackend.RemovePeer(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.RemovePeer() error: %w", err)
+	}
+	return result, nil
+}
+
+func (back *RemoteBackend) AddTrustedPeer(ctx context.Context, request *remote.AddTrustedPeerRequest) (*remote.AddTrustedPeerReply, error) {
+	result, err := back.remoteEthBackend.AddTrustedPeer(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.AddTrustedPeer() error: %w", err)
+	}
+	return result, nil
+}
+
+func (back *RemoteBackend) RemoveTrustedPeer(ctx context.Context, request *remote.RemoveTrustedPeerRequest) (*remote.RemoveTrustedPeerReply, error) {
+	result, err := back.remoteEthBackend.RemoveTrustedPeer(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.RemoveTrustedPeer() error: %w", err)
+	}
+	return result, nil
+}
+
+func (back *RemoteBackend) PeerEvents(ctx context.Context, onEvent func(*remote.PeerEvent)) error {
+	stream, err := back.remoteEthBackend.PeerEvents(ctx, &remote.PeerEventsRequest{}, grpc.WaitForReady(true))
+	if err != nil {
+		if s, ok := status.FromError(err); ok {
+			return errors.New(s.Message())
+		}
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			log.Debug("rpcdaemon: the peer events channel was closed")
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		onEvent(event)
+	}
+	return nil
+}
+
 func (back *RemoteBackend) Peers(ctx context.Context) ([]*p2p.PeerInfo, error) {
 	rpcPeers, err := back.remoteEthBackend.Peers(ctx, &emptypb.Empty{})
 	if err != nil {
@@ -477,6 +582,27 @@ func (back *RemoteBackend) Peers(ctx context.Context) ([]*p2p.PeerInfo, error) {
 			},
 			Protocols: nil,
 		}
+		if rep := rpcPeer.Reputation; rep != nil {
+			peer.Reputation = &p2p.ReputationInfo{
+				Score:     rep.Score,
+				LatencyMs: rep.LatencyMs,
+				Responses: rep.Responses,
+				Timeouts:  rep.Timeouts,
+				Invalid:   rep.Invalid,
+			}
+		}
+		if len(rpcPeer.Traffic) > 0 {
+			peer.Traffic = make(p2p.TrafficInfo, len(rpcPeer.Traffic))
+			for _, t := range rpcPeer.Traffic {
+				peer.Traffic[t.Message] = &p2p.MsgTraffic{
+					IngressBytes:   t.IngressBytes,
+					IngressPackets: t.IngressPackets,
+					EgressBytes:    t.EgressBytes,
+					EgressPackets:  t.EgressPackets,
+					EgressDropped:  t.EgressDropped,
+				}
+			}
+		}
 
 		peers = append(peers, &peer)
 	}
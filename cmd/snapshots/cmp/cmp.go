@@ -36,6 +36,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/downloader"
 	"github.com/erigontech/erigon-lib/downloader/snaptype"
 	"github.com/erigontech/erigon/cmd/snapshots/flags"
@@ -49,6 +50,9 @@ import (
 	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
 )
 
+// stateWorkers - amount of state file pairs compared in parallel
+const stateWorkers = 4
+
 var Command = cli.Command{
 	Action:    cmp,
 	Name:      "cmp",
@@ -56,6 +60,7 @@ var Command = cli.Command{
 	ArgsUsage: "<start block> <end block>",
 	Flags: []cli.Flag{
 		&flags.SegTypes,
+		&flags.StateNames,
 		&utils.DataDirFlag,
 		&logging.LogVerbosityFlag,
 		&logging.LogConsoleVerbosityFlag,
@@ -159,6 +164,10 @@ func cmp(cliCtx *cli.Context) error {
 		pos++
 	}
 
+	if loc1 != nil && loc2 != nil && loc1.LType == sync.LocalFs && loc2.LType == sync.LocalFs {
+		return compareState(cliCtx.Context, datadir.Open(loc1.Root), datadir.Open(loc2.Root), cliCtx.StringSlice(flags.StateNames.Name), stateWorkers, logger)
+	}
+
 	if loc1.LType == sync.TorrentFs || loc2.LType == sync.TorrentFs {
 		config := sync.NewTorrentClientConfigFromCobra(cliCtx, chain)
 		torrentCli, err = sync.NewTorrentClient(cliCtx.Context, config)
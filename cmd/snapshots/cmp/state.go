@@ -0,0 +1,269 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package cmp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/holiman/uint256"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/types/accounts"
+)
+
+var stateFileExp = regexp.MustCompile(`^v([0-9]+)-([[:lower:]]+)\.([0-9]+)-([0-9]+)\.(kv|v|ef)$`)
+
+// stateFile - `.kv`, `.v` or `.ef` file of some domain/history/inverted index
+type stateFile struct {
+	path     string
+	name     string // `accounts`, `storage`, `logaddrs`, ...
+	ext      string
+	from, to uint64 // steps
+}
+
+func (f stateFile) rangeKey() string {
+	return fmt.Sprintf("%s.%d-%d.%s", f.name, f.from, f.to, f.ext)
+}
+
+func listStateFiles(dir string, names []string) (map[string]stateFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	files := map[string]stateFile{}
+	for _, ent := range entries {
+		if ent.IsDir() {
+			continue
+		}
+		subs := stateFileExp.FindStringSubmatch(ent.Name())
+		if len(subs) != 6 {
+			continue
+		}
+		if len(names) > 0 && !slices.Contains(names, subs[2]) {
+			continue
+		}
+		from, err := strconv.ParseUint(subs[3], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse file name %s: %w", ent.Name(), err)
+		}
+		to, err := strconv.ParseUint(subs[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse file name %s: %w", ent.Name(), err)
+		}
+		f := stateFile{path: filepath.Join(dir, ent.Name()), name: subs[2], ext: subs[5], from: from, to: to}
+		files[f.rangeKey()] = f
+	}
+	return files, nil
+}
+
+// compareState - compares domain, history and inverted index files of 2 datadirs.
+// Files are matched by name and step range (file versions may differ), for every pair
+// the first diverging key is reported. Files existing only in one datadir are reported too.
+func compareState(ctx context.Context, dirs1, dirs2 datadir.Dirs, names []string, workers int, logger log.Logger) error {
+	type filesPair struct {
+		f1, f2   stateFile
+		ef1, ef2 stateFile // `.ef` files paired with `.v` history files
+	}
+
+	var pairs []filesPair
+	for _, dirs := range [][2]string{{dirs1.SnapDomain, dirs2.SnapDomain}, {dirs1.SnapHistory, dirs2.SnapHistory}, {dirs1.SnapIdx, dirs2.SnapIdx}} {
+		files1, err := listStateFiles(dirs[0], names)
+		if err != nil {
+			return err
+		}
+		files2, err := listStateFiles(dirs[1], names)
+		if err != nil {
+			return err
+		}
+		for key, f1 := range files1 {
+			f2, ok := files2[key]
+			if !ok {
+				logger.Warn("[cmp] file exists only in 1-st datadir", "file", f1.path)
+				continue
+			}
+			pairs = append(pairs, filesPair{f1: f1, f2: f2})
+		}
+		for key, f2 := range files2 {
+			if _, ok := files1[key]; !ok {
+				logger.Warn("[cmp] file exists only in 2-nd datadir", "file", f2.path)
+			}
+		}
+	}
+
+	idx1, err := listStateFiles(dirs1.SnapIdx, names)
+	if err != nil {
+		return err
+	}
+	idx2, err := listStateFiles(dirs2.SnapIdx, names)
+	if err != nil {
+		return err
+	}
+	for i := range pairs {
+		if pairs[i].f1.ext != "v" {
+			continue
+		}
+		efKey := stateFile{name: pairs[i].f1.name, from: pairs[i].f1.from, to: pairs[i].f1.to, ext: "ef"}.rangeKey()
+		pairs[i].ef1, pairs[i].ef2 = idx1[efKey], idx2[efKey]
+	}
+
+	// report divergences in order of steps: the earliest diverged step is the most interesting one
+	slices.SortFunc(pairs, func(a, b filesPair) int {
+		switch {
+		case a.f1.from < b.f1.from:
+			return -1
+		case a.f1.from > b.f1.from:
+			return 1
+		case a.f1.to > b.f1.to:
+			return -1
+		case a.f1.to < b.f1.to:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	logger.Info("[cmp] comparing state files", "pairs", len(pairs), "dir1", dirs1.DataDir, "dir2", dirs2.DataDir)
+
+	startTime := time.Now()
+	diffs := make([]*state.FilesDiff, len(pairs))
+	var diffsLock sync.Mutex
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(workers)
+
+	for i, p := range pairs {
+		g.Go(func() error {
+			diff, err := compareStateFilesPair(ctx, p.f1, p.f2, p.ef1, p.ef2)
+			if err != nil {
+				return fmt.Errorf("%s: %w", p.f1.rangeKey(), err)
+			}
+
+			diffsLock.Lock()
+			defer diffsLock.Unlock()
+			diffs[i] = diff
+
+			if diff == nil {
+				logger.Debug("[cmp] files are equal", "file", p.f1.rangeKey())
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	var diverged int
+	for i, diff := range diffs {
+		if diff == nil {
+			continue
+		}
+		diverged++
+		p := pairs[i]
+		logger.Warn("[cmp] files diverged", "file1", p.f1.path, "file2", p.f2.path, "diff", diff.String())
+		for _, line := range decodeStateDiff(p.f1.name, diff) {
+			logger.Warn("[cmp]   " + line)
+		}
+	}
+
+	logger.Info("[cmp] finished state compare", "pairs", len(pairs), "diverged", diverged, "elapsed", time.Since(startTime))
+
+	if diverged > 0 {
+		return fmt.Errorf("%d state files diverged", diverged)
+	}
+	return nil
+}
+
+func compareStateFilesPair(ctx context.Context, f1, f2, ef1, ef2 stateFile) (*state.FilesDiff, error) {
+	switch f1.ext {
+	case "kv":
+		domain, err := kv.String2Domain(f1.name)
+		if err != nil {
+			return nil, err
+		}
+		return state.CompareDomainFiles(ctx, domain, f1.path, f2.path)
+	case "v":
+		domain, err := kv.String2Domain(f1.name)
+		if err != nil {
+			return nil, err
+		}
+		return state.CompareHistoryFiles(ctx, domain, ef1.path, f1.path, ef2.path, f2.path)
+	case "ef":
+		compression, err := state.InvertedIndexFilesCompression(f1.name)
+		if err != nil {
+			return nil, err
+		}
+		return state.CompareInvertedIndexFiles(ctx, compression, f1.path, f2.path)
+	default:
+		return nil, fmt.Errorf("unsupported file type: %s", f1.ext)
+	}
+}
+
+// decodeStateDiff - human-readable representation of diverged account/storage values
+func decodeStateDiff(name string, diff *state.FilesDiff) (lines []string) {
+	decodeVal := func(v []byte) string {
+		if len(v) == 0 {
+			return "<empty>"
+		}
+		switch name {
+		case kv.AccountsDomain.String():
+			var acc accounts.Account
+			if err := accounts.DeserialiseV3(&acc, v); err != nil {
+				return fmt.Sprintf("can't decode account %x: %s", v, err)
+			}
+			return fmt.Sprintf("nonce=%d balance=%s codeHash=%x incarnation=%d", acc.Nonce, acc.Balance.String(), acc.CodeHash, acc.Incarnation)
+		case kv.StorageDomain.String():
+			return new(uint256.Int).SetBytes(v).Hex()
+		case kv.CodeDomain.String():
+			return fmt.Sprintf("len=%d", len(v))
+		default:
+			return fmt.Sprintf("%x", v)
+		}
+	}
+
+	switch {
+	case name == kv.AccountsDomain.String() && len(diff.Key) == length.Addr:
+		lines = append(lines, fmt.Sprintf("address=%x", diff.Key))
+	case name == kv.StorageDomain.String() && len(diff.Key) == length.Addr+length.Hash:
+		lines = append(lines, fmt.Sprintf("address=%x location=%x", diff.Key[:length.Addr], common.BytesToHash(diff.Key[length.Addr:])))
+	}
+	if !diff.Missing1 {
+		lines = append(lines, "value1: "+decodeVal(diff.Val1))
+	}
+	if !diff.Missing2 {
+		lines = append(lines, "value2: "+decodeVal(diff.Val2))
+	}
+	return lines
+}
@@ -0,0 +1,208 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package codecs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/seg"
+	"github.com/erigontech/erigon/cmd/snapshots/sync"
+)
+
+var (
+	CodecsFlag = cli.StringSliceFlag{
+		Name:  "codecs",
+		Usage: `Codecs to compare: pattern,zstd,raw`,
+		Value: cli.NewStringSlice(seg.CodecNamePattern, seg.CodecNameZstd, seg.CodecNameRaw),
+	}
+	DictSizeFlag = cli.IntFlag{
+		Name:  "dict.size",
+		Usage: `Max size of trained zstd dictionary`,
+		Value: 110 * 1024,
+	}
+	DictSamplesFlag = cli.IntFlag{
+		Name:  "dict.samples",
+		Usage: `Amount of words (evenly distributed over training files) used to train zstd dictionary`,
+		Value: 10_000,
+	}
+	DictsDirFlag = cli.StringFlag{
+		Name:  "dicts.dir",
+		Usage: `If set: zstd dictionaries trained on all given files of type are saved to this dir as <type>.zstd-dict (for --snap.codecs.dicts)`,
+	}
+	ReadsFlag = cli.IntFlag{
+		Name:  "reads",
+		Usage: `Amount of random reads to measure latency`,
+		Value: 10_000,
+	}
+	TmpDirFlag = cli.StringFlag{
+		Name:  "tmpdir",
+		Usage: `Dir for re-encoded files. Default: os temp dir`,
+	}
+)
+
+var Command = cli.Command{
+	Action:    bench,
+	Name:      "codecs",
+	Usage:     "compare codecs of snapshot files: size and random-read latency",
+	ArgsUsage: "<file.seg|file.kv|...> ...",
+	Flags: []cli.Flag{
+		&CodecsFlag,
+		&DictSizeFlag,
+		&DictSamplesFlag,
+		&DictsDirFlag,
+		&ReadsFlag,
+		&TmpDirFlag,
+	},
+	Description: `Re-encodes all words of given files by each codec and reports size, compression time and random-read (Reset+Next) latency.
+zstd dictionary of file is trained on samples of other given files of same type (like dictionary used by --snap.codecs,
+which is trained on existing files and compresses new ones), so zstd needs at least 2 files of each type.
+With --dicts.dir: dictionary trained on all given files of type is saved for --snap.codecs.dicts`,
+}
+
+type benchFile struct {
+	d   *seg.Decompressor
+	src seg.ReaderI
+	typ string
+}
+
+func bench(cliCtx *cli.Context) error {
+	logger := sync.Logger(cliCtx.Context)
+	if cliCtx.Args().Len() == 0 {
+		return errors.New("expected at least one snapshot file")
+	}
+	tmpDir := cliCtx.String(TmpDirFlag.Name)
+	if tmpDir == "" {
+		var err error
+		if tmpDir, err = os.MkdirTemp("", "snapshots-codecs"); err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmpDir)
+	}
+
+	var files []*benchFile
+	defer func() {
+		for _, f := range files {
+			f.d.Close()
+		}
+	}()
+	for _, file := range cliCtx.Args().Slice() {
+		d, err := seg.NewDecompressor(file)
+		if err != nil {
+			return err
+		}
+		files = append(files, &benchFile{d: d, src: seg.NewReader(d.MakeGetter(), seg.DetectCompressType(d.MakeGetter())), typ: snapshotType(filepath.Base(file))})
+	}
+	if err := saveDicts(cliCtx, files); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(cliCtx.App.Writer, 1, 2, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tCODEC\tSIZE\tRATIO\tDICT\tCOMPRESS\tREAD AVG\tREAD P50\tREAD P99")
+	for i, f := range files {
+		for _, name := range cliCtx.StringSlice(CodecsFlag.Name) {
+			codec, err := newCodec(cliCtx, name, files, i)
+			if err != nil {
+				return err
+			}
+			logger.Info("[codecs] measure", "file", f.d.FileName(), "codec", name)
+			stats, err := seg.MeasureCodec(cliCtx.Context, f.src, codec, tmpDir, cliCtx.Int(ReadsFlag.Name), logger)
+			if err != nil {
+				return fmt.Errorf("%s: %w", f.d.FileName(), err)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%.2f\t%s\t%s\t%s\t%s\t%s\n", f.d.FileName(), stats.Codec,
+				common.ByteCount(uint64(stats.Size)), float64(stats.Size)/float64(f.d.Size()), common.ByteCount(uint64(stats.DictSize)),
+				stats.CompressTime.Round(time.Millisecond), stats.ReadAvg, stats.ReadP50, stats.ReadP99)
+		}
+	}
+	return w.Flush()
+}
+
+// newCodec - for measuring `files[measured]`: zstd dictionary is trained on other files of same type
+func newCodec(cliCtx *cli.Context, name string, files []*benchFile, measured int) (seg.Codec, error) {
+	if name != seg.CodecNameZstd {
+		return seg.NewCodec(name, "")
+	}
+	dict, err := trainDict(cliCtx, files, files[measured].typ, measured)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", files[measured].d.FileName(), err)
+	}
+	return seg.NewZstdCodec(dict)
+}
+
+// trainDict - on samples of files of type `typ`, except `files[skip]` (-1 - all files of type)
+func trainDict(cliCtx *cli.Context, files []*benchFile, typ string, skip int) ([]byte, error) {
+	var train []*benchFile
+	for i, f := range files {
+		if f.typ == typ && i != skip {
+			train = append(train, f)
+		}
+	}
+	if len(train) == 0 {
+		return nil, fmt.Errorf("no other files of type %s to train zstd dictionary: pass at least 2 files of type", typ)
+	}
+	limit := max(cliCtx.Int(DictSamplesFlag.Name)/len(train), 1)
+	var samples [][]byte
+	for _, f := range train {
+		samples = append(samples, seg.SampleWords(f.src, f.d.Count(), limit)...)
+	}
+	return seg.TrainZstdDict(samples, cliCtx.Int(DictSizeFlag.Name))
+}
+
+// saveDicts - dictionary of each type, trained on all given files of type, to --dicts.dir
+func saveDicts(cliCtx *cli.Context, files []*benchFile) error {
+	dictsDir := cliCtx.String(DictsDirFlag.Name)
+	if dictsDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(dictsDir, 0o755); err != nil {
+		return err
+	}
+	saved := map[string]bool{}
+	for _, f := range files {
+		if saved[f.typ] {
+			continue
+		}
+		saved[f.typ] = true
+		dict, err := trainDict(cliCtx, files, f.typ, -1)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(seg.ZstdDictPath(dictsDir, f.typ), dict, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotType - `headers` for `v1-000000-000500-headers.seg`, `accounts` for `v1-accounts.0-32.kv`
+func snapshotType(fileName string) string {
+	name := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	if i := strings.IndexByte(name, '.'); i >= 0 { // state file
+		name = name[:i]
+		return name[strings.IndexByte(name, '-')+1:]
+	}
+	return name[strings.LastIndexByte(name, '-')+1:]
+}
@@ -24,4 +24,10 @@ var (
 		Usage:    `Segment types to compare with optional e.g. headers,bodies,transactions`,
 		Required: false,
 	}
+
+	StateNames = cli.StringSliceFlag{
+		Name:     "domains",
+		Usage:    `State domains and inverted indices to compare when both locations are local datadirs e.g. accounts,storage,logaddrs`,
+		Required: false,
+	}
 )
@@ -31,6 +31,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/disk"
 	"github.com/erigontech/erigon-lib/common/mem"
 	"github.com/erigontech/erigon/cmd/snapshots/cmp"
+	"github.com/erigontech/erigon/cmd/snapshots/codecs"
 	"github.com/erigontech/erigon/cmd/snapshots/copy"
 	"github.com/erigontech/erigon/cmd/snapshots/genfromrpc"
 	"github.com/erigontech/erigon/cmd/snapshots/manifest"
@@ -58,6 +59,7 @@ func main() {
 		&torrents.Command,
 		&manifest.Command,
 		&genfromrpc.Command,
+		&codecs.Command,
 	}
 
 	app.Flags = []cli.Flag{}
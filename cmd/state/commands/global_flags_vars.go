@@ -51,6 +51,10 @@ func withDataDir(cmd *cobra.Command) {
 	must(cmd.MarkFlagDirname("chaindata"))
 }
 
+func withChain(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&chain, "chain", "", "name of the network (mainnet, bsc, chapel, ...)")
+}
+
 func withStatsfile(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&statsfile, "statsfile", "stateless.csv", "path where to write the stats file")
 	must(cmd.MarkFlagFilename("statsfile", "csv"))
@@ -0,0 +1,133 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	chain2 "github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/consensus/ethash"
+	"github.com/erigontech/erigon/consensus/merge"
+	"github.com/erigontech/erigon/consensus/parlia"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/stateless"
+)
+
+var (
+	statelessRpcUrl       string
+	statelessWitnessUrl   string
+	statelessFrom         uint64
+	statelessTo           uint64
+	statelessPollInterval time.Duration
+)
+
+func init() {
+	withChain(verifyStatelessCmd)
+	verifyStatelessCmd.Flags().StringVar(&statelessRpcUrl, "rpc.url", "http://127.0.0.1:8545", "full node serving the header chain and the blocks")
+	verifyStatelessCmd.Flags().StringVar(&statelessWitnessUrl, "witness.url", "", "full node serving eth_getWitness, defaults to --rpc.url")
+	verifyStatelessCmd.Flags().Uint64Var(&statelessFrom, "from", 0, "first block to verify, 0 starts at the head of the chain")
+	verifyStatelessCmd.Flags().Uint64Var(&statelessTo, "to", 0, "last block to verify, 0 keeps following the chain")
+	verifyStatelessCmd.Flags().DurationVar(&statelessPollInterval, "poll.interval", time.Second, "how often to poll the full node for new blocks")
+
+	rootCmd.AddCommand(verifyStatelessCmd)
+}
+
+var verifyStatelessCmd = &cobra.Command{
+	Use:   "verifyStateless",
+	Short: "Validates blocks of a full node by executing them against witnesses only, without a state database",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := debug.SetupCobra(cmd, "verify_stateless")
+		return VerifyStateless(cmd.Context(), chainConfig, statelessRpcUrl, statelessWitnessUrl, statelessFrom, statelessTo, statelessPollInterval, logger)
+	},
+}
+
+func VerifyStateless(ctx context.Context, chainConfig *chain2.Config, rpcUrl, witnessUrl string, from, to uint64, pollInterval time.Duration, logger log.Logger) error {
+	client, err := rpc.DialContext(ctx, rpcUrl, logger)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	witnessClient := client
+	if witnessUrl != "" && witnessUrl != rpcUrl {
+		if witnessClient, err = rpc.DialContext(ctx, witnessUrl, logger); err != nil {
+			return err
+		}
+		defer witnessClient.Close()
+	}
+
+	chain := stateless.NewRPCChain(ctx, chainConfig, client, logger)
+	if from == 0 {
+		head, err := chain.HeadNumber(ctx)
+		if err != nil {
+			return err
+		}
+		from = max(head, 1)
+	}
+
+	engine, err := statelessEngine(ctx, chainConfig, client, from-1, logger)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
+
+	verifier := stateless.NewVerifier(chainConfig, engine, chain, stateless.NewRPCWitnessSource(witnessClient), pollInterval, logger)
+	if err := verifier.Run(ctx, from, to); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// statelessEngine creates the consensus engine of the chain. Parlia keeps its
+// validator set in snapshots, walking the headers back to a stored one could
+// take the whole chain, so the engine is seeded with the snapshot of the parent
+// of the first verified block taken from the full node.
+func statelessEngine(ctx context.Context, chainConfig *chain2.Config, client *rpc.Client, seed uint64, logger log.Logger) (consensus.Engine, error) {
+	switch {
+	case chainConfig.Parlia != nil:
+		engine := parlia.New(chainConfig, memdb.New(os.TempDir(), kv.ConsensusDB), nil /* blobStore */, nil /* blockReader */, logger)
+		snap := new(parlia.Snapshot)
+		if err := client.CallContext(ctx, snap, "bsc_getSnapshot", hexutil.Uint64(seed)); err != nil {
+			return nil, fmt.Errorf("bsc_getSnapshot(%d): %w", seed, err)
+		}
+		if err := engine.ImportSnapshot(snap); err != nil {
+			return nil, err
+		}
+		logger.Info("Seeded parlia snapshot", "number", snap.Number, "hash", snap.Hash, "validators", len(snap.Validators))
+		return engine, nil
+	case chainConfig.Bor != nil, chainConfig.Aura != nil:
+		return nil, fmt.Errorf("stateless verification is not supported for chain %s", chainConfig.ChainName)
+	default:
+		var engine consensus.Engine = ethash.NewFaker()
+		if chainConfig.TerminalTotalDifficulty != nil {
+			engine = merge.New(engine)
+		}
+		return engine, nil
+	}
+}
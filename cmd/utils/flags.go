@@ -48,6 +48,7 @@ import (
 	"github.com/erigontech/erigon-lib/direct"
 	downloadercfg2 "github.com/erigontech/erigon-lib/downloader/downloadercfg"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/seg"
 	"github.com/erigontech/erigon-lib/state"
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cmd/downloader/downloadernat"
@@ -560,6 +561,31 @@ var (
 		Usage: "Comma separated enode URLs which are always allowed to connect, even above the peer limit",
 		Value: "",
 	}
+	PriorityPeersFlag = cli.StringFlag{
+		Name:  "p2p.priority-peers",
+		Usage: "Comma separated enode URLs or node IDs of peers (e.g. validators behind this node) which always receive new blocks and transactions and are never dropped because of the peer limit. Enode URLs are also dialed as static peers",
+		Value: "",
+	}
+	PriorityValidatorsFlag = cli.BoolFlag{
+		Name:  "p2p.priority-validators",
+		Usage: "BSC: treat the node IDs registered by the current validators in the StakeHub contract as priority peers",
+	}
+	P2pIngressRateFlag = cli.StringFlag{
+		Name:  "p2p.bandwidth.ingress",
+		Usage: "Inbound bandwidth limit of all peers in bytes per second, example: 32mb. Unlimited if not set",
+	}
+	P2pEgressRateFlag = cli.StringFlag{
+		Name:  "p2p.bandwidth.egress",
+		Usage: "Outbound bandwidth limit of all peers in bytes per second, example: 32mb. Transaction gossip is dropped first when the limit is reached. Unlimited if not set",
+	}
+	P2pPeerIngressRateFlag = cli.StringFlag{
+		Name:  "p2p.bandwidth.peer-ingress",
+		Usage: "Inbound bandwidth limit of each peer in bytes per second, example: 2mb. Unlimited if not set",
+	}
+	P2pPeerEgressRateFlag = cli.StringFlag{
+		Name:  "p2p.bandwidth.peer-egress",
+		Usage: "Outbound bandwidth limit of each peer in bytes per second, example: 2mb. Unlimited if not set",
+	}
 	NodeKeyFileFlag = cli.StringFlag{
 		Name:  "nodekey",
 		Usage: "P2P node key file",
@@ -582,6 +608,10 @@ var (
 `,
 		Value: "",
 	}
+	NATCheckIntervalFlag = cli.DurationFlag{
+		Name:  "nat.check-interval",
+		Usage: "How often UPnP, NAT-PMP and STUN are probed for the NAT diagnostics reported by admin_nodeInfo and `diag nat`, e.g. 30m. Disabled by default, as probing sends discovery requests to the local network",
+	}
 	NoDiscoverFlag = cli.BoolFlag{
 		Name:  "nodiscover",
 		Usage: "Disables the peer discovery mechanism (manual peer addition)",
@@ -715,6 +745,23 @@ var (
 		Name:  "downloader.verify",
 		Usage: "Verify snapshots on startup. It will not report problems found, but re-download broken pieces.",
 	}
+	SnapRemoteFlag = cli.StringFlag{
+		Name:  "snap.remote",
+		Usage: "Read-only S3-compatible object-store with snapshot files: files missing on local disk are fetched on first read. Example: s3://bucket/mainnet?endpoint=http://127.0.0.1:9000 (credentials: AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY env variables)",
+	}
+	SnapRemoteDiskCacheFlag = cli.StringFlag{
+		Name:  "snap.remote.cache",
+		Usage: "Disk budget for local copies of files fetched from --snap.remote (least recently used copies of closed files are removed). 0 - unlimited",
+		Value: "0",
+	}
+	SnapCodecsFlag = cli.StringFlag{
+		Name:  "snap.codecs",
+		Usage: "Codecs of new snapshot files by type or domain, other types use default codec (pattern). Codecs: pattern, zstd, raw. Example: headers=zstd,bodies=zstd,blobsidecars=raw,receipt=zstd. Files of any codec stay readable",
+	}
+	SnapCodecsDictsFlag = cli.StringFlag{
+		Name:  "snap.codecs.dicts",
+		Usage: "Dir with zstd dictionaries of --snap.codecs: <type>.zstd-dict (produced by `snapshots codecs --dicts.dir`). Default: <datadir>/snapshots/codecs",
+	}
 	DisableIPV6 = cli.BoolFlag{
 		Name:  "downloader.disable.ipv6",
 		Usage: "Turns off ipv6 for the downloader",
@@ -1241,6 +1288,49 @@ func setTrustedPeers(ctx *cli.Context, cfg *p2p.Config) {
 	cfg.TrustedNodes = append(cfg.TrustedNodes, trustedNodes...)
 }
 
+func setPriorityPeers(ctx *cli.Context, cfg *p2p.Config) {
+	if !ctx.IsSet(PriorityPeersFlag.Name) {
+		return
+	}
+
+	for _, s := range libcommon.CliString2Array(ctx.String(PriorityPeersFlag.Name)) {
+		if s == "" {
+			continue
+		}
+		if id, err := enode.ParseID(s); err == nil {
+			cfg.PriorityNodeIDs = append(cfg.PriorityNodeIDs, id)
+			continue
+		}
+		n, err := enode.Parse(enode.ValidSchemes, s)
+		if err != nil {
+			Fatalf("Option %s: invalid node URL or ID %s: %v", PriorityPeersFlag.Name, s, err)
+		}
+		cfg.PriorityNodeIDs = append(cfg.PriorityNodeIDs, n.ID())
+		cfg.StaticNodes = append(cfg.StaticNodes, n)
+	}
+}
+
+func setBandwidthLimits(ctx *cli.Context, cfg *p2p.Config) {
+	for _, limit := range []struct {
+		flag  cli.StringFlag
+		value *uint64
+	}{
+		{P2pIngressRateFlag, &cfg.MaxIngressRate},
+		{P2pEgressRateFlag, &cfg.MaxEgressRate},
+		{P2pPeerIngressRateFlag, &cfg.MaxPeerIngressRate},
+		{P2pPeerEgressRateFlag, &cfg.MaxPeerEgressRate},
+	} {
+		if !ctx.IsSet(limit.flag.Name) {
+			continue
+		}
+		var rate datasize.ByteSize
+		if err := rate.UnmarshalText([]byte(ctx.String(limit.flag.Name))); err != nil {
+			Fatalf("Option %s: %v", limit.flag.Name, err)
+		}
+		*limit.value = rate.Bytes()
+	}
+}
+
 func ParseNodesFromURLs(urls []string) ([]*enode.Node, error) {
 	nodes := make([]*enode.Node, 0, len(urls))
 	for _, url := range urls {
@@ -1381,6 +1471,9 @@ func setNAT(ctx *cli.Context, cfg *p2p.Config) {
 		cfg.NAT = natif
 		cfg.NATSpec = natSetting
 	}
+	if ctx.IsSet(NATCheckIntervalFlag.Name) {
+		cfg.NATCheckInterval = ctx.Duration(NATCheckIntervalFlag.Name)
+	}
 }
 
 // setEtherbase retrieves the etherbase from the directly specified
@@ -1440,6 +1533,8 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config, nodeName, datadir string, l
 	setBootstrapNodesV5(ctx, cfg)
 	setStaticPeers(ctx, cfg)
 	setTrustedPeers(ctx, cfg)
+	setPriorityPeers(ctx, cfg)
+	setBandwidthLimits(ctx, cfg)
 
 	if ctx.IsSet(MaxPeersFlag.Name) {
 		cfg.MaxPeers = ctx.Int(MaxPeersFlag.Name)
@@ -1933,7 +2028,7 @@ func CheckExclusive(ctx *cli.Context, args ...interface{}) {
 }
 
 // SetEthConfig applies eth-related command line flags to the config.
-func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.Config, logger log.Logger) {
+func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.Config, logger log.Logger) error {
 	cfg.CaplinConfig.CaplinDiscoveryAddr = ctx.String(CaplinDiscoveryAddrFlag.Name)
 	cfg.CaplinConfig.CaplinDiscoveryPort = ctx.Uint64(CaplinDiscoveryPortFlag.Name)
 	cfg.CaplinConfig.CaplinDiscoveryTCPPort = ctx.Uint64(CaplinDiscoveryTCPPortFlag.Name)
@@ -1946,6 +2041,7 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 		state.EnableHistoricalRCache()
 	}
 	cfg.PersistReceiptsV1 = ctx.Uint64(PersistReceiptsFlag.Name)
+	cfg.PriorityValidators = ctx.Bool(PriorityValidatorsFlag.Name)
 
 	cfg.CaplinConfig.EnableUPnP = ctx.Bool(CaplinEnableUPNPlag.Name)
 	var err error
@@ -1992,6 +2088,19 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	cfg.Snapshot.Verify = ctx.Bool(DownloaderVerifyFlag.Name)
 	cfg.Snapshot.DownloaderAddr = strings.TrimSpace(ctx.String(DownloaderAddrFlag.Name))
 	cfg.Snapshot.ChainName = chain
+	cfg.Snapshot.RemoteURL = strings.TrimSpace(ctx.String(SnapRemoteFlag.Name))
+	if err := cfg.Snapshot.RemoteDiskCache.UnmarshalText([]byte(ctx.String(SnapRemoteDiskCacheFlag.Name))); err != nil {
+		return fmt.Errorf("option %s: %w", SnapRemoteDiskCacheFlag.Name, err)
+	}
+	codecsDictsDir := ctx.String(SnapCodecsDictsFlag.Name)
+	if codecsDictsDir == "" {
+		codecsDictsDir = filepath.Join(cfg.Dirs.Snap, "codecs")
+	}
+	codecs, err := seg.ParseCodecs(ctx.String(SnapCodecsFlag.Name), codecsDictsDir)
+	if err != nil {
+		return fmt.Errorf("option %s: %w", SnapCodecsFlag.Name, err)
+	}
+	cfg.Snapshot.Codecs = codecs
 	nodeConfig.Http.Snap = cfg.Snapshot
 
 	if ctx.Command.Name == "import" {
@@ -2047,7 +2156,7 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 		genesisHash := params.GenesisHashByChainName(chain)
 		if (genesis == nil) || (genesisHash == nil) {
 			Fatalf("ChainDB name is not recognized: %s", chain)
-			return
+			return nil
 		}
 		cfg.Genesis = genesis
 		SetDNSDiscoveryDefaults(cfg, *genesisHash)
@@ -2117,6 +2226,7 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 		}
 		downloadernat.DoNat(nodeConfig.P2P.NAT, cfg.Downloader.ClientConfig, logger)
 	}
+	return nil
 }
 
 // SetDNSDiscoveryDefaults configures DNS discovery with the given URL if
@@ -4492,6 +4492,30 @@ const stakeABI = `
     ],
     "stateMutability": "view"
   },
+  {
+    "type": "function",
+    "name": "getNodeIDs",
+    "inputs": [
+      {
+        "name": "validatorsToQuery",
+        "type": "address[]",
+        "internalType": "address[]"
+      }
+    ],
+    "outputs": [
+      {
+        "name": "consensusAddresses",
+        "type": "address[]",
+        "internalType": "address[]"
+      },
+      {
+        "name": "nodeIDsList",
+        "type": "bytes32[][]",
+        "internalType": "bytes32[][]"
+      }
+    ],
+    "stateMutability": "view"
+  },
   {
     "type": "function",
     "name": "getValidatorBasicInfo",
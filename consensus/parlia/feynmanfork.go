@@ -185,6 +185,47 @@ func (p *Parlia) getMaxElectedValidators(header *types.Header, ibs *state.IntraB
 	return maxElectedValidators, nil
 }
 
+// GetValidatorNodeIDs returns the p2p node IDs registered in the StakeHub contract by the current
+// validators. Calls are executed on the given state, which has to be discarded afterwards.
+// Node IDs can only be registered since Maxwell, nil is returned before.
+func (p *Parlia) GetValidatorNodeIDs(header *types.Header, ibs *state.IntraBlockState) ([]common.Hash, error) {
+	if !p.chainConfig.IsMaxwell(header.Number.Uint64(), header.Time) {
+		return nil, nil
+	}
+	validators, _, err := p.getCurrentValidators(header, ibs)
+	if err != nil {
+		return nil, err
+	}
+
+	method := "getNodeIDs"
+
+	data, err := p.stakeHubABI.Pack(method, validators)
+	if err != nil {
+		log.Error("Unable to pack tx for getNodeIDs", "error", err)
+		return nil, err
+	}
+	msgData := (hexutility.Bytes)(data)
+
+	_, returnData, err := p.systemCall(header.Coinbase, systemcontracts.StakeHubContract, msgData[:], ibs, header, u256.Num0)
+	if err != nil {
+		return nil, err
+	}
+
+	var consensusAddrs []common.Address
+	var nodeIDsList [][][32]byte
+	if err := p.stakeHubABI.UnpackIntoInterface(&[]interface{}{&consensusAddrs, &nodeIDsList}, method, returnData); err != nil {
+		return nil, err
+	}
+
+	var nodeIDs []common.Hash
+	for _, ids := range nodeIDsList {
+		for _, id := range ids {
+			nodeIDs = append(nodeIDs, id)
+		}
+	}
+	return nodeIDs, nil
+}
+
 func getTopValidatorsByVotingPower(validatorItems []ValidatorItem, maxElectedValidators *big.Int) ([]common.Address, []uint64, [][]byte) {
 	var validatorHeap ValidatorHeap
 	for i := 0; i < len(validatorItems); i++ {
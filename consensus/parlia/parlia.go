@@ -1265,11 +1265,16 @@ func (p *Parlia) APIs(chain consensus.ChainHeaderReader) []rpc.API {
 	return []rpc.API{{
 		Namespace: "parlia",
 		Version:   "1.0",
-		Service:   &API{chain: chain, parlia: p},
+		Service:   p.API(chain),
 		Public:    false,
 	}}
 }
 
+// API returns the parlia RPC service reading the chain through chain.
+func (p *Parlia) API(chain consensus.ChainHeaderReader) *API {
+	return &API{chain: chain, parlia: p}
+}
+
 func (p *Parlia) IsServiceTransaction(sender libcommon.Address, syscall consensus.SystemCall) bool {
 	return false
 }
@@ -1694,6 +1699,28 @@ func (p *Parlia) ResetSnapshot(chain consensus.ChainHeaderReader, headers []*typ
 	return nil
 }
 
+// ImportSnapshot seeds the engine with a snapshot obtained from a trusted source,
+// e.g. another node, so that blocks past it can be processed without the header
+// chain back to the previous checkpoint.
+func (p *Parlia) ImportSnapshot(snap *Snapshot) error {
+	if snap.TurnLength == 0 {
+		snap.TurnLength = defaultTurnLength
+	}
+	if snap.EpochLength == 0 {
+		snap.EpochLength = params.DefaultEpochLength
+	}
+	if snap.BlockInterval == 0 {
+		snap.BlockInterval = params.DefaultBlockInterval
+	}
+	snap.config = p.config
+	snap.sigCache = p.signatures
+	if err := snap.store(p.db); err != nil {
+		return err
+	}
+	p.recentSnaps.Add(snap.Hash, snap)
+	return nil
+}
+
 type rwWrapper struct {
 	kv.RoDB
 }
@@ -358,6 +358,23 @@ func ExecuteBlockEphemerally(
 	return execRs, nil
 }
 
+// ExecuteBlockEphemerallyForEngine runs ExecuteBlockEphemerallyForBSC for PoSA
+// engines, which apply the system transactions of a block when finalizing it,
+// and ExecuteBlockEphemerally for the others.
+func ExecuteBlockEphemerallyForEngine(
+	chainConfig *chain.Config, vmConfig *vm.Config,
+	blockHashFunc func(n uint64) libcommon.Hash,
+	engine consensus.Engine, block *types.Block,
+	stateReader state.StateReader, stateWriter state.WriterWithChangeSets,
+	chainReader consensus.ChainReader, getTracer func(txIndex int, txHash libcommon.Hash) (vm.EVMLogger, error),
+	logger log.Logger,
+) (*EphemeralExecResult, error) {
+	if _, isPoSA := engine.(consensus.PoSA); isPoSA {
+		return ExecuteBlockEphemerallyForBSC(chainConfig, vmConfig, blockHashFunc, engine, block, stateReader, stateWriter, chainReader, getTracer, logger)
+	}
+	return ExecuteBlockEphemerally(chainConfig, vmConfig, blockHashFunc, engine, block, stateReader, stateWriter, chainReader, getTracer, logger)
+}
+
 func logReceipts(receipts types.Receipts, txns types.Transactions, cc *chain.Config, header *types.Header, logger log.Logger) {
 	if len(receipts) == 0 {
 		// no-op, can happen if vmConfig.NoReceipts=true or vmConfig.StatelessExec=true
@@ -0,0 +1,193 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types/accounts"
+)
+
+// ErrNotRecorded is returned by ReplayReader for state which was not read when
+// the recording was made, i.e. the replayed execution took another path.
+var ErrNotRecorded = errors.New("state read not recorded")
+
+// StorageSlot identifies a storage item of a given incarnation of an account.
+type StorageSlot struct {
+	Address     common.Address
+	Incarnation uint64
+	Key         common.Hash
+}
+
+// CodeKey identifies the code of a given incarnation of an account.
+type CodeKey struct {
+	Address     common.Address
+	Incarnation uint64
+}
+
+// StateReads holds the value of every state item a StateReader was asked
+// for, as of the first time it was asked. Nil accounts are absent ones.
+type StateReads struct {
+	Accounts     map[common.Address]*accounts.Account
+	Storage      map[StorageSlot][]byte
+	Code         map[CodeKey][]byte
+	Incarnations map[common.Address]uint64
+}
+
+func NewStateReads() *StateReads {
+	return &StateReads{
+		Accounts:     map[common.Address]*accounts.Account{},
+		Storage:      map[StorageSlot][]byte{},
+		Code:         map[CodeKey][]byte{},
+		Incarnations: map[common.Address]uint64{},
+	}
+}
+
+// RecordingReader is a wrapper for an instance of type StateReader which
+// records everything read through it.
+type RecordingReader struct {
+	r     StateReader
+	reads *StateReads
+}
+
+// NewRecordingReader wraps a given state reader into the recording reader
+func NewRecordingReader(r StateReader) *RecordingReader {
+	return &RecordingReader{r: r, reads: NewStateReads()}
+}
+
+// Reads returns what was read so far.
+func (rr *RecordingReader) Reads() *StateReads { return rr.reads }
+
+func (rr *RecordingReader) ReadAccountData(address common.Address) (*accounts.Account, error) {
+	a, err := rr.r.ReadAccountData(address)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := rr.reads.Accounts[address]; !ok {
+		var cpy *accounts.Account
+		if a != nil {
+			cpy = new(accounts.Account)
+			cpy.Copy(a)
+		}
+		rr.reads.Accounts[address] = cpy
+	}
+	return a, nil
+}
+
+func (rr *RecordingReader) ReadAccountDataForDebug(address common.Address) (*accounts.Account, error) {
+	return rr.ReadAccountData(address)
+}
+
+func (rr *RecordingReader) ReadAccountStorage(address common.Address, incarnation uint64, key *common.Hash) ([]byte, error) {
+	v, err := rr.r.ReadAccountStorage(address, incarnation, key)
+	if err != nil {
+		return nil, err
+	}
+	slot := StorageSlot{Address: address, Incarnation: incarnation, Key: *key}
+	if _, ok := rr.reads.Storage[slot]; !ok {
+		rr.reads.Storage[slot] = common.CopyBytes(v)
+	}
+	return v, nil
+}
+
+func (rr *RecordingReader) ReadAccountCode(address common.Address, incarnation uint64) ([]byte, error) {
+	c, err := rr.r.ReadAccountCode(address, incarnation)
+	if err != nil {
+		return nil, err
+	}
+	k := CodeKey{Address: address, Incarnation: incarnation}
+	if _, ok := rr.reads.Code[k]; !ok {
+		rr.reads.Code[k] = common.CopyBytes(c)
+	}
+	return c, nil
+}
+
+// ReadAccountCodeSize records the whole code, so that the replay can serve
+// both the size and any later read of the code itself.
+func (rr *RecordingReader) ReadAccountCodeSize(address common.Address, incarnation uint64) (int, error) {
+	c, err := rr.ReadAccountCode(address, incarnation)
+	return len(c), err
+}
+
+func (rr *RecordingReader) ReadAccountIncarnation(address common.Address) (uint64, error) {
+	inc, err := rr.r.ReadAccountIncarnation(address)
+	if err != nil {
+		return 0, err
+	}
+	if _, ok := rr.reads.Incarnations[address]; !ok {
+		rr.reads.Incarnations[address] = inc
+	}
+	return inc, nil
+}
+
+// ReplayReader serves the state recorded by a RecordingReader, it fails with
+// ErrNotRecorded on anything else.
+type ReplayReader struct {
+	reads *StateReads
+}
+
+func NewReplayReader(reads *StateReads) *ReplayReader {
+	return &ReplayReader{reads: reads}
+}
+
+func (r *ReplayReader) ReadAccountData(address common.Address) (*accounts.Account, error) {
+	a, ok := r.reads.Accounts[address]
+	if !ok {
+		return nil, fmt.Errorf("%w: account %x", ErrNotRecorded, address)
+	}
+	if a == nil {
+		return nil, nil
+	}
+	cpy := new(accounts.Account)
+	cpy.Copy(a)
+	return cpy, nil
+}
+
+func (r *ReplayReader) ReadAccountDataForDebug(address common.Address) (*accounts.Account, error) {
+	return r.ReadAccountData(address)
+}
+
+func (r *ReplayReader) ReadAccountStorage(address common.Address, incarnation uint64, key *common.Hash) ([]byte, error) {
+	v, ok := r.reads.Storage[StorageSlot{Address: address, Incarnation: incarnation, Key: *key}]
+	if !ok {
+		return nil, fmt.Errorf("%w: storage %x/%d/%x", ErrNotRecorded, address, incarnation, *key)
+	}
+	return v, nil
+}
+
+func (r *ReplayReader) ReadAccountCode(address common.Address, incarnation uint64) ([]byte, error) {
+	c, ok := r.reads.Code[CodeKey{Address: address, Incarnation: incarnation}]
+	if !ok {
+		return nil, fmt.Errorf("%w: code %x/%d", ErrNotRecorded, address, incarnation)
+	}
+	return c, nil
+}
+
+func (r *ReplayReader) ReadAccountCodeSize(address common.Address, incarnation uint64) (int, error) {
+	c, err := r.ReadAccountCode(address, incarnation)
+	return len(c), err
+}
+
+func (r *ReplayReader) ReadAccountIncarnation(address common.Address) (uint64, error) {
+	inc, ok := r.reads.Incarnations[address]
+	if !ok {
+		return 0, fmt.Errorf("%w: incarnation %x", ErrNotRecorded, address)
+	}
+	return inc, nil
+}
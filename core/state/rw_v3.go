@@ -19,6 +19,7 @@ package state
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -43,6 +44,11 @@ import (
 
 var execTxsDone = metrics.NewCounter(`exec_txs_done`)
 
+// errRecreatedContract is returned by StateWriterBufferedV3 when a contract is re-created at the
+// address of a self-destructed one: the storage to delete can't be listed by a parallel worker,
+// so the transaction has to be re-executed serially.
+var errRecreatedContract = errors.New("re-created contract must be executed serially")
+
 type StateV3 struct {
 	domains      *libstate.SharedDomains
 	triggerLock  sync.Mutex
@@ -348,6 +354,15 @@ func (rs *StateV3) ReadsValid(readLists map[string]*libstate.KvList) bool {
 	return rs.domains.ReadsValid(readLists)
 }
 
+// ResettableStateWriter - writer of exec3 workers: StateWriterV3 does write to SharedDomains,
+// StateWriterBufferedV3 does accumulate the write set of the txn for conflict-resolution.
+type ResettableStateWriter interface {
+	StateWriter
+	ResetWriteSet()
+	WriteSet() map[string]*libstate.KvList
+	PrevAndDels() (map[string][]byte, map[string]*accounts.Account, map[string][]byte, map[string]uint64)
+}
+
 // StateWriterBufferedV3 - used by parallel workers to accumulate updates and then send them to conflict-resolution.
 type StateWriterBufferedV3 struct {
 	rs           *StateV3
@@ -395,16 +410,7 @@ func (w *StateWriterBufferedV3) UpdateAccountData(address common.Address, origin
 		fmt.Printf("acc %x: {Balance: %d, Nonce: %d, Inc: %d, CodeHash: %x}\n", address, &account.Balance, account.Nonce, account.Incarnation, account.CodeHash)
 	}
 	if original.Incarnation > account.Incarnation {
-		//del, before create: to clanup code/storage
-		if err := w.rs.domains.DomainDel(kv.CodeDomain, address[:], nil, nil, 0); err != nil {
-			return err
-		}
-		if err := w.rs.domains.IterateStoragePrefix(address[:], func(k, v []byte, step uint64) error {
-			w.writeLists[kv.StorageDomain.String()].Push(string(k), nil)
-			return nil
-		}); err != nil {
-			return err
-		}
+		return errRecreatedContract
 	}
 	value := accounts.SerialiseV3(account)
 	if w.accumulator != nil {
@@ -666,10 +672,12 @@ func (r *ReaderV3) ReadAccountIncarnation(address common.Address) (uint64, error
 	return 0, nil
 }
 
+// ReaderParallelV3 - used by parallel workers, records the read values for the conflict-resolution.
 type ReaderParallelV3 struct {
 	txNum     uint64
 	trace     bool
 	sd        *libstate.SharedDomains
+	tx        kv.TemporalTx
 	composite []byte
 
 	discardReadList bool
@@ -688,13 +696,27 @@ func NewReaderParallelV3(sd *libstate.SharedDomains) *ReaderParallelV3 {
 func (r *ReaderParallelV3) DiscardReadList()                     { r.discardReadList = true }
 func (r *ReaderParallelV3) SetTxNum(txNum uint64)                { r.txNum = txNum }
 func (r *ReaderParallelV3) GetTxNum() uint64                     { return r.txNum }
-func (r *ReaderParallelV3) SetTx(tx kv.TemporalTx)               {}
+func (r *ReaderParallelV3) SetTx(tx kv.TemporalTx)               { r.tx = tx }
 func (r *ReaderParallelV3) ReadSet() map[string]*libstate.KvList { return r.readLists }
 func (r *ReaderParallelV3) SetTrace(trace bool)                  { r.trace = trace }
 func (r *ReaderParallelV3) ResetReadSet()                        { r.readLists = newReadList() }
 
+// getLatest reads the updates applied since the last flush from SharedDomains, and the rest from
+// the RoTx of the worker: the RwTx of SharedDomains can't be used by many goroutines.
+func (r *ReaderParallelV3) getLatest(domain kv.Domain, k []byte) ([]byte, error) {
+	if v, ok := r.sd.GetLatestFromMem(domain, k); ok {
+		return v, nil
+	}
+	if r.tx == nil {
+		v, _, err := r.sd.GetLatest(domain, k)
+		return v, err
+	}
+	v, _, err := r.tx.GetLatest(domain, k)
+	return v, err
+}
+
 func (r *ReaderParallelV3) ReadAccountData(address common.Address) (*accounts.Account, error) {
-	enc, _, err := r.sd.GetLatest(kv.AccountsDomain, address[:])
+	enc, err := r.getLatest(kv.AccountsDomain, address[:])
 	if err != nil {
 		return nil, err
 	}
@@ -722,7 +744,7 @@ func (r *ReaderParallelV3) ReadAccountData(address common.Address) (*accounts.Ac
 // ReadAccountDataForDebug - is like ReadAccountData, but without adding key to `readList`.
 // Used to get `prev` account balance
 func (r *ReaderParallelV3) ReadAccountDataForDebug(address common.Address) (*accounts.Account, error) {
-	enc, _, err := r.sd.GetLatest(kv.AccountsDomain, address[:])
+	enc, err := r.getLatest(kv.AccountsDomain, address[:])
 	if err != nil {
 		return nil, err
 	}
@@ -745,7 +767,7 @@ func (r *ReaderParallelV3) ReadAccountDataForDebug(address common.Address) (*acc
 
 func (r *ReaderParallelV3) ReadAccountStorage(address common.Address, incarnation uint64, key *common.Hash) ([]byte, error) {
 	r.composite = append(append(r.composite[:0], address[:]...), key.Bytes()...)
-	enc, _, err := r.sd.GetLatest(kv.StorageDomain, r.composite)
+	enc, err := r.getLatest(kv.StorageDomain, r.composite)
 	if err != nil {
 		return nil, err
 	}
@@ -763,7 +785,7 @@ func (r *ReaderParallelV3) ReadAccountStorage(address common.Address, incarnatio
 }
 
 func (r *ReaderParallelV3) ReadAccountCode(address common.Address, incarnation uint64) ([]byte, error) {
-	enc, _, err := r.sd.GetLatest(kv.CodeDomain, address[:])
+	enc, err := r.getLatest(kv.CodeDomain, address[:])
 	if err != nil {
 		return nil, err
 	}
@@ -778,7 +800,7 @@ func (r *ReaderParallelV3) ReadAccountCode(address common.Address, incarnation u
 }
 
 func (r *ReaderParallelV3) ReadAccountCodeSize(address common.Address, incarnation uint64) (int, error) {
-	enc, _, err := r.sd.GetLatest(kv.CodeDomain, address[:])
+	enc, err := r.getLatest(kv.CodeDomain, address[:])
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,179 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync/atomic"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+var (
+	analysisCacheLimit = dbg.EnvInt("JD_SHARED_LRU", 4096)
+
+	// SharedAnalysisCache holds the jumpdest analysis shared by all the EVMs of the
+	// process. It's nil when disabled with JD_SHARED_LRU=0.
+	SharedAnalysisCache = NewAnalysisCache(analysisCacheLimit)
+
+	mxAnalysisCacheHit  = metrics.GetOrCreateCounter("vm_analysis_cache_hit")
+	mxAnalysisCacheMiss = metrics.GetOrCreateCounter("vm_analysis_cache_miss")
+)
+
+// AnalysisCache is a bounded cache of jumpdest analysis keyed by code hash. Unlike
+// JumpDestCache, which is owned by a single EVM, it is safe for concurrent use, so
+// that execution workers and RPC calls share the analysis of hot contracts.
+type AnalysisCache struct {
+	lru *lru.Cache[libcommon.Hash, *analysisEntry]
+}
+
+type analysisEntry struct {
+	addr libcommon.Address // an address the code is deployed at, used to warm the cache up
+	bits bitvec
+	hits atomic.Uint64
+}
+
+// NewAnalysisCache returns a cache holding the analysis of up to size contracts,
+// or nil if size is not positive. A nil cache analyses the code on every call.
+func NewAnalysisCache(size int) *AnalysisCache {
+	if size <= 0 {
+		return nil
+	}
+	c, err := lru.New[libcommon.Hash, *analysisEntry](size)
+	if err != nil {
+		panic(err)
+	}
+	return &AnalysisCache{lru: c}
+}
+
+// analysis returns the jumpdest analysis of the code, analysing and caching it on a miss.
+func (c *AnalysisCache) analysis(codeHash libcommon.Hash, addr *libcommon.Address, code []byte) bitvec {
+	if c == nil {
+		return codeBitmap(code)
+	}
+	if e, ok := c.lru.Get(codeHash); ok {
+		e.hits.Add(1)
+		mxAnalysisCacheHit.Inc()
+		return e.bits
+	}
+	mxAnalysisCacheMiss.Inc()
+	e := &analysisEntry{bits: codeBitmap(code)}
+	if addr != nil {
+		e.addr = *addr
+	}
+	c.lru.Add(codeHash, e)
+	return e.bits
+}
+
+// Len returns the number of cached analyses.
+func (c *AnalysisCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	return c.lru.Len()
+}
+
+// HotContract identifies the code of a contract by the address it's deployed at.
+type HotContract struct {
+	Address  libcommon.Address `json:"address"`
+	CodeHash libcommon.Hash    `json:"codeHash"`
+	Hits     uint64            `json:"hits"`
+}
+
+// Hottest returns up to limit cached contracts, most hit first. Code which was
+// not executed from a known address (e.g. initcode) is left out.
+func (c *AnalysisCache) Hottest(limit int) []HotContract {
+	if c == nil {
+		return nil
+	}
+	var hot []HotContract
+	for _, codeHash := range c.lru.Keys() {
+		e, ok := c.lru.Peek(codeHash)
+		if !ok || e.addr == (libcommon.Address{}) {
+			continue
+		}
+		hot = append(hot, HotContract{Address: e.addr, CodeHash: codeHash, Hits: e.hits.Load()})
+	}
+	sort.SliceStable(hot, func(i, j int) bool { return hot[i].Hits > hot[j].Hits })
+	if len(hot) > limit {
+		hot = hot[:limit]
+	}
+	return hot
+}
+
+// Warm analyses the code of the given contracts, keeping their hit counts. The code
+// is read with readCode and skipped if it doesn't match the expected code hash.
+// It returns the number of analysed contracts.
+func (c *AnalysisCache) Warm(contracts []HotContract, readCode func(libcommon.Address) ([]byte, error)) (int, error) {
+	if c == nil {
+		return 0, nil
+	}
+	var warmed int
+	// least hit first, so that the hottest contracts are the most recently used
+	for i := len(contracts) - 1; i >= 0; i-- {
+		hc := contracts[i]
+		if c.lru.Contains(hc.CodeHash) {
+			continue
+		}
+		code, err := readCode(hc.Address)
+		if err != nil {
+			return warmed, err
+		}
+		if len(code) == 0 || crypto.Keccak256Hash(code) != hc.CodeHash {
+			continue
+		}
+		e := &analysisEntry{addr: hc.Address, bits: codeBitmap(code)}
+		e.hits.Store(hc.Hits)
+		c.lru.Add(hc.CodeHash, e)
+		warmed++
+	}
+	return warmed, nil
+}
+
+// SaveHottest writes up to limit of the hottest cached contracts to a json file,
+// to warm the cache up with LoadHottest and Warm after a restart.
+func (c *AnalysisCache) SaveHottest(fileName string, limit int) error {
+	data, err := json.Marshal(c.Hottest(limit))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, data, 0644)
+}
+
+// LoadHottest reads the contracts written by SaveHottest. A missing file is not an error.
+func LoadHottest(fileName string) ([]HotContract, error) {
+	data, err := os.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var hot []HotContract
+	if err := json.Unmarshal(data, &hot); err != nil {
+		return nil, err
+	}
+	return hot, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+)
+
+func TestAnalysisCache(t *testing.T) {
+	t.Parallel()
+	codes := map[libcommon.Address][]byte{
+		libcommon.HexToAddress("0x01"): {byte(PUSH1), byte(JUMPDEST), byte(JUMPDEST)},
+		libcommon.HexToAddress("0x02"): {byte(PUSH2), byte(JUMPDEST), byte(JUMPDEST), byte(JUMPDEST)},
+		libcommon.HexToAddress("0x03"): {byte(JUMPDEST)},
+	}
+	c := NewAnalysisCache(2)
+	// the n-th contract is executed n times, the first one gets evicted
+	for n := 1; n <= 3; n++ {
+		addr := libcommon.BytesToAddress([]byte{byte(n)})
+		code := codes[addr]
+		hash := crypto.Keccak256Hash(code)
+		for i := 0; i < n; i++ {
+			if have, want := c.analysis(hash, &addr, code), codeBitmap(code); !reflect.DeepEqual(have, want) {
+				t.Fatalf("%x: have %v, want %v", addr, have, want)
+			}
+		}
+	}
+	if c.Len() != 2 {
+		t.Fatalf("have %d entries, want 2", c.Len())
+	}
+	// initcode is not persisted, and evicts the second contract
+	initcode := []byte{byte(STOP)}
+	c.analysis(crypto.Keccak256Hash(initcode), nil, initcode)
+
+	hot := c.Hottest(10)
+	if len(hot) != 1 || hot[0].Address != libcommon.HexToAddress("0x03") || hot[0].Hits != 2 {
+		t.Fatalf("unexpected hottest contracts %+v", hot)
+	}
+
+	fileName := filepath.Join(t.TempDir(), "hot.json")
+	if err := c.SaveHottest(fileName, 10); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadHottest(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, hot) {
+		t.Fatalf("have %+v, want %+v", loaded, hot)
+	}
+	if missing, err := LoadHottest(filepath.Join(t.TempDir(), "missing.json")); missing != nil || err != nil {
+		t.Fatalf("have %v, %v for a missing file", missing, err)
+	}
+
+	// code changed since it was saved
+	loaded = append(loaded, HotContract{Address: libcommon.HexToAddress("0x01"), CodeHash: libcommon.Hash{1}})
+	warm := NewAnalysisCache(2)
+	warmed, err := warm.Warm(loaded, func(addr libcommon.Address) ([]byte, error) { return codes[addr], nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if warmed != 1 || warm.Len() != 1 || !reflect.DeepEqual(warm.Hottest(10), hot) {
+		t.Fatalf("warmed %d contracts: %+v", warmed, warm.Hottest(10))
+	}
+
+	errRead := errors.New("read failed")
+	if _, err := NewAnalysisCache(2).Warm(hot, func(libcommon.Address) ([]byte, error) { return nil, errRead }); !errors.Is(err, errRead) {
+		t.Fatalf("have %v, want %v", err, errRead)
+	}
+
+	var disabled *AnalysisCache
+	if have, want := disabled.analysis(libcommon.Hash{}, nil, codes[libcommon.HexToAddress("0x02")]), codeBitmap(codes[libcommon.HexToAddress("0x02")]); !reflect.DeepEqual(have, want) {
+		t.Fatalf("have %v, want %v", have, want)
+	}
+}
@@ -69,6 +69,18 @@ type Contract struct {
 
 	Gas   uint64
 	value *uint256.Int
+
+	// EOF execution context. Code holds the bytes of the currently executing
+	// code section when Container is set.
+	Container   *Container
+	CodeSection uint64
+	returnStack []returnFrame
+}
+
+// returnFrame is an entry of the EOF return stack pushed by CALLF.
+type returnFrame struct {
+	section uint64
+	pc      uint64
 }
 
 type JumpDestCache struct {
@@ -94,7 +106,7 @@ func (c *JumpDestCache) LogStats() {
 	if c == nil || !c.trace {
 		return
 	}
-	log.Warn("[dbg] JumpDestCache", "hit", c.hit, "total", c.total, "limit", jumpDestCacheLimit, "ratio", fmt.Sprintf("%.2f", float64(c.hit)/float64(c.total)))
+	log.Warn("[dbg] JumpDestCache", "hit", c.hit, "total", c.total, "limit", jumpDestCacheLimit, "ratio", fmt.Sprintf("%.2f", float64(c.hit)/float64(c.total)), "shared", SharedAnalysisCache.Len())
 }
 
 // NewContract returns a new contract environment for the execution of EVM.
@@ -140,9 +152,9 @@ func (c *Contract) isCode(udest uint64) bool {
 		c.jumpdests.total++
 		analysis, exist := c.jumpdests.Get(c.CodeHash)
 		if !exist {
-			// Do the analysis and save in parent context
+			// Take the analysis from the cache shared across EVMs (or do it) and save in parent context
 			// We do not need to store it in c.analysis
-			analysis = codeBitmap(c.Code)
+			analysis = SharedAnalysisCache.analysis(c.CodeHash, c.CodeAddr, c.Code)
 			c.jumpdests.Add(c.CodeHash, analysis)
 		} else {
 			c.jumpdests.hit++
@@ -240,3 +252,21 @@ func (c *Contract) SetCodeOptionalHash(addr *libcommon.Address, codeAndHash *cod
 	c.CodeHash = codeAndHash.hash
 	c.CodeAddr = addr
 }
+
+// SetEOFContainer sets the parsed EOF container of the contract and points
+// Code at its first code section.
+func (c *Contract) SetEOFContainer(container *Container) {
+	c.Container = container
+	c.setCodeSection(0)
+}
+
+// IsEOF returns whether the contract executes an EOF container.
+func (c *Contract) IsEOF() bool {
+	return c.Container != nil
+}
+
+// setCodeSection switches execution to the given EOF code section.
+func (c *Contract) setCodeSection(section uint64) {
+	c.CodeSection = section
+	c.Code = c.Container.codeSections[section]
+}
@@ -0,0 +1,465 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/erigontech/erigon/params"
+)
+
+const (
+	offsetVersion   = 2
+	offsetTypesKind = 3
+
+	kindTypes     = 1
+	kindCode      = 2
+	kindContainer = 3
+	kindData      = 0xff
+
+	eofFormatByte = 0xef
+	eof1Version   = 1
+
+	maxInputItems        = 127
+	maxOutputItems       = 127
+	maxStackHeight       = 1023
+	maxCodeSections      = 1024
+	maxContainerSections = 256
+	maxReturnStackDepth  = 1024
+
+	nonReturningFunction = 0x80
+)
+
+var eofMagic = []byte{eofFormatByte, 0x00}
+
+var (
+	errInvalidMagic               = errors.New("invalid magic")
+	errUndefinedInstruction       = errors.New("undefined instruction")
+	errTruncatedImmediate         = errors.New("truncated immediate")
+	errInvalidSectionArgument     = errors.New("invalid section argument")
+	errInvalidCallArgument        = errors.New("callf into non-returning section")
+	errInvalidDataloadNArgument   = errors.New("invalid dataloadN argument")
+	errInvalidJumpDest            = errors.New("invalid jump destination")
+	errInvalidBackwardJump        = errors.New("invalid backward jump")
+	errInvalidOutputs             = errors.New("invalid number of outputs")
+	errInvalidMaxStackHeight      = errors.New("invalid max stack height")
+	errInvalidCodeTermination     = errors.New("invalid code termination")
+	errEOFCreateWithTruncatedData = errors.New("eofcreate with truncated data section")
+	errOrphanedSubcontainer       = errors.New("subcontainer not referenced at all")
+	errIncompatibleContainerKind  = errors.New("incompatible container kind")
+	errStopInInitCode             = errors.New("initcode contains a RETURN or STOP opcode")
+	errTruncatedTopLevelContainer = errors.New("truncated top level container")
+	errUnreachableCode            = errors.New("unreachable code")
+	errInvalidNonReturning        = errors.New("invalid non-returning flag, bad RETF")
+	errInvalidVersion             = errors.New("invalid version")
+	errMissingTypeHeader          = errors.New("missing type header")
+	errInvalidTypeSize            = errors.New("invalid type section size")
+	errMissingCodeHeader          = errors.New("missing code header")
+	errInvalidCodeSize            = errors.New("invalid code size")
+	errInvalidContainerSize       = errors.New("invalid container size")
+	errMissingDataHeader          = errors.New("missing data header")
+	errMissingTerminator          = errors.New("missing header terminator")
+	errTooManyInputs              = errors.New("invalid type content, too many inputs")
+	errTooManyOutputs             = errors.New("invalid type content, too many outputs")
+	errInvalidSection0Type        = errors.New("invalid section 0 type, input and output should be zero and non-returning (0x80)")
+	errTooLargeMaxStackHeight     = errors.New("invalid type content, max stack height exceeds limit")
+	errInvalidContainerArgument   = errors.New("invalid container argument")
+	errStackUnderflow             = errors.New("stack underflow")
+	errStackOverflow              = errors.New("stack overflow")
+)
+
+// HasEOFMagic returns true if code starts with the EOF magic 0xEF00 (EIP-3540).
+func HasEOFMagic(code []byte) bool {
+	return len(code) >= len(eofMagic) && bytes.Equal(eofMagic, code[:len(eofMagic)])
+}
+
+// isEOFVersion1 returns true if the code's version byte equals eof1Version. It
+// does not verify the EOF magic is valid.
+func isEOFVersion1(code []byte) bool {
+	return len(code) > offsetVersion && code[offsetVersion] == eof1Version
+}
+
+// Container is an EOF container object (EIP-3540).
+type Container struct {
+	types             []*functionMetadata
+	codeSections      [][]byte
+	subContainers     []*Container
+	subContainerCodes [][]byte
+	data              []byte
+	dataSize          int // might be more than len(data) for not yet deployed containers
+}
+
+// functionMetadata is an EOF function signature.
+type functionMetadata struct {
+	inputs           uint8
+	outputs          uint8
+	maxStackIncrease uint16
+}
+
+// maxHeight returns the maximum stack height the function may reach,
+// counting its own inputs.
+func (meta *functionMetadata) maxHeight() int {
+	return int(meta.inputs) + int(meta.maxStackIncrease)
+}
+
+// checkInputs checks the current minimum stack (stackMin) against the required inputs
+// of the metadata, and returns an error if the stack is too shallow.
+func (meta *functionMetadata) checkInputs(stackMin int) error {
+	if int(meta.inputs) > stackMin {
+		return errStackUnderflow
+	}
+	return nil
+}
+
+// checkStackMax checks if current maximum stack combined with the
+// function max stack will result in a stack overflow, and if so returns an error.
+func (meta *functionMetadata) checkStackMax(stackMax int) error {
+	if stackMax+int(meta.maxStackIncrease) > int(params.StackLimit) {
+		return errStackOverflow
+	}
+	return nil
+}
+
+// CodeSection returns the code of the section with the given index.
+func (c *Container) CodeSection(section uint64) []byte {
+	return c.codeSections[section]
+}
+
+// Size returns the length of the serialized container.
+func (c *Container) Size() int {
+	size := c.headerSize() + 4*len(c.types) + len(c.data)
+	for _, code := range c.codeSections {
+		size += len(code)
+	}
+	for _, sub := range c.subContainerCodes {
+		size += len(sub)
+	}
+	return size
+}
+
+func (c *Container) headerSize() int {
+	size := 3 + 3 + 3 + 2*len(c.codeSections) + 3 + 1
+	if len(c.subContainers) > 0 {
+		size += 3 + 4*len(c.subContainers)
+	}
+	return size
+}
+
+// MarshalBinary encodes an EOF container into binary format.
+func (c *Container) MarshalBinary() []byte {
+	b := make([]byte, 0, c.Size())
+	// Build header.
+	b = append(b, eofMagic...)
+	b = append(b, eof1Version)
+	b = append(b, kindTypes)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(c.types)*4))
+	b = append(b, kindCode)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(c.codeSections)))
+	for _, code := range c.codeSections {
+		b = binary.BigEndian.AppendUint16(b, uint16(len(code)))
+	}
+	if len(c.subContainers) > 0 {
+		b = append(b, kindContainer)
+		b = binary.BigEndian.AppendUint16(b, uint16(len(c.subContainers)))
+		for _, sub := range c.subContainerCodes {
+			b = binary.BigEndian.AppendUint32(b, uint32(len(sub)))
+		}
+	}
+	b = append(b, kindData)
+	b = binary.BigEndian.AppendUint16(b, uint16(c.dataSize))
+	b = append(b, 0) // terminator
+
+	// Write section contents.
+	for _, ty := range c.types {
+		b = append(b, ty.inputs, ty.outputs, byte(ty.maxStackIncrease>>8), byte(ty.maxStackIncrease))
+	}
+	for _, code := range c.codeSections {
+		b = append(b, code...)
+	}
+	for _, sub := range c.subContainerCodes {
+		b = append(b, sub...)
+	}
+	b = append(b, c.data...)
+	return b
+}
+
+// UnmarshalBinary decodes an EOF container. The data section of the
+// container must be complete and no trailing bytes are allowed.
+func (c *Container) UnmarshalBinary(b []byte) error {
+	return c.unmarshal(b, false)
+}
+
+// eofHeader is the decoded header of an EOF container.
+type eofHeader struct {
+	typesSize      int
+	codeSizes      []int
+	containerSizes []int
+	dataSize       int
+	size           int // length of the header itself
+}
+
+// bodySize returns the length of the container body as declared in the header.
+func (h *eofHeader) bodySize() int {
+	size := h.typesSize + h.dataSize
+	for _, s := range h.codeSizes {
+		size += s
+	}
+	for _, s := range h.containerSizes {
+		size += s
+	}
+	return size
+}
+
+func parseEOFHeader(b []byte) (*eofHeader, error) {
+	if !HasEOFMagic(b) {
+		return nil, fmt.Errorf("%w: want %x", errInvalidMagic, eofMagic)
+	}
+	if !isEOFVersion1(b) {
+		if len(b) <= offsetVersion {
+			return nil, fmt.Errorf("%w: missing version", errInvalidVersion)
+		}
+		return nil, fmt.Errorf("%w: have %d, want %d", errInvalidVersion, b[offsetVersion], eof1Version)
+	}
+	var (
+		h   = &eofHeader{}
+		idx = offsetTypesKind
+		err error
+	)
+	// Parse type section header.
+	var kind int
+	kind, h.typesSize, err = parseSection(b, idx)
+	if err != nil {
+		return nil, err
+	}
+	if kind != kindTypes {
+		return nil, fmt.Errorf("%w: found section kind %x instead", errMissingTypeHeader, kind)
+	}
+	if h.typesSize < 4 || h.typesSize%4 != 0 {
+		return nil, fmt.Errorf("%w: type section size must be divisible by 4, have %d", errInvalidTypeSize, h.typesSize)
+	}
+	if h.typesSize/4 > maxCodeSections {
+		return nil, fmt.Errorf("%w: type section must not exceed 4*%d, have %d", errInvalidTypeSize, maxCodeSections, h.typesSize)
+	}
+	idx += 3
+
+	// Parse code section header.
+	kind, h.codeSizes, err = parseSectionList(b, idx, 2)
+	if err != nil {
+		return nil, err
+	}
+	if kind != kindCode {
+		return nil, fmt.Errorf("%w: found section kind %x instead", errMissingCodeHeader, kind)
+	}
+	if len(h.codeSizes) != h.typesSize/4 {
+		return nil, fmt.Errorf("%w: mismatch of code sections found and type signatures, types %d, code %d", errInvalidCodeSize, h.typesSize/4, len(h.codeSizes))
+	}
+	idx += 3 + 2*len(h.codeSizes)
+
+	// Parse the optional container section header.
+	if idx < len(b) && b[idx] == kindContainer {
+		_, h.containerSizes, err = parseSectionList(b, idx, 4)
+		if err != nil {
+			return nil, err
+		}
+		if len(h.containerSizes) > maxContainerSections {
+			return nil, fmt.Errorf("%w: number of container sections must not exceed %d, have %d", errInvalidContainerSize, maxContainerSections, len(h.containerSizes))
+		}
+		idx += 3 + 4*len(h.containerSizes)
+	}
+
+	// Parse data section header.
+	kind, h.dataSize, err = parseSection(b, idx)
+	if err != nil {
+		return nil, err
+	}
+	if kind != kindData {
+		return nil, fmt.Errorf("%w: found section kind %x instead", errMissingDataHeader, kind)
+	}
+	idx += 3
+
+	// Check for terminator.
+	if idx >= len(b) {
+		return nil, fmt.Errorf("%w: no terminator found", errMissingTerminator)
+	}
+	if b[idx] != 0 {
+		return nil, fmt.Errorf("%w: have %x", errMissingTerminator, b[idx])
+	}
+	h.size = idx + 1
+	return h, nil
+}
+
+// unmarshal decodes an EOF container. If allowTruncatedData is set, the data
+// section may be shorter than declared in the header, which is only the case
+// for subcontainers deployed through RETURNCONTRACT.
+func (c *Container) unmarshal(b []byte, allowTruncatedData bool) error {
+	h, err := parseEOFHeader(b)
+	if err != nil {
+		return err
+	}
+	// Verify overall container size.
+	expectedSize := h.size + h.bodySize()
+	if len(b) < expectedSize-h.dataSize {
+		return fmt.Errorf("%w: have %d, want %d", errInvalidContainerSize, len(b), expectedSize)
+	}
+	if len(b) > expectedSize {
+		return fmt.Errorf("%w: have %d, want %d", errInvalidContainerSize, len(b), expectedSize)
+	}
+	idx := h.size
+
+	// Parse types section.
+	var types = make([]*functionMetadata, 0, h.typesSize/4)
+	for i := 0; i < h.typesSize/4; i++ {
+		sig := &functionMetadata{
+			inputs:           b[idx+i*4],
+			outputs:          b[idx+i*4+1],
+			maxStackIncrease: binary.BigEndian.Uint16(b[idx+i*4+2:]),
+		}
+		if sig.inputs > maxInputItems {
+			return fmt.Errorf("%w for section %d: have %d", errTooManyInputs, i, sig.inputs)
+		}
+		if sig.outputs > maxOutputItems && sig.outputs != nonReturningFunction {
+			return fmt.Errorf("%w for section %d: have %d", errTooManyOutputs, i, sig.outputs)
+		}
+		if sig.maxHeight() > maxStackHeight {
+			return fmt.Errorf("%w for section %d: have %d", errTooLargeMaxStackHeight, i, sig.maxHeight())
+		}
+		types = append(types, sig)
+	}
+	if types[0].inputs != 0 || types[0].outputs != nonReturningFunction {
+		return fmt.Errorf("%w: have %d, %d", errInvalidSection0Type, types[0].inputs, types[0].outputs)
+	}
+	c.types = types
+	idx += h.typesSize
+
+	// Parse code sections.
+	var codeSections = make([][]byte, 0, len(h.codeSizes))
+	for _, size := range h.codeSizes {
+		codeSections = append(codeSections, b[idx:idx+size])
+		idx += size
+	}
+	c.codeSections = codeSections
+
+	// Parse the optional container sections.
+	if len(h.containerSizes) > 0 {
+		subContainerCodes := make([][]byte, 0, len(h.containerSizes))
+		subContainers := make([]*Container, 0, len(h.containerSizes))
+		for i, size := range h.containerSizes {
+			subContainer := new(Container)
+			if err := subContainer.unmarshal(b[idx:idx+size], true); err != nil {
+				return fmt.Errorf("subcontainer %d: %w", i, err)
+			}
+			subContainers = append(subContainers, subContainer)
+			subContainerCodes = append(subContainerCodes, b[idx:idx+size])
+			idx += size
+		}
+		c.subContainers = subContainers
+		c.subContainerCodes = subContainerCodes
+	}
+
+	// Parse data section.
+	end := len(b)
+	if !allowTruncatedData && end < idx+h.dataSize {
+		return fmt.Errorf("%w: have %d, want %d", errTruncatedTopLevelContainer, len(b), expectedSize)
+	}
+	c.data = b[idx:end]
+	c.dataSize = h.dataSize
+	return nil
+}
+
+// parseSection decodes a (kind, size) pair from an EOF header.
+func parseSection(b []byte, idx int) (kind, size int, err error) {
+	if idx+3 > len(b) {
+		return 0, 0, fmt.Errorf("%w: truncated header", errInvalidContainerSize)
+	}
+	kind = int(b[idx])
+	size = int(binary.BigEndian.Uint16(b[idx+1:]))
+	return kind, size, nil
+}
+
+// parseSectionList decodes a (kind, len, []sizes) section list from an EOF
+// header, where each size is encoded with the given number of bytes.
+func parseSectionList(b []byte, idx int, width int) (kind int, list []int, err error) {
+	if idx+3 > len(b) {
+		return 0, nil, fmt.Errorf("%w: truncated header", errInvalidContainerSize)
+	}
+	kind = int(b[idx])
+	count := int(binary.BigEndian.Uint16(b[idx+1:]))
+	if count == 0 {
+		return 0, nil, fmt.Errorf("%w: section kind %x has no entries", errInvalidCodeSize, kind)
+	}
+	idx += 3
+	if idx+count*width > len(b) {
+		return 0, nil, fmt.Errorf("%w: truncated section list", errInvalidContainerSize)
+	}
+	list = make([]int, 0, count)
+	for i := 0; i < count; i++ {
+		var size int
+		if width == 2 {
+			size = int(binary.BigEndian.Uint16(b[idx+i*width:]))
+		} else {
+			size = int(binary.BigEndian.Uint32(b[idx+i*width:]))
+		}
+		if size == 0 {
+			return 0, nil, fmt.Errorf("%w: section kind %x entry %d is empty", errInvalidCodeSize, kind, i)
+		}
+		list = append(list, size)
+	}
+	return kind, list, nil
+}
+
+// splitEOFInitcode splits the data of an EOF contract creation transaction
+// (EIP-7698) into the initcontainer and the calldata following it.
+func splitEOFInitcode(data []byte) (initcode, calldata []byte, err error) {
+	h, err := parseEOFHeader(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	size := h.size + h.bodySize()
+	if size > len(data) {
+		return nil, nil, fmt.Errorf("%w: have %d, want %d", errTruncatedTopLevelContainer, len(data), size)
+	}
+	return data[:size], data[size:], nil
+}
+
+// ParseAndValidateEOF decodes an EOF container and validates it against the
+// EOF v1 rules, either as initcode or as runtime code.
+func ParseAndValidateEOF(code []byte, isInitcode bool) (*Container, error) {
+	var c Container
+	if err := c.UnmarshalBinary(code); err != nil {
+		return nil, err
+	}
+	if err := c.ValidateCode(&eofInstructionSet, isInitcode); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// String returns a human-readable summary of the container, listing the
+// code sections with their signatures.
+func (c *Container) String() string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "types:%d code:%d containers:%d data:%d", len(c.types), len(c.codeSections), len(c.subContainers), c.dataSize)
+	for i, code := range c.codeSections {
+		ty := c.types[i]
+		fmt.Fprintf(&out, "\n  section %d: inputs=%d outputs=%d max_stack_increase=%d code=%x", i, ty.inputs, ty.outputs, ty.maxStackIncrease, code)
+	}
+	return out.String()
+}
@@ -0,0 +1,423 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/params"
+)
+
+// Status codes pushed onto the stack by EXTCALL, EXTDELEGATECALL and EXTSTATICCALL (EIP-7069).
+const (
+	extCallSuccess = 0
+	extCallRevert  = 1
+	extCallFailure = 2
+)
+
+// opRjump implements the RJUMP opcode.
+func opRjump(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		code   = scope.Contract.Code
+		offset = parseInt16(code[*pc+1:])
+	)
+	// move pc past op and operand (+3), add relative offset, subtract 1 to
+	// account for interpreter loop.
+	*pc = uint64(int64(*pc+3) + int64(offset) - 1)
+	return nil, nil
+}
+
+// opRjumpi implements the RJUMPI opcode
+func opRjumpi(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	condition := scope.Stack.Pop()
+	if condition.IsZero() {
+		// Not branching, just skip over immediate argument.
+		*pc += 2
+		return nil, nil
+	}
+	return opRjump(pc, interpreter, scope)
+}
+
+// opRjumpv implements the RJUMPV opcode
+func opRjumpv(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		code     = scope.Contract.Code
+		maxIndex = uint64(code[*pc+1]) + 1
+		idx      = scope.Stack.Pop()
+	)
+	if idx, overflow := idx.Uint64WithOverflow(); overflow || idx >= maxIndex {
+		// Index out-of-bounds, don't branch, just skip over immediate
+		// argument.
+		*pc += 1 + maxIndex*2
+		return nil, nil
+	}
+	offset := parseInt16(code[*pc+2+2*idx.Uint64():])
+	*pc = uint64(int64(*pc+2+maxIndex*2) + int64(offset) - 1)
+	return nil, nil
+}
+
+// opCallf implements the CALLF opcode
+func opCallf(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		code = scope.Contract.Code
+		idx  = binary.BigEndian.Uint16(code[*pc+1:])
+		typ  = scope.Contract.Container.types[idx]
+	)
+	if scope.Stack.Len()+int(typ.maxStackIncrease) > int(params.StackLimit) {
+		return nil, &ErrStackOverflow{stackLen: scope.Stack.Len(), limit: int(params.StackLimit) - int(typ.maxStackIncrease)}
+	}
+	if len(scope.Contract.returnStack) >= maxReturnStackDepth {
+		return nil, ErrReturnStackExceeded
+	}
+	scope.Contract.returnStack = append(scope.Contract.returnStack, returnFrame{
+		section: scope.Contract.CodeSection,
+		pc:      *pc + 3,
+	})
+	scope.Contract.setCodeSection(uint64(idx))
+	// the interpreter loop increments pc, wrapping it around to 0
+	*pc = math.MaxUint64
+	return nil, nil
+}
+
+// opRetf implements the RETF opcode
+func opRetf(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		last  = len(scope.Contract.returnStack) - 1
+		frame = scope.Contract.returnStack[last]
+	)
+	scope.Contract.returnStack = scope.Contract.returnStack[:last]
+	scope.Contract.setCodeSection(frame.section)
+	*pc = frame.pc - 1
+	return nil, nil
+}
+
+// opJumpf implements the JUMPF opcode
+func opJumpf(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		code = scope.Contract.Code
+		idx  = binary.BigEndian.Uint16(code[*pc+1:])
+		typ  = scope.Contract.Container.types[idx]
+	)
+	if scope.Stack.Len()+int(typ.maxStackIncrease) > int(params.StackLimit) {
+		return nil, &ErrStackOverflow{stackLen: scope.Stack.Len(), limit: int(params.StackLimit) - int(typ.maxStackIncrease)}
+	}
+	scope.Contract.setCodeSection(uint64(idx))
+	// the interpreter loop increments pc, wrapping it around to 0
+	*pc = math.MaxUint64
+	return nil, nil
+}
+
+// opDupN implements the DUPN opcode
+func opDupN(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	n := int(scope.Contract.Code[*pc+1]) + 1
+	scope.Stack.Dup(n)
+	*pc += 1 // move past immediate
+	return nil, nil
+}
+
+// opSwapN implements the SWAPN opcode
+func opSwapN(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	n := int(scope.Contract.Code[*pc+1]) + 1
+	scope.Stack.Swap(n + 1)
+	*pc += 1 // move past immediate
+	return nil, nil
+}
+
+// opExchange implements the EXCHANGE opcode
+func opExchange(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		imm  = scope.Contract.Code[*pc+1]
+		n    = int(imm>>4) + 1
+		m    = int(imm&0x0f) + 1
+		data = scope.Stack.Data
+		top  = len(data) - 1
+	)
+	data[top-n], data[top-n-m] = data[top-n-m], data[top-n]
+	*pc += 1 // move past immediate
+	return nil, nil
+}
+
+// opDataLoad implements the DATALOAD opcode
+func opDataLoad(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	stackItem := scope.Stack.Peek()
+	offset, overflow := stackItem.Uint64WithOverflow()
+	if overflow {
+		offset = math.MaxUint64
+	}
+	stackItem.SetBytes(getData(scope.Contract.Container.data, offset, 32))
+	return nil, nil
+}
+
+// opDataLoadN implements the DATALOADN opcode
+func opDataLoadN(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	offset := uint64(binary.BigEndian.Uint16(scope.Contract.Code[*pc+1:]))
+	scope.Stack.Push(new(uint256.Int).SetBytes(getData(scope.Contract.Container.data, offset, 32)))
+	*pc += 2 // move past immediates
+	return nil, nil
+}
+
+// opDataSize implements the DATASIZE opcode
+func opDataSize(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	scope.Stack.Push(new(uint256.Int).SetUint64(uint64(len(scope.Contract.Container.data))))
+	return nil, nil
+}
+
+// opDataCopy implements the DATACOPY opcode
+func opDataCopy(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		memOffset = scope.Stack.Pop()
+		offset    = scope.Stack.Pop()
+		size      = scope.Stack.Pop()
+	)
+	offset64, overflow := offset.Uint64WithOverflow()
+	if overflow {
+		offset64 = math.MaxUint64
+	}
+	// These values are checked for overflow during memory expansion calculation
+	// (the memorySize function on the opcode).
+	data := getData(scope.Contract.Container.data, offset64, size.Uint64())
+	scope.Memory.Set(memOffset.Uint64(), size.Uint64(), data)
+	return nil, nil
+}
+
+// opReturnDataLoad implements the RETURNDATALOAD opcode
+func opReturnDataLoad(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	stackItem := scope.Stack.Peek()
+	offset, overflow := stackItem.Uint64WithOverflow()
+	if overflow {
+		offset = math.MaxUint64
+	}
+	stackItem.SetBytes(getData(interpreter.returnData, offset, 32))
+	return nil, nil
+}
+
+// opEOFCreate implements the EOFCREATE opcode
+func opEOFCreate(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	if interpreter.readOnly {
+		return nil, ErrWriteProtection
+	}
+	var (
+		idx          = scope.Contract.Code[*pc+1]
+		value        = scope.Stack.Pop()
+		salt         = scope.Stack.Pop()
+		offset, size = scope.Stack.Pop(), scope.Stack.Pop()
+		input        = scope.Memory.GetCopy(int64(offset.Uint64()), int64(size.Uint64()))
+		gas          = scope.Contract.Gas
+	)
+	*pc += 1 // move past immediate
+	gas -= gas / 64
+	scope.Contract.UseGas(gas, tracing.GasChangeCallContractCreation2)
+	// reuse size int for stackvalue
+	stackValue := size
+	res, addr, returnGas, suberr := interpreter.evm.EOFCreate(scope.Contract, scope.Contract.Container.subContainerCodes[idx], input, gas, &value, &salt)
+	if suberr != nil {
+		stackValue.Clear()
+	} else {
+		stackValue.SetBytes(addr.Bytes())
+	}
+	scope.Stack.Push(&stackValue)
+	scope.Contract.RefundGas(returnGas, tracing.GasChangeCallLeftOverRefunded)
+
+	if suberr == ErrExecutionReverted {
+		interpreter.returnData = res // set REVERT data to return data buffer
+		return res, nil
+	}
+	interpreter.returnData = nil // clear dirty return data buffer
+	return nil, nil
+}
+
+// opReturnContract implements the RETURNCONTRACT opcode. It returns the
+// referenced subcontainer with the auxiliary data appended to its data section
+// as the code to be deployed.
+func opReturnContract(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		idx          = scope.Contract.Code[*pc+1]
+		offset, size = scope.Stack.Pop(), scope.Stack.Pop()
+		auxData      = scope.Memory.GetPtr(int64(offset.Uint64()), int64(size.Uint64()))
+		deploy       = *scope.Contract.Container.subContainers[idx]
+	)
+	dataSize := len(deploy.data) + len(auxData)
+	if dataSize < deploy.dataSize {
+		return nil, fmt.Errorf("%w: have %d, want at least %d", ErrInvalidEOFAuxData, dataSize, deploy.dataSize)
+	}
+	if dataSize > math.MaxUint16 {
+		return nil, fmt.Errorf("%w: data size %d exceeds limit", ErrInvalidEOFAuxData, dataSize)
+	}
+	deploy.data = append(libcommon.CopyBytes(deploy.data), auxData...)
+	deploy.dataSize = dataSize
+	return deploy.MarshalBinary(), errStopToken
+}
+
+// opExtCall implements the EXTCALL opcode
+func opExtCall(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	stack := scope.Stack
+	addr, inOffset, inSize, value := stack.Pop(), stack.Pop(), stack.Pop(), stack.Pop()
+	if interpreter.readOnly && !value.IsZero() {
+		return nil, ErrWriteProtection
+	}
+	// Get the arguments from the memory.
+	args := scope.Memory.GetPtr(int64(inOffset.Uint64()), int64(inSize.Uint64()))
+	return extCall(EXTCALL, libcommon.Address(addr.Bytes20()), args, &value, interpreter, scope)
+}
+
+// opExtDelegateCall implements the EXTDELEGATECALL opcode
+func opExtDelegateCall(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	stack := scope.Stack
+	addr, inOffset, inSize := stack.Pop(), stack.Pop(), stack.Pop()
+	// Get the arguments from the memory.
+	args := scope.Memory.GetPtr(int64(inOffset.Uint64()), int64(inSize.Uint64()))
+	return extCall(EXTDELEGATECALL, libcommon.Address(addr.Bytes20()), args, new(uint256.Int), interpreter, scope)
+}
+
+// opExtStaticCall implements the EXTSTATICCALL opcode
+func opExtStaticCall(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	stack := scope.Stack
+	addr, inOffset, inSize := stack.Pop(), stack.Pop(), stack.Pop()
+	// Get the arguments from the memory.
+	args := scope.Memory.GetPtr(int64(inOffset.Uint64()), int64(inSize.Uint64()))
+	return extCall(EXTSTATICCALL, libcommon.Address(addr.Bytes20()), args, new(uint256.Int), interpreter, scope)
+}
+
+// extCall performs the call of the EXTCALL family: the callee gets all but
+// max(1/64, ExtCallMinRetainedGas) of the available gas and the call status is
+// pushed onto the stack instead of a success flag.
+func extCall(typ OpCode, toAddr libcommon.Address, args []byte, value *uint256.Int, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		evm    = interpreter.evm
+		status = new(uint256.Int)
+		gas    = scope.Contract.Gas
+	)
+	if retained := max(gas/64, params.ExtCallMinRetainedGas); gas > retained {
+		gas -= retained
+	} else {
+		gas = 0
+	}
+	lightFailure := gas < params.ExtCallMinCalleeGas || interpreter.Depth() > int(params.CallCreateDepth)
+	if !lightFailure && !value.IsZero() {
+		canTransfer, err := evm.Context.CanTransfer(evm.IntraBlockState(), scope.Contract.Address(), value)
+		if err != nil {
+			return nil, err
+		}
+		lightFailure = !canTransfer
+	}
+	if !lightFailure && typ == EXTDELEGATECALL {
+		// EXTDELEGATECALL into legacy code is not allowed
+		code, err := evm.IntraBlockState().ResolveCode(toAddr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
+		}
+		lightFailure = !HasEOFMagic(code)
+	}
+	if lightFailure {
+		interpreter.returnData = nil
+		scope.Stack.Push(status.SetUint64(extCallRevert))
+		return nil, nil
+	}
+	scope.Contract.UseGas(gas, tracing.GasChangeCallOpCode)
+
+	var (
+		ret       []byte
+		returnGas uint64
+		err       error
+	)
+	switch typ {
+	case EXTCALL:
+		ret, returnGas, err = evm.Call(scope.Contract, toAddr, args, gas, value, false /* bailout */)
+	case EXTDELEGATECALL:
+		ret, returnGas, err = evm.DelegateCall(scope.Contract, toAddr, args, gas)
+	case EXTSTATICCALL:
+		ret, returnGas, err = evm.StaticCall(scope.Contract, toAddr, args, gas)
+	}
+	switch err {
+	case nil:
+		status.SetUint64(extCallSuccess)
+	case ErrExecutionReverted:
+		status.SetUint64(extCallRevert)
+	default:
+		status.SetUint64(extCallFailure)
+	}
+	scope.Stack.Push(status)
+	scope.Contract.RefundGas(returnGas, tracing.GasChangeCallLeftOverRefunded)
+
+	interpreter.returnData = ret
+	return ret, nil
+}
+
+// eofCodeStub is what legacy code observes as the code of an EOF account.
+var eofCodeStub = []byte{0xEF, 0x00}
+
+// opExtCodeSizeEOF implements EXTCODESIZE for legacy code once EOF is active.
+func opExtCodeSizeEOF(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	slot := scope.Stack.Peek()
+	code, err := interpreter.evm.IntraBlockState().GetCode(slot.Bytes20())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
+	}
+	if HasEOFMagic(code) {
+		code = eofCodeStub
+	}
+	slot.SetUint64(uint64(len(code)))
+	return nil, nil
+}
+
+// opExtCodeCopyEOF implements EXTCODECOPY for legacy code once EOF is active.
+func opExtCodeCopyEOF(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		stack      = scope.Stack
+		a          = stack.Pop()
+		memOffset  = stack.Pop()
+		codeOffset = stack.Pop()
+		length     = stack.Pop()
+	)
+	addr := libcommon.Address(a.Bytes20())
+	len64 := length.Uint64()
+
+	code, err := interpreter.evm.IntraBlockState().GetCode(addr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
+	}
+	if HasEOFMagic(code) {
+		code = eofCodeStub
+	}
+	codeCopy := getDataBig(code, &codeOffset, len64)
+	scope.Memory.Set(memOffset.Uint64(), len64, codeCopy)
+	return nil, nil
+}
+
+// opExtCodeHashEOF implements EXTCODEHASH for legacy code once EOF is active.
+func opExtCodeHashEOF(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	slot := scope.Stack.Peek()
+	address := libcommon.Address(slot.Bytes20())
+
+	code, err := interpreter.evm.IntraBlockState().GetCode(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
+	}
+	if HasEOFMagic(code) {
+		slot.SetBytes(crypto.Keccak256(eofCodeStub))
+		return nil, nil
+	}
+	return opExtCodeHash(pc, interpreter, scope)
+}
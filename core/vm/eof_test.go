@@ -0,0 +1,232 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type testSection struct {
+	inputs, outputs  uint8
+	maxStackIncrease uint16
+	code             []byte
+}
+
+// makeContainer serializes an EOF container from the given code sections.
+func makeContainer(data []byte, subContainers [][]byte, sections ...testSection) []byte {
+	c := &Container{data: data, dataSize: len(data)}
+	for _, s := range sections {
+		c.types = append(c.types, &functionMetadata{inputs: s.inputs, outputs: s.outputs, maxStackIncrease: s.maxStackIncrease})
+		c.codeSections = append(c.codeSections, s.code)
+	}
+	for _, sub := range subContainers {
+		c.subContainers = append(c.subContainers, &Container{})
+		c.subContainerCodes = append(c.subContainerCodes, sub)
+	}
+	return c.MarshalBinary()
+}
+
+func nonReturning(maxStackIncrease uint16, code ...OpCode) testSection {
+	s := testSection{outputs: nonReturningFunction, maxStackIncrease: maxStackIncrease}
+	for _, op := range code {
+		s.code = append(s.code, byte(op))
+	}
+	return s
+}
+
+func TestEOFMarshaling(t *testing.T) {
+	t.Parallel()
+	sub := makeContainer(nil, nil, nonReturning(0, INVALID))
+	for i, code := range [][]byte{
+		makeContainer(nil, nil, nonReturning(0, STOP)),
+		makeContainer([]byte{1, 2, 3}, nil, nonReturning(0, STOP)),
+		makeContainer(nil, nil,
+			nonReturning(2, PUSH0, PUSH0, CALLF, 0, 1, STOP),
+			testSection{inputs: 2, outputs: 0, code: []byte{byte(POP), byte(POP), byte(RETF)}},
+		),
+		makeContainer([]byte{0xaa}, [][]byte{sub, sub}, nonReturning(0, STOP)),
+	} {
+		var c Container
+		if err := c.UnmarshalBinary(code); err != nil {
+			t.Fatalf("test %d: failed to unmarshal: %v", i, err)
+		}
+		if have := c.MarshalBinary(); !bytes.Equal(have, code) {
+			t.Fatalf("test %d: roundtrip mismatch:\nhave %x\nwant %x", i, have, code)
+		}
+		if c.Size() != len(code) {
+			t.Fatalf("test %d: size mismatch: have %d, want %d", i, c.Size(), len(code))
+		}
+	}
+}
+
+func TestEOFUnmarshalErrors(t *testing.T) {
+	t.Parallel()
+	valid := makeContainer(nil, nil, nonReturning(0, STOP))
+	for i, tt := range []struct {
+		code []byte
+		want error
+	}{
+		{append([]byte{0xef, 0x01}, valid[2:]...), errInvalidMagic},
+		{append([]byte{0xef, 0x00, 0x02}, valid[3:]...), errInvalidVersion},
+		{append(append([]byte{}, valid...), 0x00), errInvalidContainerSize},
+		{valid[:len(valid)-1], errInvalidContainerSize},
+		{makeContainer(nil, nil, testSection{outputs: 0, code: []byte{byte(STOP)}}), errInvalidSection0Type},
+		{makeContainer([]byte{1, 2}, nil, nonReturning(0, STOP))[:len(valid)+1], errTruncatedTopLevelContainer},
+	} {
+		var c Container
+		if err := c.UnmarshalBinary(tt.code); !errors.Is(err, tt.want) {
+			t.Errorf("test %d: have %v, want %v", i, err, tt.want)
+		}
+	}
+}
+
+func TestEOFValidation(t *testing.T) {
+	t.Parallel()
+	sub := makeContainer(nil, nil, nonReturning(0, INVALID))
+	for _, tt := range []struct {
+		name       string
+		code       []byte
+		isInitcode bool
+		want       error
+	}{
+		{
+			name: "stop",
+			code: makeContainer(nil, nil, nonReturning(0, STOP)),
+		},
+		{
+			name: "infinite loop",
+			code: makeContainer(nil, nil, nonReturning(0, RJUMP, 0xff, 0xfd)),
+		},
+		{
+			name: "callf",
+			code: makeContainer(nil, nil,
+				nonReturning(2, PUSH0, PUSH0, CALLF, 0, 1, POP, STOP),
+				testSection{inputs: 2, outputs: 1, code: []byte{byte(ADD), byte(RETF)}},
+			),
+		},
+		{
+			name:       "returncontract",
+			code:       makeContainer(nil, [][]byte{sub}, nonReturning(2, PUSH0, PUSH0, RETURNCONTRACT, 0)),
+			isInitcode: true,
+		},
+		{
+			name: "eofcreate",
+			code: makeContainer(nil, [][]byte{makeContainer(nil, [][]byte{sub}, nonReturning(2, PUSH0, PUSH0, RETURNCONTRACT, 0))},
+				nonReturning(4, PUSH0, PUSH0, PUSH0, PUSH0, EOFCREATE, 0, POP, STOP),
+			),
+		},
+		{
+			name: "legacy jump",
+			code: makeContainer(nil, nil, nonReturning(1, PUSH1, 0, JUMP)),
+			want: errUndefinedInstruction,
+		},
+		{
+			name: "truncated push",
+			code: makeContainer(nil, nil, nonReturning(1, PUSH2, 0)),
+			want: errTruncatedImmediate,
+		},
+		{
+			name: "missing terminator",
+			code: makeContainer(nil, nil, nonReturning(1, PUSH0, POP)),
+			want: errInvalidCodeTermination,
+		},
+		{
+			name: "rjump into immediate",
+			code: makeContainer(nil, nil, nonReturning(0, RJUMP, 0xff, 0xff)),
+			want: errInvalidJumpDest,
+		},
+		{
+			name: "stack underflow",
+			code: makeContainer(nil, nil, nonReturning(0, ADD, STOP)),
+			want: errStackUnderflow,
+		},
+		{
+			name: "wrong max stack",
+			code: makeContainer(nil, nil, nonReturning(0, PUSH0, POP, STOP)),
+			want: errInvalidMaxStackHeight,
+		},
+		{
+			name: "unreachable instruction",
+			code: makeContainer(nil, nil, nonReturning(0, STOP, STOP)),
+			want: errUnreachableCode,
+		},
+		{
+			name: "unreachable section",
+			code: makeContainer(nil, nil, nonReturning(0, STOP), nonReturning(0, STOP)),
+			want: errUnreachableCode,
+		},
+		{
+			name: "backward jump changes stack",
+			code: makeContainer(nil, nil, nonReturning(1, PUSH0, RJUMP, 0xff, 0xfc)),
+			want: errInvalidBackwardJump,
+		},
+		{
+			name: "callf into non-returning section",
+			code: makeContainer(nil, nil, nonReturning(0, CALLF, 0, 1, STOP), nonReturning(0, STOP)),
+			want: errInvalidCallArgument,
+		},
+		{
+			name: "retf in non-returning section",
+			code: makeContainer(nil, nil, nonReturning(0, RETF)),
+			want: errInvalidNonReturning,
+		},
+		{
+			name: "dataloadn out of bounds",
+			code: makeContainer(make([]byte, 16), nil, nonReturning(1, DATALOADN, 0, 0, POP, STOP)),
+			want: errInvalidDataloadNArgument,
+		},
+		{
+			name:       "stop in initcode",
+			code:       makeContainer(nil, nil, nonReturning(0, STOP)),
+			isInitcode: true,
+			want:       errStopInInitCode,
+		},
+		{
+			name: "returncontract in runtime code",
+			code: makeContainer(nil, [][]byte{sub}, nonReturning(2, PUSH0, PUSH0, RETURNCONTRACT, 0)),
+			want: errIncompatibleContainerKind,
+		},
+		{
+			name: "orphaned subcontainer",
+			code: makeContainer(nil, [][]byte{sub}, nonReturning(0, STOP)),
+			want: errOrphanedSubcontainer,
+		},
+	} {
+		_, err := ParseAndValidateEOF(tt.code, tt.isInitcode)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: have %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestSplitEOFInitcode(t *testing.T) {
+	t.Parallel()
+	initcode := makeContainer(nil, nil, nonReturning(0, INVALID))
+	calldata := []byte{1, 2, 3}
+	have, input, err := splitEOFInitcode(append(append([]byte{}, initcode...), calldata...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(have, initcode) || !bytes.Equal(input, calldata) {
+		t.Fatalf("have %x/%x, want %x/%x", have, input, initcode, calldata)
+	}
+	if _, _, err := splitEOFInitcode(initcode[:len(initcode)-1]); !errors.Is(err, errTruncatedTopLevelContainer) {
+		t.Fatalf("have %v, want %v", err, errTruncatedTopLevelContainer)
+	}
+}
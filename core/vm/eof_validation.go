@@ -0,0 +1,335 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Subcontainers are referenced either as initcode by EOFCREATE, or as runtime
+// code by RETURNCONTRACT. A subcontainer must be referenced in only one way.
+const (
+	refByEOFCreate = iota + 1
+	refByReturnContract
+)
+
+// validationResult holds the code sections and subcontainers referenced from
+// a single code section.
+type validationResult struct {
+	visitedCode          map[int]struct{}
+	visitedSubContainers map[int]int
+}
+
+// ValidateCode validates each code section of the container against the EOF v1
+// rule-set, and recursively validates all of its subcontainers. Initcode
+// containers may not contain RETURN or STOP, runtime containers may not contain
+// RETURNCONTRACT.
+func (c *Container) ValidateCode(jt *JumpTable, isInitcode bool) error {
+	refBy := refByReturnContract
+	if isInitcode {
+		refBy = refByEOFCreate
+	}
+	return c.validateSubContainer(jt, refBy)
+}
+
+func (c *Container) validateSubContainer(jt *JumpTable, refBy int) error {
+	var (
+		visited             = make(map[int]struct{})
+		subContainerVisited = make(map[int]int)
+		toVisit             = []int{0}
+	)
+	for len(toVisit) > 0 {
+		index := toVisit[0]
+		toVisit = toVisit[1:]
+		if _, ok := visited[index]; ok {
+			continue
+		}
+		res, err := validateCode(c.codeSections[index], index, c, jt, refBy == refByEOFCreate)
+		if err != nil {
+			return fmt.Errorf("code section %d: %w", index, err)
+		}
+		visited[index] = struct{}{}
+		for idx := range res.visitedCode {
+			if _, ok := visited[idx]; !ok {
+				toVisit = append(toVisit, idx)
+			}
+		}
+		for idx, reference := range res.visitedSubContainers {
+			if prev, ok := subContainerVisited[idx]; ok && prev != reference {
+				return fmt.Errorf("%w: subcontainer %d referenced by both EOFCREATE and RETURNCONTRACT", errIncompatibleContainerKind, idx)
+			}
+			subContainerVisited[idx] = reference
+		}
+	}
+	if len(visited) != len(c.codeSections) {
+		return fmt.Errorf("%w: %d of %d code sections are reachable", errUnreachableCode, len(visited), len(c.codeSections))
+	}
+	for idx, sub := range c.subContainers {
+		reference, ok := subContainerVisited[idx]
+		if !ok {
+			return fmt.Errorf("%w: subcontainer %d", errOrphanedSubcontainer, idx)
+		}
+		if reference == refByEOFCreate && len(sub.data) != sub.dataSize {
+			return fmt.Errorf("%w: subcontainer %d", errEOFCreateWithTruncatedData, idx)
+		}
+		if err := sub.validateSubContainer(jt, reference); err != nil {
+			return fmt.Errorf("subcontainer %d: %w", idx, err)
+		}
+	}
+	return nil
+}
+
+// validateCode validates the code parameter against the EOF v1 validity requirements.
+func validateCode(code []byte, section int, container *Container, jt *JumpTable, isInitcode bool) (*validationResult, error) {
+	var (
+		i                    = 0
+		op                   OpCode
+		types                = container.types
+		isInstruction        = make([]bool, len(code))
+		jumpDests            []int
+		returns              bool // whether the section can return to its caller
+		visitedCode          = make(map[int]struct{})
+		visitedSubContainers = make(map[int]int)
+	)
+	for i < len(code) {
+		op = OpCode(code[i])
+		isInstruction[i] = true
+		// INVALID is the designated invalid instruction, which is allowed
+		if jt[op].undefined && op != INVALID {
+			return nil, fmt.Errorf("%w: op %s, pos %d", errUndefinedInstruction, op, i)
+		}
+		size := int(immediates[op])
+		if size != 0 && len(code) <= i+size {
+			return nil, fmt.Errorf("%w: op %s, pos %d", errTruncatedImmediate, op, i)
+		}
+		switch op {
+		case RJUMP, RJUMPI:
+			jumpDests = append(jumpDests, i+size+1+int(parseInt16(code[i+1:])))
+		case RJUMPV:
+			maxIndex := int(code[i+1])
+			size = 1 + 2*(maxIndex+1)
+			if len(code) <= i+size {
+				return nil, fmt.Errorf("%w: jump table truncated, op %s, pos %d", errTruncatedImmediate, op, i)
+			}
+			for j := 0; j <= maxIndex; j++ {
+				jumpDests = append(jumpDests, i+size+1+int(parseInt16(code[i+2+2*j:])))
+			}
+		case CALLF:
+			arg := int(binary.BigEndian.Uint16(code[i+1:]))
+			if arg >= len(types) {
+				return nil, fmt.Errorf("%w: arg %d, last %d, pos %d", errInvalidSectionArgument, arg, len(types), i)
+			}
+			if types[arg].outputs == nonReturningFunction {
+				return nil, fmt.Errorf("%w: section %d", errInvalidCallArgument, arg)
+			}
+			visitedCode[arg] = struct{}{}
+		case RETF:
+			if types[section].outputs == nonReturningFunction {
+				return nil, fmt.Errorf("%w: section %d", errInvalidNonReturning, section)
+			}
+			returns = true
+		case JUMPF:
+			arg := int(binary.BigEndian.Uint16(code[i+1:]))
+			if arg >= len(types) {
+				return nil, fmt.Errorf("%w: arg %d, last %d, pos %d", errInvalidSectionArgument, arg, len(types), i)
+			}
+			if types[arg].outputs != nonReturningFunction {
+				if types[section].outputs == nonReturningFunction {
+					return nil, fmt.Errorf("%w: jumpf into returning section %d from non-returning section %d", errInvalidNonReturning, arg, section)
+				}
+				if types[arg].outputs > types[section].outputs {
+					return nil, fmt.Errorf("%w: jumpf into section %d with %d outputs from section %d with %d outputs", errInvalidOutputs, arg, types[arg].outputs, section, types[section].outputs)
+				}
+				returns = true
+			}
+			visitedCode[arg] = struct{}{}
+		case DATALOADN:
+			arg := int(binary.BigEndian.Uint16(code[i+1:]))
+			if arg+32 > container.dataSize {
+				return nil, fmt.Errorf("%w: arg %d, data size %d, pos %d", errInvalidDataloadNArgument, arg, container.dataSize, i)
+			}
+		case RETURNCONTRACT, EOFCREATE:
+			arg := int(code[i+1])
+			if arg >= len(container.subContainers) {
+				return nil, fmt.Errorf("%w: arg %d, last %d, pos %d", errInvalidContainerArgument, arg, len(container.subContainers), i)
+			}
+			reference := refByEOFCreate
+			if op == RETURNCONTRACT {
+				if !isInitcode {
+					return nil, fmt.Errorf("%w: RETURNCONTRACT in runtime code, pos %d", errIncompatibleContainerKind, i)
+				}
+				reference = refByReturnContract
+			}
+			if prev, ok := visitedSubContainers[arg]; ok && prev != reference {
+				return nil, fmt.Errorf("%w: subcontainer %d referenced by both EOFCREATE and RETURNCONTRACT", errIncompatibleContainerKind, arg)
+			}
+			visitedSubContainers[arg] = reference
+		case RETURN, STOP:
+			if isInitcode {
+				return nil, fmt.Errorf("%w: op %s, pos %d", errStopInInitCode, op, i)
+			}
+		}
+		i += size + 1
+	}
+	// Code sections may not "fall through" and require proper termination.
+	// Therefore, the last instruction must be considered terminal or RJUMP.
+	if !terminals[op] && op != RJUMP {
+		return nil, fmt.Errorf("%w: end with %s", errInvalidCodeTermination, op)
+	}
+	if types[section].outputs != nonReturningFunction && !returns {
+		return nil, fmt.Errorf("%w: section %d is declared returning but never returns", errInvalidNonReturning, section)
+	}
+	for _, dest := range jumpDests {
+		if dest < 0 || dest >= len(code) || !isInstruction[dest] {
+			return nil, fmt.Errorf("%w: dest %d", errInvalidJumpDest, dest)
+		}
+	}
+	if err := validateControlFlow(code, section, types, jt); err != nil {
+		return nil, err
+	}
+	return &validationResult{
+		visitedCode:          visitedCode,
+		visitedSubContainers: visitedSubContainers,
+	}, nil
+}
+
+// stackBounds is the range of possible operand stack heights at an instruction.
+type stackBounds struct {
+	min, max int
+}
+
+// validateControlFlow performs the EIP-5450 stack validation in a single linear
+// pass: every instruction must be reachable by sequential flow or a forward
+// jump, backward jumps must target instructions with the exact same stack
+// bounds, and the maximum reached height must match the declared one.
+func validateControlFlow(code []byte, section int, metadata []*functionMetadata, jt *JumpTable) error {
+	var (
+		heights   = make([]stackBounds, len(code))
+		visited   = make([]bool, len(code))
+		maxHeight = int(metadata[section].inputs)
+	)
+	heights[0] = stackBounds{maxHeight, maxHeight}
+	visited[0] = true
+	for pos := 0; pos < len(code); {
+		if !visited[pos] {
+			return fmt.Errorf("%w: pos %d", errUnreachableCode, pos)
+		}
+		var (
+			cur  = heights[pos]
+			op   = OpCode(code[pos])
+			size = int(immediates[op])
+		)
+		if op == RJUMPV {
+			size = 1 + 2*(int(code[pos+1])+1)
+		}
+		next := pos + size + 1
+		want, have := jt[op].numPop, jt[op].numPush
+		switch op {
+		case CALLF:
+			arg := int(binary.BigEndian.Uint16(code[pos+1:]))
+			if err := metadata[arg].checkInputs(cur.min); err != nil {
+				return fmt.Errorf("%w: CALLF at pos %d", err, pos)
+			}
+			if err := metadata[arg].checkStackMax(cur.max); err != nil {
+				return fmt.Errorf("%w: CALLF at pos %d", err, pos)
+			}
+			want, have = int(metadata[arg].inputs), int(metadata[arg].outputs)
+		case RETF:
+			if cur.min != cur.max || cur.max != int(metadata[section].outputs) {
+				return fmt.Errorf("%w: RETF at pos %d with stack [%d, %d], want %d", errInvalidOutputs, pos, cur.min, cur.max, metadata[section].outputs)
+			}
+		case JUMPF:
+			arg := int(binary.BigEndian.Uint16(code[pos+1:]))
+			if err := metadata[arg].checkStackMax(cur.max); err != nil {
+				return fmt.Errorf("%w: JUMPF at pos %d", err, pos)
+			}
+			if metadata[arg].outputs == nonReturningFunction {
+				if err := metadata[arg].checkInputs(cur.min); err != nil {
+					return fmt.Errorf("%w: JUMPF at pos %d", err, pos)
+				}
+			} else {
+				expected := int(metadata[section].outputs) + int(metadata[arg].inputs) - int(metadata[arg].outputs)
+				if cur.min < expected {
+					return fmt.Errorf("%w: JUMPF at pos %d", errStackUnderflow, pos)
+				}
+				if cur.min != cur.max || cur.max != expected {
+					return fmt.Errorf("%w: JUMPF at pos %d with stack [%d, %d], want %d", errInvalidOutputs, pos, cur.min, cur.max, expected)
+				}
+			}
+		case DUPN:
+			n := int(code[pos+1]) + 1
+			want, have = n, n+1
+		case SWAPN:
+			n := int(code[pos+1]) + 1
+			want, have = n+1, n+1
+		case EXCHANGE:
+			n, m := int(code[pos+1]>>4)+1, int(code[pos+1]&0x0f)+1
+			want, have = n+m+1, n+m+1
+		}
+		if cur.min < want {
+			return fmt.Errorf("%w: op %s at pos %d, have %d, want %d", errStackUnderflow, op, pos, cur.min, want)
+		}
+		out := stackBounds{cur.min - want + have, cur.max - want + have}
+		maxHeight = max(maxHeight, out.max)
+		if maxHeight > maxStackHeight {
+			return fmt.Errorf("%w: op %s at pos %d", errStackOverflow, op, pos)
+		}
+
+		var successors []int
+		switch {
+		case op == RJUMP:
+			successors = append(successors, next+int(parseInt16(code[pos+1:])))
+		case op == RJUMPI:
+			successors = append(successors, next, next+int(parseInt16(code[pos+1:])))
+		case op == RJUMPV:
+			successors = append(successors, next)
+			for j := 0; j <= int(code[pos+1]); j++ {
+				successors = append(successors, next+int(parseInt16(code[pos+2+2*j:])))
+			}
+		case terminals[op]:
+		default:
+			successors = append(successors, next)
+		}
+		for _, succ := range successors {
+			if succ >= len(code) {
+				return fmt.Errorf("%w: op %s at pos %d falls through the end of code", errInvalidCodeTermination, op, pos)
+			}
+			if succ > pos {
+				if !visited[succ] {
+					heights[succ], visited[succ] = out, true
+				} else {
+					heights[succ] = stackBounds{min(heights[succ].min, out.min), max(heights[succ].max, out.max)}
+				}
+				continue
+			}
+			if heights[succ] != out {
+				return fmt.Errorf("%w: from pos %d to %d, have [%d, %d], want [%d, %d]", errInvalidBackwardJump, pos, succ, out.min, out.max, heights[succ].min, heights[succ].max)
+			}
+		}
+		pos = next
+	}
+	if maxHeight != metadata[section].maxHeight() {
+		return fmt.Errorf("%w in code section %d: have %d, want %d", errInvalidMaxStackHeight, section, maxHeight, metadata[section].maxHeight())
+	}
+	return nil
+}
+
+// parseInt16 returns the int16 interpretation of the first two bytes of b.
+func parseInt16(b []byte) int16 {
+	return int16(binary.BigEndian.Uint16(b))
+}
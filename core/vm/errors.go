@@ -45,6 +45,9 @@ var (
 	ErrReturnStackExceeded      = errors.New("return stack limit reached")
 	ErrInvalidCode              = errors.New("invalid code")
 	ErrNonceUintOverflow        = errors.New("nonce uint64 overflow")
+	ErrInvalidEOFInitcode       = errors.New("invalid eof initcode")
+	ErrInvalidEOFAuxData        = errors.New("invalid eof aux data size")
+	ErrInvalidAddress           = errors.New("invalid address")
 
 	// errStopToken is an internal token indicating interpreter loop termination,
 	// never returned to outside callers.
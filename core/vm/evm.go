@@ -401,11 +401,11 @@ func (c *codeAndHash) Hash() libcommon.Hash {
 }
 
 func (evm *EVM) OverlayCreate(caller ContractRef, codeAndHash *codeAndHash, gas uint64, value *uint256.Int, address libcommon.Address, typ OpCode, incrementNonce bool) ([]byte, libcommon.Address, uint64, error) {
-	return evm.create(caller, codeAndHash, gas, value, address, typ, incrementNonce, false)
+	return evm.create(caller, codeAndHash, nil, gas, value, address, typ, incrementNonce, false)
 }
 
 // create creates a new contract using code as deployment code.
-func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gasRemaining uint64, value *uint256.Int, address libcommon.Address, typ OpCode, incrementNonce bool, bailout bool) ([]byte, libcommon.Address, uint64, error) {
+func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, input []byte, gasRemaining uint64, value *uint256.Int, address libcommon.Address, typ OpCode, incrementNonce bool, bailout bool) ([]byte, libcommon.Address, uint64, error) {
 	var ret []byte
 	var err error
 	var gasConsumption uint64
@@ -487,7 +487,15 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gasRemainin
 		return nil, address, gasRemaining, nil
 	}
 
-	ret, err = run(evm, contract, nil, false)
+	if evm.chainRules.IsOsaka && (typ == EOFCREATE || HasEOFMagic(codeAndHash.code)) {
+		var container *Container
+		if container, input, err = evm.parseEOFInitcode(codeAndHash.code, input, typ, depth); err == nil {
+			contract.SetEOFContainer(container)
+		}
+	}
+	if err == nil {
+		ret, err = run(evm, contract, input, false)
+	}
 
 	// EIP-170: Contract code size limit
 	if err == nil && evm.chainRules.IsSpuriousDragon && len(ret) > evm.maxCodeSize() {
@@ -498,8 +506,9 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gasRemainin
 		}
 	}
 
-	// Reject code starting with 0xEF if EIP-3541 is enabled.
-	if err == nil && evm.chainRules.IsLondon && len(ret) >= 1 && ret[0] == 0xEF {
+	// Reject code starting with 0xEF if EIP-3541 is enabled. EOF initcode
+	// deploys an EOF container, which has been validated already.
+	if err == nil && evm.chainRules.IsLondon && !contract.IsEOF() && len(ret) >= 1 && ret[0] == 0xEF {
 		err = ErrInvalidCode
 	}
 	// If the contract creation ran successfully and no errors were returned,
@@ -550,7 +559,7 @@ func (evm *EVM) Create(caller ContractRef, code []byte, gasRemaining uint64, end
 		return nil, libcommon.Address{}, 0, err
 	}
 	contractAddr = crypto.CreateAddress(caller.Address(), nonce)
-	return evm.create(caller, &codeAndHash{code: code}, gasRemaining, endowment, contractAddr, CREATE, true /* incrementNonce */, bailout)
+	return evm.create(caller, &codeAndHash{code: code}, nil, gasRemaining, endowment, contractAddr, CREATE, true /* incrementNonce */, bailout)
 }
 
 // Create2 creates a new contract using code as deployment code.
@@ -561,13 +570,54 @@ func (evm *EVM) Create(caller ContractRef, code []byte, gasRemaining uint64, end
 func (evm *EVM) Create2(caller ContractRef, code []byte, gasRemaining uint64, endowment *uint256.Int, salt *uint256.Int, bailout bool) (ret []byte, contractAddr libcommon.Address, leftOverGas uint64, err error) {
 	codeAndHash := &codeAndHash{code: code}
 	contractAddr = crypto.CreateAddress2(caller.Address(), salt.Bytes32(), codeAndHash.Hash().Bytes())
-	return evm.create(caller, codeAndHash, gasRemaining, endowment, contractAddr, CREATE2, true /* incrementNonce */, bailout)
+	return evm.create(caller, codeAndHash, nil, gasRemaining, endowment, contractAddr, CREATE2, true /* incrementNonce */, bailout)
+}
+
+// EOFCreate creates a new contract from an EOF initcode container (EIP-7620).
+//
+// The contract address is keccak256(0xff ++ zero-padded msg.sender ++ salt)[12:]. Unlike
+// Create2 it does not depend on the initcode, which is part of the calling container.
+func (evm *EVM) EOFCreate(caller ContractRef, initContainer []byte, input []byte, gasRemaining uint64, endowment *uint256.Int, salt *uint256.Int) (ret []byte, contractAddr libcommon.Address, leftOverGas uint64, err error) {
+	var buf [1 + 32 + 32]byte
+	buf[0] = 0xff
+	copy(buf[1+12:], caller.Address().Bytes())
+	saltBytes := salt.Bytes32()
+	copy(buf[1+32:], saltBytes[:])
+	contractAddr = libcommon.BytesToAddress(crypto.Keccak256(buf[:])[12:])
+	return evm.create(caller, &codeAndHash{code: initContainer}, input, gasRemaining, endowment, contractAddr, EOFCREATE, true /* incrementNonce */, false)
+}
+
+// parseEOFInitcode returns the EOF container to run as initcode and its input.
+// Creation transactions carry the initcode container followed by the calldata
+// (EIP-7698), while EOFCREATE runs an already validated subcontainer. Legacy
+// CREATE and CREATE2 cannot run EOF initcode.
+func (evm *EVM) parseEOFInitcode(code []byte, input []byte, typ OpCode, depth int) (*Container, []byte, error) {
+	switch {
+	case typ == EOFCREATE:
+		var container Container
+		if err := container.UnmarshalBinary(code); err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidEOFInitcode, err)
+		}
+		return &container, input, nil
+	case typ == CREATE && depth == 0:
+		initcode, calldata, err := splitEOFInitcode(code)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidEOFInitcode, err)
+		}
+		container, err := ParseAndValidateEOF(initcode, true)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidEOFInitcode, err)
+		}
+		return container, calldata, nil
+	default:
+		return nil, nil, ErrInvalidEOFInitcode
+	}
 }
 
 // SysCreate is a special (system) contract creation methods for genesis constructors.
 // Unlike the normal Create & Create2, it doesn't increment caller's nonce.
 func (evm *EVM) SysCreate(caller ContractRef, code []byte, gas uint64, endowment *uint256.Int, contractAddr libcommon.Address) (ret []byte, leftOverGas uint64, err error) {
-	ret, _, leftOverGas, err = evm.create(caller, &codeAndHash{code: code}, gas, endowment, contractAddr, CREATE, false /* incrementNonce */, false)
+	ret, _, leftOverGas, err = evm.create(caller, &codeAndHash{code: code}, nil, gas, endowment, contractAddr, CREATE, false /* incrementNonce */, false)
 	return
 }
 
@@ -18,11 +18,13 @@ package vm
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 
 	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon/core/vm/evmtypes"
 	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/polygon/bor/borcfg"
 	"github.com/holiman/uint256"
 	"pgregory.net/rapid"
 )
@@ -425,3 +427,25 @@ func trace(isEVMSlice []bool, readOnlySlice []*readOnlyState) string {
 	}
 	return res
 }
+
+func TestInterpreterBorOsaka(t *testing.T) {
+	t.Parallel()
+	config := *params.TestChainConfig
+	config.OsakaTime = big.NewInt(0)
+	config.Bor = &borcfg.BorConfig{NapoliBlock: big.NewInt(0), BhilaiBlock: big.NewInt(0)}
+	env := NewEVM(evmtypes.BlockContext{}, evmtypes.TxContext{}, &dummyStatedb{}, &config, Config{})
+	jt := env.interpreter.(*EVMInterpreter).jt
+	if jt != &bhilaiOsakaInstructionSet {
+		t.Fatal("expected the bhilai based osaka instruction set")
+	}
+	// bhilai has no blob opcodes
+	if !jt[BLOBHASH].undefined || !jt[BLOBBASEFEE].undefined {
+		t.Error("blob opcodes enabled on bor")
+	}
+	if jt[TLOAD].undefined || jt[MCOPY].undefined {
+		t.Error("napoli opcodes disabled on bor")
+	}
+	if env.interpreter.(*EVMInterpreter).eofJt == nil {
+		t.Error("EOF disabled on bor osaka")
+	}
+}
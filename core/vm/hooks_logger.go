@@ -0,0 +1,114 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"errors"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/core/tracing"
+)
+
+// hooksLogger adapts a set of tracing.Hooks to the EVMLogger interface, so
+// that hook based tracers can be plugged into vm.Config.
+type hooksLogger struct {
+	hooks *tracing.Hooks
+	env   *EVM
+	depth int
+}
+
+// NewHooksLogger returns an EVMLogger which forwards VM events to the given hooks.
+// Nil hooks are skipped. The VM does not know about the transaction it executes,
+// so OnTxStart and OnTxEnd bracket every top-level call (system calls included)
+// and are invoked with a nil transaction and receipt.
+func NewHooksLogger(hooks *tracing.Hooks) EVMLogger {
+	return &hooksLogger{hooks: hooks}
+}
+
+func (l *hooksLogger) CaptureTxStart(gasLimit uint64) {}
+
+func (l *hooksLogger) CaptureTxEnd(restGas uint64) {}
+
+func (l *hooksLogger) CaptureStart(env *EVM, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	l.env, l.depth = env, 0
+	if l.hooks.OnTxStart != nil {
+		l.hooks.OnTxStart(&tracing.VMContext{
+			Coinbase:    env.Context.Coinbase,
+			BlockNumber: env.Context.BlockNumber,
+			Time:        env.Context.Time,
+			Random:      env.Context.PrevRanDao,
+			GasPrice:    env.GasPrice,
+			ChainConfig: env.ChainConfig(),
+			TxHash:      env.TxHash,
+		}, nil, from)
+	}
+	typ := CALL
+	if create {
+		typ = CREATE
+	}
+	l.enter(typ, from, to, precompile, input, gas, value, code)
+}
+
+func (l *hooksLogger) CaptureEnd(output []byte, usedGas uint64, err error) {
+	l.exit(output, usedGas, err)
+	if l.hooks.OnTxEnd != nil {
+		l.hooks.OnTxEnd(nil, err)
+	}
+}
+
+func (l *hooksLogger) CaptureEnter(typ OpCode, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	l.depth++
+	l.enter(typ, from, to, precompile, input, gas, value, code)
+}
+
+func (l *hooksLogger) CaptureExit(output []byte, usedGas uint64, err error) {
+	l.exit(output, usedGas, err)
+	l.depth--
+}
+
+func (l *hooksLogger) CaptureState(pc uint64, op OpCode, gas, cost uint64, scope *ScopeContext, rData []byte, depth int, err error) {
+	if l.hooks.OnOpcode != nil {
+		l.hooks.OnOpcode(pc, byte(op), gas, cost, scope, rData, depth, err)
+	}
+}
+
+func (l *hooksLogger) CaptureFault(pc uint64, op OpCode, gas, cost uint64, scope *ScopeContext, depth int, err error) {
+	if l.hooks.OnFault != nil {
+		l.hooks.OnFault(pc, byte(op), gas, cost, scope, depth, err)
+	}
+}
+
+func (l *hooksLogger) enter(typ OpCode, from libcommon.Address, to libcommon.Address, precompile bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	if l.hooks.OnEnter != nil {
+		l.hooks.OnEnter(l.depth, byte(typ), from, to, precompile, input, gas, value, code)
+	}
+}
+
+func (l *hooksLogger) exit(output []byte, usedGas uint64, err error) {
+	if l.hooks.OnExit == nil {
+		return
+	}
+	reverted := err != nil
+	// Before Homestead a failure to store the created code did not revert the creation.
+	if reverted && l.env != nil && !l.env.ChainRules().IsHomestead && errors.Is(err, ErrCodeStoreOutOfGas) {
+		reverted = false
+	}
+	l.hooks.OnExit(l.depth, output, usedGas, err, reverted)
+}
@@ -24,6 +24,8 @@ import (
 	"hash"
 	"sync"
 
+	"github.com/holiman/uint256"
+
 	"github.com/erigontech/erigon-lib/chain"
 	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/math"
@@ -85,6 +87,57 @@ type ScopeContext struct {
 	Contract *Contract
 }
 
+var _ tracing.OpContext = (*ScopeContext)(nil)
+
+// MemoryData returns the underlying memory slice. Callers must not modify the contents
+// of the returned data.
+func (ctx *ScopeContext) MemoryData() []byte {
+	if ctx.Memory == nil {
+		return nil
+	}
+	return ctx.Memory.Data()
+}
+
+// StackData returns the stack data. Callers must not modify the contents
+// of the returned data.
+func (ctx *ScopeContext) StackData() []uint256.Int {
+	if ctx.Stack == nil {
+		return nil
+	}
+	return ctx.Stack.Data
+}
+
+// Caller returns the current caller.
+func (ctx *ScopeContext) Caller() libcommon.Address {
+	return ctx.Contract.Caller()
+}
+
+// Address returns the address where this scope of execution is taking place.
+func (ctx *ScopeContext) Address() libcommon.Address {
+	return ctx.Contract.Address()
+}
+
+// CallValue returns the value supplied with this call.
+func (ctx *ScopeContext) CallValue() *uint256.Int {
+	return ctx.Contract.Value()
+}
+
+// CallInput returns the input/calldata with this call. Callers must not modify
+// the contents of the returned data.
+func (ctx *ScopeContext) CallInput() []byte {
+	return ctx.Contract.Input
+}
+
+// Code returns the code being executed in this scope.
+func (ctx *ScopeContext) Code() []byte {
+	return ctx.Contract.Code
+}
+
+// CodeHash returns the hash of the code being executed in this scope.
+func (ctx *ScopeContext) CodeHash() libcommon.Hash {
+	return ctx.Contract.CodeHash
+}
+
 // keccakState wraps sha3.state. In addition to the usual hash methods, it also supports
 // Read to get a variable amount of data from the hash state. Read is faster than Sum
 // because it doesn't copy the internal state, but also modifies the internal state.
@@ -97,6 +150,7 @@ type keccakState interface {
 type EVMInterpreter struct {
 	*VM
 	jt    *JumpTable // EVM instruction table
+	eofJt *JumpTable // EOF instruction table, nil before EOF is enabled
 	depth int
 }
 
@@ -127,8 +181,14 @@ func copyJumpTable(jt *JumpTable) *JumpTable {
 
 // NewEVMInterpreter returns a new instance of the Interpreter.
 func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
-	var jt *JumpTable
+	var jt, eofJt *JumpTable
 	switch {
+	case evm.ChainRules().IsOsaka && evm.ChainRules().IsBhilai:
+		jt = &bhilaiOsakaInstructionSet
+		eofJt = &eofInstructionSet
+	case evm.ChainRules().IsOsaka:
+		jt = &osakaInstructionSet
+		eofJt = &eofInstructionSet
 	case evm.ChainRules().IsBhilai:
 		jt = &bhilaiInstructionSet
 	case evm.ChainRules().IsPrague:
@@ -174,7 +234,8 @@ func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
 			evm: evm,
 			cfg: cfg,
 		},
-		jt: jt,
+		jt:    jt,
+		eofJt: eofJt,
 	}
 }
 
@@ -194,6 +255,22 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 	// as every returning call will return new data anyway.
 	in.returnData = nil
 
+	// Deployed EOF code has been validated on creation, so it only needs to
+	// be parsed before running it with the EOF instruction set.
+	jt := in.jt
+	if in.eofJt != nil {
+		if contract.Container == nil && HasEOFMagic(contract.Code) {
+			var container Container
+			if err := container.UnmarshalBinary(contract.Code); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidCode, err)
+			}
+			contract.SetEOFContainer(&container)
+		}
+		if contract.IsEOF() {
+			jt = in.eofJt
+		}
+	}
+
 	var (
 		op          OpCode // current opcode
 		mem         = pool.Get().(*Memory)
@@ -263,7 +340,7 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 		// Get the operation from the jump table and validate the stack to ensure there are
 		// enough stack items available to perform the operation.
 		op = contract.GetOp(_pc)
-		operation := in.jt[op]
+		operation := jt[op]
 		cost = operation.constantGas // For tracing
 		// Validate stack
 		if sLen := locStack.Len(); sLen < operation.numPop {
@@ -51,6 +51,8 @@ type operation struct {
 	opNum   int // only for push, swap, dup
 	// memorySize returns the memory size required for the operation
 	memorySize memorySizeFunc
+	// undefined denotes if the instruction is not officially defined in the jump table
+	undefined bool
 }
 
 var (
@@ -68,8 +70,19 @@ var (
 	bhilaiInstructionSet           = newBhilaiInstructionSet()
 	cancunInstructionSet           = newCancunInstructionSet()
 	pragueInstructionSet           = newPragueInstructionSet()
+	osakaInstructionSet            = newOsakaInstructionSet()
+	bhilaiOsakaInstructionSet      = newBhilaiOsakaInstructionSet()
 )
 
+// eofInstructionSet is filled in by init: EOFCREATE creates contracts through
+// EOF container validation, which uses this table, so a static initializer
+// would be an initialization cycle.
+var eofInstructionSet JumpTable
+
+func init() {
+	eofInstructionSet = newEOFInstructionSet()
+}
+
 // JumpTable contains the EVM opcodes supported at a given fork.
 type JumpTable [256]*operation
 
@@ -91,6 +104,35 @@ func validateAndFillMaxStack(jt *JumpTable) {
 	}
 }
 
+// newEOFInstructionSet returns the instructions available to EOF code
+// (EIP-7692). It is only used to run and validate EOF containers, legacy code
+// keeps running with newOsakaInstructionSet.
+func newEOFInstructionSet() JumpTable {
+	instructionSet := newOsakaInstructionSet()
+	enableEOF(&instructionSet)
+	validateAndFillMaxStack(&instructionSet)
+	return instructionSet
+}
+
+// newOsakaInstructionSet returns the frontier, homestead, byzantium,
+// constantinople, istanbul, petersburg, berlin, london, paris, shanghai,
+// cancun, prague and osaka instructions for legacy code.
+func newOsakaInstructionSet() JumpTable {
+	instructionSet := newPragueInstructionSet()
+	enable3540(&instructionSet) // EXTCODE* on EOF accounts
+	validateAndFillMaxStack(&instructionSet)
+	return instructionSet
+}
+
+// newBhilaiOsakaInstructionSet returns the osaka instructions for legacy code
+// on Bor chains, which build on bhilai rather than prague.
+func newBhilaiOsakaInstructionSet() JumpTable {
+	instructionSet := newBhilaiInstructionSet()
+	enable3540(&instructionSet) // EXTCODE* on EOF accounts
+	validateAndFillMaxStack(&instructionSet)
+	return instructionSet
+}
+
 // newPragueInstructionSet returns the frontier, homestead, byzantium,
 // constantinople, istanbul, petersburg, berlin, london, paris, shanghai,
 // cancun, and prague instructions.
@@ -1223,7 +1265,7 @@ func newFrontierInstructionSet() JumpTable {
 	// Fill all unassigned slots with opUndefined.
 	for i, entry := range tbl {
 		if entry == nil {
-			tbl[i] = &operation{execute: opUndefined}
+			tbl[i] = &operation{execute: opUndefined, undefined: true}
 		}
 	}
 
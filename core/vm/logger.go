@@ -53,3 +53,60 @@ type FlushableTracer interface {
 	EVMLogger
 	Flush(tx types.Transaction)
 }
+
+// multiLogger forwards every event to a list of loggers.
+type multiLogger []EVMLogger
+
+// NewMultiLogger returns an EVMLogger which forwards all events to the given
+// loggers, in order.
+func NewMultiLogger(loggers ...EVMLogger) EVMLogger {
+	return multiLogger(loggers)
+}
+
+func (m multiLogger) CaptureTxStart(gasLimit uint64) {
+	for _, l := range m {
+		l.CaptureTxStart(gasLimit)
+	}
+}
+
+func (m multiLogger) CaptureTxEnd(restGas uint64) {
+	for _, l := range m {
+		l.CaptureTxEnd(restGas)
+	}
+}
+
+func (m multiLogger) CaptureStart(env *EVM, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	for _, l := range m {
+		l.CaptureStart(env, from, to, precompile, create, input, gas, value, code)
+	}
+}
+
+func (m multiLogger) CaptureEnd(output []byte, usedGas uint64, err error) {
+	for _, l := range m {
+		l.CaptureEnd(output, usedGas, err)
+	}
+}
+
+func (m multiLogger) CaptureEnter(typ OpCode, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	for _, l := range m {
+		l.CaptureEnter(typ, from, to, precompile, create, input, gas, value, code)
+	}
+}
+
+func (m multiLogger) CaptureExit(output []byte, usedGas uint64, err error) {
+	for _, l := range m {
+		l.CaptureExit(output, usedGas, err)
+	}
+}
+
+func (m multiLogger) CaptureState(pc uint64, op OpCode, gas, cost uint64, scope *ScopeContext, rData []byte, depth int, err error) {
+	for _, l := range m {
+		l.CaptureState(pc, op, gas, cost, scope, rData, depth, err)
+	}
+}
+
+func (m multiLogger) CaptureFault(pc uint64, op OpCode, gas, cost uint64, scope *ScopeContext, depth int, err error) {
+	for _, l := range m {
+		l.CaptureFault(pc, op, gas, cost, scope, depth, err)
+	}
+}
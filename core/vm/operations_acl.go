@@ -317,3 +317,50 @@ func makeCallVariantGasCallEIP7702(oldCalculator gasFunc) gasFunc {
 		return gas, nil
 	}
 }
+
+// makeExtCallGasFn creates the dynamic gas function of the EXTCALL family
+// (EIP-7069). Unlike the legacy calls, the callee gas is not part of the
+// dynamic cost: it is computed and deducted by the instruction itself.
+func makeExtCallGasFn(withValue bool) gasFunc {
+	return func(evm *EVM, contract *Contract, stack *stack.Stack, mem *Memory, memorySize uint64) (uint64, error) {
+		target := stack.Back(0)
+		// The target address must fit into 20 bytes
+		if target.BitLen() > 160 {
+			return 0, ErrInvalidAddress
+		}
+		gas, err := memoryGasCost(mem, memorySize)
+		if err != nil {
+			return 0, err
+		}
+		var overflow bool
+		addr := libcommon.Address(target.Bytes20())
+		if evm.IntraBlockState().AddAddressToAccessList(addr) {
+			// The warm storage read cost is already charged as constantGas
+			if gas, overflow = math.SafeAdd(gas, params.ColdAccountAccessCostEIP2929-params.WarmStorageReadCostEIP2929); overflow {
+				return 0, ErrGasUintOverflow
+			}
+		}
+		if !withValue || stack.Back(3).IsZero() {
+			return gas, nil
+		}
+		if gas, overflow = math.SafeAdd(gas, params.CallValueTransferGas); overflow {
+			return 0, ErrGasUintOverflow
+		}
+		empty, err := evm.IntraBlockState().Empty(addr)
+		if err != nil {
+			return 0, err
+		}
+		if empty {
+			if gas, overflow = math.SafeAdd(gas, params.CallNewAccountGas); overflow {
+				return 0, ErrGasUintOverflow
+			}
+		}
+		return gas, nil
+	}
+}
+
+var (
+	gasExtCall         = makeExtCallGasFn(true)
+	gasExtDelegateCall = makeExtCallGasFn(false)
+	gasExtStaticCall   = makeExtCallGasFn(false)
+)
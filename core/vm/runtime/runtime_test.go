@@ -20,7 +20,9 @@
 package runtime
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
@@ -141,6 +143,39 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+func TestExecuteEOF(t *testing.T) {
+	t.Parallel()
+	// Section 0 pushes 2 and 3, calls section 1 to add them and returns the result.
+	code := libcommon.FromHex("ef0001010008020002000d0002ff000000008000020201000060026003e300015f5260205ff301e4")
+	ret, _, err := Execute(code, nil, nil, t.TempDir())
+	if err != nil {
+		t.Fatal("didn't expect error", err)
+	}
+	num := new(big.Int).SetBytes(ret)
+	if num.Cmp(big.NewInt(5)) != 0 {
+		t.Error("Expected 5, got", num)
+	}
+}
+
+func TestCreateEOF(t *testing.T) {
+	t.Parallel()
+	// The initcode returns its only subcontainer, which consists of INVALID.
+	deployed := libcommon.FromHex("ef00010100040200010001ff00000000800000fe")
+	initcode := libcommon.FromHex("ef0001010004020001000403000100000014ff000000008000025f5fee00ef00010100040200010001ff00000000800000fe")
+	ret, _, _, err := Create(initcode, nil, 0)
+	if err != nil {
+		t.Fatal("didn't expect error", err)
+	}
+	if !bytes.Equal(ret, deployed) {
+		t.Fatalf("deployed code mismatch: have %x, want %x", ret, deployed)
+	}
+	// Legacy initcode cannot deploy EOF code.
+	legacy := append([]byte{byte(vm.PUSH1), byte(len(deployed)), byte(vm.DUP1), byte(vm.PUSH1), 9, byte(vm.PUSH0), byte(vm.CODECOPY), byte(vm.PUSH0), byte(vm.RETURN)}, deployed...)
+	if _, _, _, err := Create(legacy, nil, 0); !errors.Is(err, vm.ErrInvalidCode) {
+		t.Fatalf("have %v, want %v", err, vm.ErrInvalidCode)
+	}
+}
+
 func TestCall(t *testing.T) {
 	t.Parallel()
 	_, tx, _ := NewTestTemporalDb(t)
@@ -56,6 +56,16 @@ type Dirs struct {
 }
 
 func New(datadir string) Dirs {
+	dirs := Open(datadir)
+	dir.MustExist(dirs.Chaindata, dirs.Tmp,
+		dirs.SnapIdx, dirs.SnapHistory, dirs.SnapDomain, dirs.SnapAccessors, dirs.SnapCaplin,
+		dirs.Downloader, dirs.TxPool, dirs.Nodes, dirs.CaplinBlobs, dirs.CaplinIndexing, dirs.CaplinLatest, dirs.CaplinGenesis, dirs.Blobs)
+	return dirs
+}
+
+// Open - same as New, but doesn't create missing directories. For read-only tools
+// which must leave the datadir untouched.
+func Open(datadir string) Dirs {
 	relativeDataDir := datadir
 	if datadir != "" {
 		var err error
@@ -86,10 +96,6 @@ func New(datadir string) Dirs {
 		CaplinGenesis:   filepath.Join(datadir, "caplin", "genesis-state"),
 		Blobs:           filepath.Join(datadir, "blobs"),
 	}
-
-	dir.MustExist(dirs.Chaindata, dirs.Tmp,
-		dirs.SnapIdx, dirs.SnapHistory, dirs.SnapDomain, dirs.SnapAccessors, dirs.SnapCaplin,
-		dirs.Downloader, dirs.TxPool, dirs.Nodes, dirs.CaplinBlobs, dirs.CaplinIndexing, dirs.CaplinLatest, dirs.CaplinGenesis, dirs.Blobs)
 	return dirs
 }
 
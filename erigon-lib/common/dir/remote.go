@@ -0,0 +1,120 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package dir
+
+import (
+	"io"
+	"os"
+	"sort"
+	"sync/atomic"
+)
+
+// RemoteFiles - read-only storage of cold files (for example: object-store with snapshots).
+// Remote files are visible by `ReadDir`/`FileExist` as if they are on local disk. Files openers read them
+// by `OpenRemote` (seg headers, existence filter) and make them local by `EnsureLocal` when data is mmap'ed (seg words, recsplit, btree).
+type RemoteFiles interface {
+	// ReadDir - remote files which belong to given local dir
+	ReadDir(localDir string) ([]os.DirEntry, error)
+	Exists(localPath string) bool
+	// Open - random access to remote file without fetching it whole. nil - file is on local disk and is not managed by remote storage
+	Open(localPath string) (RemoteFile, error)
+	// Acquire - make remote file available on `localPath`. Local copy is kept until `release` is called
+	Acquire(localPath string) (release func(), err error)
+}
+
+type RemoteFile interface {
+	io.ReaderAt
+	Size() int64
+}
+
+var remoteFiles atomic.Pointer[RemoteFiles]
+
+// SetRemoteFiles - nil disables remote files
+func SetRemoteFiles(r RemoteFiles) {
+	if r == nil {
+		remoteFiles.Store(nil)
+		return
+	}
+	remoteFiles.Store(&r)
+}
+
+func remote() RemoteFiles {
+	r := remoteFiles.Load()
+	if r == nil {
+		return nil
+	}
+	return *r
+}
+
+func noRelease() {}
+
+// EnsureLocal - if file exists on remote storage - fetch it (if it's not on local disk yet).
+// `release` must be called after file is closed: until then local copy of remote file is not evicted
+func EnsureLocal(path string) (release func(), err error) {
+	r := remote()
+	if r == nil || !r.Exists(path) {
+		return noRelease, nil
+	}
+	return r.Acquire(path)
+}
+
+// OpenRemote - random access to file of remote storage. nil - file is not remote (open it from local disk)
+func OpenRemote(path string) (RemoteFile, error) {
+	r := remote()
+	if r == nil || !r.Exists(path) {
+		return nil, nil
+	}
+	return r.Open(path)
+}
+
+func remoteExists(path string) bool {
+	r := remote()
+	return r != nil && r.Exists(path)
+}
+
+// ReadDir - local dir entries merged with remote-only entries (local files have priority)
+func ReadDir(name string) ([]os.DirEntry, error) {
+	local, err := readDir(name)
+	r := remote()
+	if r == nil {
+		return local, err
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	remoteEntries, rerr := r.ReadDir(name)
+	if rerr != nil {
+		return nil, rerr
+	}
+	if err != nil && len(remoteEntries) == 0 {
+		return nil, err
+	}
+	if len(remoteEntries) == 0 {
+		return local, nil
+	}
+	seen := make(map[string]struct{}, len(local))
+	for _, e := range local {
+		seen[e.Name()] = struct{}{}
+	}
+	for _, e := range remoteEntries {
+		if _, ok := seen[e.Name()]; !ok {
+			local = append(local, e)
+		}
+	}
+	sort.Slice(local, func(i, j int) bool { return local[i].Name() < local[j].Name() })
+	return local, nil
+}
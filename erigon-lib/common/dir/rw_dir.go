@@ -59,7 +59,7 @@ func FileExist(path string) (exists bool, err error) {
 	fi, err := os.Stat(path)
 	switch {
 	case os.IsNotExist(err):
-		return false, nil
+		return remoteExists(path), nil
 	case err != nil:
 		return false, err
 	default:
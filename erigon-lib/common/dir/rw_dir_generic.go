@@ -20,6 +20,6 @@ package dir
 
 import "os"
 
-func ReadDir(name string) ([]os.DirEntry, error) {
+func readDir(name string) ([]os.DirEntry, error) {
 	return os.ReadDir(name)
 }
@@ -25,7 +25,7 @@ import (
 	"golang.org/x/sys/windows"
 )
 
-func ReadDir(name string) ([]os.DirEntry, error) {
+func readDir(name string) ([]os.DirEntry, error) {
 	files, err := os.ReadDir(name)
 	if err != nil {
 		// some windows remote drived return this error
@@ -76,6 +76,7 @@ type SyncStatistics struct {
 	SnapshotDownload SnapshotDownloadStatistics `json:"snapshotDownload"`
 	SnapshotIndexing SnapshotIndexingStatistics `json:"snapshotIndexing"`
 	SnapshotFillDB   SnapshotFillDBStatistics   `json:"snapshotFillDB"`
+	SnapshotRetire   SnapshotRetireStatistics   `json:"snapshotRetire"`
 	SyncFinished     bool                       `json:"syncFinished"`
 }
 
@@ -153,6 +154,26 @@ type SnapshotFillDBStageUpdate struct {
 	TimeElapsed float64             `json:"timeElapsed"`
 }
 
+type SnapshotRetireStatistics struct {
+	Jobs []SnapshotRetireJob `json:"jobs"`
+}
+
+type SnapshotRetireJob struct {
+	Type        string  `json:"type"`
+	Name        string  `json:"name"`
+	From        uint64  `json:"from"`
+	To          uint64  `json:"to"`
+	Status      string  `json:"status"`
+	Processed   uint64  `json:"processed"`
+	Total       uint64  `json:"total"`
+	TimeElapsed float64 `json:"timeElapsed"`
+	Err         string  `json:"err,omitempty"`
+}
+
+type SnapshotRetireJobUpdate struct {
+	Job SnapshotRetireJob `json:"job"`
+}
+
 type SnapshoFilesList struct {
 	Files []string `json:"files"`
 }
@@ -351,3 +372,7 @@ func (ti HeadersProcessedUpdate) Type() Type {
 func (ti SnapshotFillDBStageUpdate) Type() Type {
 	return TypeOf(ti)
 }
+
+func (ti SnapshotRetireJobUpdate) Type() Type {
+	return TypeOf(ti)
+}
@@ -41,6 +41,7 @@ func (d *DiagnosticClient) setupSnapshotDiagnostics(rootCtx context.Context) {
 	d.runSegmentIndexingListener(rootCtx)
 	d.runFileDownloadedListener(rootCtx)
 	d.runFillDBListener(rootCtx)
+	d.runRetireListener(rootCtx)
 }
 
 func (d *DiagnosticClient) runFillDBListener(rootCtx context.Context) {
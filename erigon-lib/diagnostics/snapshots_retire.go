@@ -0,0 +1,65 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package diagnostics
+
+import (
+	"context"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+const (
+	RetireJobPending = "pending"
+	RetireJobRunning = "running"
+	RetireJobDone    = "done"
+	RetireJobSkipped = "skipped" // done by previous run, restored from checkpoint
+	RetireJobFailed  = "failed"
+)
+
+func (d *DiagnosticClient) runRetireListener(rootCtx context.Context) {
+	go func() {
+		ctx, ch, closeChannel := Context[SnapshotRetireJobUpdate](rootCtx, 64) // job state changes are bursty: don't drop them
+		defer closeChannel()
+
+		StartProviders(ctx, TypeOf(SnapshotRetireJobUpdate{}), log.Root())
+		for {
+			select {
+			case <-rootCtx.Done():
+				return
+			case info := <-ch:
+				d.AddOrUpdateRetireJob(info.Job)
+			}
+		}
+	}()
+}
+
+func (d *DiagnosticClient) AddOrUpdateRetireJob(job SnapshotRetireJob) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.addOrUpdateRetireJob(job)
+}
+
+func (d *DiagnosticClient) addOrUpdateRetireJob(job SnapshotRetireJob) {
+	jobs := d.syncStats.SnapshotRetire.Jobs
+	for i := range jobs {
+		if jobs[i].Type == job.Type && jobs[i].Name == job.Name && jobs[i].From == job.From && jobs[i].To == job.To {
+			jobs[i] = job
+			return
+		}
+	}
+	d.syncStats.SnapshotRetire.Jobs = append(jobs, job)
+}
@@ -182,3 +182,20 @@ func TestAddOrUpdateSegmentIndexingState(t *testing.T) {
 	stats = d.SyncStatistics()
 	require.True(t, stats.SnapshotIndexing.IndexingFinished)
 }
+
+func TestAddOrUpdateRetireJob(t *testing.T) {
+	d, err := NewTestDiagnosticClient()
+	require.NoError(t, err)
+
+	job := diagnostics.SnapshotRetireJob{Type: "blocks", Name: "retire", From: 0, To: 500_000, Status: diagnostics.RetireJobRunning, Total: 1}
+	d.AddOrUpdateRetireJob(job)
+	d.AddOrUpdateRetireJob(diagnostics.SnapshotRetireJob{Type: "state", Name: "merge", Status: diagnostics.RetireJobPending})
+
+	job.Status, job.Processed = diagnostics.RetireJobDone, 1
+	d.AddOrUpdateRetireJob(job)
+
+	jobs := d.SyncStatistics().SnapshotRetire.Jobs
+	require.Len(t, jobs, 2)
+	require.Equal(t, job, jobs[0])
+	require.Equal(t, diagnostics.RetireJobPending, jobs[1].Status)
+}
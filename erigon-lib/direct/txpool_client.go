@@ -123,3 +123,7 @@ func (s *TxPoolClient) Nonce(ctx context.Context, in *txpool_proto.NonceRequest,
 func (s *TxPoolClient) GetBlobs(ctx context.Context, in *txpool_proto.GetBlobsRequest, opts ...grpc.CallOption) (*txpool_proto.GetBlobsReply, error) {
 	return s.server.GetBlobs(ctx, in)
 }
+
+func (s *TxPoolClient) PropagationStats(ctx context.Context, in *txpool_proto.PropagationStatsRequest, opts ...grpc.CallOption) (*txpool_proto.PropagationStatsReply, error) {
+	return s.server.PropagationStats(ctx, in)
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package objstore
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/c2h5oh/datasize"
+)
+
+const (
+	DefaultPageSize      = 4 * datasize.MB
+	DefaultPageCacheSize = 256 * datasize.MB
+	DefaultFetchWorkers  = 8
+)
+
+type Config struct {
+	Endpoint string // `http(s)://host:port` of S3-compatible service
+	Region   string
+	Bucket   string
+	Prefix   string // objects with this prefix are mapped to local snapshots dir: `<prefix>/domain/v1-accounts.0-32.kv` -> `<datadir>/snapshots/domain/v1-accounts.0-32.kv`
+
+	// if empty - AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY env variables are used
+	AccessKey string
+	SecretKey string
+
+	PageSize      datasize.ByteSize // size of range-request
+	PageCacheSize datasize.ByteSize // in-memory cache of pages read by `File.ReadAt`
+	DiskCacheSize datasize.ByteSize // budget for local copies of remote files. 0 - unlimited
+	FetchWorkers  int               // parallel range-requests while fetching whole file
+}
+
+// ParseURL - `s3://bucket/prefix?endpoint=http://127.0.0.1:9000&region=us-east-1`
+// if `endpoint` is not set - AWS endpoint of region is used
+func ParseURL(s string) (Config, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return Config{}, fmt.Errorf("objstore: invalid url %q: %w", s, err)
+	}
+	if u.Scheme != "s3" {
+		return Config{}, fmt.Errorf("objstore: invalid url %q: expected s3:// scheme", s)
+	}
+	if u.Host == "" {
+		return Config{}, fmt.Errorf("objstore: invalid url %q: bucket is not set", s)
+	}
+	q := u.Query()
+	cfg := Config{
+		Bucket:   u.Host,
+		Prefix:   strings.Trim(u.Path, "/"),
+		Region:   q.Get("region"),
+		Endpoint: q.Get("endpoint"),
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+	return cfg.withDefaults(), nil
+}
+
+func (cfg Config) withDefaults() Config {
+	if cfg.PageSize == 0 {
+		cfg.PageSize = DefaultPageSize
+	}
+	if cfg.PageCacheSize == 0 {
+		cfg.PageCacheSize = DefaultPageCacheSize
+	}
+	if cfg.FetchWorkers <= 0 {
+		cfg.FetchWorkers = DefaultFetchWorkers
+	}
+	return cfg
+}
+
+func (cfg Config) String() string {
+	return fmt.Sprintf("s3://%s/%s?endpoint=%s", cfg.Bucket, cfg.Prefix, cfg.Endpoint)
+}
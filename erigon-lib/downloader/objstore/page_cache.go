@@ -0,0 +1,80 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package objstore
+
+import (
+	"container/list"
+	"sync"
+)
+
+type pageKey struct {
+	key  string
+	page int64
+}
+
+type page struct {
+	k    pageKey
+	data []byte
+}
+
+// pageCache - LRU of object pages, bounded by total size of pages
+type pageCache struct {
+	lock     sync.Mutex
+	capacity int64
+	size     int64
+	lru      *list.List // front - most recently used
+	items    map[pageKey]*list.Element
+}
+
+func newPageCache(capacity int64) *pageCache {
+	return &pageCache{capacity: capacity, lru: list.New(), items: map[pageKey]*list.Element{}}
+}
+
+func (c *pageCache) get(k pageKey) ([]byte, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	e, ok := c.items[k]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	return e.Value.(*page).data, true
+}
+
+func (c *pageCache) add(k pageKey, data []byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if e, ok := c.items[k]; ok {
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.items[k] = c.lru.PushFront(&page{k: k, data: data})
+	c.size += int64(len(data))
+	for c.size > c.capacity && c.lru.Len() > 1 {
+		e := c.lru.Back()
+		p := e.Value.(*page)
+		c.lru.Remove(e)
+		delete(c.items, p.k)
+		c.size -= int64(len(p.data))
+	}
+}
+
+func (c *pageCache) len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Len()
+}
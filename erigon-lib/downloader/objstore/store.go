@@ -0,0 +1,403 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package objstore - read-only snapshots backend on S3-compatible object-store.
+//
+// Cold snapshot files (.seg, .kv, .v, .ef, accessors) may live only in bucket:
+//   - `Store` is installed by `dir.SetRemoteFiles`: remote files are visible to snapshot files listing (RoSnapshots, Aggregator)
+//   - `File.ReadAt` - lazy random reads by pages (range-requests), pages are cached in-memory LRU.
+//     Files openers read headers/dictionaries this way (seg), or read small files whole (existence filter)
+//   - `Store.Acquire` - file is downloaded by parallel range-requests on first read of data which is mmap'ed (seg words,
+//     recsplit/btree accessors) and kept in local disk LRU (bounded by `Config.DiskCacheSize`)
+//
+// Local copies are referenced by opened files: only copies which are not referenced (all files which use it are closed)
+// are evicted, so disk usage may exceed `Config.DiskCacheSize` by size of opened files.
+package objstore
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+const fetchChunk = 32 * 1024 * 1024
+
+type Store struct {
+	ctx       context.Context // downloads and page reads of files openers are cancelled when it's done
+	cfg       Config
+	client    *Client
+	localRoot string // local dir mapped to `cfg.Prefix`
+	logger    log.Logger
+
+	objectsLock sync.RWMutex
+	objects     map[string]map[string]Object // local dir -> file name -> object
+
+	pages     *pageCache
+	pageGroup singleflight.Group
+
+	fetchGroup singleflight.Group
+	localLock  sync.Mutex
+	local      *list.List // local copies fetched by this store. front - most recently used
+	locals     map[string]*list.Element
+	localSize  int64
+}
+
+type localCopy struct {
+	path string
+	size int64
+	refs int // amount of opened files which use this copy
+}
+
+// Open - lists bucket and returns store. `localRoot` - local snapshots dir (`dirs.Snap`).
+// `ctx` - lifetime of store: fetches and page reads of files openers are cancelled when it's done
+func Open(ctx context.Context, cfg Config, localRoot string, logger log.Logger) (*Store, error) {
+	cfg = cfg.withDefaults()
+	client, err := NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{
+		ctx:       ctx,
+		cfg:       cfg,
+		client:    client,
+		localRoot: filepath.Clean(localRoot),
+		logger:    logger,
+		pages:     newPageCache(int64(cfg.PageCacheSize)),
+		local:     list.New(),
+		locals:    map[string]*list.Element{},
+	}
+	if err := s.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Refresh - re-read list of remote files
+func (s *Store) Refresh(ctx context.Context) error {
+	prefix := s.cfg.Prefix
+	if prefix != "" {
+		prefix += "/"
+	}
+	objs, err := s.client.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+	objects := map[string]map[string]Object{}
+	for _, o := range objs {
+		rel := strings.TrimPrefix(o.Key, prefix)
+		if rel == "" || strings.HasSuffix(rel, "/") || o.Size == 0 {
+			continue
+		}
+		relDir, name := path.Split(rel)
+		if strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".tmp") {
+			continue
+		}
+		localDir := filepath.Join(s.localRoot, filepath.FromSlash(relDir))
+		if objects[localDir] == nil {
+			objects[localDir] = map[string]Object{}
+		}
+		objects[localDir][name] = o
+	}
+	s.objectsLock.Lock()
+	s.objects = objects
+	s.objectsLock.Unlock()
+	s.logger.Info("[objstore] remote files", "url", s.cfg.String(), "files", len(objs))
+	return nil
+}
+
+func (s *Store) object(localPath string) (Object, bool) {
+	localDir, name := filepath.Split(filepath.Clean(localPath))
+	s.objectsLock.RLock()
+	defer s.objectsLock.RUnlock()
+	o, ok := s.objects[filepath.Clean(localDir)][name]
+	return o, ok
+}
+
+// ReadDir - implements dir.RemoteFiles
+func (s *Store) ReadDir(localDir string) ([]os.DirEntry, error) {
+	s.objectsLock.RLock()
+	defer s.objectsLock.RUnlock()
+	files := s.objects[filepath.Clean(localDir)]
+	res := make([]os.DirEntry, 0, len(files))
+	for name, o := range files {
+		res = append(res, remoteEntry{name: name, size: o.Size})
+	}
+	return res, nil
+}
+
+// Exists - implements dir.RemoteFiles
+func (s *Store) Exists(localPath string) bool {
+	_, ok := s.object(localPath)
+	return ok
+}
+
+// Open - implements dir.RemoteFiles. Returns nil if file is on local disk and was not fetched by this store
+func (s *Store) Open(localPath string) (dir.RemoteFile, error) {
+	localPath = filepath.Clean(localPath)
+	s.localLock.Lock()
+	_, fetched := s.locals[localPath]
+	s.localLock.Unlock()
+	if !fetched {
+		if _, err := os.Stat(localPath); err == nil {
+			return nil, nil
+		}
+	}
+	f, err := s.OpenFile(localPath)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Acquire - implements dir.RemoteFiles: makes remote file available on `localPath`. Local copy is not evicted
+// until `release` is called
+func (s *Store) Acquire(localPath string) (release func(), err error) {
+	localPath = filepath.Clean(localPath)
+	for {
+		release, local := s.ref(localPath)
+		if release != nil {
+			return release, nil
+		}
+		if local {
+			return func() {}, nil
+		}
+		if err := s.Fetch(s.ctx, localPath); err != nil {
+			return nil, err
+		}
+		// copy may be evicted before it's referenced (budget is enforced by `ref`) - then fetch again
+	}
+}
+
+// ref - references local copy of `localPath`. `local` - file is on local disk, but was not fetched by this store
+func (s *Store) ref(localPath string) (release func(), local bool) {
+	s.localLock.Lock()
+	defer s.localLock.Unlock()
+	e, ok := s.locals[localPath]
+	if !ok {
+		_, err := os.Stat(localPath)
+		return nil, err == nil
+	}
+	c := e.Value.(*localCopy)
+	c.refs++
+	s.local.MoveToFront(e)
+	s.evict()
+	var once sync.Once
+	return func() { once.Do(func() { s.unref(c) }) }, false
+}
+
+func (s *Store) unref(c *localCopy) {
+	s.localLock.Lock()
+	defer s.localLock.Unlock()
+	c.refs--
+	s.evict()
+}
+
+// Fetch - downloads remote file to `localPath`. Copy is not referenced: it may be evicted, use `Acquire` to read it
+func (s *Store) Fetch(ctx context.Context, localPath string) error {
+	localPath = filepath.Clean(localPath)
+	_, err, _ := s.fetchGroup.Do(localPath, func() (interface{}, error) {
+		if _, err := os.Stat(localPath); err == nil {
+			return nil, nil
+		}
+		o, ok := s.object(localPath)
+		if !ok {
+			return nil, fmt.Errorf("objstore: %s: %w", localPath, ErrNotFound)
+		}
+		t := time.Now()
+		if err := s.download(ctx, o, localPath); err != nil {
+			return nil, err
+		}
+		s.logger.Debug("[objstore] fetched", "file", filepath.Base(localPath), "size", o.Size, "took", time.Since(t))
+		return nil, nil
+	})
+	return err
+}
+
+func (s *Store) download(ctx context.Context, o Object, localPath string) error {
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+		return err
+	}
+	tmpPath := localPath + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpPath)
+	defer f.Close()
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(s.cfg.FetchWorkers)
+	for offset := int64(0); offset < o.Size; offset += fetchChunk {
+		g.Go(func() error {
+			buf := make([]byte, min(fetchChunk, o.Size-offset))
+			if err := s.client.ReadRange(gctx, o.Key, offset, buf); err != nil {
+				return err
+			}
+			_, err := f.WriteAt(buf, offset)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// file appears on disk and in LRU atomically: `ref` must not see file which is not in LRU yet
+	s.localLock.Lock()
+	defer s.localLock.Unlock()
+	if err := os.Rename(tmpPath, localPath); err != nil {
+		return err
+	}
+	s.locals[localPath] = s.local.PushFront(&localCopy{path: localPath, size: o.Size})
+	s.localSize += o.Size
+	return nil
+}
+
+// evict - removes least recently used local copies which are not referenced, while disk budget is exceeded.
+// must be called under `localLock`
+func (s *Store) evict() {
+	if s.cfg.DiskCacheSize == 0 {
+		return
+	}
+	for e := s.local.Back(); e != nil && s.localSize > int64(s.cfg.DiskCacheSize); {
+		c := e.Value.(*localCopy)
+		prev := e.Prev()
+		if c.refs > 0 {
+			e = prev
+			continue
+		}
+		if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+			s.logger.Warn("[objstore] evict local copy", "file", c.path, "err", err)
+			e = prev
+			continue
+		}
+		s.local.Remove(e)
+		delete(s.locals, c.path)
+		s.localSize -= c.size
+		s.logger.Debug("[objstore] evicted local copy", "file", filepath.Base(c.path), "size", c.size)
+		e = prev
+	}
+}
+
+// OpenFile - random access to remote file without fetching it whole
+func (s *Store) OpenFile(localPath string) (*File, error) {
+	o, ok := s.object(localPath)
+	if !ok {
+		return nil, fmt.Errorf("objstore: %s: %w", localPath, ErrNotFound)
+	}
+	return &File{s: s, obj: o}, nil
+}
+
+// File - remote file, reads are served by pages through in-memory LRU. Implements dir.RemoteFile
+type File struct {
+	s   *Store
+	obj Object
+}
+
+func (f *File) Size() int64 { return f.obj.Size }
+
+func (f *File) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, fmt.Errorf("objstore: negative offset %d", off)
+	}
+	if off >= f.obj.Size {
+		return 0, io.EOF
+	}
+	pageSize := int64(f.s.cfg.PageSize)
+	for n < len(p) && off < f.obj.Size {
+		pageNum := off / pageSize
+		data, err := f.s.page(f.s.ctx, f.obj, pageNum)
+		if err != nil {
+			return n, err
+		}
+		copied := copy(p[n:], data[off-pageNum*pageSize:])
+		n += copied
+		off += int64(copied)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (s *Store) page(ctx context.Context, o Object, pageNum int64) ([]byte, error) {
+	k := pageKey{key: o.Key, page: pageNum}
+	if data, ok := s.pages.get(k); ok {
+		return data, nil
+	}
+	v, err, _ := s.pageGroup.Do(fmt.Sprintf("%s#%d", o.Key, pageNum), func() (interface{}, error) {
+		pageSize := int64(s.cfg.PageSize)
+		data := make([]byte, min(pageSize, o.Size-pageNum*pageSize))
+		if err := s.client.ReadRange(ctx, o.Key, pageNum*pageSize, data); err != nil {
+			return nil, err
+		}
+		s.pages.add(k, data)
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]byte), nil
+}
+
+type remoteEntry struct {
+	name string
+	size int64
+}
+
+func (e remoteEntry) Name() string               { return e.name }
+func (e remoteEntry) IsDir() bool                { return false }
+func (e remoteEntry) Type() fs.FileMode          { return 0 }
+func (e remoteEntry) Info() (fs.FileInfo, error) { return e, nil }
+func (e remoteEntry) Size() int64                { return e.size }
+func (e remoteEntry) Mode() fs.FileMode          { return 0o444 }
+func (e remoteEntry) ModTime() time.Time         { return time.Time{} }
+func (e remoteEntry) Sys() any                   { return nil }
+
+// Setup - opens store by `s3://` url and installs it as `dir.RemoteFiles`: after this call snapshot files
+// of `localRoot` which exist only in bucket are visible and opened transparently
+func Setup(ctx context.Context, url string, diskCacheSize datasize.ByteSize, localRoot string, logger log.Logger) (*Store, error) {
+	cfg, err := ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	cfg.DiskCacheSize = diskCacheSize
+	s, err := Open(ctx, cfg, localRoot, logger)
+	if err != nil {
+		return nil, err
+	}
+	dir.SetRemoteFiles(s)
+	return s, nil
+}
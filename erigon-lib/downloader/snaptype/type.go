@@ -27,6 +27,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
@@ -100,6 +101,20 @@ func (f IndexBuilderFunc) Build(ctx context.Context, info FileInfo, salt uint32,
 var saltMap = map[string]uint32{}
 var saltLock sync.RWMutex
 
+var codecs atomic.Pointer[seg.CodecsCfg]
+
+// SetCodecs - codecs of new files by type name (`headers`, `bodies`, `blobsidecars`, ...). Codec is recorded in file
+// header: existing files stay readable after codec of type is changed
+func SetCodecs(cfg seg.CodecsCfg) { codecs.Store(&cfg) }
+
+// CompressCfg - `cfg` with codec of new files of type `t` (see `SetCodecs`)
+func CompressCfg(t Type, cfg seg.Cfg) seg.Cfg {
+	if c := codecs.Load(); c != nil {
+		cfg.Codec = c.For(t.Name())
+	}
+	return cfg
+}
+
 func ReadAndCreateSaltIfNeeded(baseDir string) (uint32, error) {
 	fpath := filepath.Join(baseDir, "salt-blocks.txt")
 	exists, err := dir.FileExist(fpath)
@@ -563,7 +578,7 @@ func BuildIndexWithSnapName(ctx context.Context, info FileInfo, cfg recsplit.Rec
 func ExtractRange(ctx context.Context, f FileInfo, extractor RangeExtractor, indexBuilder IndexBuilder, firstKey FirstKeyGetter, chainDB kv.RoDB, chainConfig *chain.Config, tmpDir string, workers int, lvl log.Lvl, logger log.Logger, hashResolver BlockHashResolver) (uint64, error) {
 	var lastKeyValue uint64
 
-	sn, err := seg.NewCompressor(ctx, "Snapshot "+f.Type.Name(), f.Path, tmpDir, seg.DefaultCfg, lvl, logger)
+	sn, err := seg.NewCompressor(ctx, "Snapshot "+f.Type.Name(), f.Path, tmpDir, CompressCfg(f.Type, seg.DefaultCfg), lvl, logger)
 
 	if err != nil {
 		return lastKeyValue, err
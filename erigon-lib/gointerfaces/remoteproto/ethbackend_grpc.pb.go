@@ -38,6 +38,10 @@ const (
 	ETHBACKEND_NodeInfo_FullMethodName                = "/remote.ETHBACKEND/NodeInfo"
 	ETHBACKEND_Peers_FullMethodName                   = "/remote.ETHBACKEND/Peers"
 	ETHBACKEND_AddPeer_FullMethodName                 = "/remote.ETHBACKEND/AddPeer"
+	ETHBACKEND_RemovePeer_FullMethodName              = "/remote.ETHBACKEND/RemovePeer"
+	ETHBACKEND_AddTrustedPeer_FullMethodName          = "/remote.ETHBACKEND/AddTrustedPeer"
+	ETHBACKEND_RemoveTrustedPeer_FullMethodName       = "/remote.ETHBACKEND/RemoveTrustedPeer"
+	ETHBACKEND_PeerEvents_FullMethodName              = "/remote.ETHBACKEND/PeerEvents"
 	ETHBACKEND_PendingBlock_FullMethodName            = "/remote.ETHBACKEND/PendingBlock"
 	ETHBACKEND_BorTxnLookup_FullMethodName            = "/remote.ETHBACKEND/BorTxnLookup"
 	ETHBACKEND_BorEvents_FullMethodName               = "/remote.ETHBACKEND/BorEvents"
@@ -79,6 +83,14 @@ type ETHBACKENDClient interface {
 	// Peers collects and returns peers information from all running sentry instances.
 	Peers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PeersReply, error)
 	AddPeer(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AddPeerReply, error)
+	// RemovePeer disconnects the peer and removes it from the static nodes.
+	RemovePeer(ctx context.Context, in *RemovePeerRequest, opts ...grpc.CallOption) (*RemovePeerReply, error)
+	// AddTrustedPeer allows the node to always connect, even if slots are full.
+	AddTrustedPeer(ctx context.Context, in *AddTrustedPeerRequest, opts ...grpc.CallOption) (*AddTrustedPeerReply, error)
+	// RemoveTrustedPeer removes the node from the trusted set, without disconnecting it.
+	RemoveTrustedPeer(ctx context.Context, in *RemoveTrustedPeerRequest, opts ...grpc.CallOption) (*RemoveTrustedPeerReply, error)
+	// PeerEvents streams connected and disconnected peers of all running sentry instances.
+	PeerEvents(ctx context.Context, in *PeerEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PeerEvent], error)
 	// PendingBlock returns latest built block.
 	PendingBlock(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PendingBlockReply, error)
 	BorTxnLookup(ctx context.Context, in *BorTxnLookupRequest, opts ...grpc.CallOption) (*BorTxnLookupReply, error)
@@ -275,6 +287,55 @@ func (c *eTHBACKENDClient) AddPeer(ctx context.Context, in *AddPeerRequest, opts
 	return out, nil
 }
 
+func (c *eTHBACKENDClient) RemovePeer(ctx context.Context, in *RemovePeerRequest, opts ...grpc.CallOption) (*RemovePeerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemovePeerReply)
+	err := c.cc.Invoke(ctx, ETHBACKEND_RemovePeer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eTHBACKENDClient) AddTrustedPeer(ctx context.Context, in *AddTrustedPeerRequest, opts ...grpc.CallOption) (*AddTrustedPeerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTrustedPeerReply)
+	err := c.cc.Invoke(ctx, ETHBACKEND_AddTrustedPeer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eTHBACKENDClient) RemoveTrustedPeer(ctx context.Context, in *RemoveTrustedPeerRequest, opts ...grpc.CallOption) (*RemoveTrustedPeerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveTrustedPeerReply)
+	err := c.cc.Invoke(ctx, ETHBACKEND_RemoveTrustedPeer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eTHBACKENDClient) PeerEvents(ctx context.Context, in *PeerEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PeerEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ETHBACKEND_ServiceDesc.Streams[2], ETHBACKEND_PeerEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PeerEventsRequest, PeerEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ETHBACKEND_PeerEventsClient = grpc.ServerStreamingClient[PeerEvent]
+
 func (c *eTHBACKENDClient) PendingBlock(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PendingBlockReply, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PendingBlockReply)
@@ -341,6 +402,14 @@ type ETHBACKENDServer interface {
 	// Peers collects and returns peers information from all running sentry instances.
 	Peers(context.Context, *emptypb.Empty) (*PeersReply, error)
 	AddPeer(context.Context, *AddPeerRequest) (*AddPeerReply, error)
+	// RemovePeer disconnects the peer and removes it from the static nodes.
+	RemovePeer(context.Context, *RemovePeerRequest) (*RemovePeerReply, error)
+	// AddTrustedPeer allows the node to always connect, even if slots are full.
+	AddTrustedPeer(context.Context, *AddTrustedPeerRequest) (*AddTrustedPeerReply, error)
+	// RemoveTrustedPeer removes the node from the trusted set, without disconnecting it.
+	RemoveTrustedPeer(context.Context, *RemoveTrustedPeerRequest) (*RemoveTrustedPeerReply, error)
+	// PeerEvents streams connected and disconnected peers of all running sentry instances.
+	PeerEvents(*PeerEventsRequest, grpc.ServerStreamingServer[PeerEvent]) error
 	// PendingBlock returns latest built block.
 	PendingBlock(context.Context, *emptypb.Empty) (*PendingBlockReply, error)
 	BorTxnLookup(context.Context, *BorTxnLookupRequest) (*BorTxnLookupReply, error)
@@ -406,6 +475,18 @@ func (UnimplementedETHBACKENDServer) Peers(context.Context, *emptypb.Empty) (*Pe
 func (UnimplementedETHBACKENDServer) AddPeer(context.Context, *AddPeerRequest) (*AddPeerReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddPeer not implemented")
 }
+func (UnimplementedETHBACKENDServer) RemovePeer(context.Context, *RemovePeerRequest) (*RemovePeerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemovePeer not implemented")
+}
+func (UnimplementedETHBACKENDServer) AddTrustedPeer(context.Context, *AddTrustedPeerRequest) (*AddTrustedPeerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTrustedPeer not implemented")
+}
+func (UnimplementedETHBACKENDServer) RemoveTrustedPeer(context.Context, *RemoveTrustedPeerRequest) (*RemoveTrustedPeerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTrustedPeer not implemented")
+}
+func (UnimplementedETHBACKENDServer) PeerEvents(*PeerEventsRequest, grpc.ServerStreamingServer[PeerEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method PeerEvents not implemented")
+}
 func (UnimplementedETHBACKENDServer) PendingBlock(context.Context, *emptypb.Empty) (*PendingBlockReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PendingBlock not implemented")
 }
@@ -724,6 +805,71 @@ func _ETHBACKEND_AddPeer_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ETHBACKEND_RemovePeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ETHBACKENDServer).RemovePeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ETHBACKEND_RemovePeer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ETHBACKENDServer).RemovePeer(ctx, req.(*RemovePeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ETHBACKEND_AddTrustedPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTrustedPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ETHBACKENDServer).AddTrustedPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ETHBACKEND_AddTrustedPeer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ETHBACKENDServer).AddTrustedPeer(ctx, req.(*AddTrustedPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ETHBACKEND_RemoveTrustedPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTrustedPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ETHBACKENDServer).RemoveTrustedPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ETHBACKEND_RemoveTrustedPeer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ETHBACKENDServer).RemoveTrustedPeer(ctx, req.(*RemoveTrustedPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ETHBACKEND_PeerEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PeerEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ETHBACKENDServer).PeerEvents(m, &grpc.GenericServerStream[PeerEventsRequest, PeerEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ETHBACKEND_PeerEventsServer = grpc.ServerStreamingServer[PeerEvent]
+
 func _ETHBACKEND_PendingBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -845,6 +991,18 @@ var ETHBACKEND_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddPeer",
 			Handler:    _ETHBACKEND_AddPeer_Handler,
 		},
+		{
+			MethodName: "RemovePeer",
+			Handler:    _ETHBACKEND_RemovePeer_Handler,
+		},
+		{
+			MethodName: "AddTrustedPeer",
+			Handler:    _ETHBACKEND_AddTrustedPeer_Handler,
+		},
+		{
+			MethodName: "RemoveTrustedPeer",
+			Handler:    _ETHBACKEND_RemoveTrustedPeer_Handler,
+		},
 		{
 			MethodName: "PendingBlock",
 			Handler:    _ETHBACKEND_PendingBlock_Handler,
@@ -870,6 +1028,11 @@ var ETHBACKEND_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "PeerEvents",
+			Handler:       _ETHBACKEND_PeerEvents_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "remote/ethbackend.proto",
 }
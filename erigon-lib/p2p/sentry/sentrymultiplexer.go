@@ -8,6 +8,7 @@ import (
 	"io"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/gointerfaces"
@@ -727,6 +728,63 @@ func (m *sentryMultiplexer) AddPeer(ctx context.Context, in *sentryproto.AddPeer
 	return &sentryproto.AddPeerReply{Success: success}, nil
 }
 
+func (m *sentryMultiplexer) RemovePeer(ctx context.Context, in *sentryproto.RemovePeerRequest, opts ...grpc.CallOption) (*sentryproto.RemovePeerReply, error) {
+	success, err := m.anySuccess(ctx, func(ctx context.Context, client *client) (bool, error) {
+		result, err := client.RemovePeer(ctx, in, opts...)
+		return result.GetSuccess(), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sentryproto.RemovePeerReply{Success: success}, nil
+}
+
+func (m *sentryMultiplexer) AddTrustedPeer(ctx context.Context, in *sentryproto.AddTrustedPeerRequest, opts ...grpc.CallOption) (*sentryproto.AddTrustedPeerReply, error) {
+	success, err := m.anySuccess(ctx, func(ctx context.Context, client *client) (bool, error) {
+		result, err := client.AddTrustedPeer(ctx, in, opts...)
+		return result.GetSuccess(), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sentryproto.AddTrustedPeerReply{Success: success}, nil
+}
+
+func (m *sentryMultiplexer) RemoveTrustedPeer(ctx context.Context, in *sentryproto.RemoveTrustedPeerRequest, opts ...grpc.CallOption) (*sentryproto.RemoveTrustedPeerReply, error) {
+	success, err := m.anySuccess(ctx, func(ctx context.Context, client *client) (bool, error) {
+		result, err := client.RemoveTrustedPeer(ctx, in, opts...)
+		return result.GetSuccess(), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sentryproto.RemoveTrustedPeerReply{Success: success}, nil
+}
+
+// anySuccess - calls all clients concurrently: success if any client returns success
+func (m *sentryMultiplexer) anySuccess(ctx context.Context, call func(ctx context.Context, client *client) (bool, error)) (bool, error) {
+	g, gctx := errgroup.WithContext(ctx)
+
+	var success atomic.Bool
+	for _, client := range m.clients {
+		g.Go(func() error {
+			ok, err := call(gctx, client)
+			if err != nil {
+				return err
+			}
+			if ok {
+				success.Store(true)
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return false, err
+	}
+	return success.Load(), nil
+}
+
 func (m *sentryMultiplexer) NodeInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*typesproto.NodeInfoReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, `method "NodeInfo" not implemented: use "NodeInfos" instead`)
 }
@@ -0,0 +1,97 @@
+package sentry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	"github.com/erigontech/erigon-lib/p2p/sentry"
+)
+
+type peerReply struct {
+	success bool
+	err     error
+}
+
+func TestMultiplexerPeerManagement(t *testing.T) {
+	errSentry := errors.New("sentry failure")
+	tests := []struct {
+		name    string
+		replies []peerReply
+		success bool
+		err     error
+	}{
+		{name: "no sentries", success: false},
+		{name: "single success", replies: []peerReply{{success: true}}, success: true},
+		{name: "single failure", replies: []peerReply{{success: false}}, success: false},
+		{name: "any success", replies: []peerReply{{success: false}, {success: true}, {success: false}}, success: true},
+		{name: "no success", replies: []peerReply{{success: false}, {success: false}}, success: false},
+		{name: "error", replies: []peerReply{{success: true}, {err: errSentry}}, err: errSentry},
+	}
+
+	calls := []struct {
+		name   string
+		expect func(c *sentryproto.MockSentryClient, r peerReply)
+		call   func(mux sentryproto.SentryClient) (bool, error)
+	}{
+		{
+			name: "RemovePeer",
+			expect: func(c *sentryproto.MockSentryClient, r peerReply) {
+				c.EXPECT().RemovePeer(gomock.Any(), &sentryproto.RemovePeerRequest{Url: "enode://a"}, gomock.Any()).
+					Return(&sentryproto.RemovePeerReply{Success: r.success}, r.err)
+			},
+			call: func(mux sentryproto.SentryClient) (bool, error) {
+				reply, err := mux.RemovePeer(context.Background(), &sentryproto.RemovePeerRequest{Url: "enode://a"})
+				return reply.GetSuccess(), err
+			},
+		},
+		{
+			name: "AddTrustedPeer",
+			expect: func(c *sentryproto.MockSentryClient, r peerReply) {
+				c.EXPECT().AddTrustedPeer(gomock.Any(), &sentryproto.AddTrustedPeerRequest{Url: "enode://a"}, gomock.Any()).
+					Return(&sentryproto.AddTrustedPeerReply{Success: r.success}, r.err)
+			},
+			call: func(mux sentryproto.SentryClient) (bool, error) {
+				reply, err := mux.AddTrustedPeer(context.Background(), &sentryproto.AddTrustedPeerRequest{Url: "enode://a"})
+				return reply.GetSuccess(), err
+			},
+		},
+		{
+			name: "RemoveTrustedPeer",
+			expect: func(c *sentryproto.MockSentryClient, r peerReply) {
+				c.EXPECT().RemoveTrustedPeer(gomock.Any(), &sentryproto.RemoveTrustedPeerRequest{Url: "enode://a"}, gomock.Any()).
+					Return(&sentryproto.RemoveTrustedPeerReply{Success: r.success}, r.err)
+			},
+			call: func(mux sentryproto.SentryClient) (bool, error) {
+				reply, err := mux.RemoveTrustedPeer(context.Background(), &sentryproto.RemoveTrustedPeerRequest{Url: "enode://a"})
+				return reply.GetSuccess(), err
+			},
+		},
+	}
+
+	for _, c := range calls {
+		for _, tt := range tests {
+			t.Run(c.name+"/"+tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				clients := make([]sentryproto.SentryClient, len(tt.replies))
+				for i, r := range tt.replies {
+					client := sentryproto.NewMockSentryClient(ctrl)
+					c.expect(client, r)
+					clients[i] = client
+				}
+
+				success, err := c.call(sentry.NewSentryMultiplexer(clients))
+				if tt.err != nil {
+					require.ErrorIs(t, err, tt.err)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, tt.success, success)
+			})
+		}
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/c2h5oh/datasize"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/common/dir"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/mmap"
 	"github.com/erigontech/erigon-lib/recsplit/eliasfano16"
@@ -99,6 +100,7 @@ type Index struct {
 	existence          []byte
 
 	readers *sync.Pool
+	release func() // releases local copy of remote file
 }
 
 func MustOpen(indexFile string) *Index {
@@ -129,6 +131,9 @@ func OpenIndex(indexFilePath string) (idx *Index, err error) {
 		}
 	}()
 
+	if idx.release, err = dir.EnsureLocal(indexFilePath); err != nil {
+		return nil, err
+	}
 	idx.f, err = os.Open(indexFilePath)
 	if err != nil {
 		return nil, err
@@ -267,7 +272,14 @@ func (idx *Index) FileName() string   { return idx.fileName }
 func (idx *Index) IsOpen() bool       { return idx != nil && idx.f != nil }
 
 func (idx *Index) Close() {
-	if idx == nil || idx.f == nil {
+	if idx == nil {
+		return
+	}
+	if idx.release != nil {
+		defer idx.release()
+		idx.release = nil
+	}
+	if idx.f == nil {
 		return
 	}
 	if err := mmap.Munmap(idx.mmapHandle1, idx.mmapHandle2); err != nil {
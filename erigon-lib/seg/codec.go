@@ -0,0 +1,261 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package seg
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/dict"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec - how words are encoded inside .seg container. Codec is picked by writer (`Cfg.Codec`) and recorded in file
+// header: Decompressor reads files of any codec, Getter decodes words transparently.
+//
+//   - pattern - default: per-file patterns dictionary + huffman. Files have no codec header (same format as before codecs)
+//   - zstd    - each word is zstd frame, compressed with dictionary shared by all files of snapshot type (copy of
+//     dictionary is stored in header of each file)
+//   - raw     - no compression
+//
+// Non-pattern codec encodes all words: `Compressor.AddWord` and `Compressor.AddUncompressedWord` are same,
+// and all reads of Getter return decoded words. So files of any `FileCompression` can switch codec.
+type Codec interface {
+	Name() string
+	ID() CodecID
+	Encode(dst, word []byte) []byte             // appends encoded word to dst
+	Decode(dst, encoded []byte) ([]byte, error) // appends decoded word to dst
+}
+
+const (
+	CodecNamePattern = "pattern"
+	CodecNameZstd    = "zstd"
+	CodecNameRaw     = "raw"
+)
+
+// CodecID - codec of file, stored in .seg header. Files of pattern codec have no codec in header (same format as before codecs)
+type CodecID byte
+
+const (
+	CodecIDPattern CodecID = iota
+	CodecIDZstd
+	CodecIDRaw
+)
+
+func (id CodecID) String() string {
+	switch id {
+	case CodecIDPattern:
+		return CodecNamePattern
+	case CodecIDZstd:
+		return CodecNameZstd
+	case CodecIDRaw:
+		return CodecNameRaw
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(id))
+	}
+}
+
+var (
+	PatternCodec Codec = patternCodec{}
+	RawCodec     Codec = rawCodec{}
+)
+
+// isPattern - nil codec is pattern codec (zero value of `Cfg.Codec`)
+func isPattern(c Codec) bool { return c == nil || c.ID() == CodecIDPattern }
+
+// patternCodec - words are compressed by Compressor itself
+type patternCodec struct{}
+
+func (patternCodec) Name() string                         { return CodecNamePattern }
+func (patternCodec) ID() CodecID                          { return CodecIDPattern }
+func (patternCodec) Encode(dst, word []byte) []byte       { return append(dst, word...) }
+func (patternCodec) Decode(dst, w []byte) ([]byte, error) { return append(dst, w...), nil }
+
+type rawCodec struct{}
+
+func (rawCodec) Name() string                         { return CodecNameRaw }
+func (rawCodec) ID() CodecID                          { return CodecIDRaw }
+func (rawCodec) Encode(dst, word []byte) []byte       { return append(dst, word...) }
+func (rawCodec) Decode(dst, w []byte) ([]byte, error) { return append(dst, w...), nil }
+
+// ZstdCodec - words are compressed independently (random access by offset stays cheap),
+// small words compress well only because of dictionary trained on samples of same snapshot type
+type ZstdCodec struct {
+	dict    []byte
+	encOpts []zstd.EOption
+	encOnce sync.Once
+	enc     *zstd.Encoder
+	encErr  error
+	decs    sync.Pool
+}
+
+// NewZstdCodec - `dict` is result of `TrainZstdDict`, nil - no dictionary
+func NewZstdCodec(dict []byte) (*ZstdCodec, error) {
+	encOpts := []zstd.EOption{zstd.WithEncoderCRC(false), zstd.WithEncoderConcurrency(1), zstd.WithEncoderLevel(zstd.SpeedBetterCompression)}
+	decOpts := []zstd.DOption{zstd.IgnoreChecksum(true), zstd.WithDecoderConcurrency(1)}
+	if len(dict) > 0 {
+		encOpts = append(encOpts, zstd.WithEncoderDict(dict))
+		decOpts = append(decOpts, zstd.WithDecoderDicts(dict))
+	}
+	dec, err := zstd.NewReader(nil, decOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("zstd codec: %w", err)
+	}
+	c := &ZstdCodec{dict: dict, encOpts: encOpts}
+	c.decs.New = func() any {
+		dec, _ := zstd.NewReader(nil, decOpts...) // options are validated above
+		return dec
+	}
+	c.decs.Put(dec)
+	return c, nil
+}
+
+func (c *ZstdCodec) Name() string { return CodecNameZstd }
+func (c *ZstdCodec) ID() CodecID  { return CodecIDZstd }
+func (c *ZstdCodec) Dict() []byte { return c.dict }
+
+// encoder - created on first write: files are opened for reading much more often
+func (c *ZstdCodec) encoder() *zstd.Encoder {
+	c.encOnce.Do(func() { c.enc, c.encErr = zstd.NewWriter(nil, c.encOpts...) })
+	if c.encErr != nil {
+		panic(fmt.Errorf("zstd codec: %w", c.encErr))
+	}
+	return c.enc
+}
+
+func (c *ZstdCodec) Encode(dst, word []byte) []byte { return c.encoder().EncodeAll(word, dst) }
+
+func (c *ZstdCodec) Decode(dst, encoded []byte) ([]byte, error) {
+	dec := c.decs.Get().(*zstd.Decoder)
+	defer c.decs.Put(dec)
+	out, err := dec.DecodeAll(encoded, dst)
+	if err != nil {
+		return dst, fmt.Errorf("zstd codec: %w", err)
+	}
+	return out, nil
+}
+
+// codecHeader - `[segFormatV1][CodecID][params]`, params of zstd: `[dictLen uint32][dict]`. Empty for pattern codec
+func codecHeader(c Codec) []byte {
+	if isPattern(c) {
+		return nil
+	}
+	hdr := []byte{segFormatV1, byte(c.ID())}
+	if zc, ok := c.(*ZstdCodec); ok {
+		hdr = binary.BigEndian.AppendUint32(hdr, uint32(len(zc.dict)))
+		hdr = append(hdr, zc.dict...)
+	}
+	return hdr
+}
+
+// codecHeaderSize - size of `codecHeader` at the beginning of file. `data` must have at least `codecHeaderMinSize` bytes
+func codecHeaderSize(data []byte) uint64 {
+	if data[0] != segFormatV1 {
+		return 0
+	}
+	if CodecID(data[1]) == CodecIDZstd {
+		return codecHeaderMinSize + uint64(binary.BigEndian.Uint32(data[2:codecHeaderMinSize]))
+	}
+	return 2
+}
+
+const codecHeaderMinSize = 6
+
+// codecFromHeader - decoder of words of file with header `hdr` (see `codecHeader`)
+func codecFromHeader(hdr []byte) (Codec, error) {
+	if len(hdr) == 0 {
+		return PatternCodec, nil
+	}
+	switch id := CodecID(hdr[1]); id {
+	case CodecIDRaw:
+		return RawCodec, nil
+	case CodecIDZstd:
+		return NewZstdCodec(hdr[codecHeaderMinSize:])
+	default:
+		return nil, fmt.Errorf("unknown codec %d", byte(id))
+	}
+}
+
+// TrainZstdDict - builds shared dictionary from samples (words of snapshot type). Good dictionary needs 100+ samples.
+func TrainZstdDict(samples [][]byte, maxSize int) ([]byte, error) {
+	d, err := dict.BuildZstdDict(samples, dict.Options{MaxDictSize: maxSize, HashBytes: 6, ZstdLevel: zstd.SpeedBetterCompression})
+	if err != nil {
+		return nil, fmt.Errorf("train zstd dict: %w", err)
+	}
+	return d, nil
+}
+
+// ZstdDictPath - shared dictionary of snapshot type `name`
+func ZstdDictPath(dir, name string) string { return filepath.Join(dir, name+".zstd-dict") }
+
+// CodecsCfg - codec per snapshot type or domain name (`headers`, `receipts`, `blobsidecars`, `accounts`, ...).
+// Types without entry use `PatternCodec`.
+type CodecsCfg map[string]Codec
+
+func (c CodecsCfg) For(name string) Codec {
+	if codec, ok := c[name]; ok {
+		return codec
+	}
+	return PatternCodec
+}
+
+// ParseCodecs - `receipts=zstd,blobsidecars=raw`. Dictionaries of zstd codecs are loaded by `ZstdDictPath(dictsDir, name)`:
+// zstd codec without dictionary file uses no dictionary.
+func ParseCodecs(s, dictsDir string) (CodecsCfg, error) {
+	cfg := CodecsCfg{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, codecName, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid codec %q: expected <type>=<codec>", part)
+		}
+		codec, err := NewCodec(codecName, ZstdDictPath(dictsDir, name))
+		if err != nil {
+			return nil, err
+		}
+		cfg[name] = codec
+	}
+	return cfg, nil
+}
+
+// NewCodec - by name. `dictPath` is used by zstd codec, if file exists
+func NewCodec(name, dictPath string) (Codec, error) {
+	switch name {
+	case CodecNamePattern:
+		return PatternCodec, nil
+	case CodecNameRaw:
+		return RawCodec, nil
+	case CodecNameZstd:
+		var d []byte
+		if dictPath != "" {
+			var err error
+			if d, err = os.ReadFile(dictPath); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+		}
+		return NewZstdCodec(d)
+	default:
+		return nil, fmt.Errorf("unknown codec: %s, expected one of: %s, %s, %s", name, CodecNamePattern, CodecNameZstd, CodecNameRaw)
+	}
+}
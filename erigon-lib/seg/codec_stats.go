@@ -0,0 +1,139 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package seg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+type CodecStats struct {
+	Codec        string
+	Words        int
+	Size         int64 // of re-encoded file
+	DictSize     int   // shared dictionary (copy is stored in header of file: included in Size)
+	CompressTime time.Duration
+
+	ReadAvg, ReadP50, ReadP99 time.Duration // random read: Reset(offset) + Next
+}
+
+func (s CodecStats) String() string {
+	return fmt.Sprintf("codec=%s, words=%d, size=%s, dict=%s, compress=%s, read avg=%s p50=%s p99=%s",
+		s.Codec, s.Words, common.ByteCount(uint64(s.Size)), common.ByteCount(uint64(s.DictSize)), s.CompressTime.Round(time.Millisecond),
+		s.ReadAvg, s.ReadP50, s.ReadP99)
+}
+
+// SampleWords - up to `limit` words evenly distributed over `src` (for example: to train zstd dictionary)
+func SampleWords(src ReaderI, count, limit int) [][]byte {
+	step := max(count/max(limit, 1), 1)
+	samples := make([][]byte, 0, min(count, limit))
+	src.Reset(0)
+	for i := 0; src.HasNext() && len(samples) < limit; i++ {
+		if i%step != 0 {
+			src.Skip()
+			continue
+		}
+		w, _ := src.Next(nil)
+		samples = append(samples, w)
+	}
+	return samples
+}
+
+// MeasureCodec - re-encodes all words of `src` by `codec` into `tmpDir`,
+// checks that all words can be read back, and measures size and latency of `reads` random reads
+func MeasureCodec(ctx context.Context, src ReaderI, codec Codec, tmpDir string, reads int, logger log.Logger) (stats CodecStats, err error) {
+	stats.Codec = codec.Name()
+	if zc, ok := codec.(*ZstdCodec); ok {
+		stats.DictSize = len(zc.Dict())
+	}
+	outPath := filepath.Join(tmpDir, fmt.Sprintf("%s.%s.seg", filepath.Base(src.FileName()), codec.Name()))
+	defer os.Remove(outPath)
+
+	t := time.Now()
+	cfg := DefaultCfg
+	cfg.Codec = codec
+	comp, err := NewCompressor(ctx, "codec "+codec.Name(), outPath, tmpDir, cfg, log.LvlDebug, logger)
+	if err != nil {
+		return stats, err
+	}
+	defer comp.Close()
+	comp.DisableFsync()
+	var word []byte
+	for src.Reset(0); src.HasNext(); {
+		word, _ = src.Next(word[:0])
+		if err := comp.AddWord(word); err != nil {
+			return stats, err
+		}
+	}
+	if err := comp.Compress(); err != nil {
+		return stats, err
+	}
+	stats.CompressTime = time.Since(t)
+
+	d, err := NewDecompressor(outPath)
+	if err != nil {
+		return stats, err
+	}
+	defer d.Close()
+	stats.Size, stats.Words = d.Size(), d.Count()
+
+	if d.Codec().ID() != codec.ID() {
+		return stats, fmt.Errorf("codec %s: file header has codec %s", codec.Name(), d.Codec().Name())
+	}
+	// read back all words: collect offsets and compare with source
+	r := d.MakeGetter()
+	offsets := make([]uint64, 0, d.Count())
+	var got []byte
+	var offset uint64
+	for src.Reset(0); src.HasNext(); {
+		word, _ = src.Next(word[:0])
+		offsets = append(offsets, offset)
+		got, offset = r.Next(got[:0])
+		if !bytes.Equal(word, got) {
+			return stats, fmt.Errorf("codec %s: word %d mismatch after decode", codec.Name(), len(offsets)-1)
+		}
+	}
+	if len(offsets) == 0 || reads <= 0 {
+		return stats, nil
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	latencies := make([]time.Duration, reads)
+	var total time.Duration
+	for i := range latencies {
+		off := offsets[rnd.Intn(len(offsets))]
+		t := time.Now()
+		r.Reset(off)
+		got, _ = r.Next(got[:0])
+		latencies[i] = time.Since(t)
+		total += latencies[i]
+	}
+	slices.Sort(latencies)
+	stats.ReadAvg = total / time.Duration(reads)
+	stats.ReadP50 = latencies[reads/2]
+	stats.ReadP99 = latencies[reads*99/100]
+	return stats, nil
+}
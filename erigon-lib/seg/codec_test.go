@@ -0,0 +1,256 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package seg
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func codecTestWords() [][]byte {
+	words := make([][]byte, 0, len(loremStrings)*4)
+	for i := 0; i < 4; i++ {
+		for k, w := range loremStrings {
+			words = append(words, []byte(fmt.Sprintf("{\"id\":%d,\"word\":\"%s\",\"status\":\"0x1\",\"logs\":[]}", i*len(loremStrings)+k, w)))
+		}
+	}
+	words = append(words, nil) // empty word
+	return words
+}
+
+func writeCodecFile(t *testing.T, codec Codec, words [][]byte) *Decompressor {
+	t.Helper()
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, "compressed")
+	cfg := DefaultCfg
+	cfg.MinPatternScore = 1
+	cfg.Codec = codec
+	c, err := NewCompressor(context.Background(), t.Name(), file, tmpDir, cfg, log.LvlDebug, log.New())
+	require.NoError(t, err)
+	defer c.Close()
+	for _, word := range words {
+		require.NoError(t, c.AddWord(word))
+	}
+	require.NoError(t, c.Compress())
+	d, err := NewDecompressor(file)
+	require.NoError(t, err)
+	t.Cleanup(d.Close)
+	return d
+}
+
+func TestCodecs(t *testing.T) {
+	words := codecTestWords()
+	dict, err := TrainZstdDict(words, 4*1024)
+	require.NoError(t, err)
+	zstdDict, err := NewZstdCodec(dict)
+	require.NoError(t, err)
+	zstdNoDict, err := NewZstdCodec(nil)
+	require.NoError(t, err)
+
+	sizes := map[string]int64{}
+	for name, codec := range map[string]Codec{"pattern": PatternCodec, "raw": RawCodec, "zstd": zstdDict, "zstd-nodict": zstdNoDict} {
+		t.Run(name, func(t *testing.T) {
+			d := writeCodecFile(t, codec, words)
+			sizes[name] = d.Size() - int64(len(dict))
+			require.Equal(t, codec.ID(), d.Codec().ID())
+			g := d.MakeGetter()
+			var buf []byte
+			var offsets []uint64
+			var offset uint64
+			for i := 0; g.HasNext(); i++ {
+				offsets = append(offsets, offset)
+				buf, offset = g.Next(buf[:0])
+				require.Equal(t, string(words[i]), string(buf), i)
+			}
+			require.Len(t, offsets, len(words))
+
+			// random access
+			for _, i := range []int{7, 0, len(words) - 2, len(words) - 1} {
+				g.Reset(offsets[i])
+				buf, _ = g.Next(buf[:0])
+				require.Equal(t, string(words[i]), string(buf), i)
+				g.Reset(offsets[i])
+				buf, _ = g.FastNext(make([]byte, len(words[i])))
+				require.Equal(t, string(words[i]), string(buf), i)
+			}
+
+			g.Reset(0)
+			next, _ := g.Skip()
+			require.Equal(t, offsets[1], next)
+
+			g.Reset(offsets[1])
+			require.True(t, g.MatchPrefix(words[1][:5]))
+			require.False(t, g.MatchPrefix([]byte("x")))
+			require.Equal(t, offsets[1], g.dataP)
+			require.NotZero(t, g.MatchCmp(words[2]))
+			require.Equal(t, offsets[1], g.dataP)
+			require.Zero(t, g.MatchCmp(words[1]))
+			require.Equal(t, offsets[2], g.dataP)
+		})
+	}
+	require.Less(t, sizes["zstd"], sizes["zstd-nodict"])
+	require.Less(t, sizes["zstd"], sizes["raw"])
+}
+
+// TestCodecKeysValues - keys and values of .kv files are encoded by codec, whatever `FileCompression` of file is
+func TestCodecKeysValues(t *testing.T) {
+	words := codecTestWords()
+	zc, err := NewZstdCodec(nil)
+	require.NoError(t, err)
+
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, "compressed.kv")
+	cfg := DefaultCfg
+	cfg.Codec = zc
+	c, err := NewCompressor(context.Background(), t.Name(), file, tmpDir, cfg, log.LvlDebug, log.New())
+	require.NoError(t, err)
+	defer c.Close()
+	w := NewWriter(c, CompressNone)
+	for i, word := range words {
+		_, err = w.Write([]byte(fmt.Sprintf("key%04d", i)))
+		require.NoError(t, err)
+		_, err = w.Write(word)
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.Compress())
+	d, err := NewDecompressor(file)
+	require.NoError(t, err)
+	defer d.Close()
+
+	r := NewReader(d.MakeGetter(), CompressNone)
+	var k, v []byte
+	for i := 0; r.HasNext(); i++ {
+		k, _ = r.Next(k[:0])
+		require.Equal(t, fmt.Sprintf("key%04d", i), string(k))
+		v, _ = r.Next(v[:0])
+		require.Equal(t, string(words[i]), string(v), i)
+	}
+	r.Reset(0)
+	require.Positive(t, r.MatchCmp([]byte("key0000a")))
+	require.Zero(t, r.MatchCmp([]byte("key0000")))
+
+	g := d.MakeGetter()
+	require.Zero(t, g.MatchCmpUncompressed([]byte("key0000")))
+	require.True(t, g.MatchPrefixUncompressed([]byte("key")))
+	k, _ = g.NextUncompressed()
+	require.Equal(t, "key0000", string(k))
+	// words are zstd frames in file
+	g.Reset(0)
+	k, _ = g.nextRaw()
+	require.NotEqual(t, "key0000", string(k))
+}
+
+func TestCodecHeader(t *testing.T) {
+	words := codecTestWords()
+	dict, err := TrainZstdDict(words, 4*1024)
+	require.NoError(t, err)
+	zc, err := NewZstdCodec(dict)
+	require.NoError(t, err)
+
+	// pattern files keep format of files written before codecs
+	d := writeCodecFile(t, PatternCodec, words)
+	data, err := os.ReadFile(d.FilePath())
+	require.NoError(t, err)
+	require.Zero(t, data[0])
+	require.Equal(t, PatternCodec, d.Codec())
+
+	raw := writeCodecFile(t, RawCodec, words)
+	require.Equal(t, RawCodec, raw.Codec())
+	zstdFile := writeCodecFile(t, zc, words)
+	data, err = os.ReadFile(zstdFile.FilePath())
+	require.NoError(t, err)
+	require.Equal(t, codecHeader(zc), data[:codecHeaderSize(data)])
+	require.Equal(t, []byte{segFormatV1, byte(CodecIDZstd)}, data[:2])
+	require.Equal(t, len(words), zstdFile.Count())
+	// reader doesn't need dictionary: it's in header
+	require.Equal(t, dict, zstdFile.Codec().(*ZstdCodec).Dict())
+
+	// remote files: dictionaries are read without words
+	for _, f := range []*Decompressor{d, raw, zstdFile} {
+		data, err := os.ReadFile(f.FilePath())
+		require.NoError(t, err)
+		hdr, err := readDictionaries(bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		require.Equal(t, data[:f.wordsStart], hdr)
+	}
+
+	// unknown codec and truncated dictionary: file is corrupted
+	corrupted := filepath.Join(t.TempDir(), "corrupted")
+	bad := slices.Clone(data)
+	bad[1] = 100
+	require.NoError(t, os.WriteFile(corrupted, bad, 0o644))
+	_, err = NewDecompressor(corrupted)
+	var errCorrupted *ErrCompressedFileCorrupted
+	require.ErrorAs(t, err, &errCorrupted)
+
+	bad = slices.Clone(data)
+	binary.BigEndian.PutUint32(bad[2:], uint32(len(bad)))
+	require.NoError(t, os.WriteFile(corrupted, bad, 0o644))
+	_, err = NewDecompressor(corrupted)
+	require.ErrorAs(t, err, &errCorrupted)
+}
+
+func TestParseCodecs(t *testing.T) {
+	dir := t.TempDir()
+	dict, err := TrainZstdDict(codecTestWords(), 4*1024)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(ZstdDictPath(dir, "receipts"), dict, 0o644))
+
+	cfg, err := ParseCodecs("receipts=zstd, blobsidecars=raw,headers=zstd", dir)
+	require.NoError(t, err)
+	require.Equal(t, CodecNameZstd, cfg.For("receipts").Name())
+	require.Equal(t, dict, cfg.For("receipts").(*ZstdCodec).Dict())
+	require.Empty(t, cfg.For("headers").(*ZstdCodec).Dict())
+	require.Equal(t, RawCodec, cfg.For("blobsidecars"))
+	require.Equal(t, PatternCodec, cfg.For("bodies"))
+
+	_, err = ParseCodecs("receipts=lz4", dir)
+	require.Error(t, err)
+	_, err = ParseCodecs("receipts", dir)
+	require.Error(t, err)
+}
+
+func TestMeasureCodec(t *testing.T) {
+	words := codecTestWords()
+	src := writeCodecFile(t, PatternCodec, words)
+	r := NewReader(src.MakeGetter(), CompressKeys|CompressVals)
+
+	samples := SampleWords(r, src.Count(), 100)
+	require.Len(t, samples, 100)
+	dict, err := TrainZstdDict(samples, 4*1024)
+	require.NoError(t, err)
+	zc, err := NewZstdCodec(dict)
+	require.NoError(t, err)
+
+	for _, codec := range []Codec{PatternCodec, RawCodec, zc} {
+		stats, err := MeasureCodec(context.Background(), r, codec, t.TempDir(), 100, log.New())
+		require.NoError(t, err)
+		require.Equal(t, len(words), stats.Words)
+		require.NotZero(t, stats.Size)
+		require.LessOrEqual(t, stats.ReadP50, stats.ReadP99)
+	}
+}
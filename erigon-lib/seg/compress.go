@@ -72,6 +72,9 @@ type Cfg struct {
 	SamplingFactor uint64
 
 	Workers int
+
+	// Codec - encodes all words (both `AddWord` and `AddUncompressedWord`), recorded in file header. nil - `PatternCodec`
+	Codec Codec
 }
 
 var DefaultCfg = Cfg{
@@ -119,8 +122,13 @@ type Compressor struct {
 	trace            bool
 	logger           log.Logger
 	noFsync          bool // fsync is enabled by default, but tests can manually disable
+	codecBuf         []byte
 }
 
+// segFormatV1 - first byte of .seg file which records codec: `codecHeader` precedes header of files
+// of non-pattern codecs. Other files start with words count, which high byte is 0
+const segFormatV1 = 1
+
 func NewCompressor(ctx context.Context, logPrefix, outputFile, tmpDir string, cfg Cfg, lvl log.Lvl, logger log.Logger) (*Compressor, error) {
 	workers := cfg.Workers
 	dir2.MustExist(tmpDir)
@@ -193,6 +201,9 @@ func (c *Compressor) ReadFrom(g *Getter) error {
 }
 
 func (c *Compressor) AddWord(word []byte) error {
+	if !isPattern(c.Codec) { // codec encodes all words
+		return c.AddUncompressedWord(word)
+	}
 	select {
 	case <-c.ctx.Done():
 		return c.ctx.Err()
@@ -229,6 +240,10 @@ func (c *Compressor) AddUncompressedWord(word []byte) error {
 	}
 
 	c.wordsCount++
+	if !isPattern(c.Codec) {
+		c.codecBuf = c.Codec.Encode(c.codecBuf[:0], word)
+		word = c.codecBuf
+	}
 	return c.uncompressedFile.AppendUncompressed(word)
 }
 
@@ -265,6 +280,11 @@ func (c *Compressor) Compress() error {
 		return err
 	}
 	defer cf.Close()
+	if hdr := codecHeader(c.Codec); len(hdr) > 0 {
+		if _, err := cf.Write(hdr); err != nil {
+			return err
+		}
+	}
 	t := time.Now()
 	if err := compressWithPatternCandidates(c.ctx, c.trace, c.Cfg, c.logPrefix, c.tmpOutFilePath, cf, c.uncompressedFile, db, c.lvl, c.logger); err != nil {
 		return err
@@ -21,10 +21,13 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 	"unsafe"
 
@@ -32,6 +35,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/common/assert"
 	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/common/dir"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/mmap"
 )
@@ -133,8 +137,16 @@ type Decompressor struct {
 
 	serializedDictSize uint64
 	dictWords          int
+	codec              Codec // nil - pattern codec
 
 	filePath, FileName1 string
+
+	// remote file (see `dir.RemoteFiles`): open reads only dictionaries, words are fetched and mmap'ed on first read
+	remote   dir.RemoteFile
+	loadOnce sync.Once
+	loaded   atomic.Bool
+	loadErr  error
+	release  func() // releases local copy of remote file
 }
 
 const (
@@ -193,16 +205,24 @@ func NewDecompressor(compressedFilePath string) (*Decompressor, error) {
 		}
 	}()
 
-	d.f, err = os.Open(compressedFilePath)
-	if err != nil {
+	if d.remote, err = dir.OpenRemote(compressedFilePath); err != nil {
 		return nil, err
 	}
+	if d.remote != nil {
+		d.size = d.remote.Size()
+	} else {
+		d.f, err = os.Open(compressedFilePath)
+		if err != nil {
+			return nil, err
+		}
 
-	var stat os.FileInfo
-	if stat, err = d.f.Stat(); err != nil {
-		return nil, err
+		var stat os.FileInfo
+		if stat, err = d.f.Stat(); err != nil {
+			return nil, err
+		}
+		d.size = stat.Size()
+		d.modTime = stat.ModTime()
 	}
-	d.size = stat.Size()
 	if d.size < compressedMinSize {
 		return nil, &ErrCompressedFileCorrupted{
 			FileName: fName,
@@ -210,19 +230,33 @@ func NewDecompressor(compressedFilePath string) (*Decompressor, error) {
 				datasize.ByteSize(d.size).HR(), datasize.ByteSize(compressedMinSize).HR())}
 	}
 
-	d.modTime = stat.ModTime()
-	if d.mmapHandle1, d.mmapHandle2, err = mmap.Mmap(d.f, int(d.size)); err != nil {
-		return nil, err
+	if d.remote != nil {
+		if d.data, err = readDictionaries(d.remote, d.size); err != nil {
+			return nil, err
+		}
+	} else {
+		if d.mmapHandle1, d.mmapHandle2, err = mmap.Mmap(d.f, int(d.size)); err != nil {
+			return nil, err
+		}
+		// read patterns from file
+		d.data = d.mmapHandle1[:d.size]
+		defer d.MadvNormal().DisableReadAhead() //speedup opening on slow drives
 	}
-	// read patterns from file
-	d.data = d.mmapHandle1[:d.size]
-	defer d.MadvNormal().DisableReadAhead() //speedup opening on slow drives
 
-	d.wordsCount = binary.BigEndian.Uint64(d.data[:8])
-	d.emptyWordsCount = binary.BigEndian.Uint64(d.data[8:16])
+	hdr := codecHeaderSize(d.data)
+	if hdr+24 > uint64(d.size) {
+		return nil, &ErrCompressedFileCorrupted{FileName: fName, Reason: fmt.Sprintf("invalid codec header size %d while file size is just %d", hdr, d.size)}
+	}
+	if hdr > 0 {
+		if d.codec, err = codecFromHeader(d.data[:hdr]); err != nil {
+			return nil, &ErrCompressedFileCorrupted{FileName: fName, Reason: err.Error()}
+		}
+	}
+	d.wordsCount = binary.BigEndian.Uint64(d.data[hdr : hdr+8])
+	d.emptyWordsCount = binary.BigEndian.Uint64(d.data[hdr+8 : hdr+16])
 
-	pos := uint64(24)
-	dictSize := binary.BigEndian.Uint64(d.data[16:pos])
+	pos := hdr + 24
+	dictSize := binary.BigEndian.Uint64(d.data[hdr+16 : pos])
 	d.serializedDictSize = dictSize
 
 	if pos+dictSize > uint64(d.size) {
@@ -274,7 +308,7 @@ func NewDecompressor(compressedFilePath string) (*Decompressor, error) {
 		}
 	}
 
-	if assert.Enable && pos != 24 {
+	if assert.Enable && pos != hdr+24 {
 		panic("pos != 24")
 	}
 	pos += dictSize // offset patterns
@@ -332,14 +366,99 @@ func NewDecompressor(compressedFilePath string) (*Decompressor, error) {
 	}
 	d.wordsStart = pos + dictSize
 
-	if d.Count() == 0 && dictSize == 0 && d.size > compressedMinSize {
+	if d.Count() == 0 && dictSize == 0 && d.size > compressedMinSize+int64(hdr) {
 		return nil, &ErrCompressedFileCorrupted{
 			FileName: fName, Reason: fmt.Sprintf("size %v but no words in it", datasize.ByteSize(d.size).HR())}
 	}
+	if d.remote != nil {
+		d.data = nil // patterns are still referencing dictionaries
+	}
 	validationPassed = true
 	return d, nil
 }
 
+// readDictionaries - reads part of remote file which is parsed by `NewDecompressor`: counters and both dictionaries.
+// Sizes are validated by the caller
+func readDictionaries(r io.ReaderAt, size int64) ([]byte, error) {
+	data, err := readPrefix(r, nil, 26)
+	if err != nil {
+		return nil, err
+	}
+	pos := codecHeaderSize(data) + 24
+	if pos > uint64(size) {
+		return data, nil
+	}
+	if data, err = readPrefix(r, data, pos); err != nil {
+		return nil, err
+	}
+	patternsDictSize := binary.BigEndian.Uint64(data[pos-8 : pos])
+	if pos+8 > uint64(size) || patternsDictSize > uint64(size)-pos-8 {
+		return data, nil
+	}
+	if data, err = readPrefix(r, data, pos+patternsDictSize+8); err != nil {
+		return nil, err
+	}
+	posDictSize := binary.BigEndian.Uint64(data[len(data)-8:])
+	if posDictSize > uint64(size)-uint64(len(data)) {
+		return data, nil
+	}
+	return readPrefix(r, data, uint64(len(data))+posDictSize)
+}
+
+// readPrefix - extends `data` (prefix of file) to `n` bytes
+func readPrefix(r io.ReaderAt, data []byte, n uint64) ([]byte, error) {
+	if n <= uint64(len(data)) {
+		return data[:n], nil
+	}
+	grown := make([]byte, n)
+	copy(grown, data)
+	if _, err := r.ReadAt(grown[len(data):], int64(len(data))); err != nil {
+		return nil, err
+	}
+	return grown, nil
+}
+
+// load - fetches remote file and mmap's it. Panics if file can't be fetched: like reads of corrupted file, it can't be
+// reported by getters
+func (d *Decompressor) load() {
+	if d.remote == nil {
+		return
+	}
+	d.loadOnce.Do(func() {
+		release, err := dir.EnsureLocal(d.filePath)
+		if err != nil {
+			d.loadErr = err
+			return
+		}
+		f, err := os.Open(d.filePath)
+		if err != nil {
+			release()
+			d.loadErr = err
+			return
+		}
+		if d.mmapHandle1, d.mmapHandle2, err = mmap.Mmap(f, int(d.size)); err != nil {
+			f.Close()
+			release()
+			d.loadErr = err
+			return
+		}
+		d.f, d.release = f, release
+		d.data = d.mmapHandle1[:d.size]
+		d.loaded.Store(true)
+	})
+	if d.loadErr != nil {
+		panic(fmt.Errorf("fetch remote file %s: %w", d.FileName1, d.loadErr))
+	}
+}
+
+// mmapHandle - nil if file is not mmap'ed yet (remote file)
+func (d *Decompressor) mmapHandle() []byte {
+	if d.remote != nil && !d.loaded.Load() {
+		return nil
+	}
+	return d.mmapHandle1
+}
+
 func buildCondensedPatternTable(table *patternTable, depths []uint64, patterns [][]byte, code uint16, bits int, depth uint64, maxDepth uint64) (int, error) {
 	if maxDepth > maxAllowedDepth {
 		return 0, fmt.Errorf("buildCondensedPatternTable: maxDepth=%d is too deep", maxDepth)
@@ -434,8 +553,18 @@ func buildPosTable(depths []uint64, poss []uint64, table *posTable, code uint16,
 }
 
 func (d *Decompressor) DataHandle() unsafe.Pointer {
+	d.load()
 	return unsafe.Pointer(&d.data[0])
 }
+
+// Codec - codec of words, recorded in file header by `Compressor`
+func (d *Decompressor) Codec() Codec {
+	if d.codec == nil {
+		return PatternCodec
+	}
+	return d.codec
+}
+
 func (d *Decompressor) SerializedDictSize() uint64 { return d.serializedDictSize }
 func (d *Decompressor) DictWords() int             { return d.dictWords }
 
@@ -448,7 +577,7 @@ func (d *Decompressor) ModTime() time.Time {
 }
 
 func (d *Decompressor) IsOpen() bool {
-	return d != nil && d.f != nil
+	return d != nil && (d.f != nil || d.remote != nil)
 }
 
 func (d *Decompressor) checkFileLenChage() {
@@ -468,7 +597,17 @@ func (d *Decompressor) checkFileLenChage() {
 }
 
 func (d *Decompressor) Close() {
-	if d == nil || d.f == nil {
+	if d == nil {
+		return
+	}
+	if d.release != nil {
+		defer d.release()
+		d.release = nil
+	}
+	d.remote = nil
+	if d.f == nil {
+		d.posDict = nil
+		d.dict = nil
 		return
 	}
 	d.checkFileLenChage()
@@ -490,7 +629,7 @@ func (d *Decompressor) FileName() string { return d.FileName1 }
 
 // WithReadAhead - Expect read in sequential order. (Hence, pages in the given range can be aggressively read ahead, and may be freed soon after they are accessed.)
 func (d *Decompressor) WithReadAhead(f func() error) error {
-	if d == nil || d.mmapHandle1 == nil {
+	if d == nil || d.mmapHandle() == nil {
 		return nil
 	}
 	defer d.EnableReadAhead().DisableReadAhead()
@@ -499,36 +638,36 @@ func (d *Decompressor) WithReadAhead(f func() error) error {
 
 // DisableReadAhead - usage: `defer d.EnableReadAhead().DisableReadAhead()`. Please don't use this funcs without `defer` to avoid leak.
 func (d *Decompressor) DisableReadAhead() {
-	if d == nil || d.mmapHandle1 == nil {
+	if d == nil || d.mmapHandle() == nil {
 		return
 	}
 	if !dbg.SnapshotMadvRnd { // all files
-		_ = mmap.MadviseNormal(d.mmapHandle1)
+		_ = mmap.MadviseNormal(d.mmapHandle())
 		return
 	}
 
-	_ = mmap.MadviseRandom(d.mmapHandle1)
+	_ = mmap.MadviseRandom(d.mmapHandle())
 }
 
 func (d *Decompressor) EnableReadAhead() *Decompressor {
-	if d == nil || d.mmapHandle1 == nil {
+	if d == nil || d.mmapHandle() == nil {
 		return d
 	}
-	_ = mmap.MadviseSequential(d.mmapHandle1)
+	_ = mmap.MadviseSequential(d.mmapHandle())
 	return d
 }
 func (d *Decompressor) MadvNormal() *Decompressor {
-	if d == nil || d.mmapHandle1 == nil {
+	if d == nil || d.mmapHandle() == nil {
 		return d
 	}
-	_ = mmap.MadviseNormal(d.mmapHandle1)
+	_ = mmap.MadviseNormal(d.mmapHandle())
 	return d
 }
 func (d *Decompressor) MadvWillNeed() *Decompressor {
-	if d == nil || d.mmapHandle1 == nil {
+	if d == nil || d.mmapHandle() == nil {
 		return d
 	}
-	_ = mmap.MadviseWillNeed(d.mmapHandle1)
+	_ = mmap.MadviseWillNeed(d.mmapHandle())
 	return d
 }
 
@@ -540,7 +679,9 @@ type Getter struct {
 	fName       string
 	data        []byte
 	dataP       uint64
-	dataBit     int // Value 0..7 - position of the bit
+	dataBit     int   // Value 0..7 - position of the bit
+	codec       Codec // nil - pattern codec: words are decoded by `patternDict`
+	codecBuf    []byte
 	trace       bool
 }
 
@@ -646,11 +787,13 @@ func (d *Decompressor) EmptyWordsCount() int { return int(d.emptyWordsCount) }
 // Getter is not thread-safe, but there can be multiple getters used simultaneously and concurrently
 // for the same decompressor
 func (d *Decompressor) MakeGetter() *Getter {
+	d.load()
 	return &Getter{
 		posDict:     d.posDict,
 		data:        d.data[d.wordsStart:],
 		patternDict: d.dict,
 		fName:       d.FileName1,
+		codec:       d.codec,
 	}
 }
 
@@ -667,6 +810,10 @@ func (g *Getter) HasNext() bool {
 // and appends it to the given buf, returning the result of appending
 // After extracting next word, it moves to the beginning of the next one
 func (g *Getter) Next(buf []byte) ([]byte, uint64) {
+	if g.codec != nil {
+		w, offset := g.nextRaw()
+		return g.decode(buf, w), offset
+	}
 	savePos := g.dataP
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
@@ -732,7 +879,18 @@ func (g *Getter) Next(buf []byte) ([]byte, uint64) {
 	return buf, postLoopPos
 }
 
+// NextUncompressed - word added by `Compressor.AddUncompressedWord`. Word of file of non-pattern codec is decoded
+// into new buffer
 func (g *Getter) NextUncompressed() ([]byte, uint64) {
+	if g.codec != nil {
+		w, offset := g.nextRaw()
+		return g.decode(nil, w), offset
+	}
+	return g.nextRaw()
+}
+
+// nextRaw - word as it's stored in file (word of non-pattern codec is not decoded)
+func (g *Getter) nextRaw() ([]byte, uint64) {
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
 	if wordLen == 0 {
@@ -752,8 +910,30 @@ func (g *Getter) NextUncompressed() ([]byte, uint64) {
 	return g.data[pos:g.dataP], g.dataP
 }
 
+// decode - appends word of non-pattern codec to buf. Panics on corrupted word, like other reads of corrupted file
+func (g *Getter) decode(buf, w []byte) []byte {
+	out, err := g.codec.Decode(buf, w)
+	if err != nil {
+		panic(fmt.Errorf("file: %s, offset: %d, %w", g.fName, g.dataP, err))
+	}
+	return out
+}
+
+// peekDecoded - decodes word of non-pattern codec at current offset, doesn't move offset
+func (g *Getter) peekDecoded() []byte {
+	savePos := g.dataP
+	w, _ := g.nextRaw()
+	g.codecBuf = g.decode(g.codecBuf[:0], w)
+	g.dataP, g.dataBit = savePos, 0
+	return g.codecBuf
+}
+
 // Skip moves offset to the next word and returns the new offset and the length of the word.
+// Length of word of non-pattern codec is length of encoded word
 func (g *Getter) Skip() (uint64, int) {
+	if g.codec != nil {
+		return g.SkipUncompressed()
+	}
 	l := g.nextPos(true)
 	l-- // because when create huffman tree we do ++ , because 0 is terminator
 	if l == 0 {
@@ -811,6 +991,9 @@ func (g *Getter) SkipUncompressed() (uint64, int) {
 
 // MatchPrefix only checks if the word at the current offset has a buf prefix. Does not move offset to the next word.
 func (g *Getter) MatchPrefix(prefix []byte) bool {
+	if g.codec != nil {
+		return bytes.HasPrefix(g.peekDecoded(), prefix)
+	}
 	savePos := g.dataP
 	defer func() {
 		g.dataP, g.dataBit = savePos, 0
@@ -894,6 +1077,13 @@ func (g *Getter) MatchPrefix(prefix []byte) bool {
 // MatchCmp lexicographically compares given buf with the word at the current offset in the file.
 // returns 0 if buf == word, -1 if buf < word, 1 if buf > word
 func (g *Getter) MatchCmp(buf []byte) int {
+	if g.codec != nil {
+		cmp := bytes.Compare(buf, g.peekDecoded())
+		if cmp == 0 {
+			g.SkipUncompressed()
+		}
+		return cmp
+	}
 	savePos := g.dataP
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
@@ -954,6 +1144,9 @@ func (g *Getter) MatchCmp(buf []byte) int {
 }
 
 func (g *Getter) MatchPrefixUncompressed(prefix []byte) bool {
+	if g.codec != nil {
+		return bytes.HasPrefix(g.peekDecoded(), prefix)
+	}
 	savePos := g.dataP
 	defer func() {
 		g.dataP, g.dataBit = savePos, 0
@@ -975,6 +1168,9 @@ func (g *Getter) MatchPrefixUncompressed(prefix []byte) bool {
 }
 
 func (g *Getter) MatchCmpUncompressed(buf []byte) int {
+	if g.codec != nil {
+		return bytes.Compare(buf, g.peekDecoded())
+	}
 	savePos := g.dataP
 	defer func() {
 		g.dataP, g.dataBit = savePos, 0
@@ -1000,6 +1196,10 @@ func (g *Getter) MatchCmpUncompressed(buf []byte) int {
 // It is important to allocate enough buf size. Could throw an error if word in file is larger then the buf size.
 // After extracting next word, it moves to the beginning of the next one
 func (g *Getter) FastNext(buf []byte) ([]byte, uint64) {
+	if g.codec != nil {
+		w, offset := g.nextRaw()
+		return g.decode(buf[:0], w), offset
+	}
 	savePos := g.dataP
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
@@ -97,6 +97,8 @@ type Aggregator struct {
 	aggRoTxAutoIncrement atomic.Uint64
 
 	produce bool
+
+	storageGrowth storageGrowth // progress samples for `StorageStats` forecast
 }
 
 const AggregatorSqueezeCommitmentValues = true
@@ -330,6 +332,15 @@ func (a *Aggregator) SetCompressWorkers(i int) {
 	}
 }
 
+// SetCodecs - codecs of new .kv and .v files by domain name (`accounts`, `receipt`, ...). Codec is recorded in file
+// header: existing files stay readable after codec of domain is changed
+func (a *Aggregator) SetCodecs(codecs seg.CodecsCfg) {
+	for _, d := range a.d {
+		d.CompressCfg.Codec = codecs.For(d.filenameBase)
+		d.History.CompressorCfg.Codec = codecs.For(d.filenameBase)
+	}
+}
+
 func (a *Aggregator) DiscardHistory(name kv.Domain) *Aggregator {
 	a.d[name].historyDisabled = true
 	return a
@@ -176,6 +176,98 @@ func TestAggregatorV3_Merge(t *testing.T) {
 	require.EqualValues(t, otherMaxWrite, binary.BigEndian.Uint64(v[:]))
 }
 
+// TestAggregatorV3_Codecs - domain and history files built and merged by configured codecs are read back
+func TestAggregatorV3_Codecs(t *testing.T) {
+	t.Parallel()
+	db, agg := testDbAndAggregatorv3(t, 10)
+	zc, err := seg.NewZstdCodec(nil)
+	require.NoError(t, err)
+	agg.SetCodecs(seg.CodecsCfg{kv.AccountsDomain.String(): zc, kv.StorageDomain.String(): seg.RawCodec})
+
+	rwTx, err := db.BeginRwNosync(context.Background())
+	require.NoError(t, err)
+	defer func() {
+		if rwTx != nil {
+			rwTx.Rollback()
+		}
+	}()
+	ac := agg.BeginFilesRo()
+	defer ac.Close()
+	domains, err := NewSharedDomains(WrapTxWithCtx(rwTx, ac), log.New())
+	require.NoError(t, err)
+	defer domains.Close()
+
+	txs := uint64(1000)
+	addrs := make([][]byte, 16)
+	for i := range addrs {
+		addrs[i] = bytes.Repeat([]byte{byte(i + 1)}, length.Addr)
+	}
+	loc := bytes.Repeat([]byte{0xaa}, length.Hash)
+	written := map[uint64][]byte{} // txNum -> value of account written at txNum
+	latest := map[string][]byte{}
+	for txNum := uint64(1); txNum <= txs; txNum++ {
+		domains.SetTxNum(txNum)
+		addr := addrs[txNum%uint64(len(addrs))]
+		buf := accounts.SerialiseV3(&accounts.Account{Nonce: txNum, Balance: *uint256.NewInt(txNum)})
+		pv, step, err := domains.GetLatest(kv.AccountsDomain, addr)
+		require.NoError(t, err)
+		require.NoError(t, domains.DomainPut(kv.AccountsDomain, addr, nil, buf, pv, step))
+		pv, step, err = domains.GetLatest(kv.StorageDomain, append(common.Copy(addr), loc...))
+		require.NoError(t, err)
+		require.NoError(t, domains.DomainPut(kv.StorageDomain, addr, loc, buf[:2], pv, step))
+		written[txNum], latest[string(addr)] = buf, buf
+	}
+	require.NoError(t, domains.Flush(context.Background(), rwTx))
+	require.NoError(t, rwTx.Commit())
+	rwTx = nil
+
+	require.NoError(t, agg.BuildFiles(txs))
+	rwTx, err = db.BeginRw(context.Background())
+	require.NoError(t, err)
+	defer rwTx.Rollback()
+	logEvery := time.NewTicker(30 * time.Second)
+	defer logEvery.Stop()
+	_, err = ac.prune(context.Background(), rwTx, 0, logEvery)
+	require.NoError(t, err)
+	require.NoError(t, rwTx.Commit())
+	require.NoError(t, agg.MergeLoop(context.Background()))
+
+	for name, want := range map[kv.Domain]seg.CodecID{kv.AccountsDomain: seg.CodecIDZstd, kv.StorageDomain: seg.CodecIDRaw, kv.CodeDomain: seg.CodecIDPattern} {
+		d := agg.d[name]
+		for _, items := range [][]*filesItem{d.dirtyFiles.Items(), d.History.dirtyFiles.Items()} {
+			require.NotEmpty(t, items)
+			for _, item := range items {
+				require.Equal(t, want, item.decompressor.Codec().ID(), item.decompressor.FileName())
+			}
+		}
+		for _, item := range d.History.InvertedIndex.dirtyFiles.Items() {
+			require.Equal(t, seg.CodecIDPattern, item.decompressor.Codec().ID(), item.decompressor.FileName())
+		}
+	}
+
+	roTx, err := db.BeginRo(context.Background())
+	require.NoError(t, err)
+	defer roTx.Rollback()
+	dc := agg.BeginFilesRo()
+	defer dc.Close()
+	for _, addr := range addrs {
+		v, _, ok, err := dc.GetLatest(kv.AccountsDomain, addr, roTx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, latest[string(addr)], v)
+		v, _, ok, err = dc.GetLatest(kv.StorageDomain, append(common.Copy(addr), loc...), roTx)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, latest[string(addr)][:2], v)
+	}
+	for txNum := uint64(1); txNum < 900; txNum += 7 {
+		v, ok, err := dc.GetAsOf(roTx, kv.AccountsDomain, addrs[txNum%uint64(len(addrs))], txNum+1)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, written[txNum], v, txNum)
+	}
+}
+
 func TestAggregatorV3_DirtyFilesRo(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
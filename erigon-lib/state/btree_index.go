@@ -37,6 +37,7 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/background"
 	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/common/dir"
 	"github.com/erigontech/erigon-lib/etl"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/recsplit/eliasfano32"
@@ -770,6 +771,7 @@ type BtIndex struct {
 	size     int64
 	modTime  time.Time
 	pool     sync.Pool
+	release  func() // releases local copy of remote file
 }
 
 // Decompressor should be managed by caller (could be closed after index is built). When index is built, external getter should be passed to seekInFiles function
@@ -884,6 +886,9 @@ func OpenBtreeIndexWithDecompressor(indexPath string, M uint64, kv *seg.Decompre
 		}
 	}()
 
+	if idx.release, err = dir.EnsureLocal(indexPath); err != nil {
+		return nil, err
+	}
 	s, err := os.Stat(indexPath)
 	if err != nil {
 		return nil, err
@@ -916,16 +921,14 @@ func OpenBtreeIndexWithDecompressor(indexPath string, M uint64, kv *seg.Decompre
 		return &Cursor{ef: idx.ef, returnInto: &idx.pool}
 	}
 
-	defer kv.MadvNormal().DisableReadAhead()
-	kvGetter := seg.NewReader(kv.MakeGetter(), compress)
-
 	idx.useBplus = true
 
 	//fmt.Printf("open btree index %s with %d keys b+=%t data compressed %t\n", indexPath, idx.ef.Count(), UseBpsTree, idx.compressed)
 	switch idx.useBplus {
 	case true:
 		if len(idx.data[pos:]) == 0 {
-			idx.bplus = NewBpsTree(kvGetter, idx.ef, M, idx.dataLookup, idx.keyCmp)
+			defer kv.MadvNormal().DisableReadAhead()
+			idx.bplus = NewBpsTree(seg.NewReader(kv.MakeGetter(), compress), idx.ef, M, idx.dataLookup, idx.keyCmp)
 			idx.bplus.cursorGetter = idx.newCursor
 			// fallback for files without nodes encoded
 		} else {
@@ -933,13 +936,20 @@ func OpenBtreeIndexWithDecompressor(indexPath string, M uint64, kv *seg.Decompre
 			if err != nil {
 				return nil, err
 			}
+			// kv is read on open only to assert the nodes (remote kv file is fetched on first read)
+			var kvGetter *seg.Reader
+			if envAssertBTKeys {
+				defer kv.MadvNormal().DisableReadAhead()
+				kvGetter = seg.NewReader(kv.MakeGetter(), compress)
+			}
 			idx.bplus = NewBpsTreeWithNodes(kvGetter, idx.ef, M, idx.dataLookup, idx.keyCmp, nodes)
 			idx.bplus.cursorGetter = idx.newCursor
 		}
 	default:
+		defer kv.MadvNormal().DisableReadAhead()
 		idx.alloc = newBtAlloc(idx.ef.Count(), M, false, idx.dataLookup, idx.keyCmp)
 		if idx.alloc != nil {
-			idx.alloc.WarmUp(kvGetter)
+			idx.alloc.WarmUp(seg.NewReader(kv.MakeGetter(), compress))
 		}
 	}
 
@@ -1021,6 +1031,10 @@ func (b *BtIndex) Close() {
 	if b == nil {
 		return
 	}
+	if b.release != nil {
+		defer b.release()
+		b.release = nil
+	}
 	if b.m != nil {
 		if err := b.m.Unmap(); err != nil {
 			log.Log(dbg.FileCloseLogLevel, "unmap", "err", err, "file", b.FileName(), "stack", dbg.Stack())
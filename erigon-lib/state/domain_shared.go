@@ -25,6 +25,7 @@ import (
 	"math"
 	"path/filepath"
 	"runtime"
+	"sync"
 	"sync/atomic"
 	"time"
 	"unsafe"
@@ -90,7 +91,10 @@ type SharedDomains struct {
 	blockNum atomic.Uint64
 	estSize  int
 	trace    bool //nolint
-	//muMaps   sync.RWMutex
+	// muMaps guards the in-memory maps while parallel execution workers read them,
+	// concurrently with the validated results being applied. See SetParallelReaders.
+	muMaps          sync.RWMutex
+	parallelReaders bool
 	//walLock sync.RWMutex
 
 	domains [kv.DomainLen]map[string]dataWithPrevStep
@@ -378,9 +382,17 @@ func (sd *SharedDomains) SeekCommitment(ctx context.Context, tx kv.Tx) (txsFromB
 	return 0, nil
 }
 
+// SetParallelReaders is called when a parallel executor is attached, its workers read the in-memory
+// maps concurrently. Must be called before the workers get the domains.
+func (sd *SharedDomains) SetParallelReaders(parallel bool) {
+	sd.parallelReaders = parallel
+}
+
 func (sd *SharedDomains) ClearRam(resetCommitment bool) {
-	//sd.muMaps.Lock()
-	//defer sd.muMaps.Unlock()
+	if sd.parallelReaders {
+		sd.muMaps.Lock()
+		defer sd.muMaps.Unlock()
+	}
 	for i := range sd.domains {
 		sd.domains[i] = map[string]dataWithPrevStep{}
 	}
@@ -402,8 +414,10 @@ func (sd *SharedDomains) SaveCommitment(blockNum uint64, rootHash []byte) error
 }
 
 func (sd *SharedDomains) put(domain kv.Domain, key string, val []byte) {
-	// disable mutex - because work on parallel execution postponed after E3 release.
-	//sd.muMaps.Lock()
+	if sd.parallelReaders {
+		sd.muMaps.Lock()
+		defer sd.muMaps.Unlock()
+	}
 	valWithPrevStep := dataWithPrevStep{data: val, prevStep: sd.txNum / sd.aggTx.a.StepSize()}
 	if domain == kv.StorageDomain {
 		if old, ok := sd.storage.Set(key, valWithPrevStep); ok {
@@ -420,12 +434,14 @@ func (sd *SharedDomains) put(domain kv.Domain, key string, val []byte) {
 		sd.estSize += len(key) + len(val)
 	}
 	sd.domains[domain][key] = valWithPrevStep
-	//sd.muMaps.Unlock()
 }
 
 // get returns cached value by key. Cache is invalidated when associated WAL is flushed
 func (sd *SharedDomains) get(table kv.Domain, key []byte) (v []byte, prevStep uint64, ok bool) {
-	//sd.muMaps.RLock()
+	if sd.parallelReaders {
+		sd.muMaps.RLock()
+		defer sd.muMaps.RUnlock()
+	}
 	keyS := toStringZeroCopy(key)
 	var dataWithPrevStep dataWithPrevStep
 	if table == kv.StorageDomain {
@@ -435,12 +451,20 @@ func (sd *SharedDomains) get(table kv.Domain, key []byte) (v []byte, prevStep ui
 	}
 	dataWithPrevStep, ok = sd.domains[table][keyS]
 	return dataWithPrevStep.data, dataWithPrevStep.prevStep, ok
-	//sd.muMaps.RUnlock()
+}
+
+// GetLatestFromMem returns the value written since the last flush, ok is false if the key
+// hasn't been written. Parallel execution workers read the rest from their own RoTx.
+func (sd *SharedDomains) GetLatestFromMem(domain kv.Domain, k []byte) (v []byte, ok bool) {
+	v, _, ok = sd.get(domain, k)
+	return v, ok
 }
 
 func (sd *SharedDomains) SizeEstimate() uint64 {
-	//sd.muMaps.RLock()
-	//defer sd.muMaps.RUnlock()
+	if sd.parallelReaders {
+		sd.muMaps.RLock()
+		defer sd.muMaps.RUnlock()
+	}
 
 	// multiply 2: to cover data-structures overhead (and keep accounting cheap)
 	// and muliply 2 more: for Commitment calculation when batch is full
@@ -561,8 +585,10 @@ func (sd *SharedDomains) replaceShortenedKeysInBranch(prefix []byte, branch comm
 const CodeSizeTableFake = "CodeSize"
 
 func (sd *SharedDomains) ReadsValid(readLists map[string]*KvList) bool {
-	//sd.muMaps.RLock()
-	//defer sd.muMaps.RUnlock()
+	if sd.parallelReaders {
+		sd.muMaps.RLock()
+		defer sd.muMaps.RUnlock()
+	}
 
 	for table, list := range readLists {
 		switch table {
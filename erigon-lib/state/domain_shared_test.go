@@ -538,3 +538,58 @@ func TestSharedDomain_StorageIter(t *testing.T) {
 	domains.Close()
 	ac.Close()
 }
+
+func TestSharedDomain_ReadsValid(t *testing.T) {
+	t.Parallel()
+
+	stepSize := uint64(100)
+	db, agg := testDbAndAggregatorv3(t, stepSize)
+
+	ctx := context.Background()
+	rwTx, err := db.BeginRw(ctx)
+	require.NoError(t, err)
+	defer rwTx.Rollback()
+
+	ac := agg.BeginFilesRo()
+	defer ac.Close()
+
+	domains, err := NewSharedDomains(WrapTxWithCtx(rwTx, ac), log.New())
+	require.NoError(t, err)
+	defer domains.Close()
+
+	acc := func(nonce uint64) []byte {
+		return accounts3.SerialiseV3(&accounts3.Account{Nonce: nonce, Balance: *uint256.NewInt(1), Incarnation: 1})
+	}
+	written, untouched := common.Address{1}, common.Address{2}
+	domains.SetTxNum(1)
+	require.NoError(t, domains.DomainPut(kv.AccountsDomain, written[:], nil, acc(1), nil, 0))
+
+	v, ok := domains.GetLatestFromMem(kv.AccountsDomain, written[:])
+	require.True(t, ok)
+	require.Equal(t, acc(1), v)
+	_, ok = domains.GetLatestFromMem(kv.AccountsDomain, untouched[:])
+	require.False(t, ok)
+
+	// A worker read the account before the write of an earlier transaction was applied.
+	readList := map[string]*KvList{kv.AccountsDomain.String(): {}}
+	readList[kv.AccountsDomain.String()].Push(string(written[:]), acc(1))
+	readList[kv.AccountsDomain.String()].Push(string(untouched[:]), nil)
+	require.True(t, domains.ReadsValid(readList))
+
+	// Workers read concurrently with the writes of the applied transactions.
+	domains.SetParallelReaders(true)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			domains.GetLatestFromMem(kv.AccountsDomain, written[:])
+			domains.ReadsValid(readList)
+		}
+	}()
+	for i := uint64(2); i < 100; i++ {
+		domains.SetTxNum(i)
+		require.NoError(t, domains.DomainPut(kv.AccountsDomain, written[:], nil, acc(i), nil, 0))
+	}
+	<-done
+	require.False(t, domains.ReadsValid(readList))
+}
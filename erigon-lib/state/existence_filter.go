@@ -20,6 +20,7 @@ import (
 	"bufio"
 	"fmt"
 	"hash"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -145,23 +146,33 @@ func OpenExistenceFilter(filePath string) (exFilder *ExistenceFilter, err error)
 	if !exists {
 		return nil, fmt.Errorf("file doesn't exists: %s", fileName)
 	}
-
-	f, err := os.Open(filePath)
+	var r io.Reader
+	var size int64
+	remote, err := dir.OpenRemote(filePath)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
-	stat, err := f.Stat()
-	if err != nil {
-		return nil, err
+	if remote != nil { // filter is loaded into memory: no reason to keep local copy
+		r, size = io.NewSectionReader(remote, 0, remote.Size()), remote.Size()
+	} else {
+		f, err := os.Open(filePath)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		stat, err := f.Stat()
+		if err != nil {
+			return nil, err
+		}
+		r, size = f, stat.Size()
 	}
-	idx.empty = stat.Size() == 0
+	idx.empty = size == 0
 	if idx.empty {
 		return idx, nil
 	}
 
 	filter := new(bloomfilter.Filter)
-	_, err = filter.UnmarshalFromReaderNoVerify(bufio.NewReaderSize(f, 1*1024*1024))
+	_, err = filter.UnmarshalFromReaderNoVerify(bufio.NewReaderSize(r, 1*1024*1024))
 	if err != nil {
 		return nil, fmt.Errorf("OpenExistenceFilter: %w, %s", err, fileName)
 	}
@@ -0,0 +1,294 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/page"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/recsplit/eliasfano32"
+	"github.com/erigontech/erigon-lib/seg"
+)
+
+// FilesDiff - first divergence found between two state files of the same type and step range.
+// Files are compared in their on-disk order, so first divergence is also the smallest diverging key.
+type FilesDiff struct {
+	Ordinal uint64 // amount of equal entries before divergence
+	Key     []byte
+	TxNum   uint64 // only for history and inverted index files
+	Val1    []byte
+	Val2    []byte
+
+	Missing1 bool // Key exists only in 2-nd file
+	Missing2 bool // Key exists only in 1-st file
+}
+
+func (d *FilesDiff) String() string {
+	switch {
+	case d.Missing1:
+		return fmt.Sprintf("entry %d: key %x txNum %d missing in 1-st file", d.Ordinal, d.Key, d.TxNum)
+	case d.Missing2:
+		return fmt.Sprintf("entry %d: key %x txNum %d missing in 2-nd file", d.Ordinal, d.Key, d.TxNum)
+	default:
+		return fmt.Sprintf("entry %d: key %x txNum %d values differ: %x != %x", d.Ordinal, d.Key, d.TxNum, d.Val1, d.Val2)
+	}
+}
+
+// DomainFilesCompression - compression of `.kv` (domain) and `.v` (history) files of given domain
+func DomainFilesCompression(name kv.Domain) (domain, history seg.FileCompression, err error) {
+	cfg, ok := Schema[name]
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown domain: %s", name)
+	}
+	return cfg.Compression, cfg.hist.Compression, nil
+}
+
+// InvertedIndexFilesCompression - compression of `.ef` files. `filenameBase` is domain or standalone index name: `accounts`, `logaddrs`, ...
+func InvertedIndexFilesCompression(filenameBase string) (seg.FileCompression, error) {
+	if name, err := kv.String2Domain(filenameBase); err == nil {
+		return Schema[name].hist.iiCfg.Compression, nil
+	}
+	for _, cfg := range StandaloneIISchema {
+		if cfg.filenameBase == filenameBase {
+			return cfg.Compression, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown inverted index: %s", filenameBase)
+}
+
+// CompareDomainFiles - compare key/value pairs of 2 `.kv` files. Returns nil if files have equal content.
+func CompareDomainFiles(ctx context.Context, name kv.Domain, path1, path2 string) (*FilesDiff, error) {
+	compression, _, err := DomainFilesCompression(name)
+	if err != nil {
+		return nil, err
+	}
+	d1, err := seg.NewDecompressor(path1)
+	if err != nil {
+		return nil, err
+	}
+	defer d1.Close()
+	d2, err := seg.NewDecompressor(path2)
+	if err != nil {
+		return nil, err
+	}
+	defer d2.Close()
+	defer d1.EnableReadAhead().DisableReadAhead()
+	defer d2.EnableReadAhead().DisableReadAhead()
+
+	r1, r2 := seg.NewReader(d1.MakeGetter(), compression), seg.NewReader(d2.MakeGetter(), compression)
+	return compareEntries(ctx, &domainFileIter{r: r1}, &domainFileIter{r: r2})
+}
+
+// CompareInvertedIndexFiles - compare keys and txNums of 2 `.ef` files. Returns nil if files have equal content.
+func CompareInvertedIndexFiles(ctx context.Context, compression seg.FileCompression, path1, path2 string) (*FilesDiff, error) {
+	d1, err := seg.NewDecompressor(path1)
+	if err != nil {
+		return nil, err
+	}
+	defer d1.Close()
+	d2, err := seg.NewDecompressor(path2)
+	if err != nil {
+		return nil, err
+	}
+	defer d2.Close()
+	defer d1.EnableReadAhead().DisableReadAhead()
+	defer d2.EnableReadAhead().DisableReadAhead()
+
+	it1 := &historyFileIter{ii: seg.NewReader(d1.MakeGetter(), compression)}
+	it2 := &historyFileIter{ii: seg.NewReader(d2.MakeGetter(), compression)}
+	return compareEntries(ctx, it1, it2)
+}
+
+// CompareHistoryFiles - compare `.v` files of given domain. History values have no keys - so
+// paired `.ef` files are used to restore key and txNum of each value.
+// For domains which store values on compressed pages `.ef` files are not used and may be empty.
+func CompareHistoryFiles(ctx context.Context, name kv.Domain, efPath1, vPath1, efPath2, vPath2 string) (*FilesDiff, error) {
+	cfg, ok := Schema[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown domain: %s", name)
+	}
+	open := func(efPath, vPath string) (*historyFileIter, func(), error) {
+		v, err := seg.NewDecompressor(vPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		v.EnableReadAhead()
+		it := &historyFileIter{v: seg.NewReader(v.MakeGetter(), cfg.hist.Compression), paged: cfg.hist.historyValuesOnCompressedPage > 1}
+		if it.paged {
+			return it, func() { v.DisableReadAhead(); v.Close() }, nil
+		}
+		ef, err := seg.NewDecompressor(efPath)
+		if err != nil {
+			v.Close()
+			return nil, nil, err
+		}
+		ef.EnableReadAhead()
+		it.ii = seg.NewReader(ef.MakeGetter(), cfg.hist.iiCfg.Compression)
+		return it, func() { ef.DisableReadAhead(); ef.Close(); v.DisableReadAhead(); v.Close() }, nil
+	}
+	it1, close1, err := open(efPath1, vPath1)
+	if err != nil {
+		return nil, err
+	}
+	defer close1()
+	it2, close2, err := open(efPath2, vPath2)
+	if err != nil {
+		return nil, err
+	}
+	defer close2()
+	return compareEntries(ctx, it1, it2)
+}
+
+type fileEntry struct {
+	key   []byte
+	txNum uint64
+	val   []byte
+}
+
+type fileEntriesIter interface {
+	HasNext() bool
+	Next() (fileEntry, error)
+}
+
+func compareEntries(ctx context.Context, it1, it2 fileEntriesIter) (*FilesDiff, error) {
+	var e1, e2 fileEntry
+	var err error
+	var ordinal uint64
+	for it1.HasNext() && it2.HasNext() {
+		if e1, err = it1.Next(); err != nil {
+			return nil, err
+		}
+		if e2, err = it2.Next(); err != nil {
+			return nil, err
+		}
+		switch cmp := bytes.Compare(e1.key, e2.key); {
+		case cmp < 0 || (cmp == 0 && e1.txNum < e2.txNum):
+			return newFilesDiff(ordinal, e1, fileEntry{}, false, true), nil
+		case cmp > 0 || (cmp == 0 && e1.txNum > e2.txNum):
+			return newFilesDiff(ordinal, fileEntry{}, e2, true, false), nil
+		}
+		if !bytes.Equal(e1.val, e2.val) {
+			return newFilesDiff(ordinal, e1, e2, false, false), nil
+		}
+		ordinal++
+
+		if ordinal%1024 == 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+		}
+	}
+	if it1.HasNext() {
+		if e1, err = it1.Next(); err != nil {
+			return nil, err
+		}
+		return newFilesDiff(ordinal, e1, fileEntry{}, false, true), nil
+	}
+	if it2.HasNext() {
+		if e2, err = it2.Next(); err != nil {
+			return nil, err
+		}
+		return newFilesDiff(ordinal, fileEntry{}, e2, true, false), nil
+	}
+	return nil, nil
+}
+
+// newFilesDiff - copy entries, because they may point to mmap'ed files which will be closed
+func newFilesDiff(ordinal uint64, e1, e2 fileEntry, missing1, missing2 bool) *FilesDiff {
+	d := &FilesDiff{Ordinal: ordinal, Val1: common.Copy(e1.val), Val2: common.Copy(e2.val), Missing1: missing1, Missing2: missing2}
+	if missing1 {
+		d.Key, d.TxNum = common.Copy(e2.key), e2.txNum
+	} else {
+		d.Key, d.TxNum = common.Copy(e1.key), e1.txNum
+	}
+	return d
+}
+
+type domainFileIter struct {
+	r *seg.Reader
+}
+
+func (it *domainFileIter) HasNext() bool { return it.r.HasNext() }
+func (it *domainFileIter) Next() (fileEntry, error) {
+	k, _ := it.r.Next(nil)
+	if !it.r.HasNext() {
+		return fileEntry{}, fmt.Errorf("%s: key %x has no value", it.r.FileName(), k)
+	}
+	v, _ := it.r.Next(nil)
+	return fileEntry{key: k, val: v}, nil
+}
+
+// historyFileIter - iterates over (key, txNum) pairs of `.ef` file. If `.v` file is set - also returns values.
+type historyFileIter struct {
+	ii    *seg.Reader
+	v     *seg.Reader
+	paged bool
+
+	key   []byte
+	efIt  *eliasfano32.EliasFanoIter
+	pageR *page.Reader
+}
+
+func (it *historyFileIter) HasNext() bool {
+	if it.paged {
+		return (it.pageR != nil && it.pageR.HasNext()) || it.v.HasNext()
+	}
+	return (it.efIt != nil && it.efIt.HasNext()) || it.ii.HasNext()
+}
+
+func (it *historyFileIter) Next() (fileEntry, error) {
+	if it.paged {
+		if it.pageR == nil || !it.pageR.HasNext() {
+			p, _ := it.v.Next(nil)
+			it.pageR = page.FromBytes(p, true)
+		}
+		k, v := it.pageR.Next()
+		if len(k) < 8 {
+			return fileEntry{}, fmt.Errorf("%s: too short history key %x", it.v.FileName(), k)
+		}
+		return fileEntry{key: k[8:], txNum: binary.BigEndian.Uint64(k), val: v}, nil
+	}
+
+	if it.efIt == nil || !it.efIt.HasNext() {
+		it.key, _ = it.ii.Next(nil)
+		if !it.ii.HasNext() {
+			return fileEntry{}, fmt.Errorf("%s: key %x has no value", it.ii.FileName(), it.key)
+		}
+		efBuf, _ := it.ii.Next(nil)
+		ef, _ := eliasfano32.ReadEliasFano(efBuf)
+		it.efIt = ef.Iterator()
+	}
+	txNum, err := it.efIt.Next()
+	if err != nil {
+		return fileEntry{}, err
+	}
+	e := fileEntry{key: it.key, txNum: txNum}
+	if it.v != nil {
+		if !it.v.HasNext() {
+			return fileEntry{}, fmt.Errorf("%s: no value for key %x txNum %d", it.v.FileName(), it.key, txNum)
+		}
+		e.val, _ = it.v.Next(nil)
+	}
+	return e, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/recsplit/eliasfano32"
+	"github.com/erigontech/erigon-lib/seg"
+)
+
+func writeTestSegFile(t *testing.T, path string, compression seg.FileCompression, words ...[]byte) {
+	t.Helper()
+	comp, err := seg.NewCompressor(context.Background(), "cmp", path, t.TempDir(), seg.DefaultCfg, log.LvlDebug, log.New())
+	require.NoError(t, err)
+	defer comp.Close()
+	w := seg.NewWriter(comp, compression)
+	for _, word := range words {
+		_, err = w.Write(word)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Compress())
+}
+
+func testEF(txNums ...uint64) []byte {
+	ef := eliasfano32.NewEliasFano(uint64(len(txNums)), txNums[len(txNums)-1])
+	for _, txNum := range txNums {
+		ef.AddOffset(txNum)
+	}
+	ef.Build()
+	return ef.AppendBytes(nil)
+}
+
+func TestCompareDomainFiles(t *testing.T) {
+	t.Parallel()
+
+	ctx, dir := context.Background(), t.TempDir()
+	compression, _, err := DomainFilesCompression(kv.StorageDomain)
+	require.NoError(t, err)
+
+	f1, f2, f3, f4 := filepath.Join(dir, "1.kv"), filepath.Join(dir, "2.kv"), filepath.Join(dir, "3.kv"), filepath.Join(dir, "4.kv")
+	writeTestSegFile(t, f1, compression, []byte("k1"), []byte("v1"), []byte("k2"), []byte("v2"))
+	writeTestSegFile(t, f2, compression, []byte("k1"), []byte("v1"), []byte("k2"), []byte("v2"))
+	writeTestSegFile(t, f3, compression, []byte("k1"), []byte("v1"), []byte("k2"), []byte("v3"))
+	writeTestSegFile(t, f4, compression, []byte("k1"), []byte("v1"), []byte("k11"), []byte("v1"), []byte("k2"), []byte("v2"))
+
+	diff, err := CompareDomainFiles(ctx, kv.StorageDomain, f1, f2)
+	require.NoError(t, err)
+	require.Nil(t, diff)
+
+	diff, err = CompareDomainFiles(ctx, kv.StorageDomain, f1, f3)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	require.Equal(t, uint64(1), diff.Ordinal)
+	require.Equal(t, []byte("k2"), diff.Key)
+	require.Equal(t, []byte("v2"), diff.Val1)
+	require.Equal(t, []byte("v3"), diff.Val2)
+
+	diff, err = CompareDomainFiles(ctx, kv.StorageDomain, f1, f4)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	require.True(t, diff.Missing1)
+	require.Equal(t, []byte("k11"), diff.Key)
+}
+
+func TestCompareHistoryFiles(t *testing.T) {
+	t.Parallel()
+
+	ctx, dir := context.Background(), t.TempDir()
+	_, vCompression, err := DomainFilesCompression(kv.AccountsDomain)
+	require.NoError(t, err)
+	efCompression, err := InvertedIndexFilesCompression(kv.AccountsDomain.String())
+	require.NoError(t, err)
+
+	ef1, ef2 := filepath.Join(dir, "1.ef"), filepath.Join(dir, "2.ef")
+	writeTestSegFile(t, ef1, efCompression, []byte("k1"), testEF(1, 5), []byte("k2"), testEF(3))
+	writeTestSegFile(t, ef2, efCompression, []byte("k1"), testEF(1, 5), []byte("k2"), testEF(4))
+
+	v1, v2 := filepath.Join(dir, "1.v"), filepath.Join(dir, "2.v")
+	writeTestSegFile(t, v1, vCompression, []byte("a"), []byte("b"), []byte("c"))
+	writeTestSegFile(t, v2, vCompression, []byte("a"), []byte("x"), []byte("c"))
+
+	diff, err := CompareHistoryFiles(ctx, kv.AccountsDomain, ef1, v1, ef1, v1)
+	require.NoError(t, err)
+	require.Nil(t, diff)
+
+	diff, err = CompareHistoryFiles(ctx, kv.AccountsDomain, ef1, v1, ef1, v2)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	require.Equal(t, []byte("k1"), diff.Key)
+	require.Equal(t, uint64(5), diff.TxNum)
+	require.Equal(t, []byte("b"), diff.Val1)
+	require.Equal(t, []byte("x"), diff.Val2)
+
+	diff, err = CompareInvertedIndexFiles(ctx, efCompression, ef1, ef2)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	require.True(t, diff.Missing2)
+	require.Equal(t, []byte("k2"), diff.Key)
+	require.Equal(t, uint64(3), diff.TxNum)
+}
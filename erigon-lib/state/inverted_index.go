@@ -24,7 +24,6 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -131,10 +130,10 @@ func (ii *InvertedIndex) efFilePath(fromStep, toStep uint64) string {
 	return filepath.Join(ii.dirs.SnapIdx, fmt.Sprintf("v1-%s.%d-%d.ef", ii.filenameBase, fromStep, toStep))
 }
 
-func filesFromDir(dir string) ([]string, error) {
-	allFiles, err := os.ReadDir(dir)
+func filesFromDir(dirPath string) ([]string, error) {
+	allFiles, err := dir.ReadDir(dirPath)
 	if err != nil {
-		return nil, fmt.Errorf("filesFromDir: %w, %s", err, dir)
+		return nil, fmt.Errorf("filesFromDir: %w, %s", err, dirPath)
 	}
 	filtered := make([]string, 0, len(allFiles))
 	for _, f := range allFiles {
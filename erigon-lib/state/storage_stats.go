@@ -0,0 +1,289 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/config3"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+const (
+	StorageEntityDomain  = "domain"
+	StorageEntityHistory = "history"
+	StorageEntityII      = "ii"
+)
+
+// EntityStorageStats - disk usage of one domain, history or inverted index
+type EntityStorageStats struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"` // domain, history, ii
+	Files         int    `json:"files"`
+	FilesSize     uint64 `json:"filesSize"` // data files and their accessors
+	DBSize        uint64 `json:"dbSize"`
+	StepsInFiles  uint64 `json:"stepsInFiles"`
+	UnmergedSteps uint64 `json:"unmergedSteps"` // steps in files smaller than `StepsInFrozenFile`: they will be merged
+	PruneLagSteps uint64 `json:"pruneLagSteps"` // steps which are already in files, but not pruned from DB yet
+}
+
+type StorageStats struct {
+	Time      time.Time            `json:"time"`
+	StepSize  uint64               `json:"stepSize"`
+	TxNum     uint64               `json:"txNum"` // progress: files + DB
+	FilesSize uint64               `json:"filesSize"`
+	DBSize    uint64               `json:"dbSize"`
+	Entities  []EntityStorageStats `json:"entities"`
+	Forecast  *GrowthForecast      `json:"forecast,omitempty"` // nil until enough samples collected
+}
+
+func (s *StorageStats) Size() uint64 { return s.FilesSize + s.DBSize }
+
+// GrowthForecast - simple linear projection: `size + days * txNumsPerDay * bytesPerTxNum`.
+// `txNumsPerDay` is measured by samples collected by this process, `bytesPerTxNum` is average over chain lifetime
+type GrowthForecast struct {
+	SampledFor    time.Duration     `json:"sampledFor"`
+	TxNumsPerDay  float64           `json:"txNumsPerDay"`
+	BytesPerTxNum float64           `json:"bytesPerTxNum"`
+	BytesPerDay   float64           `json:"bytesPerDay"`
+	Projected     map[string]uint64 `json:"projected"` // `30d`, `90d`, `365d` -> total size
+}
+
+var forecastDays = []struct {
+	name string
+	days float64
+}{{"30d", 30}, {"90d", 90}, {"365d", 365}}
+
+const (
+	storageSampleInterval = 10 * time.Minute
+	storageSamplesLimit   = 1024
+	minForecastSampling   = time.Hour
+)
+
+type storageSample struct {
+	t     time.Time
+	txNum uint64
+}
+
+// storageGrowth - progress samples of this process, used by forecast
+type storageGrowth struct {
+	lock    sync.Mutex
+	samples []storageSample
+}
+
+func (g *storageGrowth) add(s storageSample) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if len(g.samples) > 0 && s.t.Sub(g.samples[len(g.samples)-1].t) < storageSampleInterval {
+		return
+	}
+	if len(g.samples) >= storageSamplesLimit {
+		g.samples = append(g.samples[:0], g.samples[1:]...)
+	}
+	g.samples = append(g.samples, s)
+}
+
+func (g *storageGrowth) oldest() (storageSample, bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if len(g.samples) == 0 {
+		return storageSample{}, false
+	}
+	return g.samples[0], true
+}
+
+func forecastGrowth(oldest storageSample, st *StorageStats) *GrowthForecast {
+	elapsed := st.Time.Sub(oldest.t)
+	if elapsed < minForecastSampling || st.TxNum <= oldest.txNum || st.TxNum == 0 {
+		return nil
+	}
+	f := &GrowthForecast{
+		SampledFor:    elapsed,
+		TxNumsPerDay:  float64(st.TxNum-oldest.txNum) / elapsed.Hours() * 24,
+		BytesPerTxNum: float64(st.Size()) / float64(st.TxNum),
+		Projected:     map[string]uint64{},
+	}
+	f.BytesPerDay = f.TxNumsPerDay * f.BytesPerTxNum
+	for _, d := range forecastDays {
+		f.Projected[d.name] = st.Size() + uint64(f.BytesPerDay*d.days)
+	}
+	return f
+}
+
+// StorageStats - sizes of all domains, histories and inverted indices (files + DB), unmerged steps and pruning lag.
+// Also adds progress sample for growth forecast.
+func (at *AggregatorRoTx) StorageStats(tx kv.Tx) (*StorageStats, error) {
+	st := &StorageStats{Time: time.Now(), StepSize: at.StepSize()}
+	add := func(name, typ string, files visibleFiles, tables []string, keysTable string) error {
+		es := EntityStorageStats{Name: name, Type: typ, Files: len(files)}
+		for _, f := range files {
+			es.FilesSize += visibleFileSize(f)
+			if f.endTxNum-f.startTxNum < config3.StepsInFrozenFile*st.StepSize {
+				es.UnmergedSteps += (f.endTxNum - f.startTxNum) / st.StepSize
+			}
+		}
+		filesEndTxNum := files.EndTxNum()
+		es.StepsInFiles = filesEndTxNum / st.StepSize
+		for _, table := range tables {
+			size, err := tx.BucketSize(table)
+			if err != nil {
+				return err
+			}
+			es.DBSize += size
+		}
+		first, last, err := txNumRangeInDB(tx, keysTable)
+		if err != nil {
+			return err
+		}
+		if last > 0 && filesEndTxNum > first {
+			es.PruneLagSteps = filesEndTxNum/st.StepSize - first/st.StepSize
+		}
+		st.TxNum = max(st.TxNum, filesEndTxNum, last)
+		st.FilesSize += es.FilesSize
+		st.DBSize += es.DBSize
+		st.Entities = append(st.Entities, es)
+		return nil
+	}
+
+	for _, dt := range at.d {
+		if dt == nil || dt.d.disable {
+			continue
+		}
+		ht, iit := dt.ht, dt.ht.iit
+		if err := add(dt.d.filenameBase, StorageEntityDomain, dt.files, []string{dt.d.valuesTable}, iit.ii.keysTable); err != nil {
+			return nil, err
+		}
+		if ht.h.historyDisabled {
+			continue
+		}
+		if err := add(dt.d.filenameBase, StorageEntityHistory, ht.files, []string{ht.h.valuesTable}, iit.ii.keysTable); err != nil {
+			return nil, err
+		}
+		if err := add(iit.ii.filenameBase, StorageEntityII, iit.files, iit.ii.Tables(), iit.ii.keysTable); err != nil {
+			return nil, err
+		}
+	}
+	for _, iit := range at.iis {
+		if iit.ii.disable {
+			continue
+		}
+		if err := add(iit.ii.filenameBase, StorageEntityII, iit.files, iit.ii.Tables(), iit.ii.keysTable); err != nil {
+			return nil, err
+		}
+	}
+
+	growth := &at.a.storageGrowth
+	if oldest, ok := growth.oldest(); ok {
+		st.Forecast = forecastGrowth(oldest, st)
+	}
+	growth.add(storageSample{t: st.Time, txNum: st.TxNum})
+	return st, nil
+}
+
+func visibleFileSize(f visibleFile) (size uint64) {
+	src := f.src
+	if src.decompressor != nil {
+		size += uint64(src.decompressor.Size())
+	}
+	if src.index != nil {
+		size += uint64(src.index.Size())
+	}
+	if src.bindex != nil {
+		size += uint64(src.bindex.Size())
+	}
+	if src.existence != nil && src.existence.FilePath != "" {
+		if fi, err := os.Stat(src.existence.FilePath); err == nil {
+			size += uint64(fi.Size())
+		}
+	}
+	return size
+}
+
+// txNumRangeInDB - `keysTable` of inverted index is `txNum -> key`: [first, last+1) or zeros if table is empty
+func txNumRangeInDB(tx kv.Tx, keysTable string) (first, last uint64, err error) {
+	fst, err := kv.FirstKey(tx, keysTable)
+	if err != nil || len(fst) < 8 {
+		return 0, 0, err
+	}
+	lst, err := kv.LastKey(tx, keysTable)
+	if err != nil || len(lst) < 8 {
+		return 0, 0, err
+	}
+	return binary.BigEndian.Uint64(fst), binary.BigEndian.Uint64(lst) + 1, nil
+}
+
+var (
+	mxStorageSize          = metrics.GetOrCreateGaugeVec("storage_size_bytes", []string{"name", "type", "location"}, "Size of domain/history/inverted index in files and in DB")
+	mxStorageUnmergedSteps = metrics.GetOrCreateGaugeVec("storage_unmerged_steps", []string{"name", "type"}, "Steps in files which will be merged")
+	mxStoragePruneLagSteps = metrics.GetOrCreateGaugeVec("storage_prune_lag_steps", []string{"name", "type"}, "Steps which are in files, but not pruned from DB yet")
+	mxStorageTotal         = metrics.GetOrCreateGaugeVec("storage_total_bytes", []string{"location"}, "Size of all state files and state tables in DB")
+	mxStorageForecast      = metrics.GetOrCreateGaugeVec("storage_forecast_bytes", []string{"after"}, "Projected total size of state")
+)
+
+func updateStorageMetrics(st *StorageStats) {
+	for _, e := range st.Entities {
+		mxStorageSize.WithLabelValues(e.Name, e.Type, "files").Set(float64(e.FilesSize))
+		mxStorageSize.WithLabelValues(e.Name, e.Type, "db").Set(float64(e.DBSize))
+		mxStorageUnmergedSteps.WithLabelValues(e.Name, e.Type).Set(float64(e.UnmergedSteps))
+		mxStoragePruneLagSteps.WithLabelValues(e.Name, e.Type).Set(float64(e.PruneLagSteps))
+	}
+	mxStorageTotal.WithLabelValues("files").Set(float64(st.FilesSize))
+	mxStorageTotal.WithLabelValues("db").Set(float64(st.DBSize))
+	if st.Forecast != nil {
+		for after, size := range st.Forecast.Projected {
+			mxStorageForecast.WithLabelValues(after).Set(float64(size))
+		}
+	}
+}
+
+// ReportStorageStats - periodically exports `StorageStats` as metrics. Stops on `agg.Close`
+func (a *Aggregator) ReportStorageStats(every time.Duration) {
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			if err := a.reportStorageStats(a.ctx); err != nil {
+				a.logger.Debug("[agg] storage stats", "err", err)
+			}
+			select {
+			case <-a.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (a *Aggregator) reportStorageStats(ctx context.Context) error {
+	return a.db.View(ctx, func(tx kv.Tx) error {
+		at := a.BeginFilesRo()
+		defer at.Close()
+		st, err := at.StorageStats(tx)
+		if err != nil {
+			return err
+		}
+		updateStorageMetrics(st)
+		return nil
+	})
+}
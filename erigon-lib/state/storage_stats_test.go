@@ -0,0 +1,87 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregator_StorageStats(t *testing.T) {
+	db, agg := testDbAggregatorWithFiles(t, &testAggConfig{stepSize: 10})
+	defer db.Close()
+
+	tx, err := db.BeginRo(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+	at := agg.BeginFilesRo()
+	defer at.Close()
+
+	st, err := at.StorageStats(tx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), st.StepSize)
+	require.Equal(t, uint64(320), st.TxNum)
+	require.Nil(t, st.Forecast) // first sample
+
+	var found bool
+	var filesSize, dbSize uint64
+	for _, e := range st.Entities {
+		filesSize += e.FilesSize
+		dbSize += e.DBSize
+		require.LessOrEqual(t, e.UnmergedSteps, e.StepsInFiles, e.Name)
+		if e.Name == "accounts" && e.Type == StorageEntityDomain {
+			found = true
+			require.NotZero(t, e.Files)
+			require.NotZero(t, e.FilesSize)
+			require.NotZero(t, e.DBSize)
+			require.Equal(t, uint64(31), e.StepsInFiles) // last step is still in DB
+			require.NotZero(t, e.PruneLagSteps)          // nothing pruned
+			require.LessOrEqual(t, e.PruneLagSteps, e.StepsInFiles)
+		}
+	}
+	require.True(t, found)
+	require.Equal(t, filesSize, st.FilesSize)
+	require.Equal(t, dbSize, st.DBSize)
+}
+
+func TestForecastGrowth(t *testing.T) {
+	now := time.Now()
+	st := &StorageStats{Time: now, TxNum: 2_000, FilesSize: 150_000, DBSize: 50_000}
+
+	require.Nil(t, forecastGrowth(storageSample{t: now.Add(-10 * time.Minute), txNum: 1_000}, st)) // too short sampling
+	require.Nil(t, forecastGrowth(storageSample{t: now.Add(-2 * time.Hour), txNum: 2_000}, st))    // no progress
+
+	f := forecastGrowth(storageSample{t: now.Add(-12 * time.Hour), txNum: 1_000}, st)
+	require.NotNil(t, f)
+	require.InDelta(t, 2_000, f.TxNumsPerDay, 0.001)
+	require.InDelta(t, 100, f.BytesPerTxNum, 0.001)
+	require.InDelta(t, 200_000, f.BytesPerDay, 0.001)
+	require.Equal(t, uint64(200_000+30*200_000), f.Projected["30d"])
+	require.Equal(t, uint64(200_000+365*200_000), f.Projected["365d"])
+
+	var g storageGrowth
+	g.add(storageSample{t: now, txNum: 1})
+	g.add(storageSample{t: now.Add(time.Minute), txNum: 2}) // too often
+	g.add(storageSample{t: now.Add(storageSampleInterval), txNum: 3})
+	require.Len(t, g.samples, 2)
+	oldest, ok := g.oldest()
+	require.True(t, ok)
+	require.Equal(t, uint64(1), oldest.txNum)
+}
@@ -33,15 +33,6 @@ package tools
 // build tag 'trick_go_mod_tidy' - is used to hide warnings of IDEA (because we can't import `main` packages in go)
 
 import (
-	_ "github.com/erigontech/interfaces"
-	_ "github.com/erigontech/interfaces/downloader"
-	_ "github.com/erigontech/interfaces/execution"
-	_ "github.com/erigontech/interfaces/p2psentinel"
-	_ "github.com/erigontech/interfaces/p2psentry"
-	_ "github.com/erigontech/interfaces/remote"
-	_ "github.com/erigontech/interfaces/txpool"
-	_ "github.com/erigontech/interfaces/types"
-	_ "github.com/erigontech/interfaces/web3"
 	_ "go.uber.org/mock/mockgen"
 	_ "go.uber.org/mock/mockgen/model"
 	_ "google.golang.org/grpc/cmd/protoc-gen-go-grpc"
@@ -26,7 +26,9 @@ import (
 	"fmt"
 	"github.com/erigontech/erigon/consensus/parlia"
 	parliafinality "github.com/erigontech/erigon/consensus/parlia/finality"
+	"io"
 	"io/fs"
+	"math"
 	"math/big"
 	"net"
 	"os"
@@ -65,6 +67,7 @@ import (
 	"github.com/erigontech/erigon-lib/downloader"
 	"github.com/erigontech/erigon-lib/downloader/downloadercfg"
 	"github.com/erigontech/erigon-lib/downloader/downloadergrpc"
+	"github.com/erigontech/erigon-lib/downloader/objstore"
 	"github.com/erigontech/erigon-lib/downloader/snaptype"
 	"github.com/erigontech/erigon-lib/event"
 	protodownloader "github.com/erigontech/erigon-lib/gointerfaces/downloaderproto"
@@ -96,6 +99,7 @@ import (
 	"github.com/erigontech/erigon/core/rawdb"
 	"github.com/erigontech/erigon/core/rawdb/blockio"
 	snaptype2 "github.com/erigontech/erigon/core/snaptype"
+	"github.com/erigontech/erigon/core/state"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/eth/consensuschain"
@@ -524,6 +528,13 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 
 			cfg.ListenAddr = fmt.Sprintf("%s:%d", listenHost, listenPort)
 			server := sentry.NewGrpcServer(backend.sentryCtx, nil, readNodeInfo, &cfg, protocol, logger)
+			if chainConfig.Parlia != nil {
+				blobBlocks := params.MinBlocksForBlobRequests
+				if config.DisableBlobPrune {
+					blobBlocks = math.MaxUint64
+				}
+				server.SetBscHistory(config.Prune.Blocks.PruneTo, blobBlocks)
+			}
 			backend.sentryServers = append(backend.sentryServers, server)
 			sentries = append(sentries, direct.NewSentryClientDirect(protocol, server))
 		}
@@ -1012,7 +1023,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		backend.syncUnwindOrder = stagedsync.PolygonSyncUnwindOrder
 		backend.syncPruneOrder = stagedsync.PolygonSyncPruneOrder
 	} else {
-		backend.syncStages = stages2.NewDefaultStages(backend.sentryCtx, backend.chainDB, snapDb, blobStore, p2pConfig, config, backend.sentriesClient, backend.notifications, backend.downloaderClient,
+		backend.syncStages = stages2.NewDefaultStages(backend.sentryCtx, backend.chainDB, snapDb, blobStore, p2pConfig, config, backend.sentriesClient, backend.notifications, backend.rpcDaemonStateCache, backend.downloaderClient,
 			blockReader, blockRetire, backend.silkworm, backend.forkValidator, backend.engine, heimdallClient, heimdallStore, bridgeStore, recents, signatures, logger)
 		backend.syncUnwindOrder = stagedsync.DefaultUnwindOrder
 		backend.syncPruneOrder = stagedsync.DefaultPruneOrder
@@ -1571,6 +1582,11 @@ func setUpBlockReader(ctx context.Context, db kv.RwDB, dirs datadir.Dirs, snConf
 		}
 	}
 
+	if snConfig.Snapshot.RemoteURL != "" {
+		if _, err := objstore.Setup(ctx, snConfig.Snapshot.RemoteURL, snConfig.Snapshot.RemoteDiskCache, dirs.Snap, logger); err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, nil, err
+		}
+	}
 	allSnapshots := freezeblocks.NewRoSnapshots(snConfig.Snapshot, dirs.Snap, minFrozenBlock, logger)
 
 	var allBorSnapshots *heimdall.RoSnapshots
@@ -1594,6 +1610,7 @@ func setUpBlockReader(ctx context.Context, db kv.RwDB, dirs datadir.Dirs, snConf
 		allBscSnapshots = freezeblocks.NewBscRoSnapshots(snConfig.Snapshot, dirs.Snap, minFrozenBlock, logger)
 	}
 
+	snaptype.SetCodecs(snConfig.Snapshot.Codecs)
 	blockReader := freezeblocks.NewBlockReader(allSnapshots, allBorSnapshots, heimdallStore, bridgeStore, allBscSnapshots)
 	agg, err := libstate.NewAggregator2(ctx, dirs, config3.DefaultStepSize, db, logger)
 	if err != nil {
@@ -1601,6 +1618,8 @@ func setUpBlockReader(ctx context.Context, db kv.RwDB, dirs datadir.Dirs, snConf
 	}
 	agg.SetSnapshotBuildSema(blockSnapBuildSema)
 	agg.SetProduceMod(snConfig.Snapshot.ProduceE3)
+	agg.SetCodecs(snConfig.Snapshot.Codecs)
+	agg.ReportStorageStats(time.Minute)
 
 	allSegmentsDownloadComplete, err := rawdb.AllSegmentsDownloadCompleteFromDB(db)
 	if err != nil {
@@ -1647,6 +1666,70 @@ func (s *Ethereum) AddPeer(ctx context.Context, req *remote.AddPeerRequest) (*re
 	return &remote.AddPeerReply{Success: true}, nil
 }
 
+func (s *Ethereum) RemovePeer(ctx context.Context, req *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		_, err := sentryClient.RemovePeer(ctx, &protosentry.RemovePeerRequest{Url: req.Url})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.RemovePeer error: %w", err)
+		}
+	}
+	return &remote.RemovePeerReply{Success: true}, nil
+}
+
+func (s *Ethereum) AddTrustedPeer(ctx context.Context, req *remote.AddTrustedPeerRequest) (*remote.AddTrustedPeerReply, error) {
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		_, err := sentryClient.AddTrustedPeer(ctx, &protosentry.AddTrustedPeerRequest{Url: req.Url})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.AddTrustedPeer error: %w", err)
+		}
+	}
+	return &remote.AddTrustedPeerReply{Success: true}, nil
+}
+
+func (s *Ethereum) RemoveTrustedPeer(ctx context.Context, req *remote.RemoveTrustedPeerRequest) (*remote.RemoveTrustedPeerReply, error) {
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		_, err := sentryClient.RemoveTrustedPeer(ctx, &protosentry.RemoveTrustedPeerRequest{Url: req.Url})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.RemoveTrustedPeer error: %w", err)
+		}
+	}
+	return &remote.RemoveTrustedPeerReply{Success: true}, nil
+}
+
+// PeerEvents - subscribes to peer events of all sentries and calls `onEvent` until `ctx` is done or any stream fails
+func (s *Ethereum) PeerEvents(ctx context.Context, onEvent func(*remote.PeerEvent) error) error {
+	var onEventLock sync.Mutex // events of all sentries are sent to one stream
+	g, gctx := errgroup.WithContext(ctx)
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		g.Go(func() error {
+			stream, err := sentryClient.PeerEvents(gctx, &protosentry.PeerEventsRequest{})
+			if err != nil {
+				return fmt.Errorf("ethereum backend MultiClient.PeerEvents error: %w", err)
+			}
+			for {
+				event, err := stream.Recv()
+				if err != nil {
+					if errors.Is(err, io.EOF) || gctx.Err() != nil {
+						return nil
+					}
+					return err
+				}
+				eventId := remote.PeerEvent_Connect
+				if event.EventId == protosentry.PeerEvent_Disconnect {
+					eventId = remote.PeerEvent_Disconnect
+				}
+				onEventLock.Lock()
+				err = onEvent(&remote.PeerEvent{PeerId: event.PeerId, EventId: eventId})
+				onEventLock.Unlock()
+				if err != nil {
+					return err
+				}
+			}
+		})
+	}
+	return g.Wait()
+}
+
 // Protocols returns all the currently configured
 // network protocols to start.
 func (s *Ethereum) Protocols() []p2p.Protocol {
@@ -1657,6 +1740,99 @@ func (s *Ethereum) Protocols() []p2p.Protocol {
 	return protocols
 }
 
+const priorityValidatorsRefreshInterval = 10 * time.Minute
+
+// priorityValidatorsLoop periodically makes the node IDs registered by the current
+// validators priority peers of all sentries.
+func (s *Ethereum) priorityValidatorsLoop(ctx context.Context) {
+	var prl *parlia.Parlia
+	if p, ok := s.engine.(*parlia.Parlia); ok {
+		prl = p
+	} else if cl, ok := s.engine.(*merge.Merge); ok {
+		if p, ok := cl.InnerEngine().(*parlia.Parlia); ok {
+			prl = p
+		}
+	}
+	if prl == nil {
+		return
+	}
+
+	refresh := time.NewTicker(priorityValidatorsRefreshInterval)
+	defer refresh.Stop()
+	for {
+		ids, err := s.validatorNodeIDs(ctx, prl)
+		if err != nil {
+			s.logger.Warn("[p2p] Failed to read validator node IDs", "err", err)
+		} else {
+			s.logger.Debug("[p2p] Updating priority validator peers", "count", len(ids))
+			for _, srv := range s.sentryServers {
+				srv.SetPriorityNodes(ids)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-refresh.C:
+		}
+	}
+}
+
+func (s *Ethereum) validatorNodeIDs(ctx context.Context, prl *parlia.Parlia) ([]enode.ID, error) {
+	tx, err := s.chainDB.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	header := rawdb.ReadCurrentHeader(tx)
+	if header == nil {
+		return nil, nil
+	}
+	nodeIDs, err := prl.GetValidatorNodeIDs(header, state.New(rpchelper.NewLatestStateReader(tx)))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]enode.ID, len(nodeIDs))
+	for i, id := range nodeIDs {
+		ids[i] = enode.ID(id)
+	}
+	return ids, nil
+}
+
+// jumpDestAnalysisFile keeps the hottest contracts of vm.SharedAnalysisCache across
+// restarts when JD_SHARED_PERSIST is set.
+const jumpDestAnalysisFile = "jumpdest_analysis.json"
+
+var persistAnalysisCache = dbg.EnvBool("JD_SHARED_PERSIST", false)
+
+// warmAnalysisCache analyses the code of the contracts which were the hottest
+// before the last shutdown.
+func (s *Ethereum) warmAnalysisCache(ctx context.Context) {
+	hot, err := vm.LoadHottest(filepath.Join(s.config.Dirs.DataDir, jumpDestAnalysisFile))
+	if err != nil {
+		s.logger.Warn("Failed to load jumpdest analysis cache", "err", err)
+		return
+	}
+	if len(hot) == 0 {
+		return
+	}
+	tx, err := s.chainDB.BeginTemporalRo(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to warm jumpdest analysis cache", "err", err)
+		return
+	}
+	defer tx.Rollback()
+	reader := rpchelper.NewLatestStateReader(tx)
+	warmed, err := vm.SharedAnalysisCache.Warm(hot, func(addr libcommon.Address) ([]byte, error) {
+		return reader.ReadAccountCode(addr, 0)
+	})
+	if err != nil {
+		s.logger.Warn("Failed to warm jumpdest analysis cache", "err", err)
+		return
+	}
+	s.logger.Info("Warmed jumpdest analysis cache", "contracts", warmed, "saved", len(hot))
+}
+
 // Start implements node.Lifecycle, starting all internal goroutines needed by the
 // Ethereum protocol implementation.
 func (s *Ethereum) Start() error {
@@ -1725,6 +1901,9 @@ func (s *Ethereum) Start() error {
 
 	if s.chainConfig.Parlia != nil {
 		parliafinality.RegisterService()
+		if s.config.PriorityValidators {
+			go s.priorityValidatorsLoop(s.sentryCtx)
+		}
 	}
 
 	if s.silkwormRPCDaemonService != nil {
@@ -1753,6 +1932,10 @@ func (s *Ethereum) Start() error {
 		})
 	}
 
+	if persistAnalysisCache {
+		go s.warmAnalysisCache(s.sentryCtx)
+	}
+
 	if s.shutterPool != nil {
 		s.bgComponentsEg.Go(func() error {
 			defer func() { s.logger.Info("shutter pool goroutine terminated") }()
@@ -1801,6 +1984,11 @@ func (s *Ethereum) Stop() error {
 	for _, sentryServer := range s.sentryServers {
 		sentryServer.Close()
 	}
+	if persistAnalysisCache {
+		if err := vm.SharedAnalysisCache.SaveHottest(filepath.Join(s.config.Dirs.DataDir, jumpDestAnalysisFile), vm.SharedAnalysisCache.Len()); err != nil {
+			s.logger.Warn("Failed to save jumpdest analysis cache", "err", err)
+		}
+	}
 	s.chainDB.Close()
 
 	if s.silkwormRPCDaemonService != nil {
@@ -1,12 +1,27 @@
 package eth
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	protosentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/typesproto"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/p2p/sentry/sentry_multi_client"
 )
 
 func TestRemoveContents(t *testing.T) {
@@ -50,3 +65,152 @@ func TestRemoveContents(t *testing.T) {
 
 	require.Len(t, list, 0)
 }
+
+func newTestEthereum(t *testing.T, sentries ...protosentry.SentryClient) *Ethereum {
+	sentriesClient, err := sentry_multi_client.NewMultiClient(nil, &chain.Config{}, nil, sentries, ethconfig.Sync{}, nil, 0, nil, false, nil, true, log.New())
+	require.NoError(t, err)
+	return &Ethereum{sentriesClient: sentriesClient}
+}
+
+func TestPeerManagement(t *testing.T) {
+	const url = "enode://a979fb575495b8d6db44f750317d0f4622bf4c2aa3365d6af7c284339968eef29b69ad0dce72a4d8db5ebb4968de0e3bec910127f134779fbcb0cb6d3331163c@52.16.188.185:30303"
+	errSentry := errors.New("sentry failure")
+	calls := []struct {
+		name   string
+		expect func(s *protosentry.MockSentryClient, err error)
+		call   func(s *Ethereum) (bool, error)
+	}{
+		{
+			name: "RemovePeer",
+			expect: func(s *protosentry.MockSentryClient, err error) {
+				s.EXPECT().RemovePeer(gomock.Any(), &protosentry.RemovePeerRequest{Url: url}, gomock.Any()).
+					Return(&protosentry.RemovePeerReply{Success: err == nil}, err)
+			},
+			call: func(s *Ethereum) (bool, error) {
+				reply, err := s.RemovePeer(context.Background(), &remote.RemovePeerRequest{Url: url})
+				return reply.GetSuccess(), err
+			},
+		},
+		{
+			name: "AddTrustedPeer",
+			expect: func(s *protosentry.MockSentryClient, err error) {
+				s.EXPECT().AddTrustedPeer(gomock.Any(), &protosentry.AddTrustedPeerRequest{Url: url}, gomock.Any()).
+					Return(&protosentry.AddTrustedPeerReply{Success: err == nil}, err)
+			},
+			call: func(s *Ethereum) (bool, error) {
+				reply, err := s.AddTrustedPeer(context.Background(), &remote.AddTrustedPeerRequest{Url: url})
+				return reply.GetSuccess(), err
+			},
+		},
+		{
+			name: "RemoveTrustedPeer",
+			expect: func(s *protosentry.MockSentryClient, err error) {
+				s.EXPECT().RemoveTrustedPeer(gomock.Any(), &protosentry.RemoveTrustedPeerRequest{Url: url}, gomock.Any()).
+					Return(&protosentry.RemoveTrustedPeerReply{Success: err == nil}, err)
+			},
+			call: func(s *Ethereum) (bool, error) {
+				reply, err := s.RemoveTrustedPeer(context.Background(), &remote.RemoveTrustedPeerRequest{Url: url})
+				return reply.GetSuccess(), err
+			},
+		},
+	}
+	for _, c := range calls {
+		t.Run(c.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			first, second := protosentry.NewMockSentryClient(ctrl), protosentry.NewMockSentryClient(ctrl)
+			c.expect(first, nil)
+			c.expect(second, nil)
+
+			success, err := c.call(newTestEthereum(t, first, second))
+			require.NoError(t, err)
+			require.True(t, success)
+		})
+		t.Run(c.name+"/error", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			first, second := protosentry.NewMockSentryClient(ctrl), protosentry.NewMockSentryClient(ctrl)
+			c.expect(first, nil)
+			c.expect(second, errSentry)
+
+			_, err := c.call(newTestEthereum(t, first, second))
+			require.ErrorIs(t, err, errSentry)
+		})
+	}
+}
+
+type testPeerEventsStream struct {
+	grpc.ClientStream
+	events []*protosentry.PeerEvent
+}
+
+func (s *testPeerEventsStream) Recv() (*protosentry.PeerEvent, error) {
+	if len(s.events) == 0 {
+		return nil, io.EOF
+	}
+	event := s.events[0]
+	s.events = s.events[1:]
+	return event, nil
+}
+
+func TestPeerEvents(t *testing.T) {
+	peer := func(b byte) *typesproto.H512 {
+		var id [64]byte
+		id[0] = b
+		return gointerfaces.ConvertHashToH512(id)
+	}
+	newSentry := func(ctrl *gomock.Controller, events ...*protosentry.PeerEvent) *protosentry.MockSentryClient {
+		s := protosentry.NewMockSentryClient(ctrl)
+		s.EXPECT().PeerEvents(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&testPeerEventsStream{events: events}, nil)
+		return s
+	}
+
+	t.Run("merges sentries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		s := newTestEthereum(t,
+			newSentry(ctrl,
+				&protosentry.PeerEvent{PeerId: peer(1), EventId: protosentry.PeerEvent_Connect},
+				&protosentry.PeerEvent{PeerId: peer(1), EventId: protosentry.PeerEvent_Disconnect}),
+			newSentry(ctrl,
+				&protosentry.PeerEvent{PeerId: peer(2), EventId: protosentry.PeerEvent_Connect}),
+		)
+
+		var events []*remote.PeerEvent
+		err := s.PeerEvents(context.Background(), func(event *remote.PeerEvent) error {
+			events = append(events, event)
+			return nil
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []*remote.PeerEvent{
+			{PeerId: peer(1), EventId: remote.PeerEvent_Connect},
+			{PeerId: peer(1), EventId: remote.PeerEvent_Disconnect},
+			{PeerId: peer(2), EventId: remote.PeerEvent_Connect},
+		}, events)
+	})
+
+	t.Run("stops on send error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		s := newTestEthereum(t, newSentry(ctrl,
+			&protosentry.PeerEvent{PeerId: peer(1), EventId: protosentry.PeerEvent_Connect},
+			&protosentry.PeerEvent{PeerId: peer(2), EventId: protosentry.PeerEvent_Connect}))
+
+		errSend := errors.New("client is gone")
+		var sent int
+		err := s.PeerEvents(context.Background(), func(event *remote.PeerEvent) error {
+			sent++
+			return errSend
+		})
+		require.ErrorIs(t, err, errSend)
+		require.Equal(t, 1, sent)
+	})
+
+	t.Run("stream error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		errStream := errors.New("stream failure")
+		failing := protosentry.NewMockSentryClient(ctrl)
+		failing.EXPECT().PeerEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errStream)
+		s := newTestEthereum(t, failing)
+
+		err := s.PeerEvents(context.Background(), func(event *remote.PeerEvent) error { return nil })
+		require.ErrorIs(t, err, errStream)
+	})
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/downloader/downloadercfg"
+	"github.com/erigontech/erigon-lib/seg"
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/consensus/ethash/ethashcfg"
 	"github.com/erigontech/erigon/core/types"
@@ -124,6 +125,7 @@ var Defaults = Config{
 		BodyDownloadTimeoutSeconds: 2,
 		//LoopBlockLimit:             100_000,
 		ParallelStateFlushing: true,
+		ExecPrefetchCPUBudget: 1,
 		ChaosMonkey:           false,
 	},
 	Ethash: ethashcfg.Config{
@@ -187,6 +189,11 @@ type BlocksFreezing struct {
 	DisableDownloadE3 bool // disable download state snapshots
 	DownloaderAddr    string
 	ChainName         string
+
+	RemoteURL       string            // `s3://bucket/prefix?endpoint=...` - read-only object-store with snapshot files which are not on local disk
+	RemoteDiskCache datasize.ByteSize // budget for local copies of remote files. 0 - unlimited
+
+	Codecs seg.CodecsCfg // codecs of new files by snapshot type or domain name. Files are read by codec recorded in their header
 }
 
 func (s BlocksFreezing) String() string {
@@ -255,7 +262,8 @@ type Config struct {
 	Aura   chain.AuRaConfig
 	Parlia chain.ParliaConfig
 
-	DisableBlobPrune bool // Disable prune Bsc BlobSidecars
+	DisableBlobPrune   bool // Disable prune Bsc BlobSidecars
+	PriorityValidators bool // Treat node IDs of current Bsc validators as p2p priority peers
 
 	// Transaction pool options
 	TxPool  txpoolcfg.Config
@@ -323,6 +331,9 @@ type Sync struct {
 	BreakAfterStage            string
 	LoopBlockLimit             uint
 	ParallelStateFlushing      bool
+	ParallelExec               bool
+	ExecPrefetch               bool    // warm up the state of the blocks executed at chain tip
+	ExecPrefetchCPUBudget      float64 // CPU cores the state prefetcher may use
 
 	UploadLocation   string
 	UploadFrom       rpc.BlockNumber
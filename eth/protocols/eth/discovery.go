@@ -26,6 +26,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/rlp"
 	"github.com/erigontech/erigon/core/forkid"
+	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/p2p/enr"
 )
 
@@ -59,3 +60,74 @@ func LoadENRForkID(r *enr.Record) (*forkid.ID, error) {
 	}
 	return &entry.ForkID, nil
 }
+
+// BscENREntry is the ENR entry which advertises the BSC network of a node and the
+// history it serves, so that nodes can be selected before dialing.
+type BscENREntry struct {
+	Chain       string // network name, e.g. bsc or chapel
+	HistoryFrom uint64 // first block whose bodies and receipts are served
+	BlobsFrom   uint64 // first block whose blob sidecars are served
+
+	// Ignore additional fields (for forward compatibility).
+	Rest []rlp.RawValue `rlp:"tail"`
+}
+
+// ENRKey implements enr.Entry.
+func (e BscENREntry) ENRKey() string {
+	return "bsc"
+}
+
+// bscHistoryGranularity is the step in which the advertised history bounds move.
+// It keeps the ENR sequence number from changing with every new block.
+const bscHistoryGranularity = 1 << 16
+
+// NewBscENREntry constructs a `bsc` ENR entry for a node serving bodies and blob
+// sidecars from the given blocks.
+func NewBscENREntry(chain string, historyFrom, blobsFrom uint64) *BscENREntry {
+	return &BscENREntry{
+		Chain:       chain,
+		HistoryFrom: roundUpHistory(historyFrom),
+		BlobsFrom:   roundUpHistory(blobsFrom),
+	}
+}
+
+// roundUpHistory rounds the first served block up, so that the advertised range stays
+// valid while the node prunes.
+func roundUpHistory(from uint64) uint64 {
+	return (from + bscHistoryGranularity - 1) / bscHistoryGranularity * bscHistoryGranularity
+}
+
+func LoadENRBscEntry(r *enr.Record) (*BscENREntry, error) {
+	var entry BscENREntry
+	if err := r.Load(&entry); err != nil {
+		if enr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load bsc entry from ENR: %w", err)
+	}
+	return &entry, nil
+}
+
+// NewBscNodeFilter returns a filter for discovered nodes, which drops nodes of other BSC
+// networks and nodes not serving the history we need. need returns the first block we
+// need from peers and whether its blob sidecars are needed. Nodes without the `bsc` entry
+// are accepted.
+func NewBscNodeFilter(chain string, need func() (block uint64, blobs bool)) func(*enode.Node) bool {
+	return func(n *enode.Node) bool {
+		entry, err := LoadENRBscEntry(n.Record())
+		if err != nil {
+			return false
+		}
+		if entry == nil {
+			return true
+		}
+		if entry.Chain != chain {
+			return false
+		}
+		block, blobs := need()
+		if entry.HistoryFrom > block {
+			return false
+		}
+		return !blobs || entry.BlobsFrom <= block
+	}
+}
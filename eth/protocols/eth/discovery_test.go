@@ -0,0 +1,64 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/p2p/enr"
+)
+
+func TestBscENREntry(t *testing.T) {
+	entry := NewBscENREntry("bsc", 900_000, 990_000)
+	require.Equal(t, uint64(917_504), entry.HistoryFrom)
+	require.Equal(t, uint64(1_048_576), entry.BlobsFrom)
+
+	entry = NewBscENREntry("bsc", 0, 1_048_576)
+	require.Equal(t, uint64(0), entry.HistoryFrom)
+	require.Equal(t, uint64(1_048_576), entry.BlobsFrom)
+}
+
+func TestBscNodeFilter(t *testing.T) {
+	newNode := func(entry *BscENREntry) *enode.Node {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		var r enr.Record
+		if entry != nil {
+			r.Set(entry)
+		}
+		require.NoError(t, enode.SignV4(&r, key))
+		n, err := enode.New(enode.ValidSchemes, &r)
+		require.NoError(t, err)
+		return n
+	}
+
+	var needBlobs bool
+	filter := NewBscNodeFilter("bsc", func() (uint64, bool) { return 200_000, needBlobs })
+
+	require.True(t, filter(newNode(nil)))
+	require.True(t, filter(newNode(&BscENREntry{Chain: "bsc", HistoryFrom: 0, BlobsFrom: 1 << 20})))
+	require.False(t, filter(newNode(&BscENREntry{Chain: "chapel"})))
+	require.False(t, filter(newNode(&BscENREntry{Chain: "bsc", HistoryFrom: 1 << 20})))
+
+	needBlobs = true
+	require.False(t, filter(newNode(&BscENREntry{Chain: "bsc", HistoryFrom: 0, BlobsFrom: 1 << 20})))
+	require.True(t, filter(newNode(&BscENREntry{Chain: "bsc", HistoryFrom: 0, BlobsFrom: 0})))
+}
@@ -45,6 +45,7 @@ import (
 	"github.com/erigontech/erigon/core/rawdb/rawdbhelpers"
 	"github.com/erigontech/erigon/core/state"
 	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/eth/ethconfig/estimate"
 	"github.com/erigontech/erigon/eth/stagedsync/stages"
 	"github.com/erigontech/erigon/execution/exec3"
@@ -200,7 +201,7 @@ func nothingToExec(applyTx kv.Tx, txNumsReader rawdbv3.TxNumsReader, inputTxNum
 
 func ExecV3(ctx context.Context,
 	execStage *StageState, u Unwinder, workerCount int, cfg ExecuteBlockCfg, txc wrap.TxContainer,
-	parallel bool, //nolint
+	parallel bool,
 	maxBlockNum uint64,
 	logger log.Logger,
 	initialCycle bool,
@@ -208,8 +209,6 @@ func ExecV3(ctx context.Context,
 ) error {
 	inMemExec := txc.Doms != nil
 
-	// TODO: e35 doesn't support parallel-exec yet
-	parallel = false //nolint
 	if parallel && cfg.chainConfig.ChainName == networkname.Gnosis {
 		panic("gnosis consensus doesn't support parallel exec yet: https://github.com/erigontech/erigon/issues/12054")
 	}
@@ -227,16 +226,14 @@ func ExecV3(ctx context.Context,
 	applyTx := txc.Tx
 	useExternalTx := applyTx != nil
 	if !useExternalTx {
-		if !parallel {
-			var err error
-			applyTx, err = cfg.db.BeginRw(ctx) //nolint
-			if err != nil {
-				return err
-			}
-			defer func() { // need callback - because tx may be committed
-				applyTx.Rollback()
-			}()
+		var err error
+		applyTx, err = cfg.db.BeginRw(ctx) //nolint
+		if err != nil {
+			return err
 		}
+		defer func() { // need callback - because tx may be committed
+			applyTx.Rollback()
+		}()
 	}
 	agg := cfg.db.(state2.HasAgg).Agg().(*state2.Aggregator)
 	if !inMemExec && !isMining {
@@ -310,9 +307,14 @@ func ExecV3(ctx context.Context,
 	}
 	rs := state.NewStateV3(doms, cfg.syncCfg, cfg.chainConfig.Bor != nil, logger)
 
-	////TODO: owner of `resultCh` is main goroutine, but owner of `retryQueue` is applyLoop.
-	// Now rwLoop closing both (because applyLoop we completely restart)
-	// Maybe need split channels? Maybe don't exit from ApplyLoop? Maybe current way is also ok?
+	// workers can't see uncommitted state of external RwTx, and their writes are speculative - can't report them to txpool
+	parallel = parallel && workerCount > 1 && !useExternalTx && !inMemExec && !isMining && accumulator == nil
+	// tracers are not safe for concurrent use and must observe transactions in order
+	var tracer vm.EVMLogger
+	if cfg.vmConfig != nil && cfg.vmConfig.Debug {
+		tracer = cfg.vmConfig.Tracer
+	}
+	parallel = parallel && tracer == nil
 
 	if applyTx != nil {
 		if inputTxNum, maxTxNum, offsetFromBlockBeginning, err = restoreTxNum(ctx, &cfg, applyTx, doms, maxBlockNum); err != nil {
@@ -338,6 +340,8 @@ func ExecV3(ctx context.Context,
 	defer applyWorker.LogLRUStats()
 
 	applyWorker.ResetState(rs, accumulator)
+	applyWorker.SetTracer(tracer)
+	defer applyWorker.SetTracer(nil)
 
 	commitThreshold := cfg.batchSize.Bytes()
 
@@ -347,72 +351,46 @@ func ExecV3(ctx context.Context,
 
 	logEvery := time.NewTicker(20 * time.Second)
 	defer logEvery.Stop()
-	pruneEvery := time.NewTicker(2 * time.Second)
-	defer pruneEvery.Stop()
 
 	var logGas uint64
 	var stepsInDB float64
 	var executor executor
 
+	applyWorker.ResetTx(applyTx)
+	doms.SetTx(applyTx)
+
+	se := &serialExecutor{
+		txExecutor: txExecutor{
+			cfg:            cfg,
+			execStage:      execStage,
+			rs:             rs,
+			doms:           doms,
+			agg:            agg,
+			u:              u,
+			isMining:       isMining,
+			inMemExec:      inMemExec,
+			applyTx:        applyTx,
+			applyWorker:    applyWorker,
+			outputTxNum:    &outputTxNum,
+			outputBlockNum: stages.SyncMetrics[stages.Execution],
+			logger:         logger,
+		},
+	}
+
+	defer func() {
+		processed.Log("Done", executor.readState(), nil, nil, se.txCount, logGas, inputBlockNum.Load(), outputBlockNum.GetValueUint64(), outputTxNum.Load(), mxExecRepeats.GetValueUint64(), stepsInDB, shouldGenerateChangesets, inMemExec)
+	}()
+
+	executor = se
 	if parallel {
 		pe := &parallelExecutor{
-			txExecutor: txExecutor{
-				cfg:            cfg,
-				execStage:      execStage,
-				rs:             rs,
-				doms:           doms,
-				agg:            agg,
-				accumulator:    accumulator,
-				isMining:       isMining,
-				inMemExec:      inMemExec,
-				applyTx:        applyTx,
-				applyWorker:    applyWorker,
-				outputTxNum:    &outputTxNum,
-				outputBlockNum: stages.SyncMetrics[stages.Execution],
-				logger:         logger,
-			},
-			shouldGenerateChangesets: shouldGenerateChangesets,
-			workerCount:              workerCount,
-			pruneEvery:               pruneEvery,
-			logEvery:                 logEvery,
-			progress:                 progress,
-		}
-
-		executorCancel := pe.run(ctx, maxTxNum, logger)
+			serialExecutor: se,
+			workerCount:    workerCount,
+		}
+		executorCancel := pe.run(ctx)
 		defer executorCancel()
 
-		defer func() {
-			processed.Log("Done", executor.readState(), nil, pe.rws, 0 /*txCount - TODO*/, logGas, inputBlockNum.Load(), outputBlockNum.GetValueUint64(), outputTxNum.Load(), mxExecRepeats.GetValueUint64(), stepsInDB, shouldGenerateChangesets, inMemExec)
-		}()
-
 		executor = pe
-	} else {
-		applyWorker.ResetTx(applyTx)
-		doms.SetTx(applyTx)
-
-		se := &serialExecutor{
-			txExecutor: txExecutor{
-				cfg:            cfg,
-				execStage:      execStage,
-				rs:             rs,
-				doms:           doms,
-				agg:            agg,
-				u:              u,
-				isMining:       isMining,
-				inMemExec:      inMemExec,
-				applyTx:        applyTx,
-				applyWorker:    applyWorker,
-				outputTxNum:    &outputTxNum,
-				outputBlockNum: stages.SyncMetrics[stages.Execution],
-				logger:         logger,
-			},
-		}
-
-		defer func() {
-			processed.Log("Done", executor.readState(), nil, nil, se.txCount, logGas, inputBlockNum.Load(), outputBlockNum.GetValueUint64(), outputTxNum.Load(), mxExecRepeats.GetValueUint64(), stepsInDB, shouldGenerateChangesets, inMemExec)
-		}()
-
-		executor = se
 	}
 	posa, isPoSa := cfg.engine.(consensus.PoSA)
 
@@ -444,6 +422,19 @@ func ExecV3(ctx context.Context,
 		defer clean()
 	}
 
+	var prefetcher *exec3.StatePrefetcher
+	var nextBlock *types.Block // read ahead for the prefetcher
+	if cfg.syncCfg.ExecPrefetch && !parallel && !isMining && !inMemExec && !execStage.CurrentSyncCycle.IsInitialCycle {
+		// at chain tip there is no time for cold reads: speculatively execute the block
+		// ahead of the executor, and the next one if already there
+		prefetcher = exec3.NewStatePrefetcher(cfg.db, chainConfig, cfg.engine, blockReader, cfg.stateCache, cfg.syncCfg.ExecPrefetchCPUBudget, logger)
+		prefetcher.SetDomains(executor.domains())
+		defer prefetcher.SetDomains(nil)
+		defer prefetcher.Start(ctx)()
+		applyWorker.SetPrefetcher(prefetcher)
+		defer applyWorker.SetPrefetcher(nil)
+	}
+
 	var b *types.Block
 	var parent *types.Block
 
@@ -471,23 +462,33 @@ Loop:
 		if shouldGenerateChangesets && blockNum > 0 {
 			executor.domains().SetChangesetAccumulator(changeset)
 		}
-		if !parallel {
-			select {
-			case readAhead <- blockNum:
-			default:
-			}
+		select {
+		case readAhead <- blockNum:
+		default:
 		}
 		inputBlockNum.Store(blockNum)
 		executor.domains().SetBlockNum(blockNum)
 
-		b, err = blockWithSenders(ctx, cfg.db, executor.tx(), blockReader, blockNum)
-		if err != nil {
+		if nextBlock != nil && nextBlock.NumberU64() == blockNum {
+			b, nextBlock = nextBlock, nil
+		} else if b, err = blockWithSenders(ctx, cfg.db, executor.tx(), blockReader, blockNum); err != nil {
 			return err
 		}
 		if b == nil {
 			// TODO: panic here and see that overall process deadlock
 			return fmt.Errorf("nil block %d", blockNum)
 		}
+		if prefetcher != nil {
+			prefetcher.Prefetch(b)
+			if blockNum < maxBlockNum {
+				if nextBlock, err = blockWithSenders(ctx, cfg.db, executor.tx(), blockReader, blockNum+1); err != nil {
+					return err
+				}
+				if nextBlock != nil {
+					prefetcher.Prefetch(nextBlock)
+				}
+			}
+		}
 
 		var lastBlockTime uint64
 		if blockNum > 0 {
@@ -517,11 +518,7 @@ Loop:
 		gp := new(core.GasPool).AddGas(header.GasLimit).AddBlobGas(chainConfig.GetMaxBlobGasPerBlock(b.Time()))
 
 		// print type of engine
-		if parallel {
-			if err := executor.status(ctx, commitThreshold); err != nil {
-				return err
-			}
-		} else if accumulator != nil {
+		if accumulator != nil {
 			txs, err := blockReader.RawTransactions(context.Background(), executor.tx(), b.NumberU64(), b.NumberU64())
 			if err != nil {
 				return err
@@ -598,31 +595,25 @@ Loop:
 			inputTxNum++
 		}
 
-		if parallel {
-			if _, err := executor.execute(ctx, txTasks, nil /*gasPool*/); err != nil { // For now don't use block's gas pool for parallel
-				return err
-			}
-			agg.BuildFilesInBackground(outputTxNum.Load())
-		} else {
-			se := executor.(*serialExecutor)
-
-			se.skipPostEvaluation = skipPostEvaluation
+		se.skipPostEvaluation = skipPostEvaluation
 
-			continueLoop, err := se.execute(ctx, txTasks, gp)
+		continueLoop, err := executor.execute(ctx, txTasks, gp)
 
-			if err != nil {
-				return err
-			}
+		if err != nil {
+			return err
+		}
+		if prefetcher != nil {
+			prefetcher.Done(blockNum)
+		}
 
-			count += uint64(len(txTasks))
-			logGas += se.usedGas
+		count += uint64(len(txTasks))
+		logGas += se.usedGas
 
-			se.usedGas = 0
-			se.blobGasUsed = 0
+		se.usedGas = 0
+		se.blobGasUsed = 0
 
-			if !continueLoop {
-				break Loop
-			}
+		if !continueLoop {
+			break Loop
 		}
 
 		mxExecBlocks.Add(1)
@@ -674,78 +665,82 @@ Loop:
 		}
 
 		// MA commitTx
-		if !parallel {
-			select {
-			case <-logEvery.C:
-				if inMemExec || isMining {
-					break
-				}
-
-				stepsInDB := rawdbhelpers.IdxStepsCountV3(executor.tx())
-				progress.Log("", executor.readState(), nil, nil, count, logGas, inputBlockNum.Load(), outputBlockNum.GetValueUint64(), outputTxNum.Load(), mxExecRepeats.GetValueUint64(), stepsInDB, shouldGenerateChangesets, inMemExec)
+		select {
+		case <-logEvery.C:
+			if inMemExec || isMining {
+				break
+			}
 
-				//TODO: https://github.com/erigontech/erigon/issues/10724
-				//if executor.tx().(state2.HasAggTx).AggTx().(*state2.AggregatorRoTx).CanPrune(executor.tx(), outputTxNum.Load()) {
-				//	//small prune cause MDBX_TXN_FULL
-				//	if _, err := executor.tx().(state2.HasAggTx).AggTx().(*state2.AggregatorRoTx).PruneSmallBatches(ctx, 10*time.Hour, executor.tx()); err != nil {
-				//		return err
-				//	}
-				//}
+			stepsInDB := rawdbhelpers.IdxStepsCountV3(executor.tx())
+			progress.Log("", executor.readState(), nil, nil, count, logGas, inputBlockNum.Load(), outputBlockNum.GetValueUint64(), outputTxNum.Load(), mxExecRepeats.GetValueUint64(), stepsInDB, shouldGenerateChangesets, inMemExec)
 
-				aggregatorRo := executor.tx().(state2.HasAggTx).AggTx().(*state2.AggregatorRoTx)
+			//TODO: https://github.com/erigontech/erigon/issues/10724
+			//if executor.tx().(state2.HasAggTx).AggTx().(*state2.AggregatorRoTx).CanPrune(executor.tx(), outputTxNum.Load()) {
+			//	//small prune cause MDBX_TXN_FULL
+			//	if _, err := executor.tx().(state2.HasAggTx).AggTx().(*state2.AggregatorRoTx).PruneSmallBatches(ctx, 10*time.Hour, executor.tx()); err != nil {
+			//		return err
+			//	}
+			//}
 
-				needCalcRoot := executor.readState().SizeEstimate() >= commitThreshold ||
-					skipPostEvaluation || // If we skip post evaluation, then we should compute root hash ASAP for fail-fast
-					aggregatorRo.CanPrune(executor.tx(), outputTxNum.Load()) // if have something to prune - better prune ASAP to keep chaindata smaller
-				if !needCalcRoot {
-					break
-				}
+			aggregatorRo := executor.tx().(state2.HasAggTx).AggTx().(*state2.AggregatorRoTx)
 
-				var (
-					commitStart = time.Now()
-					tt          = time.Now()
+			needCalcRoot := executor.readState().SizeEstimate() >= commitThreshold ||
+				skipPostEvaluation || // If we skip post evaluation, then we should compute root hash ASAP for fail-fast
+				aggregatorRo.CanPrune(executor.tx(), outputTxNum.Load()) // if have something to prune - better prune ASAP to keep chaindata smaller
+			if !needCalcRoot {
+				break
+			}
 
-					t1, t3 time.Duration
-				)
+			var (
+				commitStart = time.Now()
+				tt          = time.Now()
 
-				if ok, err := flushAndCheckCommitmentV3(ctx, b.HeaderNoCopy(), executor.tx(), executor.domains(), cfg, execStage, stageProgress, parallel, logger, u, inMemExec); err != nil {
-					return err
-				} else if !ok {
-					break Loop
-				}
+				t1, t3 time.Duration
+			)
 
-				t1 = time.Since(tt) + ts
+			if ok, err := flushAndCheckCommitmentV3(ctx, b.HeaderNoCopy(), executor.tx(), executor.domains(), cfg, execStage, stageProgress, logger, u, inMemExec); err != nil {
+				return err
+			} else if !ok {
+				break Loop
+			}
 
-				tt = time.Now()
-				pruneTimeout := 250 * time.Millisecond
-				if initialCycle {
-					pruneTimeout = 10 * time.Hour
+			t1 = time.Since(tt) + ts
 
-					if err = aggregatorRo.GreedyPruneHistory(ctx, kv.CommitmentDomain, executor.tx()); err != nil {
-						return err
-					}
-				}
+			tt = time.Now()
+			pruneTimeout := 250 * time.Millisecond
+			if initialCycle {
+				pruneTimeout = 10 * time.Hour
 
-				if _, err := aggregatorRo.PruneSmallBatches(ctx, pruneTimeout, executor.tx()); err != nil {
+				if err = aggregatorRo.GreedyPruneHistory(ctx, kv.CommitmentDomain, executor.tx()); err != nil {
 					return err
 				}
-				t3 = time.Since(tt)
+			}
 
-				t2, err := executor.(*serialExecutor).commit(ctx, inputTxNum, outputBlockNum.GetValueUint64(), useExternalTx)
-				if err != nil {
-					return err
-				}
+			if _, err := aggregatorRo.PruneSmallBatches(ctx, pruneTimeout, executor.tx()); err != nil {
+				return err
+			}
+			t3 = time.Since(tt)
 
-				// on chain-tip: if batch is full then stop execution - to allow stages commit
-				if !initialCycle {
-					break Loop
-				}
-				logger.Info("Committed", "time", time.Since(commitStart),
-					"block", executor.domains().BlockNum(), "txNum", executor.domains().TxNum(),
-					"step", fmt.Sprintf("%.1f", float64(executor.domains().TxNum())/float64(agg.StepSize())),
-					"flush+commitment", t1, "tx.commit", t2, "prune", t3)
-			default:
+			if prefetcher != nil {
+				prefetcher.SetDomains(nil) // the domains are closed by the commit
+			}
+			t2, err := executor.commit(ctx, inputTxNum, outputBlockNum.GetValueUint64(), useExternalTx)
+			if err != nil {
+				return err
 			}
+			if prefetcher != nil {
+				prefetcher.SetDomains(executor.domains())
+			}
+
+			// on chain-tip: if batch is full then stop execution - to allow stages commit
+			if !initialCycle {
+				break Loop
+			}
+			logger.Info("Committed", "time", time.Since(commitStart),
+				"block", executor.domains().BlockNum(), "txNum", executor.domains().TxNum(),
+				"step", fmt.Sprintf("%.1f", float64(executor.domains().TxNum())/float64(agg.StepSize())),
+				"flush+commitment", t1, "tx.commit", t2, "prune", t3)
+		default:
 		}
 
 		select {
@@ -762,7 +757,7 @@ Loop:
 
 	if u != nil && !u.HasUnwindPoint() {
 		if b != nil {
-			_, err := flushAndCheckCommitmentV3(ctx, b.HeaderNoCopy(), executor.tx(), executor.domains(), cfg, execStage, stageProgress, parallel, logger, u, inMemExec)
+			_, err := flushAndCheckCommitmentV3(ctx, b.HeaderNoCopy(), executor.tx(), executor.domains(), cfg, execStage, stageProgress, logger, u, inMemExec)
 			if err != nil {
 				return err
 			}
@@ -876,17 +871,15 @@ func handleIncorrectRootHashError(header *types.Header, applyTx kv.RwTx, cfg Exe
 }
 
 // flushAndCheckCommitmentV3 - does write state to db and then check commitment
-func flushAndCheckCommitmentV3(ctx context.Context, header *types.Header, applyTx kv.RwTx, doms *state2.SharedDomains, cfg ExecuteBlockCfg, e *StageState, maxBlockNum uint64, parallel bool, logger log.Logger, u Unwinder, inMemExec bool) (bool, error) {
+func flushAndCheckCommitmentV3(ctx context.Context, header *types.Header, applyTx kv.RwTx, doms *state2.SharedDomains, cfg ExecuteBlockCfg, e *StageState, maxBlockNum uint64, logger log.Logger, u Unwinder, inMemExec bool) (bool, error) {
 
 	// E2 state root check was in another stage - means we did flush state even if state root will not match
 	// And Unwind expecting it
-	if !parallel {
-		if err := e.Update(applyTx, maxBlockNum); err != nil {
-			return false, err
-		}
-		if _, err := rawdb.IncrementStateVersion(applyTx); err != nil {
-			return false, fmt.Errorf("writing plain state version: %w", err)
-		}
+	if err := e.Update(applyTx, maxBlockNum); err != nil {
+		return false, err
+	}
+	if _, err := rawdb.IncrementStateVersion(applyTx); err != nil {
+		return false, fmt.Errorf("writing plain state version: %w", err)
 	}
 
 	if header == nil {
@@ -3,28 +3,21 @@ package stagedsync
 import (
 	"context"
 	"errors"
-	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/erigontech/erigon/execution/exec3"
-	chaos_monkey "github.com/erigontech/erigon/tests/chaos-monkey"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/metrics"
 	state2 "github.com/erigontech/erigon-lib/state"
-	"github.com/erigontech/erigon/consensus"
 	"github.com/erigontech/erigon/core"
-	"github.com/erigontech/erigon/core/rawdb"
-	"github.com/erigontech/erigon/core/rawdb/rawdbhelpers"
 	"github.com/erigontech/erigon/core/state"
 	"github.com/erigontech/erigon/core/types"
-	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/turbo/shards"
-	"golang.org/x/sync/errgroup"
 )
 
 /*
@@ -43,34 +36,31 @@ Until conflict-resolution succeed - none of execution updates must pass to lower
 Object TxTask it's just set of small buffers (readset + writeset) for each transaction.
 Write to TxTask happens by code like `txTask.ReadLists = rw.stateReader.ReadSet()`.
 
-- TxTask - objects coming from parallel-workers to conflict-resolution goroutine (ApplyLoop and method ReadsValid).
-Flush of data to lower-level-of-abstraction is done by method `agg.ApplyState` (method agg.ApplyHistory exists
-only for performance - to reduce time of RwLock on state, but by meaning `ApplyState+ApplyHistory` it's 1 method to
-flush changes from TxTask to lower-level-of-abstraction).
+- TxTask - objects coming from parallel-workers to conflict-resolution (parallelExecutor.execute and method ReadsValid).
+Results are applied in txNum order. If read set of txn is not valid anymore (some of previous txs changed the
+values it did read) - txn is re-executed by applyWorker, on top of valid state. Gas of block is also checked
+here: workers execute each txn with own GasPool.
+Flush of data to lower-level-of-abstraction is done by method `rs.ApplyState4` - same as in serial execution.
 
 - StateV3 - it's all updates which are stored in RAM - all parallel workers can see this updates.
-Execution of txs always done on Valid version of state (no partial-updates of state).
-Flush of updates to lower-level-of-abstractions done by method `StateV3.Flush`.
-On this level-of-abstraction also exists ReaderV3.
-IntraBlockState does call ReaderV3, and ReaderV3 call StateV3(in-mem-cache) or DB (RoTx).
-WAL - also on this level-of-abstraction - agg.ApplyHistory does write updates from TxTask to WAL.
-WAL it's like StateV3 just without reading api (can only write there). WAL flush to disk periodically (doesn't need much RAM).
-
-- RoTx - see everything what committed to DB. Commit is done by rwLoop goroutine.
-rwloop does:
-  - stop all Workers
-  - call StateV3.Flush()
+Flush of updates to lower-level-of-abstractions done by method `SharedDomains.Flush`.
+On this level-of-abstraction also exists ReaderParallelV3.
+IntraBlockState does call ReaderParallelV3, and ReaderParallelV3 call SharedDomains(in-mem) or DB (RoTx of worker).
+
+- RoTx - see everything what committed to DB. Commit is done by main goroutine - between blocks, when
+workers have nothing to do:
+  - flush SharedDomains
   - commit
-  - open new RoTx
-  - set new RoTx to all Workers
-  - start Worker start workers
+  - set new SharedDomains to all Workers
+  - Workers open new RoTx on next txn
 
-When rwLoop has nothing to do - it does Prune, or flush of WAL to RwTx (agg.rotate+agg.Flush)
+Parallel execution is block-synchronous: block-initialisation, system txs of Parlia, block-finalisation and
+history-execution do run serially on applyWorker, only regular txs of block are sent to workers.
 */
 
 type executor interface {
 	execute(ctx context.Context, tasks []*state.TxTask, gp *core.GasPool) (bool, error)
-	status(ctx context.Context, commitThreshold uint64) error
+	commit(ctx context.Context, txNum uint64, blockNum uint64, useExternalTx bool) (time.Duration, error)
 	wait() error
 	getHeader(ctx context.Context, hash common.Hash, number uint64) (h *types.Header)
 
@@ -133,414 +123,168 @@ func (te *txExecutor) getHeader(ctx context.Context, hash common.Hash, number ui
 }
 
 type parallelExecutor struct {
-	txExecutor
-	rwLoopErrCh              chan error
-	rwLoopG                  *errgroup.Group
-	applyLoopWg              sync.WaitGroup
-	execWorkers              []*exec3.Worker
-	stopWorkers              func()
-	waitWorkers              func()
-	lastBlockNum             atomic.Uint64
-	in                       *state.QueueWithRetry
-	rws                      *state.ResultsQueue
-	rwsConsumed              chan struct{}
-	shouldGenerateChangesets bool
-	workerCount              int
-	pruneEvery               *time.Ticker
-	logEvery                 *time.Ticker
-	slowDownLimit            *time.Ticker
-	progress                 *Progress
+	*serialExecutor
+	execWorkers []*exec3.Worker
+	stopWorkers func()
+	workersCtx  context.Context
+	workersErr  chan error
+	in          *state.QueueWithRetry
+	rws         *state.ResultsQueue
+	workerCount int
 }
 
-func (pe *parallelExecutor) applyLoop(ctx context.Context, maxTxNum uint64, blockComplete *atomic.Bool, errCh chan error) {
-	defer pe.applyLoopWg.Done()
-	defer func() {
-		if rec := recover(); rec != nil {
-			pe.logger.Warn("[dbg] apply loop panic", "rec", rec)
-		}
-		pe.logger.Warn("[dbg] apply loop exit")
+func (pe *parallelExecutor) run(ctx context.Context) context.CancelFunc {
+	pe.in = state.NewQueueWithRetry(100_000)
+	pe.workersErr = make(chan error, 1)
+	pe.doms.SetParallelReaders(true)
+
+	var waitWorkers func() error
+	var cancel context.CancelFunc
+	pe.workersCtx, cancel = context.WithCancel(ctx)
+	pe.execWorkers, _, pe.rws, pe.stopWorkers, waitWorkers = exec3.NewWorkersPool(
+		pe.RWMutex.RLocker(), pe.accumulator, pe.logger, pe.workersCtx, true, pe.cfg.db, pe.rs, pe.in,
+		pe.cfg.blockReader, pe.cfg.chainConfig, pe.cfg.genesis, pe.cfg.engine, pe.workerCount, pe.cfg.dirs, pe.isMining)
+	go func() {
+		// workers are stopped by first error: unblock waiting for their results
+		pe.workersErr <- waitWorkers()
+		cancel()
 	}()
-	//fmt.Println("applyLoop started")
-	//defer fmt.Println("applyLoop done")
 
-	applyLoopInner := func(ctx context.Context) error {
-		tx, err := pe.cfg.db.BeginRo(ctx)
-		if err != nil {
-			return err
-		}
-		defer tx.Rollback()
-
-		pe.applyWorker.ResetTx(tx)
+	return func() {
+		cancel()
+		pe.stopWorkers()
+		pe.in.Close()
+		pe.rws.Close()
+		pe.doms.SetParallelReaders(false)
+	}
+}
 
-		for pe.outputTxNum.Load() <= maxTxNum {
-			if err := pe.rws.Drain(ctx); err != nil {
-				return err
-			}
+// getHeader - GetHashFn is called by workers too, they can't use RwTx of executor
+func (pe *parallelExecutor) getHeader(ctx context.Context, hash common.Hash, number uint64) (h *types.Header) {
+	if err := pe.cfg.db.View(ctx, func(tx kv.Tx) (err error) {
+		h, err = pe.cfg.blockReader.Header(ctx, tx, hash, number)
+		return err
+	}); err != nil {
+		panic(err)
+	}
+	return h
+}
 
-			processedTxNum, conflicts, triggers, _ /*processedBlockNum*/, stoppedAtBlockEnd, err :=
-				pe.processResultQueue(ctx, pe.outputTxNum.Load(), pe.rwsConsumed, true, false)
-			if err != nil {
-				return err
-			}
-			//fmt.Println("QR", processedTxNum, conflicts, triggers, processedBlockNum, stoppedAtBlockEnd, err)
-			mxExecRepeats.AddInt(conflicts)
-			mxExecTriggers.AddInt(triggers)
-
-			if processedTxNum > 0 {
-				pe.outputTxNum.Store(processedTxNum)
-				blockComplete.Store(stoppedAtBlockEnd)
-				// TODO update logGas here
-			}
+// speculative - txs which can be executed by workers. Others do read or write state which is not tracked by
+// read/write sets (system contracts of consensus, history) and are executed serially.
+func (pe *parallelExecutor) speculative(txTask *state.TxTask) bool {
+	return txTask.TxIndex >= 0 && !txTask.Final && txTask.SystemTxIndex == 0 && !txTask.HistoryExecution
+}
 
+func (pe *parallelExecutor) execute(ctx context.Context, tasks []*state.TxTask, gp *core.GasPool) (bool, error) {
+	for i := 0; i < len(tasks); {
+		j := i
+		for j < len(tasks) && pe.speculative(tasks[j]) {
+			j++
 		}
-		return nil
-	}
-
-	if err := applyLoopInner(ctx); err != nil {
-		if !errors.Is(err, context.Canceled) {
-			errCh <- err
+		if j-i < 2 { // nothing to parallelize
+			j = i + 1
+			if cont, err := pe.serialExecutor.execute(ctx, tasks[i:j], gp); !cont || err != nil {
+				return cont, err
+			}
+		} else if cont, err := pe.executeSpeculative(ctx, tasks[i:j], gp); !cont || err != nil {
+			return cont, err
 		}
+		i = j
 	}
+	return true, nil
 }
 
-////TODO: owner of `resultCh` is main goroutine, but owner of `retryQueue` is applyLoop.
-// Now rwLoop closing both (because applyLoop we completely restart)
-// Maybe need split channels? Maybe don't exit from ApplyLoop? Maybe current way is also ok?
-
-func (pe *parallelExecutor) rwLoop(ctx context.Context, maxTxNum uint64, logger log.Logger) error {
-	//fmt.Println("rwLoop started", maxTxNum)
-	//defer fmt.Println("rwLoop done")
-
-	tx := pe.applyTx
-	if tx == nil {
-		tx, err := pe.cfg.db.BeginRw(ctx)
-		if err != nil {
-			return err
+// executeSpeculative - sends txs to workers and applies their results in txNum order
+func (pe *parallelExecutor) executeSpeculative(ctx context.Context, tasks []*state.TxTask, gp *core.GasPool) (bool, error) {
+	for _, txTask := range tasks {
+		if txTask.Sender() != nil {
+			if ok := pe.rs.RegisterSender(txTask); ok {
+				pe.rs.AddWork(ctx, txTask, pe.in)
+			}
+		} else {
+			pe.rs.AddWork(ctx, txTask, pe.in)
 		}
-		defer tx.Rollback()
 	}
 
-	pe.doms.SetTx(tx)
-
-	defer pe.applyLoopWg.Wait()
-	applyCtx, cancelApplyCtx := context.WithCancel(ctx)
-	defer cancelApplyCtx()
-	pe.applyLoopWg.Add(1)
-
-	blockComplete := atomic.Bool{}
-	blockComplete.Store(true)
-
-	go pe.applyLoop(applyCtx, maxTxNum, &blockComplete, pe.rwLoopErrCh)
-
-	for pe.outputTxNum.Load() <= maxTxNum {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-
-		case <-pe.logEvery.C:
-			stepsInDB := rawdbhelpers.IdxStepsCountV3(tx)
-			pe.progress.Log("", pe.rs, pe.in, pe.rws, pe.rs.DoneCount(), 0 /* TODO logGas*/, pe.lastBlockNum.Load(), pe.outputBlockNum.GetValueUint64(), pe.outputTxNum.Load(), mxExecRepeats.GetValueUint64(), stepsInDB, pe.shouldGenerateChangesets || pe.cfg.syncCfg.KeepExecutionProofs, pe.inMemExec)
-			if pe.agg.HasBackgroundFilesBuild() {
-				logger.Info(fmt.Sprintf("[%s] Background files build", pe.execStage.LogPrefix()), "progress", pe.agg.BackgroundProgress())
-			}
-		case <-pe.pruneEvery.C:
-			if pe.rs.SizeEstimate() < pe.cfg.batchSize.Bytes() {
-				if pe.doms.BlockNum() != pe.outputBlockNum.GetValueUint64() {
-					panic(fmt.Errorf("%d != %d", pe.doms.BlockNum(), pe.outputBlockNum.GetValueUint64()))
-				}
-				_, err := pe.doms.ComputeCommitment(ctx, true, pe.outputBlockNum.GetValueUint64(), pe.execStage.LogPrefix())
-				if err != nil {
-					return err
-				}
-				ac := pe.agg.BeginFilesRo()
-				if _, err = ac.PruneSmallBatches(ctx, 10*time.Second, tx); err != nil { // prune part of retired data, before commit
-					return err
-				}
-				ac.Close()
-				if !pe.inMemExec {
-					if err = pe.doms.Flush(ctx, tx); err != nil {
-						return err
-					}
+	var applied int
+	for applied < len(tasks) {
+		if err := pe.rws.Drain(pe.workersCtx); err != nil {
+			select {
+			case werr := <-pe.workersErr:
+				if werr != nil && !errors.Is(werr, context.Canceled) {
+					return false, werr
 				}
-				break
-			}
-			if pe.inMemExec {
-				break
+			default:
 			}
+			return false, err
+		}
 
-			cancelApplyCtx()
-			pe.applyLoopWg.Wait()
-
-			var t0, t1, t2, t3, t4 time.Duration
-			commitStart := time.Now()
-			logger.Info("Committing (parallel)...", "blockComplete.Load()", blockComplete.Load())
-			if err := func() error {
-				//Drain results (and process) channel because read sets do not carry over
-				for !blockComplete.Load() {
-					pe.rws.DrainNonBlocking(ctx)
-					pe.applyWorker.ResetTx(tx)
-
-					processedTxNum, conflicts, triggers, processedBlockNum, stoppedAtBlockEnd, err :=
-						pe.processResultQueue(ctx, pe.outputTxNum.Load(), nil, false, true)
-					if err != nil {
-						return err
-					}
-
-					mxExecRepeats.AddInt(conflicts)
-					mxExecTriggers.AddInt(triggers)
-					if processedBlockNum > 0 {
-						pe.outputBlockNum.SetUint64(processedBlockNum)
-					}
-					if processedTxNum > 0 {
-						pe.outputTxNum.Store(processedTxNum)
-						blockComplete.Store(stoppedAtBlockEnd)
-					}
-				}
-				t0 = time.Since(commitStart)
-				pe.Lock() // This is to prevent workers from starting work on any new txTask
-				defer pe.Unlock()
-
-				select {
-				case pe.rwsConsumed <- struct{}{}:
-				default:
-				}
-
-				// Drain results channel because read sets do not carry over
-				pe.rws.DropResults(ctx, func(txTask *state.TxTask) {
-					pe.rs.ReTry(txTask, pe.in)
-				})
-
-				//lastTxNumInDb, _ := txNumsReader.Max(tx, outputBlockNum.Get())
-				//if lastTxNumInDb != outputTxNum.Load()-1 {
-				//	panic(fmt.Sprintf("assert: %d != %d", lastTxNumInDb, outputTxNum.Load()))
-				//}
-
-				t1 = time.Since(commitStart)
-				tt := time.Now()
-				t2 = time.Since(tt)
-				tt = time.Now()
-
-				if err := pe.doms.Flush(ctx, tx); err != nil {
-					return err
-				}
-				pe.doms.ClearRam(true)
-				t3 = time.Since(tt)
+		var conflicts, triggers int
+		cont, err := func() (bool, error) {
+			rwsIt := pe.rws.Iter()
+			defer rwsIt.Close()
+			for applied < len(tasks) && rwsIt.HasNext(tasks[applied].TxNum) {
+				txTask := rwsIt.PopNext()
+				applied++
 
-				if err := pe.execStage.Update(tx, pe.outputBlockNum.GetValueUint64()); err != nil {
-					return err
+				if gp != nil {
+					pe.applyWorker.SetGaspool(gp)
 				}
-				if _, err := rawdb.IncrementStateVersion(tx); err != nil {
-					return fmt.Errorf("writing plain state version: %w", err)
+				if txTask.Error != nil || !pe.rs.ReadsValid(txTask.ReadLists) {
+					conflicts++
+					// resolve conflict right here: all previous txs are applied - so it's conflict-free
+					pe.applyWorker.RunTxTaskNoLock(txTask.Reset(), pe.isMining, pe.skipPostEvaluation)
+				} else if err := pe.subGas(txTask, gp); err != nil {
+					// not enough gas left in block: re-execute to get same error as serial execution
+					pe.applyWorker.RunTxTaskNoLock(txTask.Reset(), pe.isMining, pe.skipPostEvaluation)
+				} else {
+					pe.rs.SetTxNum(txTask.TxNum, txTask.BlockNum)
+					txTask.CreateReceipt(pe.applyWorker.Tx())
 				}
 
-				tx.CollectMetrics()
-				tt = time.Now()
-				if err := tx.Commit(); err != nil {
-					return err
+				if cont, err := pe.applyResult(ctx, txTask); !cont || err != nil {
+					return cont, err
 				}
-				t4 = time.Since(tt)
-				for i := 0; i < len(pe.execWorkers); i++ {
-					pe.execWorkers[i].ResetTx(nil)
-				}
-
-				return nil
-			}(); err != nil {
-				return err
+				triggers += pe.rs.CommitTxNum(txTask.Sender(), txTask.TxNum, pe.in)
 			}
-			var err error
-			if tx, err = pe.cfg.db.BeginRw(ctx); err != nil {
-				return err
-			}
-			defer tx.Rollback()
-			pe.doms.SetTx(tx)
-
-			applyCtx, cancelApplyCtx = context.WithCancel(ctx) //nolint:fatcontext
-			defer cancelApplyCtx()
-			pe.applyLoopWg.Add(1)
-			go pe.applyLoop(applyCtx, maxTxNum, &blockComplete, pe.rwLoopErrCh)
-
-			logger.Info("Committed", "time", time.Since(commitStart), "drain", t0, "drain_and_lock", t1, "rs.flush", t2, "agg.flush", t3, "tx.commit", t4)
+			return true, nil
+		}()
+		mxExecRepeats.AddInt(conflicts)
+		mxExecTriggers.AddInt(triggers)
+		if !cont || err != nil {
+			return cont, err
 		}
 	}
-	if err := pe.doms.Flush(ctx, tx); err != nil {
-		return err
-	}
-	if err := pe.execStage.Update(tx, pe.outputBlockNum.GetValueUint64()); err != nil {
-		return err
-	}
-	if err := tx.Commit(); err != nil {
-		return err
-	}
-	return nil
+	return true, nil
 }
 
-func (pe *parallelExecutor) processResultQueue(ctx context.Context, inputTxNum uint64, backPressure chan<- struct{}, canRetry, forceStopAtBlockEnd bool) (outputTxNum uint64, conflicts, triggers int, processedBlockNum uint64, stopedAtBlockEnd bool, err error) {
-	rwsIt := pe.rws.Iter()
-	defer rwsIt.Close()
-	//defer fmt.Println("PRQ", "Done")
-
-	var i int
-	outputTxNum = inputTxNum
-	for rwsIt.HasNext(outputTxNum) {
-		txTask := rwsIt.PopNext()
-		//fmt.Println("PRQ", txTask.BlockNum, txTask.TxIndex, txTask.TxNum)
-		if txTask.Error != nil || !pe.rs.ReadsValid(txTask.ReadLists) {
-			conflicts++
-			//fmt.Println(txTask.TxNum, txTask.Error)
-			if errors.Is(txTask.Error, vm.ErrIntraBlockStateFailed) ||
-				errors.Is(txTask.Error, core.ErrStateTransitionFailed) {
-				return outputTxNum, conflicts, triggers, processedBlockNum, false, fmt.Errorf("%w: %v", consensus.ErrInvalidBlock, txTask.Error)
-			}
-			if i > 0 && canRetry {
-				//send to re-exex
-				pe.rs.ReTry(txTask, pe.in)
-				continue
-			}
-
-			// resolve first conflict right here: it's faster and conflict-free
-			pe.applyWorker.RunTxTaskNoLock(txTask.Reset(), pe.isMining, false)
-			if txTask.Error != nil {
-				//fmt.Println("RETRY", txTask.TxNum, txTask.Error)
-				return outputTxNum, conflicts, triggers, processedBlockNum, false, fmt.Errorf("%w: %v", consensus.ErrInvalidBlock, txTask.Error)
-			}
-			if pe.cfg.syncCfg.ChaosMonkey {
-				chaosErr := chaos_monkey.ThrowRandomConsensusError(pe.execStage.CurrentSyncCycle.IsInitialCycle, txTask.TxIndex, pe.cfg.badBlockHalt, txTask.Error)
-				if chaosErr != nil {
-					log.Warn("Monkey in a consensus")
-					return outputTxNum, conflicts, triggers, processedBlockNum, false, chaosErr
-				}
-			}
-			// TODO: post-validation of gasUsed and blobGasUsed
-			i++
-		}
-
-		if txTask.Final {
-			pe.rs.SetTxNum(txTask.TxNum, txTask.BlockNum)
-			err := pe.rs.ApplyState4(ctx, txTask)
-			if err != nil {
-				return outputTxNum, conflicts, triggers, processedBlockNum, false, fmt.Errorf("StateV3.Apply: %w", err)
-			}
-
-			if processedBlockNum > pe.lastBlockNum.Load() {
-				pe.outputBlockNum.SetUint64(processedBlockNum)
-				pe.lastBlockNum.Store(processedBlockNum)
-			}
-			//if !bytes.Equal(rh, txTask.BlockRoot[:]) {
-			//	log.Error("block hash mismatch", "rh", hex.EncodeToString(rh), "blockRoot", hex.EncodeToString(txTask.BlockRoot[:]), "bn", txTask.BlockNum, "txn", txTask.TxNum)
-			//	return outputTxNum, conflicts, triggers, processedBlockNum, false, fmt.Errorf("block hashk mismatch: %x != %x bn =%d, txn= %d", rh, txTask.BlockRoot[:], txTask.BlockNum, txTask.TxNum)
-			//}
-		}
-		triggers += pe.rs.CommitTxNum(txTask.Sender(), txTask.TxNum, pe.in)
-		outputTxNum++
-		if backPressure != nil {
-			select {
-			case backPressure <- struct{}{}:
-			default:
-			}
-		}
-		if err := pe.rs.ApplyLogsAndTraces4(txTask, pe.rs.Domains()); err != nil {
-			return outputTxNum, conflicts, triggers, processedBlockNum, false, fmt.Errorf("StateV3.Apply: %w", err)
-		}
-		processedBlockNum = txTask.BlockNum
-		if !stopedAtBlockEnd {
-			stopedAtBlockEnd = txTask.Final
-		}
-		if forceStopAtBlockEnd && txTask.Final {
-			break
-		}
+// subGas - does same gas accounting as ApplyMessage does with block's GasPool
+func (pe *parallelExecutor) subGas(txTask *state.TxTask, gp *core.GasPool) error {
+	if gp == nil {
+		return nil
 	}
-	return
-}
-
-func (pe *parallelExecutor) run(ctx context.Context, maxTxNum uint64, logger log.Logger) context.CancelFunc {
-	pe.slowDownLimit = time.NewTicker(time.Second)
-	pe.rwsConsumed = make(chan struct{}, 1)
-	pe.rwLoopErrCh = make(chan error)
-	pe.in = state.NewQueueWithRetry(100_000)
-
-	pe.execWorkers, _, pe.rws, pe.stopWorkers, pe.waitWorkers = exec3.NewWorkersPool(
-		pe.RWMutex.RLocker(), pe.accumulator, logger, ctx, true, pe.cfg.db, pe.rs, pe.in,
-		pe.cfg.blockReader, pe.cfg.chainConfig, pe.cfg.genesis, pe.cfg.engine, pe.workerCount+1, pe.cfg.dirs, pe.isMining)
-
-	rwLoopCtx, rwLoopCtxCancel := context.WithCancel(ctx)
-	pe.rwLoopG, rwLoopCtx = errgroup.WithContext(rwLoopCtx)
-	pe.rwLoopG.Go(func() error {
-		defer pe.rws.Close()
-		defer pe.in.Close()
-		defer pe.applyLoopWg.Wait()
-		defer func() {
-			logger.Warn("[dbg] rwloop exit")
-		}()
-		return pe.rwLoop(rwLoopCtx, maxTxNum, logger)
-	})
-
-	return func() {
-		rwLoopCtxCancel()
-		pe.slowDownLimit.Stop()
-		pe.wait()
-		pe.stopWorkers()
-		close(pe.rwsConsumed)
-		pe.in.Close()
+	if txTask.TxAsMessage.Gas() > gp.Gas() {
+		return core.ErrGasLimitReached
 	}
-}
-
-func (pe *parallelExecutor) status(ctx context.Context, commitThreshold uint64) error {
-	select {
-	case err := <-pe.rwLoopErrCh:
-		if err != nil {
-			return err
-		}
-	case <-ctx.Done():
-		return ctx.Err()
-	default:
+	if txTask.TxAsMessage.BlobGas() > gp.BlobGas() {
+		return core.ErrBlobGasLimitReached
 	}
-
-	for pe.rws.Len() > pe.rws.Limit() || pe.rs.SizeEstimate() >= commitThreshold {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case _, ok := <-pe.rwsConsumed:
-			if !ok {
-				return nil
-			}
-		case <-pe.slowDownLimit.C:
-			//logger.Warn("skip", "rws.Len()", rws.Len(), "rws.Limit()", rws.Limit(), "rws.ResultChLen()", rws.ResultChLen())
-			//if tt := rws.Dbg(); tt != nil {
-			//	log.Warn("fst", "n", tt.TxNum, "in.len()", in.Len(), "out", outputTxNum.Load(), "in.NewTasksLen", in.NewTasksLen())
-			//}
-			return nil
-		}
+	if err := gp.SubBlobGas(txTask.TxAsMessage.BlobGas()); err != nil {
+		return err
 	}
-
-	return nil
+	return gp.SubGas(txTask.UsedGas)
 }
 
-func (pe *parallelExecutor) wait() error {
-	pe.applyLoopWg.Wait()
-
-	if pe.rwLoopG != nil {
-		err := pe.rwLoopG.Wait()
-
-		if err != nil {
-			return err
-		}
-
-		pe.waitWorkers()
+func (pe *parallelExecutor) commit(ctx context.Context, txNum uint64, blockNum uint64, useExternalTx bool) (t2 time.Duration, err error) {
+	if t2, err = pe.serialExecutor.commit(ctx, txNum, blockNum, useExternalTx); err != nil {
+		return t2, err
 	}
-
-	return nil
-}
-
-func (pe *parallelExecutor) execute(ctx context.Context, tasks []*state.TxTask, gp *core.GasPool) (bool, error) {
-	for _, txTask := range tasks {
-		if txTask.Sender() != nil {
-			if ok := pe.rs.RegisterSender(txTask); ok {
-				pe.rs.AddWork(ctx, txTask, pe.in)
-			}
-		} else {
-			pe.rs.AddWork(ctx, txTask, pe.in)
-		}
+	// workers have nothing to do between blocks: re-open RoTx to see committed state, and read new SharedDomains
+	pe.doms.SetParallelReaders(true)
+	for _, w := range pe.execWorkers {
+		w.ResetTx(nil)
+		w.ResetState(pe.rs, pe.accumulator)
 	}
-
-	return false, nil
+	return t2, nil
 }
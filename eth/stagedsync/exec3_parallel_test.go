@@ -0,0 +1,312 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/wrap"
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/consensus/ethash"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/rawdb/rawtemporaldb"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/eth/stagedsync"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+// TestExecV3Parallel executes same chain serially and by parallel workers: txs of many senders
+// do conflict on storage of one contract, and some senders have many txs in one block.
+func TestExecV3Parallel(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	keys := make([]*ecdsa.PrivateKey, 8)
+	alloc := types.GenesisAlloc{}
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		alloc[crypto.PubkeyToAddress(keys[i].PublicKey)] = types.GenesisAccount{Balance: big.NewInt(params.Ether)}
+	}
+	gspec := &types.Genesis{Config: params.TestChainConfig, GasLimit: 30_000_000, Alloc: alloc}
+	signer := types.LatestSigner(gspec.Config)
+
+	// counter: increments slot 0 on each call
+	counterCode := hexutil.MustDecode("0x6960005460010160005500600052600a6016f3")
+	var counter libcommon.Address
+
+	m := mock.MockWithGenesis(t, gspec, keys[0], false)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 8, func(i int, gen *core.BlockGen) {
+		if i == 0 {
+			from := crypto.PubkeyToAddress(keys[0].PublicKey)
+			counter = crypto.CreateAddress(from, gen.TxNonce(from))
+			tx, err := types.SignTx(types.NewContractCreation(gen.TxNonce(from), uint256.NewInt(0), 100_000, uint256.NewInt(params.GWei), counterCode), *signer, keys[0])
+			require.NoError(err)
+			gen.AddTx(tx)
+			return
+		}
+		for j, key := range keys {
+			from := crypto.PubkeyToAddress(key.PublicKey)
+			to := crypto.PubkeyToAddress(keys[(j+i)%len(keys)].PublicKey)
+			tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(from), to, uint256.NewInt(uint64(1000*(i+j))), params.TxGas, uint256.NewInt(params.GWei), nil), *signer, key)
+			require.NoError(err)
+			gen.AddTx(tx)
+			if j%2 == i%2 {
+				tx, err = types.SignTx(types.NewTransaction(gen.TxNonce(from), counter, uint256.NewInt(0), 100_000, uint256.NewInt(params.GWei), nil), *signer, key)
+				require.NoError(err)
+				gen.AddTx(tx)
+			}
+		}
+	})
+	require.NoError(err)
+	require.NoError(m.InsertChain(chain))
+
+	mp := mock.MockWithGenesis(t, gspec, keys[0], false)
+	checkExecV3Parallel(t, m, mp, chain)
+}
+
+// posaEngine is a PoSA engine like Parlia: the block producer appends a system transaction to
+// the end of the block, which the engine executes in Finalize without charging for gas.
+type posaEngine struct {
+	*ethash.FakeEthash
+	system libcommon.Address
+}
+
+func (e *posaEngine) IsSystemTransaction(tx types.Transaction, header *types.Header) (bool, error) {
+	if (tx.GetTo() == nil) || !e.IsSystemContract(tx.GetTo()) || !tx.GetPrice().IsZero() {
+		return false, nil
+	}
+	sender, err := tx.Sender(*types.LatestSignerForChainID(tx.GetChainID().ToBig()))
+	if err != nil {
+		return false, err
+	}
+	return sender == header.Coinbase, nil
+}
+
+func (e *posaEngine) IsSystemContract(to *libcommon.Address) bool              { return *to == e.system }
+func (e *posaEngine) EnoughDistance(consensus.ChainReader, *types.Header) bool { return true }
+func (e *posaEngine) IsLocalBlock(*types.Header) bool                          { return false }
+func (e *posaEngine) AllowLightProcess(consensus.ChainReader, *types.Header) bool {
+	return false
+}
+func (e *posaEngine) GetJustifiedNumberAndHash(consensus.ChainHeaderReader, *types.Header) (uint64, libcommon.Hash, error) {
+	return 0, libcommon.Hash{}, nil
+}
+func (e *posaEngine) GetFinalizedHeader(consensus.ChainHeaderReader, *types.Header) *types.Header {
+	return nil
+}
+func (e *posaEngine) ResetSnapshot(consensus.ChainHeaderReader, []*types.Header) error { return nil }
+func (e *posaEngine) GetLatestSnapshotHeight() (uint64, error)                         { return 0, nil }
+func (e *posaEngine) BlockInterval(consensus.ChainHeaderReader, *types.Header) (uint64, error) {
+	return 3000, nil
+}
+
+// Finalize executes the system transaction of the task, there are no block rewards.
+func (e *posaEngine) Finalize(_ *chain.Config, _ *types.Header, ibs *state.IntraBlockState,
+	txs types.Transactions, _ []*types.Header, receipts types.Receipts, _ []*types.Withdrawal,
+	_ consensus.ChainReader, _ consensus.SystemCall, _ bool, systemTxCall consensus.SystemTxCall, _ int,
+	_ log.Logger) (types.Transactions, types.Receipts, types.FlatRequests, error) {
+	if systemTxCall != nil {
+		if _, _, err := systemTxCall(ibs); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return txs, receipts, nil, nil
+}
+
+// FinalizeAndAssemble executes the system transactions the way the execution workers do,
+// and fills in their receipts.
+func (e *posaEngine) FinalizeAndAssemble(config *chain.Config, header *types.Header, ibs *state.IntraBlockState,
+	txs types.Transactions, uncles []*types.Header, receipts types.Receipts, withdrawals []*types.Withdrawal,
+	_ consensus.ChainReader, _ consensus.SystemCall, _ consensus.Call, _ log.Logger,
+) (*types.Block, types.Transactions, types.Receipts, types.FlatRequests, error) {
+	rules := config.Rules(header.Number.Uint64(), header.Time)
+	blockContext := core.NewEVMBlockContext(header, func(uint64) libcommon.Hash { return libcommon.Hash{} }, e, nil, config)
+	for i, tx := range txs {
+		if isSystemTx, err := e.IsSystemTransaction(tx, header); err != nil {
+			return nil, nil, nil, nil, err
+		} else if !isSystemTx {
+			continue
+		}
+		ibs.SetTxContext(i, header.Number.Uint64())
+		// fresh access list, like a worker's state
+		if err := ibs.Prepare(rules, header.Coinbase, header.Coinbase, tx.GetTo(), vm.ActivePrecompiles(rules), nil, nil); err != nil {
+			return nil, nil, nil, nil, err
+		}
+		evm := vm.NewEVM(blockContext, evmtypes.TxContext{Origin: header.Coinbase, GasPrice: uint256.NewInt(0)}, ibs, config, vm.Config{})
+		nonce, err := ibs.GetNonce(header.Coinbase)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		if err := ibs.SetNonce(header.Coinbase, nonce+1); err != nil {
+			return nil, nil, nil, nil, err
+		}
+		_, leftOverGas, err := evm.Call(vm.AccountRef(header.Coinbase), *tx.GetTo(), tx.GetData(), tx.GetGas(), tx.GetValue(), false)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		// each system tx is executed by a worker against the committed state, storage of the previous one is original
+		if err := ibs.FinalizeTx(rules, state.NewNoopWriter()); err != nil {
+			return nil, nil, nil, nil, err
+		}
+
+		usedGas := tx.GetGas() - leftOverGas
+		header.GasUsed += usedGas
+		*receipts[i] = types.Receipt{
+			Type:              tx.Type(),
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: header.GasUsed,
+			TxHash:            tx.Hash(),
+			GasUsed:           usedGas,
+			TransactionIndex:  uint(i),
+		}
+	}
+	return types.NewBlock(header, txs, uncles, receipts, withdrawals), txs, receipts, nil, nil
+}
+
+// TestExecV3ParallelSystemTxs executes same chain serially and by parallel workers, with system
+// transactions of a PoSA engine: they read the coinbase and storage written by the regular txs of
+// the block, and are executed serially after them.
+func TestExecV3ParallelSystemTxs(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	keys := make([]*ecdsa.PrivateKey, 8)
+	alloc := types.GenesisAlloc{}
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		alloc[crypto.PubkeyToAddress(keys[i].PublicKey)] = types.GenesisAccount{Balance: big.NewInt(params.Ether)}
+	}
+	validatorKey, _ := crypto.GenerateKey()
+	validator := crypto.PubkeyToAddress(validatorKey.PublicKey)
+	alloc[validator] = types.GenesisAccount{Balance: big.NewInt(params.Ether)}
+	gspec := &types.Genesis{Config: params.TestChainConfig, GasLimit: 30_000_000, Alloc: alloc}
+	signer := types.LatestSigner(gspec.Config)
+
+	// counter: increments slot 0 on each call, it is the system contract
+	counterCode := hexutil.MustDecode("0x6960005460010160005500600052600a6016f3")
+	deployer := crypto.PubkeyToAddress(keys[0].PublicKey)
+	engine := &posaEngine{FakeEthash: ethash.NewFaker(), system: crypto.CreateAddress(deployer, 0)}
+
+	m := mock.MockWithGenesisEngine(t, gspec, engine, false, true)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 8, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(validator)
+		if i == 0 {
+			tx, err := types.SignTx(types.NewContractCreation(gen.TxNonce(deployer), uint256.NewInt(0), 100_000, uint256.NewInt(params.GWei), counterCode), *signer, keys[0])
+			require.NoError(err)
+			gen.AddTx(tx)
+			return
+		}
+		for j, key := range keys {
+			from := crypto.PubkeyToAddress(key.PublicKey)
+			to := crypto.PubkeyToAddress(keys[(j+i)%len(keys)].PublicKey)
+			if j == i%len(keys) {
+				to = validator
+			}
+			tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(from), to, uint256.NewInt(uint64(1000*(i+j))), params.TxGas, uint256.NewInt(params.GWei), nil), *signer, key)
+			require.NoError(err)
+			gen.AddTx(tx)
+			if j%2 == i%2 {
+				tx, err = types.SignTx(types.NewTransaction(gen.TxNonce(from), engine.system, uint256.NewInt(0), 100_000, uint256.NewInt(params.GWei), nil), *signer, key)
+				require.NoError(err)
+				gen.AddTx(tx)
+			}
+		}
+		// system txs spend the fees collected by the validator in the block
+		for k := 0; k < 2; k++ {
+			tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(validator)+uint64(k), engine.system, uint256.NewInt(uint64(1000+k)), 1_000_000, uint256.NewInt(0), nil), *signer, validatorKey)
+			require.NoError(err)
+			gen.AddUncheckedTx(tx)
+			gen.AddUncheckedReceipt(&types.Receipt{})
+		}
+	})
+	require.NoError(err)
+	require.NoError(m.InsertChain(chain))
+
+	mp := mock.MockWithGenesisEngine(t, gspec, engine, false, true)
+	checkExecV3Parallel(t, m, mp, chain)
+}
+
+// checkExecV3Parallel executes the chain, which is already executed by m, with parallel workers by mp
+// and compares the results.
+func checkExecV3Parallel(t *testing.T, m, mp *mock.MockSentry, chain *core.ChainPack) {
+	t.Helper()
+	require := require.New(t)
+
+	// same chain, but Execution stage is done by ExecV3 with parallel workers
+	mp.Sync.MockExecFunc(stages.Execution, func(badBlockUnwind bool, s *stagedsync.StageState, u stagedsync.Unwinder, txc wrap.TxContainer, logger log.Logger) error {
+		return nil
+	})
+	require.ErrorContains(mp.InsertChain(chain), "Execution stage progress")
+
+	syncCfg := mp.Cfg().Sync
+	syncCfg.ParallelExec = true
+	cfg := stagedsync.StageExecuteBlocksCfg(mp.DB, mp.Cfg().Prune, mp.Cfg().BatchSize, mp.ChainConfig, mp.Engine, &vm.Config{}, nil, false, true, mp.Dirs, mp.BlockReader, nil, mp.Cfg().Genesis, syncCfg, nil, false)
+
+	ctx := context.Background()
+	tx, err := mp.DB.BeginRo(ctx)
+	require.NoError(err)
+	s, err := mp.Sync.StageState(stages.Execution, tx, mp.DB, true, false)
+	tx.Rollback()
+	require.NoError(err)
+
+	top := chain.TopBlock.NumberU64()
+	require.NoError(stagedsync.ExecV3(ctx, s, mp.Sync, 4, cfg, wrap.TxContainer{}, true, top, mp.Log, true, false))
+	require.False(mp.Sync.HasUnwindPoint(), "state root of parallel execution doesn't match")
+
+	// receipts are same
+	var maxTxNum uint64
+	var receipts [][3]uint64
+	require.NoError(m.DB.ViewTemporal(ctx, func(tx kv.TemporalTx) error {
+		maxTxNum, err = rawdbv3.TxNums.Max(tx, top)
+		require.NoError(err)
+		for txNum := uint64(0); txNum <= maxTxNum; txNum++ {
+			cumGasUsed, blobGasUsed, logIndex, err := rawtemporaldb.ReceiptAsOf(tx, txNum)
+			require.NoError(err)
+			receipts = append(receipts, [3]uint64{cumGasUsed, blobGasUsed, uint64(logIndex)})
+		}
+		return nil
+	}))
+	require.NoError(mp.DB.ViewTemporal(ctx, func(tx kv.TemporalTx) error {
+		progress, err := stages.GetStageProgress(tx, stages.Execution)
+		require.NoError(err)
+		require.Equal(top, progress)
+		for txNum := uint64(0); txNum <= maxTxNum; txNum++ {
+			cumGasUsed, blobGasUsed, logIndex, err := rawtemporaldb.ReceiptAsOf(tx, txNum)
+			require.NoError(err)
+			require.Equal(receipts[txNum], [3]uint64{cumGasUsed, blobGasUsed, uint64(logIndex)}, "txNum=%d", txNum)
+		}
+		return nil
+	}))
+}
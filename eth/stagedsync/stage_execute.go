@@ -31,6 +31,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/dbg"
 	"github.com/erigontech/erigon-lib/config3"
 	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
 	"github.com/erigontech/erigon-lib/kv/temporal"
 	"github.com/erigontech/erigon-lib/log/v3"
 	libstate "github.com/erigontech/erigon-lib/state"
@@ -87,6 +88,7 @@ type ExecuteBlockCfg struct {
 	polygonExtraReceipt bool
 
 	applyWorker, applyWorkerMining *exec3.Worker
+	stateCache                     kvcache.Cache // warmed up by the state prefetcher, optional
 }
 
 func StageExecuteBlocksCfg(
@@ -134,6 +136,13 @@ func StageExecuteBlocksCfg(
 	}
 }
 
+// WithStateCache sets the state cache of the RPC daemon, to warm it up along with the
+// state read by the executed blocks.
+func (cfg ExecuteBlockCfg) WithStateCache(stateCache kvcache.Cache) ExecuteBlockCfg {
+	cfg.stateCache = stateCache
+	return cfg
+}
+
 // ================ Erigon3 ================
 
 func ExecBlockV3(s *StageState, u Unwinder, txc wrap.TxContainer, toBlock uint64, ctx context.Context, cfg ExecuteBlockCfg, initialCycle bool, logger log.Logger, isMining bool) (err error) {
@@ -155,7 +164,7 @@ func ExecBlockV3(s *StageState, u Unwinder, txc wrap.TxContainer, toBlock uint64
 		return nil
 	}
 
-	parallel := txc.Tx == nil
+	parallel := txc.Tx == nil && cfg.syncCfg.ParallelExec
 	if err := ExecV3(ctx, s, u, workersCount, cfg, txc, parallel, to, logger, initialCycle, isMining); err != nil {
 		return err
 	}
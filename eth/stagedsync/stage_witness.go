@@ -138,7 +138,7 @@ func ExecuteBlockStatelessly(block *types.Block, prevHeader *types.Header, chain
 	if err != nil {
 		return libcommon.Hash{}, err
 	}
-	execResult, err := core.ExecuteBlockEphemerally(cfg.chainConfig, &vm.Config{}, getHashFn, cfg.engine, block, statelessIbs, statelessIbs, chainReader, nil, logger)
+	execResult, err := core.ExecuteBlockEphemerallyForEngine(cfg.chainConfig, &vm.Config{}, getHashFn, cfg.engine, block, statelessIbs, statelessIbs, chainReader, nil, logger)
 	if err != nil {
 		return libcommon.Hash{}, err
 	}
@@ -0,0 +1,62 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package profiler
+
+import (
+	libcommon "github.com/erigontech/erigon-lib/common"
+)
+
+// precompileNames maps the addresses of the precompiled contracts, including
+// the BSC specific ones, to a human readable name.
+var precompileNames = map[libcommon.Address]string{
+	libcommon.BytesToAddress([]byte{0x01}): "ecrecover",
+	libcommon.BytesToAddress([]byte{0x02}): "sha256",
+	libcommon.BytesToAddress([]byte{0x03}): "ripemd160",
+	libcommon.BytesToAddress([]byte{0x04}): "identity",
+	libcommon.BytesToAddress([]byte{0x05}): "modexp",
+	libcommon.BytesToAddress([]byte{0x06}): "bn256Add",
+	libcommon.BytesToAddress([]byte{0x07}): "bn256ScalarMul",
+	libcommon.BytesToAddress([]byte{0x08}): "bn256Pairing",
+	libcommon.BytesToAddress([]byte{0x09}): "blake2F",
+	libcommon.BytesToAddress([]byte{0x0a}): "pointEvaluation",
+	libcommon.BytesToAddress([]byte{0x0b}): "bls12381G1Add",
+	libcommon.BytesToAddress([]byte{0x0c}): "bls12381G1MultiExp",
+	libcommon.BytesToAddress([]byte{0x0d}): "bls12381G2Add",
+	libcommon.BytesToAddress([]byte{0x0e}): "bls12381G2MultiExp",
+	libcommon.BytesToAddress([]byte{0x0f}): "bls12381Pairing",
+	libcommon.BytesToAddress([]byte{0x10}): "bls12381MapFpToG1",
+	libcommon.BytesToAddress([]byte{0x11}): "bls12381MapFp2ToG2",
+
+	// BSC
+	libcommon.BytesToAddress([]byte{100}): "tmHeaderValidate",
+	libcommon.BytesToAddress([]byte{101}): "iavlMerkleProofValidate",
+	libcommon.BytesToAddress([]byte{102}): "blsSignatureVerify",
+	libcommon.BytesToAddress([]byte{103}): "cometBFTLightBlockValidate",
+	libcommon.BytesToAddress([]byte{104}): "verifyDoubleSignEvidence",
+	libcommon.BytesToAddress([]byte{105}): "secp256k1SignatureRecover",
+
+	libcommon.BytesToAddress([]byte{0x01, 0x00}): "p256Verify",
+}
+
+// PrecompileName returns the name of the precompiled contract at addr, or its
+// hex address if it's not a known precompile.
+func PrecompileName(addr libcommon.Address) string {
+	if name, ok := precompileNames[addr]; ok {
+		return name
+	}
+	return addr.Hex()
+}
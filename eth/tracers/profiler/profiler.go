@@ -0,0 +1,298 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package profiler implements an execution profiler which records invocation
+// counts, gas and wall time of opcodes and precompiled contracts, aggregated
+// per block range and per contract address.
+package profiler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+)
+
+// Stat holds the aggregated cost of a single opcode or precompile.
+type Stat struct {
+	Count uint64        `json:"count"`
+	Gas   uint64        `json:"gas"`
+	Time  time.Duration `json:"time"` // wall time in nanoseconds
+}
+
+func (s *Stat) add(gas uint64, elapsed time.Duration) {
+	s.Count++
+	s.Gas += gas
+	s.Time += elapsed
+}
+
+func (s *Stat) merge(o *Stat) {
+	s.Count += o.Count
+	s.Gas += o.Gas
+	s.Time += o.Time
+}
+
+// PrecompileStat is the Stat of a precompiled contract.
+type PrecompileStat struct {
+	Name string `json:"name"`
+	Stat
+}
+
+// ContractProfile holds the opcodes executed with the code of a contract.
+// Calls and Gas count the call frames entering the contract, Gas includes
+// the gas used by nested calls.
+type ContractProfile struct {
+	Calls   uint64           `json:"calls"`
+	Gas     uint64           `json:"gas"`
+	Opcodes map[string]*Stat `json:"opcodes"`
+}
+
+// Profile is the profile of a range of blocks.
+type Profile struct {
+	FromBlock   uint64                                 `json:"fromBlock"`
+	ToBlock     uint64                                 `json:"toBlock"`
+	Calls       uint64                                 `json:"calls"` // top-level calls, system calls included
+	Opcodes     map[string]*Stat                       `json:"opcodes"`
+	Precompiles map[libcommon.Address]*PrecompileStat  `json:"precompiles"`
+	Contracts   map[libcommon.Address]*ContractProfile `json:"contracts"`
+}
+
+// NewProfile returns an empty profile of a single block.
+func NewProfile(blockNum uint64) *Profile {
+	return &Profile{
+		FromBlock:   blockNum,
+		ToBlock:     blockNum,
+		Opcodes:     map[string]*Stat{},
+		Precompiles: map[libcommon.Address]*PrecompileStat{},
+		Contracts:   map[libcommon.Address]*ContractProfile{},
+	}
+}
+
+func (p *Profile) contract(addr libcommon.Address) *ContractProfile {
+	c, ok := p.Contracts[addr]
+	if !ok {
+		c = &ContractProfile{Opcodes: map[string]*Stat{}}
+		p.Contracts[addr] = c
+	}
+	return c
+}
+
+func (p *Profile) precompile(addr libcommon.Address) *PrecompileStat {
+	s, ok := p.Precompiles[addr]
+	if !ok {
+		s = &PrecompileStat{Name: PrecompileName(addr)}
+		p.Precompiles[addr] = s
+	}
+	return s
+}
+
+// Merge adds the statistics of o to p and extends its block range.
+func (p *Profile) Merge(o *Profile) {
+	p.FromBlock, p.ToBlock = min(p.FromBlock, o.FromBlock), max(p.ToBlock, o.ToBlock)
+	p.Calls += o.Calls
+	mergeOpcodes(p.Opcodes, o.Opcodes)
+	for addr, s := range o.Precompiles {
+		p.precompile(addr).merge(&s.Stat)
+	}
+	for addr, c := range o.Contracts {
+		pc := p.contract(addr)
+		pc.Calls += c.Calls
+		pc.Gas += c.Gas
+		mergeOpcodes(pc.Opcodes, c.Opcodes)
+	}
+}
+
+func mergeOpcodes(dst, src map[string]*Stat) {
+	for op, s := range src {
+		d, ok := dst[op]
+		if !ok {
+			d = &Stat{}
+			dst[op] = d
+		}
+		d.merge(s)
+	}
+}
+
+func addOpcode(m map[string]*Stat, op string, gas uint64, elapsed time.Duration) {
+	s, ok := m[op]
+	if !ok {
+		s = &Stat{}
+		m[op] = s
+	}
+	s.add(gas, elapsed)
+}
+
+// frame is a call frame on the profiler's call stack.
+type frame struct {
+	addr       libcommon.Address
+	precompile bool
+	start      time.Time
+	contract   *ContractProfile // nil for precompiles
+
+	// opcode currently executing in the frame
+	hasOp   bool
+	op      vm.OpCode
+	opGas   uint64
+	opStart time.Time
+	opTime  time.Duration // time spent before the opcode was suspended by a nested call
+}
+
+// Profiler aggregates opcode and precompile statistics of the executions it
+// is attached to. The wall time of an opcode excludes the time spent in the
+// call frames it spawns, and the gas forwarded to a nested call is attributed
+// to the callee. A Profiler is not safe for concurrent use.
+type Profiler struct {
+	rangeSize uint64
+	ranges    map[uint64]*Profile
+	current   *Profile
+	frames    []*frame
+}
+
+// New returns a Profiler which aggregates statistics per rangeSize blocks.
+// A zero rangeSize aggregates all executions in a single Profile.
+func New(rangeSize uint64) *Profiler {
+	return &Profiler{rangeSize: rangeSize, ranges: map[uint64]*Profile{}}
+}
+
+// Hooks returns the tracing hooks feeding the profiler.
+func (p *Profiler) Hooks() *tracing.Hooks {
+	return &tracing.Hooks{
+		OnTxStart: p.onTxStart,
+		OnEnter:   p.onEnter,
+		OnExit:    p.onExit,
+		OnOpcode:  p.onOpcode,
+	}
+}
+
+// Logger returns the profiler as a vm.EVMLogger to be set in vm.Config.
+func (p *Profiler) Logger() vm.EVMLogger {
+	return vm.NewHooksLogger(p.Hooks())
+}
+
+// Profiles returns the collected profiles ordered by block range.
+func (p *Profiler) Profiles() []*Profile {
+	profiles := make([]*Profile, 0, len(p.ranges))
+	for _, r := range p.ranges {
+		profiles = append(profiles, r)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].FromBlock < profiles[j].FromBlock })
+	return profiles
+}
+
+// Total returns all collected profiles merged into one, or nil if nothing was profiled.
+func (p *Profiler) Total() *Profile {
+	var total *Profile
+	for _, r := range p.Profiles() {
+		if total == nil {
+			total = NewProfile(r.FromBlock)
+		}
+		total.Merge(r)
+	}
+	return total
+}
+
+func (p *Profiler) onTxStart(env *tracing.VMContext, txn types.Transaction, from libcommon.Address) {
+	key := uint64(0)
+	if p.rangeSize > 0 {
+		key = env.BlockNumber / p.rangeSize
+	}
+	r, ok := p.ranges[key]
+	if !ok {
+		r = NewProfile(env.BlockNumber)
+		p.ranges[key] = r
+	}
+	r.FromBlock, r.ToBlock = min(r.FromBlock, env.BlockNumber), max(r.ToBlock, env.BlockNumber)
+	r.Calls++
+	p.current = r
+	p.frames = p.frames[:0]
+}
+
+func (p *Profiler) onEnter(depth int, typ byte, from libcommon.Address, to libcommon.Address, precompile bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	if p.current == nil {
+		p.onTxStart(&tracing.VMContext{}, nil, from)
+	}
+	now := time.Now()
+	if parent := p.top(); parent != nil && parent.hasOp {
+		parent.opTime += now.Sub(parent.opStart)
+		parent.opGas -= min(gas, parent.opGas)
+	}
+	f := &frame{addr: to, precompile: precompile, start: now}
+	if !precompile && vm.OpCode(typ) != vm.SELFDESTRUCT {
+		f.contract = p.current.contract(to)
+		f.contract.Calls++
+	}
+	p.frames = append(p.frames, f)
+}
+
+func (p *Profiler) onExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	f := p.top()
+	if f == nil {
+		return
+	}
+	now := time.Now()
+	p.flushOp(f, now)
+	p.frames = p.frames[:len(p.frames)-1]
+	switch {
+	case f.precompile:
+		p.current.precompile(f.addr).add(gasUsed, now.Sub(f.start))
+	case f.contract != nil:
+		f.contract.Gas += gasUsed
+	}
+	if parent := p.top(); parent != nil && parent.hasOp {
+		parent.opStart = now
+	}
+}
+
+func (p *Profiler) onOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	f := p.top()
+	if f == nil {
+		return
+	}
+	now := time.Now()
+	p.flushOp(f, now)
+	if err != nil {
+		// the opcode failed before being executed
+		return
+	}
+	f.hasOp, f.op, f.opGas, f.opStart, f.opTime = true, vm.OpCode(op), cost, now, 0
+}
+
+// flushOp records the opcode executing in the frame, if any.
+func (p *Profiler) flushOp(f *frame, now time.Time) {
+	if !f.hasOp {
+		return
+	}
+	f.hasOp = false
+	elapsed := f.opTime + now.Sub(f.opStart)
+	name := f.op.String()
+	addOpcode(p.current.Opcodes, name, f.opGas, elapsed)
+	if f.contract != nil {
+		addOpcode(f.contract.Opcodes, name, f.opGas, elapsed)
+	}
+}
+
+func (p *Profiler) top() *frame {
+	if len(p.frames) == 0 {
+		return nil
+	}
+	return p.frames[len(p.frames)-1]
+}
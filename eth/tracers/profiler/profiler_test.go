@@ -0,0 +1,137 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package profiler
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/runtime"
+	"github.com/erigontech/erigon/params"
+)
+
+// identityCall copies a word through the identity precompile.
+var identityCall = []byte{
+	byte(vm.PUSH1), 0x20, // retLength
+	byte(vm.PUSH1), 0x00, // retOffset
+	byte(vm.PUSH1), 0x20, // argsLength
+	byte(vm.PUSH1), 0x00, // argsOffset
+	byte(vm.PUSH1), 0x04, // identity
+	byte(vm.GAS),
+	byte(vm.STATICCALL),
+	byte(vm.POP),
+	byte(vm.STOP),
+}
+
+func profile(t *testing.T, p *Profiler, blockNum int64) {
+	t.Helper()
+	cfg := &runtime.Config{
+		ChainConfig: params.TestChainConfig,
+		Difficulty:  new(big.Int),
+		Time:        new(big.Int),
+		GasLimit:    1_000_000,
+		GasPrice:    new(uint256.Int),
+		Value:       new(uint256.Int),
+		BlockNumber: big.NewInt(blockNum),
+		EVMConfig:   vm.Config{Debug: true, Tracer: p.Logger()},
+	}
+	if _, _, err := runtime.Execute(identityCall, nil, cfg, t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProfiler(t *testing.T) {
+	t.Parallel()
+	p := New(0)
+	profile(t, p, 1)
+
+	profiles := p.Profiles()
+	if len(profiles) != 1 {
+		t.Fatalf("have %d profiles, want 1", len(profiles))
+	}
+	r := profiles[0]
+	if r.Calls != 1 {
+		t.Errorf("have %d calls, want 1", r.Calls)
+	}
+	for op, want := range map[string]Stat{
+		"PUSH1": {Count: 5, Gas: 15},
+		"GAS":   {Count: 1, Gas: 2},
+		// warm access + memory expansion, the forwarded gas is accounted to the precompile
+		"STATICCALL": {Count: 1, Gas: 103},
+		"POP":        {Count: 1, Gas: 2},
+		"STOP":       {Count: 1, Gas: 0},
+	} {
+		have, ok := r.Opcodes[op]
+		if !ok {
+			t.Errorf("%s: missing", op)
+			continue
+		}
+		if have.Count != want.Count || have.Gas != want.Gas {
+			t.Errorf("%s: have count %d gas %d, want count %d gas %d", op, have.Count, have.Gas, want.Count, want.Gas)
+		}
+	}
+
+	identity := r.Precompiles[libcommon.BytesToAddress([]byte{0x04})]
+	if identity == nil {
+		t.Fatal("identity precompile not profiled")
+	}
+	if identity.Name != "identity" || identity.Count != 1 || identity.Gas != 18 {
+		t.Errorf("have %s count %d gas %d, want identity count 1 gas 18", identity.Name, identity.Count, identity.Gas)
+	}
+	if identity.Time <= 0 {
+		t.Errorf("have precompile time %v, want > 0", identity.Time)
+	}
+
+	contract := r.Contracts[libcommon.BytesToAddress([]byte("contract"))]
+	if contract == nil {
+		t.Fatal("contract not profiled")
+	}
+	if contract.Calls != 1 || contract.Opcodes["PUSH1"].Count != 5 {
+		t.Errorf("have %d calls and %d PUSH1, want 1 and 5", contract.Calls, contract.Opcodes["PUSH1"].Count)
+	}
+	if _, ok := r.Contracts[libcommon.BytesToAddress([]byte{0x04})]; ok {
+		t.Error("precompile profiled as a contract")
+	}
+}
+
+func TestProfilerBlockRanges(t *testing.T) {
+	t.Parallel()
+	p := New(10)
+	for _, blockNum := range []int64{3, 7, 15} {
+		profile(t, p, blockNum)
+	}
+
+	profiles := p.Profiles()
+	if len(profiles) != 2 {
+		t.Fatalf("have %d profiles, want 2", len(profiles))
+	}
+	for i, want := range []struct{ from, to, calls uint64 }{{3, 7, 2}, {15, 15, 1}} {
+		if r := profiles[i]; r.FromBlock != want.from || r.ToBlock != want.to || r.Calls != want.calls {
+			t.Errorf("profile %d: have blocks %d-%d calls %d, want %d-%d calls %d", i, r.FromBlock, r.ToBlock, r.Calls, want.from, want.to, want.calls)
+		}
+	}
+
+	total := p.Total()
+	if total.FromBlock != 3 || total.ToBlock != 15 || total.Opcodes["PUSH1"].Count != 15 {
+		t.Errorf("have total blocks %d-%d with %d PUSH1, want 3-15 with 15", total.FromBlock, total.ToBlock, total.Opcodes["PUSH1"].Count)
+	}
+}
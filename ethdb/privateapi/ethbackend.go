@@ -70,6 +70,10 @@ type EthBackend interface {
 	NodesInfo(limit int) (*remote.NodesInfoReply, error)
 	Peers(ctx context.Context) (*remote.PeersReply, error)
 	AddPeer(ctx context.Context, url *remote.AddPeerRequest) (*remote.AddPeerReply, error)
+	RemovePeer(ctx context.Context, url *remote.RemovePeerRequest) (*remote.RemovePeerReply, error)
+	AddTrustedPeer(ctx context.Context, url *remote.AddTrustedPeerRequest) (*remote.AddTrustedPeerReply, error)
+	RemoveTrustedPeer(ctx context.Context, url *remote.RemoveTrustedPeerRequest) (*remote.RemoveTrustedPeerReply, error)
+	PeerEvents(ctx context.Context, onEvent func(*remote.PeerEvent) error) error
 }
 
 func NewEthBackendServer(ctx context.Context, eth EthBackend, db kv.RwDB, notifications *shards.Notifications, blockReader services.FullBlockReader,
@@ -380,6 +384,22 @@ func (s *EthBackendServer) AddPeer(ctx context.Context, req *remote.AddPeerReque
 	return s.eth.AddPeer(ctx, req)
 }
 
+func (s *EthBackendServer) RemovePeer(ctx context.Context, req *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	return s.eth.RemovePeer(ctx, req)
+}
+
+func (s *EthBackendServer) AddTrustedPeer(ctx context.Context, req *remote.AddTrustedPeerRequest) (*remote.AddTrustedPeerReply, error) {
+	return s.eth.AddTrustedPeer(ctx, req)
+}
+
+func (s *EthBackendServer) RemoveTrustedPeer(ctx context.Context, req *remote.RemoveTrustedPeerRequest) (*remote.RemoveTrustedPeerReply, error) {
+	return s.eth.RemoveTrustedPeer(ctx, req)
+}
+
+func (s *EthBackendServer) PeerEvents(_ *remote.PeerEventsRequest, server remote.ETHBACKEND_PeerEventsServer) error {
+	return s.eth.PeerEvents(server.Context(), server.Send)
+}
+
 func (s *EthBackendServer) SubscribeLogs(server remote.ETHBACKEND_SubscribeLogsServer) (err error) {
 	if s.logsFilter != nil {
 		return s.logsFilter.subscribeLogs(server)
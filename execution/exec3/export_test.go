@@ -0,0 +1,41 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package exec3
+
+import (
+	"context"
+
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/types"
+)
+
+var (
+	MxPrefetchHit  = mxPrefetchHit
+	MxPrefetchMiss = mxPrefetchMiss
+)
+
+func (p *StatePrefetcher) PrefetchBlock(ctx context.Context, block *types.Block) error {
+	return p.prefetch(ctx, block)
+}
+
+func (p *StatePrefetcher) IsWarm(k []byte) bool {
+	return p.isWarm(k)
+}
+
+func (p *StatePrefetcher) TrackReads(r state.ResettableStateReader) state.ResettableStateReader {
+	return &prefetchTrackingReader{ResettableStateReader: r, p: p}
+}
@@ -59,9 +59,10 @@ type Worker struct {
 	blockReader services.FullBlockReader
 	in          *state.QueueWithRetry
 	rs          *state.StateV3
-	stateWriter *state.StateWriterV3
+	stateWriter state.ResettableStateWriter
 	stateReader state.ResettableStateReader
 	historyMode bool // if true - stateReader is HistoryReaderV3, otherwise it's state reader
+	prefetcher  *StatePrefetcher
 	chainConfig *chain.Config
 
 	ctx      context.Context
@@ -116,14 +117,25 @@ func NewWorker(lock sync.Locker, logger log.Logger, ctx context.Context, backgro
 
 func (rw *Worker) LogLRUStats() { rw.evm.JumpDestCache.LogStats() }
 
+// SetTracer attaches an additional tracer to the executions of the worker, nil detaches it.
+func (rw *Worker) SetTracer(tracer vm.EVMLogger) {
+	if tracer == nil {
+		rw.vmCfg.Tracer = rw.callTracer
+		return
+	}
+	rw.vmCfg.Tracer = vm.NewMultiLogger(rw.callTracer, tracer)
+}
+
 func (rw *Worker) ResetState(rs *state.StateV3, accumulator *shards.Accumulator) {
 	rw.rs = rs
 	if rw.background {
 		rw.SetReader(state.NewReaderParallelV3(rs.Domains()))
+		// results of background workers are speculative: they are applied after validation of the read set
+		rw.stateWriter = state.NewStateWriterBufferedV3(rs, accumulator)
 	} else {
 		rw.SetReader(state.NewReaderV3(rs.Domains()))
+		rw.stateWriter = state.NewStateWriterV3(rs, accumulator)
 	}
-	rw.stateWriter = state.NewStateWriterV3(rs, accumulator)
 }
 
 func (rw *Worker) SetGaspool(gp *core.GasPool) {
@@ -170,11 +182,9 @@ func (rw *Worker) RunTxTask(txTask *state.TxTask, isMining bool) {
 // Needed to set history reader when need to offset few txs from block beginning and does not break processing,
 // like compute gas used for block and then to set state reader to continue processing on latest data.
 func (rw *Worker) SetReader(reader state.ResettableStateReader) {
-	rw.stateReader = reader
-	rw.stateReader.SetTx(rw.Tx())
-	rw.ibs.Reset()
-	rw.ibs = state.New(rw.stateReader)
-
+	if tracking, ok := reader.(*prefetchTrackingReader); ok {
+		reader = tracking.ResettableStateReader
+	}
 	switch reader.(type) {
 	case *state.HistoryReaderV3:
 		rw.historyMode = true
@@ -184,6 +194,21 @@ func (rw *Worker) SetReader(reader state.ResettableStateReader) {
 		rw.historyMode = false
 		//fmt.Printf("[worker] unknown reader %T: historyMode is set to disabled\n", reader)
 	}
+	if rw.prefetcher != nil && !rw.historyMode {
+		reader = &prefetchTrackingReader{ResettableStateReader: reader, p: rw.prefetcher}
+	}
+
+	rw.stateReader = reader
+	rw.stateReader.SetTx(rw.Tx())
+	rw.ibs.Reset()
+	rw.ibs = state.New(rw.stateReader)
+}
+
+// SetPrefetcher makes the worker count its reads hitting the state prefetched
+// by p. A nil p stops the counting.
+func (rw *Worker) SetPrefetcher(p *StatePrefetcher) {
+	rw.prefetcher = p
+	rw.SetReader(rw.stateReader)
 }
 
 func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining, skipPostEvaluaion bool) {
@@ -210,7 +235,9 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining, skipPostEvalua
 	txTask.Error = nil
 
 	rw.stateReader.SetTxNum(txTask.TxNum)
-	rw.rs.Domains().SetTxNum(txTask.TxNum)
+	if !rw.background {
+		rw.rs.Domains().SetTxNum(txTask.TxNum)
+	}
 	rw.stateReader.ResetReadSet()
 	rw.stateWriter.ResetWriteSet()
 
@@ -340,8 +367,10 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining, skipPostEvalua
 			txTask.Error = err
 		}
 	default:
-		// This doesn't make sense, but I am not sure if this wrong behaviour is needed somewhere else:
-		// rw.taskGasPool.Reset(txTask.Tx.GetGasLimit(), rw.chainConfig.GetMaxBlobGasPerBlock(header.Time))
+		if rw.background {
+			// block gas limit is checked by the apply loop - when results are applied in order
+			rw.taskGasPool.Reset(txTask.TxAsMessage.Gas(), rw.chainConfig.GetMaxBlobGasPerBlock(header.Time))
+		}
 		rw.callTracer.Reset()
 		rw.vmCfg.SkipAnalysis = txTask.SkipAnalysis
 		ibs.SetTxContext(txTask.TxIndex, txTask.BlockNum)
@@ -363,7 +392,10 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining, skipPostEvalua
 			txTask.TraceFroms = rw.callTracer.Froms()
 			txTask.TraceTos = rw.callTracer.Tos()
 
-			txTask.CreateReceipt(rw.Tx())
+			// receipt needs cumulative gas of previous txs: apply loop creates it for speculative results
+			if !rw.background {
+				txTask.CreateReceipt(rw.Tx())
+			}
 		}
 
 	}
@@ -374,7 +406,10 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining, skipPostEvalua
 		//	fmt.Printf("BalanceIncreaseSet [%x]=>[%d]\n", addr, &bal)
 		//}
 		if err = ibs.MakeWriteSet(rules, rw.stateWriter); err != nil {
-			panic(err)
+			if !rw.background {
+				panic(err)
+			}
+			txTask.Error = err // apply loop will re-execute it serially
 		}
 		txTask.ReadLists = rw.stateReader.ReadSet()
 		txTask.WriteLists = rw.stateWriter.WriteSet()
@@ -382,7 +417,7 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining, skipPostEvalua
 	}
 }
 
-func NewWorkersPool(lock sync.Locker, accumulator *shards.Accumulator, logger log.Logger, ctx context.Context, background bool, chainDb kv.RoDB, rs *state.StateV3, in *state.QueueWithRetry, blockReader services.FullBlockReader, chainConfig *chain.Config, genesis *types.Genesis, engine consensus.Engine, workerCount int, dirs datadir.Dirs, isMining bool) (reconWorkers []*Worker, applyWorker *Worker, rws *state.ResultsQueue, clear func(), wait func()) {
+func NewWorkersPool(lock sync.Locker, accumulator *shards.Accumulator, logger log.Logger, ctx context.Context, background bool, chainDb kv.RoDB, rs *state.StateV3, in *state.QueueWithRetry, blockReader services.FullBlockReader, chainConfig *chain.Config, genesis *types.Genesis, engine consensus.Engine, workerCount int, dirs datadir.Dirs, isMining bool) (reconWorkers []*Worker, applyWorker *Worker, rws *state.ResultsQueue, clear func(), wait func() error) {
 	reconWorkers = make([]*Worker, workerCount)
 
 	resultChSize := workerCount * 8
@@ -403,7 +438,7 @@ func NewWorkersPool(lock sync.Locker, accumulator *shards.Accumulator, logger lo
 					return reconWorkers[i].Run()
 				})
 			}
-			wait = func() { g.Wait() }
+			wait = g.Wait
 		}
 
 		var clearDone bool
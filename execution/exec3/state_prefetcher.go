@@ -0,0 +1,418 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package exec3
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
+	libstate "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/types/accounts"
+
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/turbo/services"
+)
+
+var (
+	mxPrefetchBlocks = metrics.GetOrCreateCounter("exec_prefetch_blocks")
+	mxPrefetchTxns   = metrics.GetOrCreateCounter("exec_prefetch_txns")
+	mxPrefetchLate   = metrics.GetOrCreateCounter("exec_prefetch_late") // txns not prefetched before their block was executed
+	mxPrefetchHit    = metrics.GetOrCreateCounter("exec_prefetch_hit")
+	mxPrefetchMiss   = metrics.GetOrCreateCounter("exec_prefetch_miss")
+)
+
+// prefetchWarmSetLimit bounds the number of keys remembered for the hit rate metrics
+const prefetchWarmSetLimit = 1 << 18
+
+// StatePrefetcher warms up the state read by the blocks about to be executed:
+// it speculatively executes their transactions on a read-only view of the
+// latest state, discarding the writes, which brings the domain file pages into
+// the page cache (and fills the state cache of the RPC daemon, if any) before
+// the serial executor reads them. The blocks are handed over by the executor,
+// at chain tip they are only in its RwTx. The view is the one of the parallel
+// workers: the updates of the executor not flushed yet are read from its
+// domains, the rest from a RoTx of the prefetcher. Transactions are executed
+// without the effects of the ones executed by other workers and with the nonce
+// and balance checks disabled, so the reads are a guess of the actual ones.
+//
+// Keys read by the prefetcher are remembered until their block is executed,
+// the reads of the executor hitting them are counted in exec_prefetch_hit.
+type StatePrefetcher struct {
+	db          kv.RoDB
+	chainConfig *chain.Config
+	engine      consensus.Engine
+	blockReader services.FullBlockReader
+	cache       kvcache.Cache // optional, warmed through state.CachedReader3
+	workers     int
+	budget      float64 // share of a CPU core each worker may use
+	logger      log.Logger
+
+	blocks    chan *types.Block
+	lastQueue uint64
+	executed  atomic.Uint64 // blocks up to this one were executed, no need to prefetch them
+
+	domainsLock sync.RWMutex // read-locked by the prefetch of a block
+	domains     *libstate.SharedDomains
+
+	warmLock sync.RWMutex
+	warm     map[string]uint64 // key -> block it was read for
+}
+
+// NewStatePrefetcher returns a prefetcher using up to cpuBudget CPU cores
+func NewStatePrefetcher(db kv.RoDB, chainConfig *chain.Config, engine consensus.Engine, blockReader services.FullBlockReader, cache kvcache.Cache, cpuBudget float64, logger log.Logger) *StatePrefetcher {
+	if cpuBudget <= 0 {
+		cpuBudget = 1
+	}
+	workers := int(math.Ceil(cpuBudget))
+	return &StatePrefetcher{
+		db:          db,
+		chainConfig: chainConfig,
+		engine:      engine,
+		blockReader: blockReader,
+		cache:       cache,
+		workers:     workers,
+		budget:      cpuBudget / float64(workers),
+		logger:      logger,
+		blocks:      make(chan *types.Block, 16),
+		warm:        map[string]uint64{},
+	}
+}
+
+// Start runs the prefetcher until the returned function is called
+func (p *StatePrefetcher) Start(ctx context.Context) context.CancelFunc {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case block := <-p.blocks:
+				if err := p.prefetch(ctx, block); err != nil && !errors.Is(err, context.Canceled) {
+					p.logger.Debug("[exec] state prefetch failed", "block", block.NumberU64(), "err", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// SetDomains makes the prefetcher read the updates of the executor not flushed
+// yet from sd, instead of the previous domains, which can be closed once it
+// returns. A nil sd stops reading the domains.
+func (p *StatePrefetcher) SetDomains(sd *libstate.SharedDomains) {
+	p.domainsLock.Lock()
+	defer p.domainsLock.Unlock()
+	if p.domains != nil {
+		p.domains.SetParallelReaders(false)
+	}
+	if sd != nil {
+		sd.SetParallelReaders(true)
+	}
+	p.domains = sd
+}
+
+// Prefetch schedules the prefetch of the block, unless it's already scheduled.
+// Doesn't block: the block is skipped if the prefetcher is behind. Not safe for
+// concurrent use.
+func (p *StatePrefetcher) Prefetch(block *types.Block) {
+	blockNum := block.NumberU64()
+	if blockNum <= p.lastQueue || blockNum <= p.executed.Load() {
+		return
+	}
+	select {
+	case p.blocks <- block:
+		p.lastQueue = blockNum
+	default:
+	}
+}
+
+// Done tells the prefetcher that the block was executed: its prefetch stops if
+// still running, and its keys are dropped.
+func (p *StatePrefetcher) Done(blockNum uint64) {
+	p.executed.Store(blockNum)
+	p.warmLock.Lock()
+	defer p.warmLock.Unlock()
+	for k, bn := range p.warm {
+		if bn <= blockNum {
+			delete(p.warm, k)
+		}
+	}
+}
+
+func (p *StatePrefetcher) prefetch(ctx context.Context, block *types.Block) error {
+	if block.NumberU64() <= p.executed.Load() {
+		return nil
+	}
+	p.domainsLock.RLock()
+	defer p.domainsLock.RUnlock()
+	mxPrefetchBlocks.Inc()
+
+	txs := block.Transactions()
+	var next atomic.Int64 // workers pick the transactions in order, to stay ahead of the executor
+	g, gCtx := errgroup.WithContext(ctx)
+	for i := 0; i < p.workers; i++ {
+		g.Go(func() error {
+			return p.prefetchTxs(gCtx, block, txs, &next)
+		})
+	}
+	return g.Wait()
+}
+
+func (p *StatePrefetcher) prefetchTxs(ctx context.Context, block *types.Block, txs types.Transactions, next *atomic.Int64) error {
+	tx, err := p.db.(kv.TemporalRoDB).BeginTemporalRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	header := block.HeaderNoCopy()
+	blockNum := header.Number.Uint64()
+	reader := &warmingReader{StateReader: p.stateReader(ctx, tx), p: p, blockNum: blockNum}
+	ibs := state.New(reader)
+	rules := p.chainConfig.Rules(blockNum, header.Time)
+	signer := types.MakeSigner(p.chainConfig, blockNum, header.Time)
+	getHashFn := core.GetHashFn(header, func(hash libcommon.Hash, number uint64) *types.Header {
+		h, _ := p.blockReader.Header(ctx, tx, hash, number)
+		return h
+	})
+	blockContext := core.NewEVMBlockContext(header, getHashFn, p.engine, nil, p.chainConfig)
+	evm := vm.NewEVM(blockContext, evmtypes.TxContext{}, ibs, p.chainConfig, vm.Config{})
+	posa, isPoSA := p.engine.(consensus.PoSA)
+
+	_, _ = reader.ReadAccountData(header.Coinbase)
+	for {
+		txIndex := int(next.Add(1) - 1)
+		if txIndex >= len(txs) {
+			return nil
+		}
+		if p.executed.Load() >= blockNum {
+			mxPrefetchLate.Inc()
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		txn := txs[txIndex]
+		if isPoSA {
+			if isSystemTx, _ := posa.IsSystemTransaction(txn, header); isSystemTx {
+				continue
+			}
+		}
+
+		start := time.Now()
+		if err := p.prefetchTx(evm, ibs, reader, txn, txIndex, header, signer, rules); err != nil {
+			return err
+		}
+		mxPrefetchTxns.Inc()
+		if p.budget < 1 {
+			// sleep so that the worker is busy only its share of the time
+			throttle := time.NewTimer(time.Duration(float64(time.Since(start)) * (1/p.budget - 1)))
+			select {
+			case <-ctx.Done():
+				throttle.Stop()
+				return ctx.Err()
+			case <-throttle.C:
+			}
+		}
+	}
+}
+
+// prefetchTx executes the transaction, or if it can't be executed reads the
+// accounts it names.
+func (p *StatePrefetcher) prefetchTx(evm *vm.EVM, ibs *state.IntraBlockState, reader *warmingReader, txn types.Transaction, txIndex int,
+	header *types.Header, signer *types.Signer, rules *chain.Rules,
+) error {
+	msg, err := txn.AsMessage(*signer, header.BaseFee, rules)
+	if err != nil {
+		return nil // invalid signature, the executor will reject the block
+	}
+	msg.SetCheckNonce(false)
+	ibs.SetTxContext(txIndex, header.Number.Uint64())
+	evm.Reset(core.NewEVMTxContext(msg), ibs)
+	gp := new(core.GasPool).AddGas(header.GasLimit).AddBlobGas(p.chainConfig.GetMaxBlobGasPerBlock(header.Time))
+	snapshot := ibs.Snapshot()
+	if _, err := core.ApplyMessage(evm, msg, gp, true /* refunds */, true /* gasBailout */, p.engine); err != nil {
+		ibs.RevertToSnapshot(snapshot)
+		warmTxAccounts(reader, msg.From(), txn)
+		return nil
+	}
+	// keep the changes in memory for the following transactions of the worker
+	return ibs.FinalizeTx(rules, state.NewNoopWriter())
+}
+
+func warmTxAccounts(reader state.StateReader, sender libcommon.Address, txn types.Transaction) {
+	_, _ = reader.ReadAccountData(sender)
+	if to := txn.GetTo(); to != nil {
+		if a, _ := reader.ReadAccountData(*to); a != nil {
+			_, _ = reader.ReadAccountCode(*to, 0)
+		}
+	}
+	for _, tuple := range txn.GetAccessList() {
+		_, _ = reader.ReadAccountData(tuple.Address)
+		for i := range tuple.StorageKeys {
+			_, _ = reader.ReadAccountStorage(tuple.Address, 0, &tuple.StorageKeys[i])
+		}
+	}
+}
+
+func (p *StatePrefetcher) stateReader(ctx context.Context, tx kv.TemporalTx) state.StateReader {
+	var reader state.StateReader = state.NewReaderV3(tx)
+	if p.cache != nil {
+		if view, err := p.cache.View(ctx, tx); err == nil {
+			reader = state.NewCachedReader3(view, tx)
+		}
+	}
+	if p.domains == nil {
+		return reader
+	}
+	mem := state.NewReaderParallelV3(p.domains)
+	mem.SetTx(tx)
+	mem.DiscardReadList()
+	return &domainsReader{StateReader: reader, domains: p.domains, mem: mem}
+}
+
+// domainsReader reads the keys updated by the executor since the last flush
+// from its domains, and the rest through the reader of the prefetcher
+type domainsReader struct {
+	state.StateReader
+	domains   *libstate.SharedDomains
+	mem       *state.ReaderParallelV3
+	composite []byte
+}
+
+func (r *domainsReader) inMem(domain kv.Domain, k []byte) bool {
+	_, ok := r.domains.GetLatestFromMem(domain, k)
+	return ok
+}
+
+func (r *domainsReader) ReadAccountData(address libcommon.Address) (*accounts.Account, error) {
+	if r.inMem(kv.AccountsDomain, address[:]) {
+		return r.mem.ReadAccountData(address)
+	}
+	return r.StateReader.ReadAccountData(address)
+}
+
+func (r *domainsReader) ReadAccountStorage(address libcommon.Address, incarnation uint64, key *libcommon.Hash) ([]byte, error) {
+	r.composite = append(append(r.composite[:0], address[:]...), key[:]...)
+	if r.inMem(kv.StorageDomain, r.composite) {
+		return r.mem.ReadAccountStorage(address, incarnation, key)
+	}
+	return r.StateReader.ReadAccountStorage(address, incarnation, key)
+}
+
+func (r *domainsReader) ReadAccountCode(address libcommon.Address, incarnation uint64) ([]byte, error) {
+	if r.inMem(kv.CodeDomain, address[:]) {
+		return r.mem.ReadAccountCode(address, incarnation)
+	}
+	return r.StateReader.ReadAccountCode(address, incarnation)
+}
+
+func (r *domainsReader) ReadAccountCodeSize(address libcommon.Address, incarnation uint64) (int, error) {
+	if r.inMem(kv.CodeDomain, address[:]) {
+		return r.mem.ReadAccountCodeSize(address, incarnation)
+	}
+	return r.StateReader.ReadAccountCodeSize(address, incarnation)
+}
+
+func (p *StatePrefetcher) warmUp(k []byte, blockNum uint64) {
+	p.warmLock.RLock()
+	_, ok := p.warm[string(k)]
+	p.warmLock.RUnlock()
+	if ok {
+		return
+	}
+	p.warmLock.Lock()
+	defer p.warmLock.Unlock()
+	if len(p.warm) < prefetchWarmSetLimit {
+		p.warm[string(k)] = blockNum
+	}
+}
+
+func (p *StatePrefetcher) isWarm(k []byte) bool {
+	p.warmLock.RLock()
+	defer p.warmLock.RUnlock()
+	_, ok := p.warm[string(k)]
+	return ok
+}
+
+// warmingReader remembers the keys read by the prefetcher
+type warmingReader struct {
+	state.StateReader
+	p         *StatePrefetcher
+	blockNum  uint64
+	composite []byte
+}
+
+func (r *warmingReader) ReadAccountData(address libcommon.Address) (*accounts.Account, error) {
+	r.p.warmUp(address[:], r.blockNum)
+	return r.StateReader.ReadAccountData(address)
+}
+
+func (r *warmingReader) ReadAccountStorage(address libcommon.Address, incarnation uint64, key *libcommon.Hash) ([]byte, error) {
+	r.composite = append(append(r.composite[:0], address[:]...), key[:]...)
+	r.p.warmUp(r.composite, r.blockNum)
+	return r.StateReader.ReadAccountStorage(address, incarnation, key)
+}
+
+// prefetchTrackingReader counts the reads of the executor which were prefetched
+type prefetchTrackingReader struct {
+	state.ResettableStateReader
+	p         *StatePrefetcher
+	composite []byte
+}
+
+func (r *prefetchTrackingReader) track(k []byte) {
+	if r.p.isWarm(k) {
+		mxPrefetchHit.Inc()
+	} else {
+		mxPrefetchMiss.Inc()
+	}
+}
+
+func (r *prefetchTrackingReader) ReadAccountData(address libcommon.Address) (*accounts.Account, error) {
+	r.track(address[:])
+	return r.ResettableStateReader.ReadAccountData(address)
+}
+
+func (r *prefetchTrackingReader) ReadAccountStorage(address libcommon.Address, incarnation uint64, key *libcommon.Hash) ([]byte, error) {
+	r.composite = append(append(r.composite[:0], address[:]...), key[:]...)
+	r.track(r.composite)
+	return r.ResettableStateReader.ReadAccountStorage(address, incarnation, key)
+}
@@ -0,0 +1,172 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package exec3_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	libstate "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/execution/exec3"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+func TestStatePrefetcher(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	gspec := &types.Genesis{
+		Config:   params.TestChainConfig,
+		GasLimit: 30_000_000,
+		Alloc:    types.GenesisAlloc{from: {Balance: big.NewInt(params.Ether)}},
+	}
+	signer := types.LatestSigner(gspec.Config)
+
+	// counter: increments slot 0 on each call
+	counterCode := hexutil.MustDecode("0x6960005460010160005500600052600a6016f3")
+	counter := crypto.CreateAddress(from, 0)
+	recipient := libcommon.HexToAddress("0xf00d")
+
+	m := mock.MockWithGenesis(t, gspec, key, false)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 2, func(i int, gen *core.BlockGen) {
+		var txn types.Transaction
+		if i == 0 {
+			txn = types.NewContractCreation(gen.TxNonce(from), uint256.NewInt(0), 100_000, uint256.NewInt(params.GWei), counterCode)
+		} else {
+			txn = types.NewTransaction(gen.TxNonce(from), counter, uint256.NewInt(0), 100_000, uint256.NewInt(params.GWei), nil)
+			signed, err := types.SignTx(txn, *signer, key)
+			require.NoError(err)
+			gen.AddTx(signed)
+			txn = types.NewTransaction(gen.TxNonce(from), recipient, uint256.NewInt(1), params.TxGas, uint256.NewInt(params.GWei), nil)
+		}
+		signed, err := types.SignTx(txn, *signer, key)
+		require.NoError(err)
+		gen.AddTx(signed)
+	})
+	require.NoError(err)
+	require.NoError(m.InsertChain(chain))
+
+	ctx := context.Background()
+	p := exec3.NewStatePrefetcher(m.DB, m.ChainConfig, m.Engine, m.BlockReader, nil, 1.5, log.New())
+	require.NoError(p.PrefetchBlock(ctx, chain.Blocks[1]))
+
+	slot := append(counter.Bytes(), make([]byte, 32)...)
+	require.True(p.IsWarm(from[:]))
+	require.True(p.IsWarm(counter[:]))
+	require.True(p.IsWarm(slot), "storage read by the speculative execution")
+	require.True(p.IsWarm(recipient[:]))
+	require.False(p.IsWarm(libcommon.HexToAddress("0xbeef").Bytes()))
+
+	// reads of the executor are counted as hits if prefetched
+	hits, misses := exec3.MxPrefetchHit.GetValueUint64(), exec3.MxPrefetchMiss.GetValueUint64()
+	require.NoError(m.DB.ViewTemporal(ctx, func(tx kv.TemporalTx) error {
+		reader := p.TrackReads(state.NewReaderV3(tx))
+		_, err := reader.ReadAccountData(counter)
+		require.NoError(err)
+		_, err = reader.ReadAccountStorage(counter, 0, &libcommon.Hash{})
+		require.NoError(err)
+		_, err = reader.ReadAccountData(libcommon.HexToAddress("0xbeef"))
+		require.NoError(err)
+		return nil
+	}))
+	require.Equal(hits+2, exec3.MxPrefetchHit.GetValueUint64())
+	require.Equal(misses+1, exec3.MxPrefetchMiss.GetValueUint64())
+
+	// keys are dropped once the block is executed, and its prefetch is skipped
+	p.Done(2)
+	require.False(p.IsWarm(from[:]))
+	require.NoError(p.PrefetchBlock(ctx, chain.Blocks[1]))
+	require.False(p.IsWarm(from[:]))
+}
+
+// At chain tip, the block is only in the RwTx of the executor, and so is the state
+// of the previous blocks, in its domains.
+func TestStatePrefetcherExternalTx(t *testing.T) {
+	require := require.New(t)
+
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	gspec := &types.Genesis{
+		Config:   params.TestChainConfig,
+		GasLimit: 30_000_000,
+		Alloc:    types.GenesisAlloc{from: {Balance: big.NewInt(params.Ether)}},
+	}
+	signer := types.LatestSigner(gspec.Config)
+
+	// counter: increments slot 0 on each call
+	counterCode := hexutil.MustDecode("0x6960005460010160005500600052600a6016f3")
+	counter := crypto.CreateAddress(from, 0)
+
+	m := mock.MockWithGenesis(t, gspec, key, false)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 2, func(i int, gen *core.BlockGen) {
+		txn := types.Transaction(types.NewContractCreation(gen.TxNonce(from), uint256.NewInt(0), 100_000, uint256.NewInt(params.GWei), counterCode))
+		if i > 0 {
+			txn = types.NewTransaction(gen.TxNonce(from), counter, uint256.NewInt(0), 100_000, uint256.NewInt(params.GWei), nil)
+		}
+		signed, err := types.SignTx(txn, *signer, key)
+		require.NoError(err)
+		gen.AddTx(signed)
+	})
+	require.NoError(err)
+
+	ctx := context.Background()
+	tx, err := m.DB.BeginTemporalRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	domains, err := libstate.NewSharedDomains(tx, log.New())
+	require.NoError(err)
+	defer domains.Close()
+
+	// the counter deployed by block 1 is only in the domains
+	ibs := state.New(state.NewReaderV3(domains))
+	ibs.CreateAccount(counter, true)
+	ibs.SetCode(counter, hexutil.MustDecode("0x60005460010160005500"))
+	rules := m.ChainConfig.Rules(1, chain.Blocks[0].Time())
+	require.NoError(ibs.FinalizeTx(rules, state.NewWriterV4(domains)))
+	require.NoError(ibs.CommitBlock(rules, state.NewWriterV4(domains)))
+	require.NoError(m.DB.View(ctx, func(roTx kv.Tx) error {
+		block, err := m.BlockReader.BlockByNumber(ctx, roTx, 2)
+		require.NoError(err)
+		require.Nil(block)
+		return nil
+	}))
+
+	p := exec3.NewStatePrefetcher(m.DB, m.ChainConfig, m.Engine, m.BlockReader, nil, 1, log.New())
+	p.SetDomains(domains)
+	defer p.SetDomains(nil)
+	require.NoError(p.PrefetchBlock(ctx, chain.Blocks[1]))
+
+	slot := append(counter.Bytes(), make([]byte, 32)...)
+	require.True(p.IsWarm(from[:]))
+	require.True(p.IsWarm(counter[:]))
+	require.True(p.IsWarm(slot), "code of the counter read from the domains")
+}
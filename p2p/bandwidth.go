@@ -0,0 +1,271 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+const (
+	// Token buckets hold up to bandwidthBurst worth of traffic. A message larger
+	// than the tokens left puts the bucket into debt, which delays the following
+	// traffic until it is paid off.
+	bandwidthBurst = time.Second
+
+	// Bulk messages are only sent while the outbound buckets hold more than this
+	// share of their capacity, the rest is reserved for other messages.
+	bulkReserve = 0.5
+)
+
+var (
+	throttledIngressMeter = metrics.GetOrCreateCounter("p2p_ingress_throttled_ms")
+	throttledEgressMeter  = metrics.GetOrCreateCounter("p2p_egress_throttled_ms")
+	droppedEgressMeter    = metrics.GetOrCreateCounter("p2p_egress_dropped")
+)
+
+// bandwidthLimiter is a token bucket limiting the traffic in one direction, either
+// of a single connection or of all of them. A nil limiter is unlimited.
+type bandwidthLimiter struct {
+	rate  float64 // bytes per second
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newBandwidthLimiter(rate uint64) *bandwidthLimiter {
+	if rate == 0 {
+		return nil
+	}
+	burst := float64(rate) * bandwidthBurst.Seconds()
+	return &bandwidthLimiter{rate: float64(rate), burst: burst, tokens: burst}
+}
+
+// refill must be called with l.mu held.
+func (l *bandwidthLimiter) refill(now time.Time) {
+	if !l.last.IsZero() && now.After(l.last) {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+}
+
+// take takes n bytes from the bucket and returns how long the caller has to wait
+// until the traffic fits into the limit.
+func (l *bandwidthLimiter) take(n int, now time.Time) time.Duration {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(now)
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// allowBulk reports whether n bytes of bulk traffic fit into the bucket without
+// using the reserve.
+func (l *bandwidthLimiter) allowBulk(n int, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(now)
+	return l.tokens-float64(n) >= l.burst*bulkReserve
+}
+
+// MsgTraffic is the traffic of a peer for one protocol message.
+type MsgTraffic struct {
+	IngressBytes   uint64 `json:"ingressBytes"`
+	IngressPackets uint64 `json:"ingressPackets"`
+	EgressBytes    uint64 `json:"egressBytes"`
+	EgressPackets  uint64 `json:"egressPackets"`
+	EgressDropped  uint64 `json:"egressDropped"` // bulk messages dropped because of bandwidth limits
+}
+
+// TrafficInfo is the traffic of a peer reported by admin_peers, keyed by
+// protocol, version and message code, e.g. "eth/68/0x07". Sizes are wire sizes.
+type TrafficInfo map[string]*MsgTraffic
+
+type trafficKey struct {
+	cap  Cap
+	code uint64
+}
+
+// peerTraffic accounts the traffic of a connection per protocol message.
+// A nil *peerTraffic discards everything.
+type peerTraffic struct {
+	mu   sync.Mutex
+	msgs map[trafficKey]*MsgTraffic
+}
+
+func newPeerTraffic() *peerTraffic {
+	return &peerTraffic{msgs: make(map[trafficKey]*MsgTraffic)}
+}
+
+// msg must be called with t.mu held.
+func (t *peerTraffic) msg(cap Cap, code uint64) *MsgTraffic {
+	key := trafficKey{cap, code}
+	m, ok := t.msgs[key]
+	if !ok {
+		m = new(MsgTraffic)
+		t.msgs[key] = m
+	}
+	return m
+}
+
+func (t *peerTraffic) addIngress(cap Cap, code uint64, size uint32) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m := t.msg(cap, code)
+	m.IngressBytes += uint64(size)
+	m.IngressPackets++
+}
+
+func (t *peerTraffic) addEgress(cap Cap, code uint64, size uint32) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m := t.msg(cap, code)
+	m.EgressBytes += uint64(size)
+	m.EgressPackets++
+}
+
+func (t *peerTraffic) addDropped(cap Cap, code uint64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.msg(cap, code).EgressDropped++
+}
+
+func (t *peerTraffic) info() TrafficInfo {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	info := make(TrafficInfo, len(t.msgs))
+	for key, m := range t.msgs {
+		mcopy := *m
+		info[fmt.Sprintf("%s/%d/%#02x", key.cap.Name, key.cap.Version, key.code)] = &mcopy
+	}
+	return info
+}
+
+// connBandwidth limits and accounts the traffic of a connection.
+type connBandwidth struct {
+	ingress, egress         *bandwidthLimiter // shared by all connections
+	peerIngress, peerEgress *bandwidthLimiter
+	traffic                 *peerTraffic
+	metrics                 bool
+
+	closeOnce sync.Once
+	closed    chan struct{} // interrupts the waits when the connection is closed
+}
+
+func newConnBandwidth(ingress, egress, peerIngress, peerEgress *bandwidthLimiter, traffic *peerTraffic, metrics bool) *connBandwidth {
+	return &connBandwidth{
+		ingress:     ingress,
+		egress:      egress,
+		peerIngress: peerIngress,
+		peerEgress:  peerEgress,
+		traffic:     traffic,
+		metrics:     metrics,
+		closed:      make(chan struct{}),
+	}
+}
+
+func (b *connBandwidth) close() {
+	if b == nil {
+		return
+	}
+	b.closeOnce.Do(func() { close(b.closed) })
+}
+
+// wait sleeps for d and reports whether the connection is still open.
+func (b *connBandwidth) wait(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-b.closed:
+		return false
+	}
+}
+
+// throttleIngress delays reading after a message of n bytes was received.
+func (b *connBandwidth) throttleIngress(n int) {
+	if b == nil {
+		return
+	}
+	now := time.Now()
+	if wait := max(b.ingress.take(n, now), b.peerIngress.take(n, now)); wait > 0 {
+		throttledIngressMeter.AddUint64(uint64(wait.Milliseconds()))
+		b.wait(wait)
+	}
+}
+
+// throttleEgress delays sending a message of n bytes. It returns false if a bulk
+// message should be dropped instead, because the bandwidth is reserved for others.
+func (b *connBandwidth) throttleEgress(n int, bulk bool) (bool, error) {
+	if b == nil {
+		return true, nil
+	}
+	now := time.Now()
+	if bulk && !(b.egress.allowBulk(n, now) && b.peerEgress.allowBulk(n, now)) {
+		droppedEgressMeter.Inc()
+		return false, nil
+	}
+	if wait := max(b.egress.take(n, now), b.peerEgress.take(n, now)); wait > 0 {
+		throttledEgressMeter.AddUint64(uint64(wait.Milliseconds()))
+		if !b.wait(wait) {
+			return false, net.ErrClosed
+		}
+	}
+	return true, nil
+}
+
+// egressMsg accounts a sent protocol message.
+func (b *connBandwidth) egressMsg(cap Cap, code uint64, size uint32) {
+	if b == nil || cap.Name == "" { // don't meter non-subprotocol messages
+		return
+	}
+	b.traffic.addEgress(cap, code, size)
+	if b.metrics {
+		m := fmt.Sprintf("%s_%s_%d_%#02x", egressMeterName, cap.Name, cap.Version, code)
+		metrics.GetOrCreateCounter(m).AddUint64(uint64(size))
+		metrics.GetOrCreateCounter(m + "_packets").Inc()
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestBandwidthLimiter(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := newBandwidthLimiter(1000)
+
+	if wait := l.take(1000, now); wait != 0 {
+		t.Fatalf("full bucket throttled: %v", wait)
+	}
+	if wait := l.take(500, now); wait != 500*time.Millisecond {
+		t.Fatalf("wrong wait for debt: %v", wait)
+	}
+	// Messages larger than the burst are paid off in full.
+	if wait := l.take(5000, now); wait != 5500*time.Millisecond {
+		t.Fatalf("wrong wait for a large message: %v", wait)
+	}
+	now = now.Add(3 * time.Second)
+	if wait := l.take(0, now); wait != 2500*time.Millisecond {
+		t.Fatalf("wrong wait for partially paid debt: %v", wait)
+	}
+	now = now.Add(3500 * time.Millisecond)
+	if wait := l.take(1000, now); wait != 0 {
+		t.Fatalf("refilled bucket throttled: %v", wait)
+	}
+
+	// Bulk traffic may not use the reserve.
+	now = now.Add(time.Second)
+	if !l.allowBulk(400, now) {
+		t.Fatal("bulk traffic rejected by full bucket")
+	}
+	l.take(400, now)
+	if l.allowBulk(200, now) {
+		t.Fatal("bulk traffic allowed into the reserve")
+	}
+	if wait := l.take(500, now); wait != 0 {
+		t.Fatalf("priority traffic throttled within the reserve: %v", wait)
+	}
+
+	var unlimited *bandwidthLimiter
+	if unlimited.take(1<<30, now) != 0 || !unlimited.allowBulk(1<<30, now) {
+		t.Fatal("nil limiter is not unlimited")
+	}
+}
+
+func TestConnBandwidthClose(t *testing.T) {
+	b := newConnBandwidth(nil, nil, newBandwidthLimiter(1000), newBandwidthLimiter(1000), newPeerTraffic(), false)
+	b.peerIngress.take(1000, time.Now())
+	b.peerEgress.take(1000, time.Now())
+
+	// Waits for an hour of debt are interrupted by closing the connection.
+	time.AfterFunc(50*time.Millisecond, b.close)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b.throttleIngress(3600 * 1000)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("ingress wait not interrupted by close")
+	}
+	if send, err := b.throttleEgress(3600*1000, false); send || !errors.Is(err, net.ErrClosed) {
+		t.Fatalf("egress wait on a closed connection: send %v, err %v", send, err)
+	}
+	b.close() // closing twice is fine
+}
+
+func TestPeerTraffic(t *testing.T) {
+	eth68 := Cap{Name: "eth", Version: 68}
+	tr := newPeerTraffic()
+	tr.addIngress(eth68, 0x07, 100)
+	tr.addIngress(eth68, 0x07, 50)
+	tr.addEgress(eth68, 0x02, 30)
+	tr.addDropped(eth68, 0x02)
+
+	info := tr.info()
+	if len(info) != 2 {
+		t.Fatalf("wrong number of messages: %v", info)
+	}
+	if m := info["eth/68/0x07"]; m == nil || m.IngressBytes != 150 || m.IngressPackets != 2 {
+		t.Errorf("wrong ingress traffic: %+v", m)
+	}
+	if m := info["eth/68/0x02"]; m == nil || m.EgressBytes != 30 || m.EgressPackets != 1 || m.EgressDropped != 1 {
+		t.Errorf("wrong egress traffic: %+v", m)
+	}
+
+	var nilTraffic *peerTraffic
+	nilTraffic.addIngress(eth68, 0x07, 1)
+	if nilTraffic.info() != nil {
+		t.Error("nil traffic returned info")
+	}
+}
@@ -50,6 +50,11 @@ const (
 	// Endpoint resolution is throttled with bounded backoff.
 	initialResolveDelay = 60 * time.Second
 	maxResolveDelay     = time.Hour
+
+	// Well-behaved peers from previous sessions are dialed before discovery results.
+	// The candidate list is reloaded from the node database at most this often.
+	priorityRefreshInterval = 5 * time.Minute
+	maxPriorityCandidates   = 64
 )
 
 // NodeDialer is used to connect to nodes in the network, typically by using
@@ -83,6 +88,7 @@ var (
 	errRecentlyDialed   = errors.New("recently dialed")
 	errNotWhitelisted   = errors.New("not contained in netrestrict whitelist")
 	errNoPort           = errors.New("node does not provide TCP port")
+	errLowReputation    = errors.New("low reputation")
 )
 
 // dialer creates outbound connections and submits them into Server.
@@ -123,6 +129,10 @@ type dialScheduler struct {
 	static     map[enode.ID]*dialTask
 	staticPool []*dialTask
 
+	// Priority candidates are known nodes with a good reputation, best first.
+	priority        []*enode.Node
+	priorityRefresh mclock.AbsTime
+
 	// The dial history keeps recently dialed nodes. Members of history are not dialed.
 	history          expHeap
 	historyTimer     mclock.Timer
@@ -145,6 +155,7 @@ type dialConfig struct {
 	log            log.Logger
 	clock          mclock.Clock
 	rand           *mrand.Rand
+	reputation     *Reputation // peer scores, disabled if nil
 }
 
 func (cfg dialConfig) withDefaults() dialConfig {
@@ -244,6 +255,9 @@ loop:
 		// Launch new dials if slots are available.
 		slots := d.freeDialSlots()
 		d.startStaticDials()
+		if slots > 0 {
+			slots -= d.startPriorityDials(slots)
+		}
 		if slots > 0 {
 			nodesCh = d.nodesIn
 		} else {
@@ -260,7 +274,7 @@ loop:
 			d.logStats()
 
 		case node := <-nodesCh:
-			if err := d.checkDial(node); err != nil {
+			if err := d.checkDynDial(node); err != nil {
 				d.log.Trace("Discarding dial candidate", "id", node.ID(), "ip", node.IP(), "reason", err)
 			} else {
 				d.startDial(newDialTask(node, dynDialedConn))
@@ -426,6 +440,39 @@ func (d *dialScheduler) checkDial(n *enode.Node) error {
 	return nil
 }
 
+// checkDynDial returns an error if the dynamic dial candidate n should not be dialed.
+func (d *dialScheduler) checkDynDial(n *enode.Node) error {
+	if err := d.checkDial(n); err != nil {
+		return err
+	}
+	if d.reputation != nil && d.reputation.Score(n.ID()) < minDialReputation {
+		return errLowReputation
+	}
+	return nil
+}
+
+// startPriorityDials starts up to n dials to known nodes with a good reputation
+// and returns the number of started dials.
+func (d *dialScheduler) startPriorityDials(n int) (started int) {
+	if d.reputation == nil {
+		return 0
+	}
+	if now := d.clock.Now(); now >= d.priorityRefresh {
+		d.priority = d.reputation.Best(maxPriorityCandidates, minPriorityReputation)
+		d.priorityRefresh = now.Add(priorityRefreshInterval)
+	}
+	for len(d.priority) > 0 && started < n {
+		node := d.priority[0]
+		d.priority = d.priority[1:]
+		if err := d.checkDial(node); err != nil {
+			continue
+		}
+		d.startDial(newDialTask(node, dynDialedConn))
+		started++
+	}
+	return started
+}
+
 // startStaticDials starts n static dial tasks.
 func (d *dialScheduler) startStaticDials() {
 	for len(d.staticPool) > 0 {
@@ -208,6 +208,17 @@ func (ln *LocalNode) UDPContact(toaddr *net.UDPAddr) {
 	ln.updateEndpoints()
 }
 
+// PredictedEndpoint returns the IPv4 endpoint of the local node as seen by other nodes,
+// and whether some of them have reached it without being contacted first, i.e. whether
+// unsolicited discovery packets get through the NAT.
+func (ln *LocalNode) PredictedEndpoint() (ip net.IP, port int, reachable bool) {
+	ln.mu.Lock()
+	defer ln.mu.Unlock()
+
+	ip, port = predictAddr(ln.endpoint4.track)
+	return ip.To4(), port, ln.endpoint4.track.PredictFullConeNAT()
+}
+
 // updateEndpoints updates the record with predicted endpoints.
 func (ln *LocalNode) updateEndpoints() {
 	ip4, udp4 := ln.endpoint4.get()
@@ -135,3 +135,26 @@ func TestLocalNodeEndpoint(t *testing.T) {
 	assert.Equal(t, fallback.Port, ln.Node().UDP())
 	assert.Equal(t, uint64(4), ln.Node().Seq())
 }
+
+func TestLocalNodePredictedEndpoint(t *testing.T) {
+	predicted := &net.UDPAddr{IP: net.IP{127, 0, 1, 2}, Port: 81}
+	logger := log.New()
+	ln, db := newLocalNodeForTesting(t.TempDir(), logger)
+	defer db.Close()
+
+	// Statements from the contacted hosts make a prediction, but don't prove reachability.
+	for i := 0; i < iptrackMinStatements; i++ {
+		from := &net.UDPAddr{IP: net.IP{10, 0, 0, byte(i)}, Port: 90}
+		ln.UDPContact(from)
+		ln.UDPEndpointStatement(from, predicted)
+	}
+	ip, port, reachable := ln.PredictedEndpoint()
+	assert.Equal(t, predicted.IP, ip)
+	assert.Equal(t, predicted.Port, port)
+	assert.False(t, reachable)
+
+	// A statement from a host which wasn't contacted does.
+	ln.UDPEndpointStatement(&net.UDPAddr{IP: net.IP{10, 0, 1, 1}, Port: 90}, predicted)
+	_, _, reachable = ln.PredictedEndpoint()
+	assert.True(t, reachable)
+}
@@ -26,6 +26,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"os"
 	"sync"
@@ -46,6 +47,7 @@ const (
 	dbVersionKey   = "version" // Version of the database to flush if changes
 	dbNodePrefix   = "n:"      // Identifier to prefix node entries with
 	dbLocalPrefix  = "local:"
+	dbRepPrefix    = "rep:" // Peer reputation, keyed by ID only so it survives node expiration
 	dbDiscoverRoot = "v4"
 	dbDiscv5Root   = "v5"
 
@@ -237,6 +239,11 @@ func localItemKey(id ID, field string) []byte {
 	return key
 }
 
+// reputationKey returns the key of a peer reputation entry.
+func reputationKey(id ID) []byte {
+	return append([]byte(dbRepPrefix), id[:]...)
+}
+
 // fetchInt64 retrieves an integer associated with a particular key.
 func (db *DB) fetchInt64(key []byte) int64 {
 	var val int64
@@ -545,6 +552,65 @@ func (db *DB) UpdateFindFailsV5(id ID, ip net.IP, fails int) error {
 	return db.storeInt64(v5Key(id, ip, dbNodeFindFails), int64(fails))
 }
 
+// UpdateReputation stores the reputation score of a peer.
+func (db *DB) UpdateReputation(id ID, score float64, updated time.Time) error {
+	return db.kv.Batch(func(tx kv.RwTx) error {
+		return tx.Put(kv.Inodes, reputationKey(id), encodeReputation(score, updated))
+	})
+}
+
+// DeleteReputation removes the stored reputation score of a peer.
+func (db *DB) DeleteReputation(id ID) error {
+	return db.kv.Batch(func(tx kv.RwTx) error {
+		return tx.Delete(kv.Inodes, reputationKey(id))
+	})
+}
+
+// ForEachReputation iterates over all stored reputation scores.
+func (db *DB) ForEachReputation(f func(id ID, score float64, updated time.Time) error) error {
+	return db.kv.View(db.ctx, func(tx kv.Tx) error {
+		c, err := tx.Cursor(kv.Inodes)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		p := []byte(dbRepPrefix)
+		for k, v, err := c.Seek(p); k != nil && bytes.HasPrefix(k, p); k, v, err = c.Next() {
+			if err != nil {
+				return err
+			}
+			if len(k) != len(p)+len(ID{}) {
+				continue
+			}
+			var id ID
+			copy(id[:], k[len(p):])
+			score, updated := decodeReputation(v)
+			if updated.IsZero() {
+				continue
+			}
+			if err := f(id, score, updated); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func encodeReputation(score float64, updated time.Time) []byte {
+	blob := make([]byte, 16)
+	binary.BigEndian.PutUint64(blob, math.Float64bits(score))
+	binary.BigEndian.PutUint64(blob[8:], uint64(updated.Unix()))
+	return blob
+}
+
+func decodeReputation(blob []byte) (float64, time.Time) {
+	if len(blob) != 16 {
+		return 0, time.Time{}
+	}
+	score := math.Float64frombits(binary.BigEndian.Uint64(blob))
+	return score, time.Unix(int64(binary.BigEndian.Uint64(blob[8:])), 0)
+}
+
 // LocalSeq retrieves the local record sequence counter.
 func (db *DB) localSeq(id ID) uint64 {
 	return db.fetchUint64(localItemKey(id, dbLocalSeq))
@@ -494,3 +494,65 @@ func TestDBExpireV5(t *testing.T) {
 	db.UpdateFindFailsV5(ID{}, ip, 4)
 	db.expireNodes()
 }
+
+// storedReputation returns the stored reputation of id, a zero time if there is none.
+func storedReputation(t *testing.T, db *DB, id ID) (score float64, updated time.Time) {
+	t.Helper()
+	if err := db.ForEachReputation(func(k ID, s float64, u time.Time) error {
+		if k == id {
+			score, updated = s, u
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to iterate reputation: %v", err)
+	}
+	return score, updated
+}
+
+// This test checks that peer reputation is stored per ID and is not affected
+// by node expiration.
+func TestDBReputation(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := OpenDB(context.Background(), "", tmpDir, log.Root())
+	if err != nil {
+		panic(err)
+	}
+	defer db.Close()
+
+	node := nodeDBExpirationNodes[0].node
+	if _, updated := storedReputation(t, db, node.ID()); !updated.IsZero() {
+		t.Fatalf("non-existing reputation: have update time %v", updated)
+	}
+	now := time.Unix(time.Now().Unix(), 0)
+	if err := db.UpdateReputation(node.ID(), -12.5, now); err != nil {
+		t.Fatalf("failed to store reputation: %v", err)
+	}
+	if err := db.UpdateLastPongReceived(node.ID(), node.IP(), now.Add(-2*dbNodeExpiration)); err != nil {
+		t.Fatalf("failed to update pong time: %v", err)
+	}
+	db.expireNodes()
+
+	score, updated := storedReputation(t, db, node.ID())
+	if score != -12.5 || !updated.Equal(now) {
+		t.Fatalf("reputation mismatch: have (%v, %v), want (%v, %v)", score, updated, -12.5, now)
+	}
+	var seen int
+	if err := db.ForEachReputation(func(id ID, score float64, updated time.Time) error {
+		if id != node.ID() {
+			t.Errorf("unexpected reputation entry %v", id)
+		}
+		seen++
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to iterate reputation: %v", err)
+	}
+	if seen != 1 {
+		t.Fatalf("reputation entries: have %d, want 1", seen)
+	}
+	if err := db.DeleteReputation(node.ID()); err != nil {
+		t.Fatalf("failed to delete reputation: %v", err)
+	}
+	if _, updated := storedReputation(t, db, node.ID()); !updated.IsZero() {
+		t.Fatalf("deleted reputation: have update time %v", updated)
+	}
+}
@@ -50,6 +50,7 @@ type Msg struct {
 	meterCap  Cap    // Protocol name and version for egress metering
 	meterCode uint64 // Message within protocol for egress metering
 	meterSize uint32 // Compressed message size for ingress metering
+	bulk      bool   // Message may be dropped if the outbound bandwidth is limited
 }
 
 // Decode parses the RLP content of a message into
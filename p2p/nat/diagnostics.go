@@ -0,0 +1,261 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package nat
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// Report is the result of the NAT traversal diagnostics of a node.
+type Report struct {
+	Interface  string    `json:"interface"`            // configured mechanism, empty if none
+	ExternalIP net.IP    `json:"externalIP,omitempty"` // reported by the configured mechanism
+	ObservedIP net.IP    `json:"observedIP,omitempty"` // predicted from the endpoints seen by discovery peers
+	Checked    time.Time `json:"checked"`              // when the report was made
+	Probed     time.Time `json:"probed,omitempty"`     // when the mechanisms were probed
+
+	Mechanisms   []MechanismStatus `json:"mechanisms"`
+	Mappings     []MappingStatus   `json:"mappings"`
+	Reachability []Reachability    `json:"reachability"`
+	Warnings     []string          `json:"warnings,omitempty"`
+}
+
+// MechanismStatus is the result of probing a NAT traversal mechanism.
+type MechanismStatus struct {
+	Name            string        `json:"name"`
+	Device          string        `json:"device,omitempty"` // discovered gateway or server
+	Available       bool          `json:"available"`
+	ExternalIP      net.IP        `json:"externalIP,omitempty"`
+	SupportsMapping bool          `json:"supportsMapping"`
+	Latency         time.Duration `json:"latency"`
+	Error           string        `json:"error,omitempty"`
+}
+
+// MappingStatus is the state of a port mapping kept alive by Mapping.Run.
+type MappingStatus struct {
+	Protocol     string    `json:"protocol"`
+	ExternalPort int       `json:"externalPort"`
+	InternalPort int       `json:"internalPort"`
+	Mapped       bool      `json:"mapped"` // the lease hasn't expired
+	Expires      time.Time `json:"expires"`
+	Renewals     uint64    `json:"renewals"`
+	Error        string    `json:"error,omitempty"` // of the last attempt
+}
+
+// ReachableWindow is how long inbound traffic proves a port is reachable.
+const ReachableWindow = time.Hour
+
+// Reachability tells whether peers have been able to reach a listening port.
+type Reachability struct {
+	Protocol    string    `json:"protocol"`
+	Port        int       `json:"port"`
+	Reachable   bool      `json:"reachable"` // inbound traffic was seen within ReachableWindow
+	Since       time.Time `json:"since"`     // listening since
+	LastInbound time.Time `json:"lastInbound"`
+}
+
+// Probe tests the mechanism by asking it for the external IP address. Port mappings aren't
+// tried, so the ones kept alive by Mapping.Run aren't disturbed.
+func Probe(name string, m Interface) MechanismStatus {
+	start := time.Now()
+	ip, err := m.ExternalIP()
+	status := MechanismStatus{
+		Name:            name,
+		Device:          m.String(),
+		SupportsMapping: m.SupportsMapping(),
+		Latency:         time.Since(start),
+	}
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Available = true
+	status.ExternalIP = ip
+	return status
+}
+
+// ProbeAll probes the auto-discovered mechanisms concurrently. Each call discovers
+// the gateways anew, so it notices changes of the local network.
+func ProbeAll() []MechanismStatus {
+	mechanisms := []struct {
+		name string
+		m    Interface
+	}{
+		{"upnp", UPnP()},
+		{"pmp", PMP(nil)},
+		{"stun", NewSTUN("")},
+	}
+	statuses := make([]MechanismStatus, len(mechanisms))
+	var wg sync.WaitGroup
+	for i, mech := range mechanisms {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i] = Probe(mech.name, mech.m)
+		}()
+	}
+	wg.Wait()
+	return statuses
+}
+
+// cgnat is the shared address space of carrier-grade NAT, RFC 6598.
+var cgnat = &net.IPNet{IP: net.IP{100, 64, 0, 0}, Mask: net.CIDRMask(10, 32)}
+
+func isPrivate(ip net.IP) bool {
+	return ip.IsPrivate() || ip.IsLoopback() || cgnat.Contains(ip)
+}
+
+// Analyze sets the warnings explaining why the node may not be reachable.
+func (r *Report) Analyze() {
+	r.Warnings = nil
+	warn := func(format string, args ...any) {
+		r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+	}
+	for _, reach := range r.Reachability {
+		if !reach.Reachable && r.Checked.Sub(reach.Since) >= ReachableWindow {
+			warn("no inbound %s traffic on port %d, the port may not be reachable from the Internet", reach.Protocol, reach.Port)
+		}
+	}
+	for _, mapping := range r.Mappings {
+		if !mapping.Mapped {
+			warn("%s port %d isn't mapped: %s", mapping.Protocol, mapping.InternalPort, mapping.Error)
+		}
+	}
+	if r.ExternalIP != nil && isPrivate(r.ExternalIP) {
+		warn("the external IP %v of %s is private, the gateway is behind another NAT and mapped ports won't be reachable", r.ExternalIP, r.Interface)
+	}
+	if r.ExternalIP != nil && r.ObservedIP != nil && !r.ExternalIP.Equal(r.ObservedIP) {
+		warn("peers see the node at %v instead of %v", r.ObservedIP, r.ExternalIP)
+	}
+	if r.Interface == "" {
+		for _, mech := range r.Mechanisms {
+			if mech.Available && mech.SupportsMapping {
+				warn("%s is available but no NAT mechanism is configured, try --nat=%s", mech.Device, mech.Name)
+			}
+		}
+	}
+}
+
+// Mapping is a port mapping kept alive by Run, it records its state for the diagnostics.
+type Mapping struct {
+	protocol string
+	extport  int
+	intport  int
+	name     string
+	remap    chan struct{}
+
+	mu       sync.Mutex
+	expires  time.Time
+	renewals uint64
+	err      error
+}
+
+func NewMapping(protocol string, extport, intport int, name string) *Mapping {
+	return &Mapping{
+		protocol: protocol,
+		extport:  extport,
+		intport:  intport,
+		name:     name,
+		remap:    make(chan struct{}, 1),
+	}
+}
+
+// Remap asks Run to renew the mapping now, e.g. when the gateway has restarted and lost it.
+func (mp *Mapping) Remap() {
+	select {
+	case mp.remap <- struct{}{}:
+	default:
+	}
+}
+
+func (mp *Mapping) Status() MappingStatus {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	status := MappingStatus{
+		Protocol:     mp.protocol,
+		ExternalPort: mp.extport,
+		InternalPort: mp.intport,
+		Mapped:       time.Now().Before(mp.expires),
+		Expires:      mp.expires,
+		Renewals:     mp.renewals,
+	}
+	if mp.err != nil {
+		status.Error = mp.err.Error()
+	}
+	return status
+}
+
+// add adds the mapping on m and returns the delay of the next renewal, earlier after failures.
+func (mp *Mapping) add(m Interface) (time.Duration, error) {
+	err := m.AddMapping(mp.protocol, mp.extport, mp.intport, mp.name, mapTimeout)
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	mp.err = err
+	if err != nil {
+		return mapRetry, err
+	}
+	if !mp.expires.IsZero() {
+		mp.renewals++
+	}
+	mp.expires = time.Now().Add(mapTimeout)
+	return mapRefresh, nil
+}
+
+// Run adds the mapping on m and renews it before the lease expires, until c is closed.
+// This function is typically invoked in its own goroutine.
+func (mp *Mapping) Run(m Interface, c <-chan struct{}, logger log.Logger) {
+	if !m.SupportsMapping() {
+		panic("Port mapping is not supported")
+	}
+
+	logger1 := logger.New("proto", mp.protocol, "extport", mp.extport, "intport", mp.intport, "interface", m)
+	delay, err := mp.add(m)
+	if err != nil {
+		logger1.Debug("Couldn't add port mapping", "err", err)
+	} else {
+		logger1.Info("Mapped network port")
+	}
+	refresh := time.NewTimer(delay)
+	defer func() {
+		refresh.Stop()
+		logger1.Trace("Deleting port mapping")
+		m.DeleteMapping(mp.protocol, mp.extport, mp.intport)
+	}()
+	for {
+		select {
+		case _, ok := <-c:
+			if !ok {
+				return
+			}
+			continue
+		case <-refresh.C:
+			logger1.Trace("Refreshing port mapping")
+		case <-mp.remap:
+			logger1.Debug("Remapping port")
+			refresh.Stop()
+		}
+		if delay, err = mp.add(m); err != nil {
+			logger1.Debug("Couldn't add port mapping", "err", err)
+		}
+		refresh.Reset(delay)
+	}
+}
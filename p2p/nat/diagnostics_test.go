@@ -0,0 +1,174 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package nat
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// fakeGateway is a mapping mechanism which fails while broken is set.
+type fakeGateway struct {
+	mu      sync.Mutex
+	broken  bool
+	added   int
+	deleted int
+}
+
+func (g *fakeGateway) AddMapping(string, int, int, string, time.Duration) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.broken {
+		return errors.New("gateway is down")
+	}
+	g.added++
+	return nil
+}
+
+func (g *fakeGateway) DeleteMapping(string, int, int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.deleted++
+	return nil
+}
+
+func (g *fakeGateway) SupportsMapping() bool { return true }
+
+func (g *fakeGateway) ExternalIP() (net.IP, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.broken {
+		return nil, errors.New("gateway is down")
+	}
+	return net.IP{33, 44, 55, 66}, nil
+}
+
+func (g *fakeGateway) String() string { return "fake" }
+
+func (g *fakeGateway) counts() (added, deleted int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.added, g.deleted
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestMappingRemap(t *testing.T) {
+	gw := &fakeGateway{broken: true}
+	mapping := NewMapping("tcp", 30303, 30303, "test")
+	quit := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		mapping.Run(gw, quit, log.New())
+		close(done)
+	}()
+
+	waitFor(t, "the failed mapping", func() bool { return mapping.Status().Error != "" })
+	if status := mapping.Status(); status.Mapped {
+		t.Fatalf("mapped by a broken gateway: %+v", status)
+	}
+
+	gw.mu.Lock()
+	gw.broken = false
+	gw.mu.Unlock()
+	mapping.Remap()
+	waitFor(t, "the mapping", func() bool { return mapping.Status().Mapped })
+	status := mapping.Status()
+	if status.Error != "" || status.Renewals != 0 || time.Until(status.Expires) <= mapRefresh {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+
+	mapping.Remap()
+	waitFor(t, "the renewal", func() bool { return mapping.Status().Renewals == 1 })
+
+	close(quit)
+	<-done
+	if added, deleted := gw.counts(); added != 2 || deleted != 1 {
+		t.Fatalf("added %d, deleted %d mappings", added, deleted)
+	}
+}
+
+func TestProbe(t *testing.T) {
+	status := Probe("fake", &fakeGateway{})
+	if !status.Available || !status.ExternalIP.Equal(net.IP{33, 44, 55, 66}) || !status.SupportsMapping || status.Device != "fake" {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	status = Probe("fake", &fakeGateway{broken: true})
+	if status.Available || status.Error == "" {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
+
+func TestReportAnalyze(t *testing.T) {
+	now := time.Now()
+	report := &Report{
+		Interface:  "UPNP IGDv1-IP1",
+		ExternalIP: net.IP{100, 64, 1, 2},
+		ObservedIP: net.IP{33, 44, 55, 66},
+		Checked:    now,
+		Mappings: []MappingStatus{
+			{Protocol: "tcp", ExternalPort: 30303, InternalPort: 30303, Mapped: true},
+			{Protocol: "udp", ExternalPort: 30303, InternalPort: 30303, Error: "gateway is down"},
+		},
+		Reachability: []Reachability{
+			{Protocol: "tcp", Port: 30303, Since: now.Add(-2 * ReachableWindow)},
+			{Protocol: "udp", Port: 30303, Since: now.Add(-time.Minute)}, // too early to tell
+			{Protocol: "tcp", Port: 30304, Since: now.Add(-2 * ReachableWindow), Reachable: true},
+		},
+	}
+	report.Analyze()
+	want := []string{
+		"no inbound tcp traffic on port 30303",
+		"udp port 30303 isn't mapped: gateway is down",
+		"the external IP 100.64.1.2 of UPNP IGDv1-IP1 is private",
+		"peers see the node at 33.44.55.66 instead of 100.64.1.2",
+	}
+	if len(report.Warnings) != len(want) {
+		t.Fatalf("unexpected warnings: %q", report.Warnings)
+	}
+	for i, w := range want {
+		if !strings.HasPrefix(report.Warnings[i], w) {
+			t.Errorf("warning %d: got %q, want %q", i, report.Warnings[i], w)
+		}
+	}
+
+	// Available mechanisms are suggested when none is configured.
+	report = &Report{Checked: now, Mechanisms: []MechanismStatus{
+		{Name: "upnp", Device: "UPNP IGDv2-IP1", Available: true, SupportsMapping: true},
+		{Name: "pmp", Device: "NAT-PMP", Error: "no NAT-PMP router discovered"},
+		{Name: "stun", Device: "STUN(stun.l.google.com:19302)", Available: true},
+	}}
+	report.Analyze()
+	if len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0], "--nat=upnp") {
+		t.Fatalf("unexpected warnings: %q", report.Warnings)
+	}
+}
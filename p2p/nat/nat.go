@@ -109,41 +109,14 @@ func Parse(spec string) (Interface, error) {
 
 const (
 	mapTimeout = 10 * time.Minute
+	mapRefresh = 8 * time.Minute // renew before the lease expires
+	mapRetry   = time.Minute     // after a failure
 )
 
 // Map adds a port mapping on m and keeps it alive until c is closed.
 // This function is typically invoked in its own goroutine.
 func Map(m Interface, c <-chan struct{}, protocol string, extport, intport int, name string, logger log.Logger) {
-	if !m.SupportsMapping() {
-		panic("Port mapping is not supported")
-	}
-
-	logger1 := logger.New("proto", protocol, "extport", extport, "intport", intport, "interface", m)
-	refresh := time.NewTimer(mapTimeout)
-	defer func() {
-		refresh.Stop()
-		logger1.Trace("Deleting port mapping")
-		m.DeleteMapping(protocol, extport, intport)
-	}()
-	if err := m.AddMapping(protocol, extport, intport, name, mapTimeout); err != nil {
-		logger1.Debug("Couldn't add port mapping", "err", err)
-	} else {
-		logger1.Info("Mapped network port")
-	}
-	for {
-		select {
-		case _, ok := <-c:
-			if !ok {
-				return
-			}
-		case <-refresh.C:
-			logger1.Trace("Refreshing port mapping")
-			if err := m.AddMapping(protocol, extport, intport, name, mapTimeout); err != nil {
-				logger1.Debug("Couldn't add port mapping", "err", err)
-			}
-			refresh.Reset(mapTimeout)
-		}
-	}
+	NewMapping(protocol, extport, intport, name).Run(m, c, logger)
 }
 
 // ExtIP assumes that the local machine is reachable on the given
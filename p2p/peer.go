@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -376,6 +377,7 @@ func (p *Peer) handle(msg Msg) error {
 			return fmt.Errorf("msg code out of range: %v", msg.Code)
 		}
 
+		p.rw.traffic.addIngress(proto.cap(), msg.Code-proto.offset, msg.meterSize)
 		if p.metricsEnabled {
 			m := fmt.Sprintf("%s_%s_%d_%#02x", ingressMeterName, proto.Name, proto.Version, msg.Code-proto.offset)
 			metrics.GetOrCreateGauge(m).SetUint32(msg.meterSize)
@@ -484,6 +486,7 @@ func (rw *protoRW) WriteMsg(msg Msg) (err error) {
 
 	msg.meterCap = rw.cap()
 	msg.meterCode = msg.Code
+	msg.bulk = slices.Contains(rw.BulkMsgs, msg.Code)
 	msg.Code += rw.offset
 
 	select {
@@ -539,7 +542,9 @@ type PeerInfo struct {
 		Trusted       bool   `json:"trusted"`
 		Static        bool   `json:"static"`
 	} `json:"network"`
-	Protocols map[string]interface{} `json:"protocols"` // Sub-protocol specific metadata fields
+	Protocols  map[string]interface{} `json:"protocols"`            // Sub-protocol specific metadata fields
+	Reputation *ReputationInfo        `json:"reputation,omitempty"` // Peer score, see Reputation
+	Traffic    TrafficInfo            `json:"traffic,omitempty"`    // Traffic per protocol message
 }
 
 // Info gathers and returns a collection of metadata known about a peer.
@@ -556,6 +561,7 @@ func (p *Peer) Info() *PeerInfo {
 		Name:      p.Fullname(),
 		Caps:      caps,
 		Protocols: make(map[string]interface{}),
+		Traffic:   p.rw.traffic.info(),
 	}
 	if p.Node().Seq() > 0 {
 		info.ENR = p.Node().String()
@@ -64,6 +64,10 @@ type Protocol struct {
 
 	// Attributes contains protocol specific information for the node record.
 	Attributes []enr.Entry
+
+	// BulkMsgs are codes of best-effort messages, e.g. transaction gossip. When the
+	// outbound bandwidth is limited, they are dropped instead of delaying others.
+	BulkMsgs []uint64
 }
 
 func (p Protocol) cap() Cap {
@@ -0,0 +1,353 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/p2p/enode"
+)
+
+const (
+	// Scores are clamped to [-maxReputation, maxReputation] and decay towards zero,
+	// so that old behaviour is eventually forgotten.
+	maxReputation       = 100
+	reputationHalfLife  = 24 * time.Hour
+	reputationForgetAbs = 0.5 // entries decayed below this are deleted from the node database
+
+	// Responses faster than fastResponse earn the full reward, responses slower
+	// than slowResponse are penalised; the score change is linear in between.
+	fastResponse    = time.Second
+	slowResponse    = 10 * time.Second
+	responseReward  = 1.0
+	slowResponsePen = -3.0
+
+	timeoutPenalty      = -5.0
+	invalidTxPenalty    = -10.0
+	kickPenalty         = -25.0
+	invalidBlockPenalty = -50.0
+
+	// Entries that have not been touched for this long are dropped from memory on
+	// flush, they are still available in the node database.
+	reputationIdleTimeout = time.Hour
+
+	// A connected peer is only evicted in favour of a new one if the newcomer is
+	// better by at least this margin.
+	evictionMargin = 10.0
+
+	// Dynamic dial candidates scoring below minDialReputation are not dialed.
+	minDialReputation = -20.0
+	// Peers scoring at least minPriorityReputation are dialed before discovery results.
+	minPriorityReputation = 5.0
+)
+
+// ReputationEvent is a peer behaviour affecting its reputation.
+type ReputationEvent uint8
+
+const (
+	ReputationTimeout      ReputationEvent = iota // request was not answered in time
+	ReputationInvalidTx                           // peer sent an invalid transaction
+	ReputationInvalidBlock                        // peer sent an invalid block or header
+	ReputationKick                                // peer was penalized and disconnected
+)
+
+func (e ReputationEvent) penalty() float64 {
+	switch e {
+	case ReputationTimeout:
+		return timeoutPenalty
+	case ReputationInvalidTx:
+		return invalidTxPenalty
+	case ReputationInvalidBlock:
+		return invalidBlockPenalty
+	case ReputationKick:
+		return kickPenalty
+	default:
+		return 0
+	}
+}
+
+// ReputationInfo is the reputation summary of a peer reported by admin_peers.
+// The score is persistent, counters cover the current process lifetime only.
+type ReputationInfo struct {
+	Score     float64 `json:"score"`
+	LatencyMs uint64  `json:"latencyMs"` // moving average of the response latency
+	Responses uint64  `json:"responses"`
+	Timeouts  uint64  `json:"timeouts"`
+	Invalid   uint64  `json:"invalid"` // invalid blocks and transactions
+}
+
+type reputationEntry struct {
+	score   float64
+	updated time.Time // last score change, used for decay
+	touched time.Time // last access, used for memory eviction
+	dirty   bool
+
+	latency   time.Duration
+	responses uint64
+	timeouts  uint64
+	invalid   uint64
+}
+
+// storedReputation is a score as written to the node database.
+type storedReputation struct {
+	score   float64
+	updated time.Time
+}
+
+// Reputation scores peers by the usefulness of their responses and persists
+// the scores per node ID in the node database. A nil *Reputation is valid and
+// treats every peer as neutral.
+type Reputation struct {
+	db     *enode.DB
+	now    func() time.Time
+	logger log.Logger
+
+	lock  sync.Mutex
+	peers map[enode.ID]*reputationEntry
+	// stored mirrors the scores in the node database, so that looking up
+	// peers without an in-memory entry, e.g. dial candidates, is not a database read.
+	stored map[enode.ID]storedReputation
+}
+
+// NewReputation creates a peer scorer backed by the given node database
+// and loads the stored scores.
+func NewReputation(db *enode.DB, logger log.Logger) *Reputation {
+	r := &Reputation{
+		db:     db,
+		now:    time.Now,
+		logger: logger,
+		peers:  make(map[enode.ID]*reputationEntry),
+		stored: make(map[enode.ID]storedReputation),
+	}
+	if err := db.ForEachReputation(func(id enode.ID, score float64, updated time.Time) error {
+		r.stored[id] = storedReputation{score, updated}
+		return nil
+	}); err != nil {
+		logger.Debug("[p2p] Failed to read peer reputation", "err", err)
+	}
+	return r
+}
+
+// entry returns the in-memory entry of a peer, starting from its stored
+// score if needed. The score of the returned entry is decayed to now.
+// Must be called with r.lock held.
+func (r *Reputation) entry(id enode.ID, now time.Time) *reputationEntry {
+	e, ok := r.peers[id]
+	if !ok {
+		e = &reputationEntry{updated: now}
+		if stored, ok := r.stored[id]; ok {
+			e.score, e.updated = stored.score, stored.updated
+		}
+		r.peers[id] = e
+	}
+	if elapsed := now.Sub(e.updated); elapsed > 0 {
+		e.score = decayReputation(e.score, elapsed)
+		e.updated = now
+	}
+	e.touched = now
+	return e
+}
+
+func decayReputation(score float64, elapsed time.Duration) float64 {
+	return score * math.Pow(0.5, float64(elapsed)/float64(reputationHalfLife))
+}
+
+func (e *reputationEntry) add(delta float64) {
+	e.score = math.Max(-maxReputation, math.Min(maxReputation, e.score+delta))
+	e.dirty = true
+}
+
+// responseScore maps a response latency to a score change.
+func responseScore(latency time.Duration) float64 {
+	switch {
+	case latency <= fastResponse:
+		return responseReward
+	case latency >= slowResponse:
+		return slowResponsePen
+	default:
+		frac := float64(latency-fastResponse) / float64(slowResponse-fastResponse)
+		return responseReward + frac*(slowResponsePen-responseReward)
+	}
+}
+
+// RecordResponse records a useful response of the peer delivered after the given latency.
+func (r *Reputation) RecordResponse(id enode.ID, latency time.Duration) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	e := r.entry(id, r.now())
+	e.add(responseScore(latency))
+	e.responses++
+	if e.latency == 0 {
+		e.latency = latency
+	} else {
+		e.latency = (e.latency*7 + latency) / 8
+	}
+}
+
+// Record records a misbehaviour of the peer.
+func (r *Reputation) Record(id enode.ID, ev ReputationEvent) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	e := r.entry(id, r.now())
+	e.add(ev.penalty())
+	switch ev {
+	case ReputationTimeout:
+		e.timeouts++
+	case ReputationInvalidTx, ReputationInvalidBlock:
+		e.invalid++
+	}
+}
+
+// Score returns the current score of the peer, zero for unknown peers.
+func (r *Reputation) Score(id enode.ID) float64 {
+	if r == nil {
+		return 0
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	now := r.now()
+	if _, ok := r.peers[id]; ok {
+		return r.entry(id, now).score
+	}
+	// Don't cache peers which are only looked up, e.g. dial candidates
+	if stored, ok := r.stored[id]; ok {
+		return decayReputation(stored.score, now.Sub(stored.updated))
+	}
+	return 0
+}
+
+// Info returns the reputation summary of the peer.
+func (r *Reputation) Info(id enode.ID) *ReputationInfo {
+	if r == nil {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	e := r.entry(id, r.now())
+	return &ReputationInfo{
+		Score:     e.score,
+		LatencyMs: uint64(e.latency.Milliseconds()),
+		Responses: e.responses,
+		Timeouts:  e.timeouts,
+		Invalid:   e.invalid,
+	}
+}
+
+// Flush writes changed scores to the node database, deletes the stored scores which
+// decayed back to neutral and drops idle entries from memory.
+func (r *Reputation) Flush() {
+	if r == nil {
+		return
+	}
+	type change struct {
+		id      enode.ID
+		score   float64
+		updated time.Time
+		forget  bool
+	}
+	var changes []change
+	r.lock.Lock()
+	now := r.now()
+	for id, e := range r.peers {
+		if e.dirty {
+			c := change{id: id, score: e.score, updated: e.updated, forget: math.Abs(e.score) < reputationForgetAbs}
+			if c.forget {
+				delete(r.stored, id)
+			} else {
+				r.stored[id] = storedReputation{c.score, c.updated}
+			}
+			changes = append(changes, c)
+			e.dirty = false
+		}
+		if now.Sub(e.touched) > reputationIdleTimeout {
+			delete(r.peers, id)
+		}
+	}
+	for id, stored := range r.stored {
+		if _, ok := r.peers[id]; ok {
+			continue
+		}
+		if math.Abs(decayReputation(stored.score, now.Sub(stored.updated))) < reputationForgetAbs {
+			delete(r.stored, id)
+			changes = append(changes, change{id: id, forget: true})
+		}
+	}
+	r.lock.Unlock()
+
+	for _, c := range changes {
+		var err error
+		if c.forget {
+			err = r.db.DeleteReputation(c.id)
+		} else {
+			err = r.db.UpdateReputation(c.id, c.score, c.updated)
+		}
+		if err != nil {
+			r.logger.Debug("[p2p] Failed to store peer reputation", "id", c.id, "err", err)
+		}
+	}
+}
+
+// Best returns up to n known nodes with a score of at least minScore, best first.
+// Nodes without a record in the node database are skipped.
+func (r *Reputation) Best(n int, minScore float64) []*enode.Node {
+	if r == nil || n <= 0 {
+		return nil
+	}
+	type scored struct {
+		id    enode.ID
+		score float64
+	}
+	r.lock.Lock()
+	now := r.now()
+	scores := make(map[enode.ID]float64, len(r.peers)+len(r.stored))
+	for id, stored := range r.stored {
+		scores[id] = decayReputation(stored.score, now.Sub(stored.updated))
+	}
+	for id, e := range r.peers {
+		scores[id] = decayReputation(e.score, now.Sub(e.updated))
+	}
+	r.lock.Unlock()
+
+	candidates := make([]scored, 0, len(scores))
+	for id, score := range scores {
+		if score >= minScore {
+			candidates = append(candidates, scored{id, score})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].score > candidates[j].score })
+
+	nodes := make([]*enode.Node, 0, n)
+	for _, c := range candidates {
+		if len(nodes) == n {
+			break
+		}
+		if node := r.db.Node(c.id); node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/p2p/enode"
+)
+
+func newTestReputation(t *testing.T, db *enode.DB, now *time.Time) *Reputation {
+	t.Helper()
+	r := NewReputation(db, log.Root())
+	r.now = func() time.Time { return *now }
+	return r
+}
+
+func openTestNodeDB(t *testing.T) *enode.DB {
+	t.Helper()
+	db, err := enode.OpenDB(context.Background(), "", t.TempDir(), log.Root())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(db.Close)
+	return db
+}
+
+// reputationStored reports whether db holds a reputation score for id.
+func reputationStored(t *testing.T, db *enode.DB, id enode.ID) bool {
+	t.Helper()
+	var stored bool
+	if err := db.ForEachReputation(func(k enode.ID, _ float64, _ time.Time) error {
+		stored = stored || k == id
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return stored
+}
+
+func TestReputationScoring(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	r := newTestReputation(t, openTestNodeDB(t), &now)
+	fast, slow, bad := uintID(1), uintID(2), uintID(3)
+
+	for i := 0; i < 10; i++ {
+		r.RecordResponse(fast, 100*time.Millisecond)
+		r.RecordResponse(slow, 20*time.Second)
+	}
+	r.Record(slow, ReputationTimeout)
+	r.Record(bad, ReputationInvalidBlock)
+	r.Record(bad, ReputationInvalidBlock)
+	r.Record(bad, ReputationInvalidBlock)
+
+	if s := r.Score(fast); s != 10*responseReward {
+		t.Errorf("fast peer score: have %v, want %v", s, 10*responseReward)
+	}
+	if s := r.Score(slow); s != 10*slowResponsePen+timeoutPenalty {
+		t.Errorf("slow peer score: have %v, want %v", s, 10*slowResponsePen+timeoutPenalty)
+	}
+	if s := r.Score(bad); s != -maxReputation {
+		t.Errorf("invalid peer score not clamped: have %v", s)
+	}
+	info := r.Info(slow)
+	if info.Responses != 10 || info.Timeouts != 1 || info.LatencyMs != 20000 {
+		t.Errorf("slow peer info mismatch: %+v", info)
+	}
+	if info := r.Info(bad); info.Invalid != 3 {
+		t.Errorf("invalid peer counter: have %d, want 3", info.Invalid)
+	}
+	if s := responseScore(5500 * time.Millisecond); s != -1 {
+		t.Errorf("intermediate latency score: have %v, want -1", s)
+	}
+}
+
+func TestReputationPersistence(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	db := openTestNodeDB(t)
+	id := uintID(1)
+
+	r := newTestReputation(t, db, &now)
+	r.Record(id, ReputationKick)
+	r.Flush()
+
+	// A new scorer on the same database sees the stored score, decayed by the elapsed time.
+	now = now.Add(reputationHalfLife)
+	r = newTestReputation(t, db, &now)
+	if s := r.Score(id); math.Abs(s-kickPenalty/2) > 1e-9 {
+		t.Errorf("decayed score: have %v, want %v", s, kickPenalty/2)
+	}
+
+	// Scores which are back to neutral are removed from the database.
+	id = uintID(2)
+	r.RecordResponse(id, 0)
+	r.Flush()
+	if !reputationStored(t, db, id) {
+		t.Fatal("reputation entry was not stored")
+	}
+	r.RecordResponse(id, 5500*time.Millisecond)
+	r.Flush()
+	if reputationStored(t, db, id) {
+		t.Fatal("neutral reputation entry was not removed")
+	}
+}
+
+func TestReputationForgetStored(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	db := openTestNodeDB(t)
+	kicked, responsive := uintID(1), uintID(2)
+
+	r := newTestReputation(t, db, &now)
+	r.Record(kicked, ReputationKick)
+	r.RecordResponse(responsive, 0)
+	r.Flush()
+
+	// Scores are loaded once: later database changes are not looked up.
+	if err := db.UpdateReputation(uintID(3), kickPenalty, now); err != nil {
+		t.Fatal(err)
+	}
+	if s := r.Score(uintID(3)); s != 0 {
+		t.Errorf("score of a peer stored after startup: have %v, want 0", s)
+	}
+
+	// Stored scores of peers which are not seen again are deleted once they
+	// decayed back to neutral, the others are kept.
+	now = now.Add(6 * reputationHalfLife)
+	r = newTestReputation(t, db, &now)
+	r.RecordResponse(responsive, 0)
+	r.Flush()
+	if reputationStored(t, db, kicked) {
+		t.Error("decayed reputation entry was not removed")
+	}
+	if s := r.Score(kicked); s != 0 {
+		t.Errorf("forgotten peer score: have %v, want 0", s)
+	}
+	if !reputationStored(t, db, responsive) {
+		t.Error("reputation entry of an active peer was removed")
+	}
+}
+
+func TestReputationBest(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	db := openTestNodeDB(t)
+	r := newTestReputation(t, db, &now)
+
+	good, better, unknown, bad := uintID(1), uintID(2), uintID(3), uintID(4)
+	for i, id := range []enode.ID{good, better, bad} {
+		if err := db.UpdateNode(newNode(id, "127.0.0.1:30303")); err != nil {
+			t.Fatalf("node %d: %v", i, err)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		r.RecordResponse(good, 0)
+		r.RecordResponse(better, 0)
+		r.RecordResponse(unknown, 0)
+	}
+	r.RecordResponse(better, 0)
+	r.Record(bad, ReputationInvalidBlock)
+	r.Flush()
+
+	best := r.Best(10, minPriorityReputation)
+	if len(best) != 2 || best[0].ID() != better || best[1].ID() != good {
+		t.Fatalf("unexpected priority candidates: %v", best)
+	}
+	if best := r.Best(1, minPriorityReputation); len(best) != 1 || best[0].ID() != better {
+		t.Fatalf("unexpected limited priority candidates: %v", best)
+	}
+}
+
+func TestReputationNil(t *testing.T) {
+	var r *Reputation
+	r.RecordResponse(uintID(1), time.Second)
+	r.Record(uintID(1), ReputationTimeout)
+	r.Flush()
+	if s := r.Score(uintID(1)); s != 0 {
+		t.Errorf("nil scorer returned score %v", s)
+	}
+	if info := r.Info(uintID(1)); info != nil {
+		t.Errorf("nil scorer returned info %+v", info)
+	}
+	if best := r.Best(1, 0); best != nil {
+		t.Errorf("nil scorer returned candidates %v", best)
+	}
+}
@@ -58,6 +58,7 @@ import (
 	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/p2p/dnsdisc"
 	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/p2p/nat"
 	"github.com/erigontech/erigon/params"
 )
 
@@ -66,6 +67,9 @@ const (
 	// complete before dropping the connection.= as malicious.
 	handshakeTimeout  = 5 * time.Second
 	maxPermitsPerPeer = 4 // How many outstanding requests per peer we may have
+
+	// Peers sending invalid transactions are only kicked once their reputation drops below this score
+	invalidTxKickReputation = -30
 )
 
 // PeerInfo collects various extra bits of information about the peer,
@@ -73,8 +77,9 @@ const (
 type PeerInfo struct {
 	peer          *p2p.Peer
 	lock          sync.RWMutex
-	deadlines     []time.Time // Request deadlines
+	deadlines     []requestDeadline
 	latestDealine time.Time
+	reputation    *p2p.Reputation
 	height        uint64
 	rw            p2p.MsgReadWriter
 	protocol      uint
@@ -93,6 +98,12 @@ type PeerInfo struct {
 	tasks chan func()
 }
 
+// requestDeadline is a request sent to the peer which is waiting for a response
+type requestDeadline struct {
+	sent     time.Time
+	deadline time.Time
+}
+
 type PeerRef struct {
 	pi     *PeerInfo
 	height uint64
@@ -172,13 +183,13 @@ func (pi *PeerInfo) ID() [64]byte {
 	return pi.peer.Pubkey()
 }
 
-// AddDeadline adds given deadline to the list of deadlines
+// AddDeadline adds given deadline of a request sent at the given time to the list of deadlines
 // Deadlines must be added in the chronological order for the function
 // ClearDeadlines to work correctly (it uses binary search)
-func (pi *PeerInfo) AddDeadline(deadline time.Time) {
+func (pi *PeerInfo) AddDeadline(sent, deadline time.Time) {
 	pi.lock.Lock()
 	defer pi.lock.Unlock()
-	pi.deadlines = append(pi.deadlines, deadline)
+	pi.deadlines = append(pi.deadlines, requestDeadline{sent: sent, deadline: deadline})
 	pi.latestDealine = deadline
 }
 
@@ -199,20 +210,40 @@ func (pi *PeerInfo) SetIncreasedHeight(newHeight uint64) {
 // ClearDeadlines goes through the deadlines of
 // given peers and removes the ones that have passed
 // Optionally, it also clears one extra deadline - this is used when response is received
+// Passed deadlines count as timeouts and the response as a useful one for the peer reputation
 // It returns the number of deadlines left
 func (pi *PeerInfo) ClearDeadlines(now time.Time, givePermit bool) int {
 	pi.lock.Lock()
-	defer pi.lock.Unlock()
 	// Look for the first deadline which is not passed yet
 	firstNotPassed := sort.Search(len(pi.deadlines), func(i int) bool {
-		return pi.deadlines[i].After(now)
+		return pi.deadlines[i].deadline.After(now)
 	})
 	cutOff := firstNotPassed
-	if cutOff < len(pi.deadlines) && givePermit {
+	var latency time.Duration
+	responded := cutOff < len(pi.deadlines) && givePermit
+	if responded {
+		latency = now.Sub(pi.deadlines[cutOff].sent)
 		cutOff++
 	}
 	pi.deadlines = pi.deadlines[cutOff:]
-	return len(pi.deadlines)
+	left := len(pi.deadlines)
+	pi.lock.Unlock()
+
+	if pi.reputation != nil {
+		id := pi.peer.ID()
+		for i := 0; i < firstNotPassed; i++ {
+			pi.reputation.Record(id, p2p.ReputationTimeout)
+		}
+		if responded {
+			pi.reputation.RecordResponse(id, latency)
+		}
+	}
+	return left
+}
+
+// Score returns the reputation score of the peer
+func (pi *PeerInfo) Score() float64 {
+	return pi.reputation.Score(pi.peer.ID())
 }
 
 func (pi *PeerInfo) LatestDeadline() time.Time {
@@ -655,6 +686,7 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 		Version:        protocol,
 		Length:         17,
 		DialCandidates: disc,
+		BulkMsgs:       []uint64{eth.TransactionsMsg, eth.NewPooledTransactionHashesMsg},
 		Run: func(peer *p2p.Peer, rw p2p.MsgReadWriter) *p2p.PeerError {
 			peerID := peer.Pubkey()
 			printablePeerID := hex.EncodeToString(peerID[:])[:20]
@@ -665,6 +697,7 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 
 			peerInfo := NewPeerInfo(peer, rw)
 			peerInfo.protocol = protocol
+			peerInfo.reputation = ss.reputation()
 			defer peerInfo.Close()
 
 			defer ss.GoodPeers.Delete(peerID)
@@ -750,7 +783,9 @@ type GrpcServer struct {
 	TxSubscribed         uint32 // Set to non-zero if downloader is subscribed to transaction messages
 	p2pServer            *p2p.Server
 	p2pServerLock        sync.RWMutex
+	priorityNodes        []enode.ID // applied to p2pServer once it is started
 	statusData           *proto_sentry.StatusData
+	bscHistory           *bscHistory // advertised in the `bsc` ENR entry if set
 	statusDataLock       sync.RWMutex
 	messageStreams       map[proto_sentry.MessageId]map[uint64]chan *proto_sentry.InboundMessage
 	messagesSubscriberID uint64
@@ -803,7 +838,8 @@ func (ss *GrpcServer) writePeer(logPrefix string, peerInfo *PeerInfo, msgcode ui
 			ss.GoodPeers.Delete(peerInfo.ID())
 		} else {
 			if ttl > 0 {
-				peerInfo.AddDeadline(time.Now().Add(ttl))
+				now := time.Now()
+				peerInfo.AddDeadline(now, now.Add(ttl))
 			}
 		}
 	}, ss.logger)
@@ -838,7 +874,21 @@ func (ss *GrpcServer) PenalizePeer(_ context.Context, req *proto_sentry.Penalize
 	//log.Warn("Received penalty", "kind", req.GetPenalty().Descriptor().FullName, "from", fmt.Sprintf("%s", req.GetPeerId()))
 	peerID := ConvertH512ToPeerID(req.PeerId)
 	peerInfo := ss.getPeer(peerID)
-	if ss.statusData != nil && peerInfo != nil && !peerInfo.peer.Info().Network.Static && !peerInfo.peer.Info().Network.Trusted {
+	if peerInfo == nil {
+		return &emptypb.Empty{}, nil
+	}
+	kick := true
+	switch req.Penalty {
+	case proto_sentry.PenaltyKind_InvalidBlock:
+		peerInfo.reputation.Record(peerInfo.peer.ID(), p2p.ReputationInvalidBlock)
+	case proto_sentry.PenaltyKind_InvalidTx:
+		// A single bad transaction is not worth a connection, keep the peer until its score drops too low
+		peerInfo.reputation.Record(peerInfo.peer.ID(), p2p.ReputationInvalidTx)
+		kick = peerInfo.Score() < invalidTxKickReputation
+	default:
+		peerInfo.reputation.Record(peerInfo.peer.ID(), p2p.ReputationKick)
+	}
+	if kick && ss.statusData != nil && !peerInfo.peer.Info().Network.Static && !peerInfo.peer.Info().Network.Trusted {
 		ss.removePeer(peerID, p2p.NewPeerError(p2p.PeerErrorDiscReason, p2p.DiscRequested, nil, "penalized peer"))
 	}
 	return &emptypb.Empty{}, nil
@@ -891,8 +941,10 @@ func (ss *GrpcServer) findBestPeersWithPermit(peerCount int) []*PeerInfo {
 
 func (ss *GrpcServer) findPeerByMinBlock(minBlock uint64) (*PeerInfo, bool) {
 	// Choose a peer that we can send this request to, with maximum number of permits
+	// Peers with the same number of permits are ordered by reputation
 	var foundPeerInfo *PeerInfo
 	var maxPermits int
+	var foundScore float64
 	now := time.Now()
 	ss.rangePeers(func(peerInfo *PeerInfo) bool {
 		if peerInfo.Height() >= minBlock {
@@ -900,9 +952,11 @@ func (ss *GrpcServer) findPeerByMinBlock(minBlock uint64) (*PeerInfo, bool) {
 			//fmt.Printf("%d deadlines for peer %s\n", deadlines, peerID)
 			if deadlines < maxPermitsPerPeer {
 				permits := maxPermitsPerPeer - deadlines
-				if permits > maxPermits {
+				score := peerInfo.Score()
+				if permits > maxPermits || (permits == maxPermits && score > foundScore) {
 					maxPermits = permits
 					foundPeerInfo = peerInfo
+					foundScore = score
 				}
 			}
 		}
@@ -981,11 +1035,20 @@ func (ss *GrpcServer) SendMessageToRandomPeers(ctx context.Context, req *proto_s
 		return reply, fmt.Errorf("sendMessageToRandomPeers not implemented for message Id: %s", req.Data.Id)
 	}
 
+	// Priority peers, e.g. validators behind this sentry, get every message in
+	// addition to the random subset.
+	srv := ss.getP2PServer()
 	peerInfos := make([]*PeerInfo, 0, 100)
 	ss.rangePeers(func(peerInfo *PeerInfo) bool {
-		if protocolVersions.Contains(peerInfo.protocol) {
-			peerInfos = append(peerInfos, peerInfo)
+		if !protocolVersions.Contains(peerInfo.protocol) {
+			return true
+		}
+		if srv != nil && srv.IsPriority(peerInfo.peer.ID()) {
+			ss.writePeer("[sentry] sendMessageToRandomPeers", peerInfo, msgcode, req.Data.Data, 0)
+			reply.Peers = append(reply.Peers, gointerfaces.ConvertHashToH512(peerInfo.ID()))
+			return true
 		}
+		peerInfos = append(peerInfos, peerInfo)
 		return true
 	})
 	rand.Shuffle(len(peerInfos), func(i int, j int) {
@@ -1058,7 +1121,11 @@ func (ss *GrpcServer) startP2PServer(genesisHash libcommon.Hash) (*p2p.Server, e
 		}
 	}
 
-	srv, err := makeP2PServer(*ss.p2p, genesisHash, ss.Protocols)
+	p2pConfig := *ss.p2p
+	if chainConfig := params.ChainConfigByGenesisHash(genesisHash); chainConfig != nil && chainConfig.Parlia != nil && p2pConfig.DiscoveryFilter == nil {
+		p2pConfig.DiscoveryFilter = eth.NewBscNodeFilter(chainConfig.ChainName, ss.bscHistoryNeeded)
+	}
+	srv, err := makeP2PServer(p2pConfig, genesisHash, ss.Protocols)
 	if err != nil {
 		return nil, err
 	}
@@ -1071,6 +1138,26 @@ func (ss *GrpcServer) startP2PServer(genesisHash libcommon.Hash) (*p2p.Server, e
 	return srv, nil
 }
 
+// reputation returns the peer scorer of the running p2p server, if any
+func (ss *GrpcServer) reputation() *p2p.Reputation {
+	if srv := ss.getP2PServer(); srv != nil {
+		return srv.Reputation()
+	}
+	return nil
+}
+
+// SetPriorityNodes replaces the priority peers which receive all broadcast blocks
+// and transactions, see p2p.Server.SetPriorityNodes.
+func (ss *GrpcServer) SetPriorityNodes(ids []enode.ID) {
+	ss.p2pServerLock.Lock()
+	ss.priorityNodes = ids
+	srv := ss.p2pServer
+	ss.p2pServerLock.Unlock()
+	if srv != nil {
+		srv.SetPriorityNodes(ids)
+	}
+}
+
 func (ss *GrpcServer) getP2PServer() *p2p.Server {
 	ss.p2pServerLock.RLock()
 	defer ss.p2pServerLock.RUnlock()
@@ -1089,6 +1176,7 @@ func (ss *GrpcServer) SetStatus(ctx context.Context, statusData *proto_sentry.St
 		if err != nil {
 			return reply, err
 		}
+		srv.SetPriorityNodes(ss.priorityNodes)
 		ss.p2pServer = srv
 	}
 
@@ -1100,9 +1188,50 @@ func (ss *GrpcServer) SetStatus(ctx context.Context, statusData *proto_sentry.St
 		// Not overwrite statusData if the message contains zero MaxBlock (comes from standalone transaction pool)
 		ss.statusData = statusData
 	}
+	if ss.bscHistory != nil {
+		if chainConfig := params.ChainConfigByGenesisHash(genesisHash); chainConfig != nil && chainConfig.Parlia != nil {
+			head := ss.statusData.MaxBlockHeight
+			ss.p2pServer.LocalNode().Set(eth.NewBscENREntry(chainConfig.ChainName, ss.bscHistory.historyFrom(head), ss.bscHistory.blobsFrom(head)))
+		}
+	}
 	return reply, nil
 }
 
+// bscHistory is the history served by a BSC node.
+type bscHistory struct {
+	historyFrom func(head uint64) uint64 // first block with bodies and receipts
+	blobBlocks  uint64                   // number of most recent blocks with blob sidecars
+}
+
+func (h *bscHistory) blobsFrom(head uint64) uint64 {
+	if h.blobBlocks >= head {
+		return 0
+	}
+	return head - h.blobBlocks
+}
+
+// SetBscHistory makes a BSC node advertise the history it serves in the `bsc` ENR entry.
+// historyFrom returns the first block with bodies and receipts for the given head,
+// blobBlocks is the number of most recent blocks whose blob sidecars are kept.
+// Must be called before the first SetStatus.
+func (ss *GrpcServer) SetBscHistory(historyFrom func(head uint64) uint64, blobBlocks uint64) {
+	ss.statusDataLock.Lock()
+	defer ss.statusDataLock.Unlock()
+	ss.bscHistory = &bscHistory{historyFrom: historyFrom, blobBlocks: blobBlocks}
+}
+
+// bscHistoryNeeded returns the first block needed from peers. Its blob sidecars are
+// needed if this node keeps all of them.
+func (ss *GrpcServer) bscHistoryNeeded() (uint64, bool) {
+	ss.statusDataLock.RLock()
+	defer ss.statusDataLock.RUnlock()
+	var block uint64
+	if ss.statusData != nil {
+		block = ss.statusData.MaxBlockHeight + 1
+	}
+	return block, ss.bscHistory != nil && ss.bscHistory.blobBlocks == math.MaxUint64
+}
+
 func (ss *GrpcServer) Peers(_ context.Context, _ *emptypb.Empty) (*proto_sentry.PeersReply, error) {
 	p2pServer := ss.getP2PServer()
 	if p2pServer == nil {
@@ -1126,6 +1255,8 @@ func (ss *GrpcServer) Peers(_ context.Context, _ *emptypb.Empty) (*proto_sentry.
 			ConnIsInbound:  peer.Network.Inbound,
 			ConnIsTrusted:  peer.Network.Trusted,
 			ConnIsStatic:   peer.Network.Static,
+			Reputation:     reputationToProto(peer.Reputation),
+			Traffic:        trafficToProto(peer.Traffic),
 		}
 		reply.Peers = append(reply.Peers, &rpcPeer)
 	}
@@ -1171,12 +1302,108 @@ func (ss *GrpcServer) PeerById(_ context.Context, req *proto_sentry.PeerByIdRequ
 			ConnIsInbound:  peer.Network.Inbound,
 			ConnIsTrusted:  peer.Network.Trusted,
 			ConnIsStatic:   peer.Network.Static,
+			Reputation:     reputationToProto(sentryPeer.reputation.Info(sentryPeer.peer.ID())),
+			Traffic:        trafficToProto(peer.Traffic),
 		}
 	}
 
 	return &proto_sentry.PeerByIdReply{Peer: rpcPeer}, nil
 }
 
+func reputationToProto(info *p2p.ReputationInfo) *proto_types.PeerReputation {
+	if info == nil {
+		return nil
+	}
+	return &proto_types.PeerReputation{
+		Score:     info.Score,
+		LatencyMs: info.LatencyMs,
+		Responses: info.Responses,
+		Timeouts:  info.Timeouts,
+		Invalid:   info.Invalid,
+	}
+}
+
+func trafficToProto(info p2p.TrafficInfo) []*proto_types.MessageTraffic {
+	msgs := make([]string, 0, len(info))
+	for msg := range info {
+		msgs = append(msgs, msg)
+	}
+	sort.Strings(msgs)
+	traffic := make([]*proto_types.MessageTraffic, 0, len(msgs))
+	for _, msg := range msgs {
+		t := info[msg]
+		traffic = append(traffic, &proto_types.MessageTraffic{
+			Message:        msg,
+			IngressBytes:   t.IngressBytes,
+			IngressPackets: t.IngressPackets,
+			EgressBytes:    t.EgressBytes,
+			EgressPackets:  t.EgressPackets,
+			EgressDropped:  t.EgressDropped,
+		})
+	}
+	return traffic
+}
+
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func ipString(ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+func natReportToProto(report *nat.Report) *proto_types.NatReport {
+	if report == nil {
+		return nil
+	}
+	ret := &proto_types.NatReport{
+		Interface:  report.Interface,
+		ExternalIp: ipString(report.ExternalIP),
+		ObservedIp: ipString(report.ObservedIP),
+		Checked:    unixTime(report.Checked),
+		Probed:     unixTime(report.Probed),
+		Warnings:   report.Warnings,
+	}
+	for _, m := range report.Mechanisms {
+		ret.Mechanisms = append(ret.Mechanisms, &proto_types.NatMechanism{
+			Name:            m.Name,
+			Device:          m.Device,
+			Available:       m.Available,
+			ExternalIp:      ipString(m.ExternalIP),
+			SupportsMapping: m.SupportsMapping,
+			LatencyMs:       uint64(m.Latency.Milliseconds()),
+			Error:           m.Error,
+		})
+	}
+	for _, m := range report.Mappings {
+		ret.Mappings = append(ret.Mappings, &proto_types.NatMapping{
+			Protocol:     m.Protocol,
+			ExternalPort: uint32(m.ExternalPort),
+			InternalPort: uint32(m.InternalPort),
+			Mapped:       m.Mapped,
+			Expires:      unixTime(m.Expires),
+			Renewals:     m.Renewals,
+			Error:        m.Error,
+		})
+	}
+	for _, r := range report.Reachability {
+		ret.Reachability = append(ret.Reachability, &proto_types.NatReachability{
+			Protocol:    r.Protocol,
+			Port:        uint32(r.Port),
+			Reachable:   r.Reachable,
+			Since:       unixTime(r.Since),
+			LastInbound: unixTime(r.LastInbound),
+		})
+	}
+	return ret
+}
+
 // setupDiscovery creates the node discovery source for the `eth` and `snap`
 // protocols.
 func setupDiscovery(urls []string) (enode.Iterator, error) {
@@ -1320,6 +1547,51 @@ func (ss *GrpcServer) AddPeer(_ context.Context, req *proto_sentry.AddPeerReques
 	return &proto_sentry.AddPeerReply{Success: true}, nil
 }
 
+func (ss *GrpcServer) RemovePeer(_ context.Context, req *proto_sentry.RemovePeerRequest) (*proto_sentry.RemovePeerReply, error) {
+	node, err := enode.Parse(enode.ValidSchemes, req.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	p2pServer := ss.getP2PServer()
+	if p2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+	p2pServer.RemovePeer(node)
+
+	return &proto_sentry.RemovePeerReply{Success: true}, nil
+}
+
+func (ss *GrpcServer) AddTrustedPeer(_ context.Context, req *proto_sentry.AddTrustedPeerRequest) (*proto_sentry.AddTrustedPeerReply, error) {
+	node, err := enode.Parse(enode.ValidSchemes, req.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	p2pServer := ss.getP2PServer()
+	if p2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+	p2pServer.AddTrustedPeer(node)
+
+	return &proto_sentry.AddTrustedPeerReply{Success: true}, nil
+}
+
+func (ss *GrpcServer) RemoveTrustedPeer(_ context.Context, req *proto_sentry.RemoveTrustedPeerRequest) (*proto_sentry.RemoveTrustedPeerReply, error) {
+	node, err := enode.Parse(enode.ValidSchemes, req.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	p2pServer := ss.getP2PServer()
+	if p2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+	p2pServer.RemoveTrustedPeer(node)
+
+	return &proto_sentry.RemoveTrustedPeerReply{Success: true}, nil
+}
+
 func (ss *GrpcServer) NodeInfo(_ context.Context, _ *emptypb.Empty) (*proto_types.NodeInfoReply, error) {
 	p2pServer := ss.getP2PServer()
 	if p2pServer == nil {
@@ -1337,6 +1609,7 @@ func (ss *GrpcServer) NodeInfo(_ context.Context, _ *emptypb.Empty) (*proto_type
 			Listener:  uint32(info.Ports.Listener),
 		},
 		ListenerAddr: info.ListenAddr,
+		Nat:          natReportToProto(info.NAT),
 	}
 
 	protos, err := json.Marshal(info.Protocols)
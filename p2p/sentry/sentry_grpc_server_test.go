@@ -31,6 +31,7 @@ import (
 	"github.com/erigontech/erigon-lib/direct"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	proto_types "github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -39,6 +40,7 @@ import (
 	"github.com/erigontech/erigon/core/rawdb"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/p2p"
+	"github.com/erigontech/erigon/p2p/enode"
 )
 
 func testSentryServer(db kv.Getter, genesis *types.Genesis, genesisHash libcommon.Hash) *GrpcServer {
@@ -212,3 +214,52 @@ func TestSentryServerImpl_SetStatusInitPanic(t *testing.T) {
 		t.Fatalf("error expected")
 	}
 }
+
+func TestPenalizePeer(t *testing.T) {
+	nodeDB, err := enode.OpenDB(context.Background(), "", t.TempDir(), log.Root())
+	require.NoError(t, err)
+	t.Cleanup(nodeDB.Close)
+	reputation := p2p.NewReputation(nodeDB, log.Root())
+
+	ss := &GrpcServer{ctx: context.Background(), statusData: &proto_sentry.StatusData{}}
+	addPeer := func(b byte) (*PeerInfo, *proto_types.H512) {
+		var peerID [64]byte
+		peerID[0] = b
+		peerInfo := NewPeerInfo(p2p.NewPeer(enode.ID{b}, peerID, "test", nil, false), nil)
+		peerInfo.reputation = reputation
+		t.Cleanup(peerInfo.Close)
+		ss.GoodPeers.Store(peerID, peerInfo)
+		return peerInfo, gointerfaces.ConvertHashToH512(peerID)
+	}
+	penalize := func(id *proto_types.H512, kind proto_sentry.PenaltyKind) {
+		_, err := ss.PenalizePeer(context.Background(), &proto_sentry.PenalizePeerRequest{PeerId: id, Penalty: kind})
+		require.NoError(t, err)
+	}
+	isRemoved := func(peerInfo *PeerInfo) bool {
+		select {
+		case <-peerInfo.removed:
+			return true
+		default:
+			return false
+		}
+	}
+
+	// An invalid transaction only costs reputation, the peer is kicked once
+	// its score falls below invalidTxKickReputation.
+	peerInfo, id := addPeer(1)
+	var penalties int
+	for ; !isRemoved(peerInfo) && penalties < 10; penalties++ {
+		require.GreaterOrEqual(t, peerInfo.Score(), float64(invalidTxKickReputation), "peer kept with a score below the threshold")
+		penalize(id, proto_sentry.PenaltyKind_InvalidTx)
+	}
+	require.True(t, isRemoved(peerInfo), "peer kept after %d invalid transactions", penalties)
+	require.Greater(t, penalties, 1, "peer kicked on the first invalid transaction")
+	require.Less(t, peerInfo.Score(), float64(invalidTxKickReputation))
+	require.Nil(t, ss.getPeer(ConvertH512ToPeerID(id)))
+
+	// Other penalties kick the peer right away.
+	peerInfo, id = addPeer(2)
+	penalize(id, proto_sentry.PenaltyKind_Kick)
+	require.True(t, isRemoved(peerInfo))
+	require.Nil(t, ss.getPeer(ConvertH512ToPeerID(id)))
+}
@@ -157,7 +157,7 @@ func (cs *MultiClient) Penalize(ctx context.Context, penalties []headerdownload.
 	for i := range penalties {
 		outreq := proto_sentry.PenalizePeerRequest{
 			PeerId:  gointerfaces.ConvertHashToH512(penalties[i].PeerID),
-			Penalty: proto_sentry.PenaltyKind_Kick, // TODO: Extend penalty kinds
+			Penalty: penaltyKind(penalties[i].Penalty),
 		}
 		for i, ok, next := cs.randSentryIndex(); ok; i, ok = next() {
 			if ready, ok := cs.sentries[i].(interface{ Ready() bool }); ok && !ready.Ready() {
@@ -170,3 +170,15 @@ func (cs *MultiClient) Penalize(ctx context.Context, penalties []headerdownload.
 		}
 	}
 }
+
+// penaltyKind maps header download penalties to the sentry penalty kinds,
+// invalid blocks weigh heavier in the peer reputation than other penalties
+func penaltyKind(penalty headerdownload.Penalty) proto_sentry.PenaltyKind {
+	switch penalty {
+	case headerdownload.BadBlockPenalty, headerdownload.InvalidSealPenalty,
+		headerdownload.WrongChildBlockHeightPenalty, headerdownload.WrongChildDifficultyPenalty:
+		return proto_sentry.PenaltyKind_InvalidBlock
+	default:
+		return proto_sentry.PenaltyKind_Kick
+	}
+}
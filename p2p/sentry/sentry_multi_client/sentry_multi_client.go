@@ -445,7 +445,7 @@ func (cs *MultiClient) newBlock66(ctx context.Context, inreq *proto_sentry.Inbou
 		} else {
 			outreq := proto_sentry.PenalizePeerRequest{
 				PeerId:  inreq.PeerId,
-				Penalty: proto_sentry.PenaltyKind_Kick, // TODO: Extend penalty kinds
+				Penalty: penaltyKind(penalty),
 			}
 			for _, sentry := range cs.sentries {
 				// TODO does this method need to be moved to the grpc api ?
@@ -71,6 +71,9 @@ const (
 	frameWriteTimeout = 20 * time.Second
 
 	serverStatsLogInterval = 60 * time.Second
+
+	// How often peer reputation scores are written to the node database.
+	reputationFlushInterval = 5 * time.Minute
 )
 
 var errServerStopped = errors.New("server stopped")
@@ -123,11 +126,27 @@ type Config struct {
 	// allowed to connect, even above the peer limit.
 	TrustedNodes []*enode.Node
 
+	// Priority nodes, typically validators behind this node, always receive new
+	// blocks and transactions and are never dropped because of peer limits.
+	// Add them to StaticNodes as well to keep them connected.
+	PriorityNodeIDs []enode.ID `toml:",omitempty"`
+
 	// Connectivity can be restricted to certain IP networks.
 	// If this option is set to a non-nil value, only hosts which match one of the
 	// IP networks contained in the list are considered.
 	NetRestrict *netutil.Netlist `toml:",omitempty"`
 
+	// DiscoveryFilter, if non-nil, drops nodes found by discovery before they are
+	// dialed, e.g. based on the entries of their node records.
+	DiscoveryFilter func(*enode.Node) bool `toml:"-"`
+
+	// Bandwidth limits in bytes per second, zero means unlimited. The global limits
+	// are shared by all connections, the peer limits apply to each one separately.
+	MaxIngressRate     uint64 `toml:",omitempty"`
+	MaxEgressRate      uint64 `toml:",omitempty"`
+	MaxPeerIngressRate uint64 `toml:",omitempty"`
+	MaxPeerEgressRate  uint64 `toml:",omitempty"`
+
 	// NodeDatabase is the path to the database containing the previously seen
 	// live nodes in the network.
 	NodeDatabase string `toml:",omitempty"`
@@ -162,6 +181,10 @@ type Config struct {
 	// NAT interface description (see NAT.Parse()).
 	NATSpec string
 
+	// NATCheckInterval is how often the NAT traversal mechanisms are probed for the
+	// diagnostics reported by NodeInfo, zero disables probing.
+	NATCheckInterval time.Duration `toml:",omitempty"`
+
 	// If Dialer is set to a non-nil value, the given Dialer
 	// is used to dial outbound peer connections.
 	Dialer NodeDialer `toml:"-"`
@@ -216,6 +239,7 @@ type Server struct {
 	logger       log.Logger
 
 	nodedb             *enode.DB
+	reputation         *Reputation
 	localnode          *enode.LocalNode
 	localnodeAddrCache atomic.Pointer[string]
 	ntab               *discover.UDPv4
@@ -238,6 +262,15 @@ type Server struct {
 	// State of run loop and listenLoop.
 	inboundHistory expHeap
 
+	ingressLimiter *bandwidthLimiter
+	egressLimiter  *bandwidthLimiter
+
+	natDiag *natDiagnostics
+
+	priorityLock   sync.RWMutex
+	priority       map[enode.ID]bool        // Config.PriorityNodeIDs and nodes set by SetPriorityNodes
+	priorityDialed map[enode.ID]*enode.Node // static dials added by SetPriorityNodes
+
 	errorsMu sync.Mutex
 	errors   map[string]uint
 }
@@ -263,12 +296,13 @@ const (
 type conn struct {
 	fd net.Conn
 	transport
-	node   *enode.Node
-	flags  connFlag
-	cont   chan error // The run loop uses cont to signal errors to SetupConn.
-	caps   []Cap      // valid after the protocol handshake
-	name   string     // valid after the protocol handshake
-	pubkey [64]byte
+	node    *enode.Node
+	flags   connFlag
+	cont    chan error // The run loop uses cont to signal errors to SetupConn.
+	caps    []Cap      // valid after the protocol handshake
+	name    string     // valid after the protocol handshake
+	pubkey  [64]byte
+	traffic *peerTraffic // per-message accounting, shared with the transport
 }
 
 type transport interface {
@@ -417,6 +451,75 @@ func (srv *Server) RemoveTrustedPeer(node *enode.Node) {
 	}
 }
 
+// IsPriority reports whether the node is a priority peer.
+func (srv *Server) IsPriority(id enode.ID) bool {
+	srv.priorityLock.RLock()
+	defer srv.priorityLock.RUnlock()
+	return srv.priority[id]
+}
+
+// SetPriorityNodes replaces the priority peers set at runtime, e.g. from the node IDs
+// registered on-chain by validators. Config.PriorityNodeIDs stay priority peers. Nodes
+// with a record in the node database are dialed like static nodes. Peers which lose the
+// priority status keep the trusted flag until they reconnect.
+func (srv *Server) SetPriorityNodes(ids []enode.ID) {
+	if !srv.running.Load() {
+		return
+	}
+	static := make(map[enode.ID]bool, len(srv.StaticNodes))
+	for _, n := range srv.StaticNodes {
+		static[n.ID()] = true
+	}
+	self := srv.localnode.ID()
+
+	var add, remove []*enode.Node
+	srv.priorityLock.Lock()
+	priority := make(map[enode.ID]bool, len(srv.PriorityNodeIDs)+len(ids))
+	for _, id := range srv.PriorityNodeIDs {
+		priority[id] = true
+	}
+	for _, id := range ids {
+		priority[id] = true
+	}
+	srv.priority = priority
+	for id, n := range srv.priorityDialed {
+		if !priority[id] {
+			delete(srv.priorityDialed, id)
+			remove = append(remove, n)
+		}
+	}
+	for _, id := range ids {
+		if _, ok := srv.priorityDialed[id]; ok || static[id] || id == self {
+			continue
+		}
+		if n := srv.nodedb.Node(id); n != nil {
+			srv.priorityDialed[id] = n
+			add = append(add, n)
+		}
+	}
+	srv.priorityLock.Unlock()
+
+	for _, n := range remove {
+		srv.dialsched.removeStatic(n)
+	}
+	for _, n := range add {
+		srv.dialsched.addStatic(n)
+	}
+	// Connected peers become priority peers without reconnecting.
+	srv.doPeerOp(func(peers map[enode.ID]*Peer) {
+		for _, id := range ids {
+			if p, ok := peers[id]; ok {
+				p.rw.set(trustedConn, true)
+			}
+		}
+	})
+}
+
+// Reputation returns the peer scorer of the server. It is nil before the server is started.
+func (srv *Server) Reputation() *Reputation {
+	return srv.reputation
+}
+
 // SubscribeEvents subscribes the given channel to peer events.
 func (srv *Server) SubscribeEvents(ch chan *PeerEvent) event.Subscription {
 	return srv.peerFeed.Subscribe(ch)
@@ -447,6 +550,7 @@ func (srv *Server) Stop() {
 		// this unblocks listener Accept
 		_ = srv.listener.Close()
 	}
+	srv.reputation.Flush()
 	if srv.nodedb != nil {
 		srv.nodedb.Close()
 	}
@@ -519,6 +623,14 @@ func (srv *Server) Start(ctx context.Context, logger log.Logger) error {
 	srv.removetrusted = make(chan *enode.Node)
 	srv.peerOp = make(chan peerOpFunc)
 	srv.peerOpDone = make(chan struct{})
+	srv.priority = make(map[enode.ID]bool, len(srv.PriorityNodeIDs))
+	for _, id := range srv.PriorityNodeIDs {
+		srv.priority[id] = true
+	}
+	srv.priorityDialed = make(map[enode.ID]*enode.Node)
+	srv.ingressLimiter = newBandwidthLimiter(srv.MaxIngressRate)
+	srv.egressLimiter = newBandwidthLimiter(srv.MaxEgressRate)
+	srv.natDiag = &natDiagnostics{}
 
 	if err := srv.setupLocalNode(); err != nil {
 		return err
@@ -534,8 +646,13 @@ func (srv *Server) Start(ctx context.Context, logger log.Logger) error {
 	srv.setupDialScheduler()
 
 	srv.running.Store(true)
-	srv.loopWG.Add(1)
+	srv.loopWG.Add(2)
 	go srv.run()
+	go func() {
+		defer debug.LogPanic()
+		defer srv.loopWG.Done()
+		srv.natLoop()
+	}()
 	return nil
 }
 
@@ -558,6 +675,7 @@ func (srv *Server) setupLocalNode() error {
 		return err
 	}
 	srv.nodedb = db
+	srv.reputation = NewReputation(db, srv.logger)
 
 	srv.localnode = enode.NewLocalNode(db, srv.PrivateKey, srv.logger)
 	srv.localnode.SetFallbackIP(net.IP{127, 0, 0, 1})
@@ -627,14 +745,10 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 	srv.logger.Trace("UDP listener up", "addr", realaddr)
 	if srv.NAT != nil {
 		if !realaddr.IP.IsLoopback() && srv.NAT.SupportsMapping() {
-			srv.loopWG.Add(1)
-			go func() {
-				defer debug.LogPanic()
-				defer srv.loopWG.Done()
-				nat.Map(srv.NAT, srv.quit, "udp", realaddr.Port, realaddr.Port, "ethereum discovery", srv.logger)
-			}()
+			srv.mapPort("udp", realaddr.Port, "ethereum discovery")
 		}
 	}
+	srv.natDiag.listening("udp", realaddr.Port)
 	srv.localnode.SetFallbackUDP(realaddr.Port)
 	srv.updateLocalNodeStaticAddrCache()
 
@@ -658,7 +772,7 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 			return err
 		}
 		srv.ntab = ntab
-		srv.discmix.AddSource(ntab.RandomNodes())
+		srv.discmix.AddSource(srv.filterDiscovered(ntab.RandomNodes()))
 	}
 
 	// Discovery V5
@@ -679,10 +793,18 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		srv.discmix.AddSource(srv.filterDiscovered(srv.DiscV5.RandomNodes()))
 	}
 	return nil
 }
 
+func (srv *Server) filterDiscovered(it enode.Iterator) enode.Iterator {
+	if srv.DiscoveryFilter == nil {
+		return it
+	}
+	return enode.Filter(it, srv.DiscoveryFilter)
+}
+
 func (srv *Server) setupDialScheduler() {
 	config := dialConfig{
 		self:           srv.localnode.ID(),
@@ -692,6 +814,7 @@ func (srv *Server) setupDialScheduler() {
 		netRestrict:    srv.NetRestrict,
 		dialer:         srv.Dialer,
 		clock:          srv.clock,
+		reputation:     srv.reputation,
 	}
 	if srv.ntab != nil {
 		config.resolver = srv.ntab
@@ -743,13 +866,9 @@ func (srv *Server) setupListening(ctx context.Context) error {
 		srv.updateLocalNodeStaticAddrCache()
 
 		if !tcp.IP.IsLoopback() && (srv.NAT != nil) && srv.NAT.SupportsMapping() {
-			srv.loopWG.Add(1)
-			go func() {
-				defer debug.LogPanic()
-				defer srv.loopWG.Done()
-				nat.Map(srv.NAT, srv.quit, "tcp", tcp.Port, tcp.Port, "ethereum p2p", srv.logger)
-			}()
+			srv.mapPort("tcp", tcp.Port, "ethereum p2p")
 		}
+		srv.natDiag.listening("tcp", tcp.Port)
 	}
 
 	srv.loopWG.Add(1)
@@ -785,6 +904,8 @@ func (srv *Server) run() {
 		peers        = make(map[enode.ID]*Peer)
 		inboundCount = 0
 		trusted      = make(map[enode.ID]bool, len(srv.TrustedNodes))
+		// Peers disconnected to free a slot for a better one, until they are gone.
+		evicting = make(map[enode.ID]*Peer)
 	)
 	// Put trusted nodes into a map to speed up checks.
 	// Trusted peers are loaded on startup or added via AddTrustedPeer RPC.
@@ -794,6 +915,8 @@ func (srv *Server) run() {
 
 	logTimer := time.NewTicker(serverStatsLogInterval)
 	defer logTimer.Stop()
+	reputationTimer := time.NewTicker(reputationFlushInterval)
+	defer reputationTimer.Stop()
 
 running:
 	for {
@@ -827,7 +950,7 @@ running:
 		case c := <-srv.checkpointPostHandshake:
 			// A connection has passed the encryption handshake so
 			// the remote identity is known (but hasn't been verified yet).
-			if trusted[c.node.ID()] {
+			if trusted[c.node.ID()] || srv.IsPriority(c.node.ID()) {
 				// Ensure that the trusted flag is set before checking against MaxPeers.
 				c.flags |= trustedConn
 			}
@@ -836,7 +959,18 @@ running:
 		case c := <-srv.checkpointAddPeer:
 			// At this point the connection is past the protocol handshake.
 			// Its capabilities are known and the remote identity is verified.
-			err := srv.postHandshakeChecks(peers, inboundCount, c)
+			peerCount, inbound := srv.activePeerCounts(peers, inboundCount, evicting)
+			err := srv.postHandshakeChecks(peers, peerCount, inbound, c)
+			if errors.Is(err, DiscTooManyPeers) {
+				inboundOnly := c.is(inboundConn) && inbound >= srv.maxInboundConns()
+				if victim := srv.evictionCandidate(peers, evicting, c, inboundOnly); victim != nil {
+					srv.logger.Trace("Evicting p2p peer for better peer", "url", victim.Node(), "new", c.node)
+					evicting[victim.ID()] = victim
+					victim.Disconnect(NewPeerError(PeerErrorDiscReason, DiscUselessPeer, nil, "evicted for better peer"))
+					peerCount, inbound = srv.activePeerCounts(peers, inboundCount, evicting)
+					err = srv.postHandshakeChecks(peers, peerCount, inbound, c)
+				}
+			}
 			if err == nil {
 				// The handshakes are done and it passed all checks.
 				p := srv.launchPeer(c, c.pubkey)
@@ -853,6 +987,7 @@ running:
 			// A peer disconnected.
 			d := libcommon.PrettyDuration(mclock.Now() - pd.created)
 			delete(peers, pd.ID())
+			delete(evicting, pd.ID())
 			srv.logger.Trace("Removing p2p peer", "peercount", len(peers), "url", pd.Node(), "duration", d, "err", pd.err)
 			srv.dialsched.peerRemoved(pd.rw)
 			if pd.Inbound() {
@@ -863,6 +998,8 @@ running:
 			vals = append(vals, srv.listErrors()...)
 
 			srv.logger.Debug("[p2p] Server", vals...)
+		case <-reputationTimer.C:
+			srv.reputation.Flush()
 		}
 	}
 
@@ -889,9 +1026,9 @@ running:
 	}
 }
 
-func (srv *Server) postHandshakeChecks(peers map[enode.ID]*Peer, inboundCount int, c *conn) error {
+func (srv *Server) postHandshakeChecks(peers map[enode.ID]*Peer, peerCount, inboundCount int, c *conn) error {
 	switch {
-	case !c.is(trustedConn) && len(peers) >= srv.MaxPeers:
+	case !c.is(trustedConn) && peerCount >= srv.MaxPeers:
 		return DiscTooManyPeers
 	case !c.is(trustedConn) && c.is(inboundConn) && inboundCount >= srv.maxInboundConns():
 		return DiscTooManyPeers
@@ -906,6 +1043,47 @@ func (srv *Server) postHandshakeChecks(peers map[enode.ID]*Peer, inboundCount in
 	}
 }
 
+// activePeerCounts returns the number of all and inbound peers, not counting
+// the peers which are being evicted.
+func (srv *Server) activePeerCounts(peers map[enode.ID]*Peer, inboundCount int, evicting map[enode.ID]*Peer) (int, int) {
+	peerCount := len(peers)
+	for _, p := range evicting {
+		peerCount--
+		if p.Inbound() {
+			inboundCount--
+		}
+	}
+	return peerCount, inboundCount
+}
+
+// evictionCandidate returns the worst scoring connected peer if the reputation of c is
+// better by at least evictionMargin, nil otherwise. Trusted and static peers are never
+// evicted. If inboundOnly is set, only inbound peers are considered.
+func (srv *Server) evictionCandidate(peers map[enode.ID]*Peer, evicting map[enode.ID]*Peer, c *conn, inboundOnly bool) *Peer {
+	if srv.reputation == nil {
+		return nil
+	}
+	var (
+		victim *Peer
+		worst  float64
+	)
+	for id, p := range peers {
+		if _, ok := evicting[id]; ok || p.rw.is(trustedConn) || p.rw.is(staticDialedConn) {
+			continue
+		}
+		if inboundOnly && !p.rw.is(inboundConn) {
+			continue
+		}
+		if score := srv.reputation.Score(id); victim == nil || score < worst {
+			victim, worst = p, score
+		}
+	}
+	if victim == nil || srv.reputation.Score(c.node.ID()) < worst+evictionMargin {
+		return nil
+	}
+	return victim
+}
+
 // listenLoop runs in its own goroutine and accepts
 // inbound connections.
 func (srv *Server) listenLoop(ctx context.Context) {
@@ -959,6 +1137,9 @@ func (srv *Server) listenLoop(ctx context.Context) {
 		}
 
 		remoteIP := netutil.AddrIP(fd.RemoteAddr())
+		if (remoteIP != nil) && !netutil.IsLAN(remoteIP) {
+			srv.natDiag.inbound("tcp")
+		}
 		if err := srv.checkInboundConn(fd, remoteIP); err != nil {
 			srv.logger.Trace("Rejected inbound connection", "addr", fd.RemoteAddr(), "err", err)
 			_ = fd.Close()
@@ -1004,12 +1185,17 @@ func (srv *Server) checkInboundConn(fd net.Conn, remoteIP net.IP) error {
 // as a peer. It returns when the connection has been added as a peer
 // or the handshakes have failed.
 func (srv *Server) SetupConn(fd net.Conn, flags connFlag, dialDest *enode.Node) error {
-	c := &conn{fd: fd, flags: flags, cont: make(chan error)}
+	c := &conn{fd: fd, flags: flags, cont: make(chan error), traffic: newPeerTraffic()}
 	if dialDest == nil {
 		c.transport = srv.newTransport(fd, nil)
 	} else {
 		c.transport = srv.newTransport(fd, dialDest.Pubkey())
 	}
+	if t, ok := c.transport.(*rlpxTransport); ok {
+		t.bandwidth = newConnBandwidth(srv.ingressLimiter, srv.egressLimiter,
+			newBandwidthLimiter(srv.MaxPeerIngressRate), newBandwidthLimiter(srv.MaxPeerEgressRate),
+			c.traffic, srv.MetricsEnabled)
+	}
 
 	err := srv.setupConn(c, flags, dialDest)
 	if err != nil {
@@ -1155,6 +1341,7 @@ type NodeInfo struct {
 	} `json:"ports"`
 	ListenAddr string                 `json:"listenAddr"`
 	Protocols  map[string]interface{} `json:"protocols"`
+	NAT        *nat.Report            `json:"nat,omitempty"` // NAT traversal diagnostics
 }
 
 // NodeInfo gathers and returns a collection of metadata known about the host.
@@ -1168,6 +1355,7 @@ func (srv *Server) NodeInfo() *NodeInfo {
 		IP:         node.IP().String(),
 		ListenAddr: srv.ListenAddr,
 		Protocols:  make(map[string]interface{}),
+		NAT:        srv.NATReport(),
 	}
 	info.Ports.Discovery = node.UDP()
 	info.Ports.Listener = node.TCP()
@@ -1192,7 +1380,9 @@ func (srv *Server) PeersInfo() []*PeerInfo {
 	infos := make([]*PeerInfo, 0, srv.PeerCount())
 	for _, peer := range srv.Peers() {
 		if peer != nil {
-			infos = append(infos, peer.Info())
+			info := peer.Info()
+			info.Reputation = srv.reputation.Info(peer.ID())
+			infos = append(infos, info)
 		}
 	}
 	// Sort the result array alphabetically by node identifier
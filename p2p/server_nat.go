@@ -0,0 +1,171 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common/debug"
+	"github.com/erigontech/erigon/p2p/nat"
+)
+
+// natSampleInterval is how often the UDP reachability predicted by discovery is sampled,
+// it must be shorter than the window of the endpoint predictor.
+const natSampleInterval = time.Minute
+
+// natDiagnostics tracks the port mappings and the reachability of the listening ports,
+// and keeps the results of the last probe of the NAT traversal mechanisms.
+type natDiagnostics struct {
+	mu         sync.Mutex
+	mappings   []*nat.Mapping
+	reach      []*nat.Reachability
+	mechanisms []nat.MechanismStatus
+	externalIP net.IP
+	probed     time.Time
+}
+
+// mapPort keeps the port mapped on the configured mechanism until the server stops.
+func (srv *Server) mapPort(protocol string, port int, name string) {
+	mapping := nat.NewMapping(protocol, port, port, name)
+	srv.natDiag.mu.Lock()
+	srv.natDiag.mappings = append(srv.natDiag.mappings, mapping)
+	srv.natDiag.mu.Unlock()
+
+	srv.loopWG.Add(1)
+	go func() {
+		defer debug.LogPanic()
+		defer srv.loopWG.Done()
+		mapping.Run(srv.NAT, srv.quit, srv.logger)
+	}()
+}
+
+// listening starts tracking the reachability of the listening port.
+func (d *natDiagnostics) listening(protocol string, port int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.reach = append(d.reach, &nat.Reachability{Protocol: protocol, Port: port, Since: time.Now()})
+}
+
+// inbound records inbound traffic which got through the NAT.
+func (d *natDiagnostics) inbound(protocol string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, reach := range d.reach {
+		if reach.Protocol == protocol {
+			reach.LastInbound = time.Now()
+		}
+	}
+}
+
+// probeNAT tests the NAT traversal mechanisms, and remaps the ports when the external
+// IP has changed, as the gateway has likely been restarted and lost the mappings.
+func (srv *Server) probeNAT() {
+	mechanisms := nat.ProbeAll()
+	var externalIP net.IP
+	if srv.NAT != nil {
+		ip, err := srv.NAT.ExternalIP()
+		if err != nil {
+			srv.logger.Debug("NAT ExternalIP resolution has failed", "interface", srv.NAT, "err", err)
+		}
+		externalIP = ip
+	}
+
+	d := srv.natDiag
+	d.mu.Lock()
+	changed := (d.externalIP != nil) && (externalIP != nil) && !d.externalIP.Equal(externalIP)
+	d.mechanisms, d.probed = mechanisms, time.Now()
+	if externalIP != nil {
+		d.externalIP = externalIP
+	}
+	mappings := d.mappings
+	d.mu.Unlock()
+
+	if changed {
+		srv.logger.Info("NAT external IP has changed, remapping ports", "ip", externalIP)
+		srv.localnode.SetStaticIP(externalIP)
+		srv.updateLocalNodeStaticAddrCache()
+	}
+	for _, mapping := range mappings {
+		if changed || !mapping.Status().Mapped {
+			mapping.Remap()
+		}
+	}
+	if report := srv.NATReport(); len(report.Warnings) > 0 {
+		srv.logger.Info("NAT diagnostics", "warnings", report.Warnings)
+	}
+}
+
+// natLoop samples the reachability of the discovery port and periodically probes
+// the NAT traversal mechanisms, if enabled by Config.NATCheckInterval.
+func (srv *Server) natLoop() {
+	sample := time.NewTicker(natSampleInterval)
+	defer sample.Stop()
+	var check <-chan time.Time
+	if srv.NATCheckInterval > 0 {
+		ticker := time.NewTicker(srv.NATCheckInterval)
+		defer ticker.Stop()
+		check = ticker.C
+		srv.probeNAT()
+	}
+	for {
+		select {
+		case <-srv.quit:
+			return
+		case <-sample.C:
+			if _, _, reachable := srv.localnode.PredictedEndpoint(); reachable {
+				srv.natDiag.inbound("udp")
+			}
+		case <-check:
+			srv.probeNAT()
+		}
+	}
+}
+
+// NATReport returns the NAT traversal diagnostics: the probed mechanisms, the state of
+// the port mappings and whether peers have been able to reach the listening ports.
+func (srv *Server) NATReport() *nat.Report {
+	report := &nat.Report{Checked: time.Now()}
+	if srv.NAT != nil {
+		report.Interface = srv.NAT.String()
+	}
+	if srv.localnode != nil {
+		report.ObservedIP, _, _ = srv.localnode.PredictedEndpoint()
+	}
+	d := srv.natDiag
+	if d == nil {
+		return report
+	}
+	d.mu.Lock()
+	report.ExternalIP = d.externalIP
+	report.Probed = d.probed
+	report.Mechanisms = append(report.Mechanisms, d.mechanisms...)
+	mappings := d.mappings
+	for _, reach := range d.reach {
+		r := *reach
+		r.Reachable = report.Checked.Sub(r.LastInbound) < nat.ReachableWindow
+		report.Reachability = append(report.Reachability, r)
+	}
+	d.mu.Unlock()
+
+	for _, mapping := range mappings {
+		report.Mappings = append(report.Mappings, mapping.Status())
+	}
+	report.Analyze()
+	return report
+}
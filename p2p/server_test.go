@@ -330,6 +330,66 @@ func TestServerAtCap(t *testing.T) {
 	}
 }
 
+func TestServerPriorityPeers(t *testing.T) {
+	logger := log.New()
+	remote := newkey()
+	configured, dynamic := randomID(), randomID()
+	srv := &Server{
+		Config: Config{
+			PrivateKey:      newkey(),
+			MaxPeers:        2,
+			MaxPendingPeers: 10,
+			NoDial:          true,
+			NoDiscovery:     true,
+			PriorityNodeIDs: []enode.ID{configured},
+		},
+	}
+	if err := srv.TestStart(logger); err != nil {
+		t.Fatalf("could not start: %v", err)
+	}
+	defer srv.Stop()
+
+	newconn := func(id enode.ID) *conn {
+		fd, _ := net.Pipe()
+		tx := newTestTransport(&remote.PublicKey, fd, nil)
+		node := enode.SignNull(new(enr.Record), id)
+		return &conn{fd: fd, transport: tx, flags: inboundConn, node: node, cont: make(chan error)}
+	}
+	for i := 0; i < srv.Config.MaxPeers; i++ {
+		if err := srv.checkpoint(newconn(randomID()), srv.checkpointAddPeer); err != nil {
+			t.Fatalf("could not add conn %d: %v", i, err)
+		}
+	}
+
+	// Priority peers are accepted at the peer limit.
+	admit := func(id enode.ID) (*conn, error) {
+		c := newconn(id)
+		if err := srv.checkpoint(c, srv.checkpointPostHandshake); err != nil {
+			return c, err
+		}
+		return c, srv.checkpoint(c, srv.checkpointAddPeer)
+	}
+	if c, err := admit(configured); err != nil || !c.is(trustedConn) {
+		t.Errorf("configured priority peer not admitted: %v", err)
+	}
+	if _, err := admit(dynamic); err != DiscTooManyPeers {
+		t.Error("wrong error for non-priority peer:", err)
+	}
+
+	srv.SetPriorityNodes([]enode.ID{dynamic})
+	if !srv.IsPriority(configured) || !srv.IsPriority(dynamic) {
+		t.Fatal("priority set not updated")
+	}
+	if c, err := admit(dynamic); err != nil || !c.is(trustedConn) {
+		t.Errorf("dynamic priority peer not admitted: %v", err)
+	}
+
+	srv.SetPriorityNodes(nil)
+	if !srv.IsPriority(configured) || srv.IsPriority(dynamic) {
+		t.Error("dynamic priority peer not removed")
+	}
+}
+
 func TestServerPeerLimits(t *testing.T) {
 	logger := log.New()
 	srvkey := newkey()
@@ -606,6 +666,57 @@ func TestServerInboundThrottle(t *testing.T) {
 	}
 }
 
+func TestServerNATReport(t *testing.T) {
+	logger := log.New()
+	srv := &Server{
+		Config: Config{
+			PrivateKey:      newkey(),
+			ListenAddr:      "127.0.0.1:0",
+			MaxPeers:        10,
+			MaxPendingPeers: 10,
+			NoDial:          true,
+			NoDiscovery:     true,
+		},
+		newTransport: func(fd net.Conn, dialDest *ecdsa.PublicKey) transport {
+			return newRLPX(fd, dialDest)
+		},
+		listenFunc: func(network, laddr string) (net.Listener, error) {
+			fakeAddr := &net.TCPAddr{IP: net.IP{95, 33, 21, 2}, Port: 4444}
+			return listenFakeAddr(network, laddr, fakeAddr)
+		},
+	}
+	if err := srv.TestStart(logger); err != nil {
+		t.Fatal("can't start: ", err)
+	}
+	defer srv.Stop()
+
+	report := srv.NodeInfo().NAT
+	if report == nil || len(report.Reachability) != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	reach := report.Reachability[0]
+	if reach.Protocol != "tcp" || reach.Port != srv.Self().TCP() || reach.Reachable {
+		t.Fatalf("unexpected reachability before inbound connections: %+v", reach)
+	}
+
+	// An inbound connection from a public address proves the port is reachable.
+	conn, err := net.DialTimeout("tcp", srv.ListenAddr, 5*time.Second)
+	if err != nil {
+		t.Fatalf("could not dial: %v", err)
+	}
+	defer conn.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for !srv.NATReport().Reachability[0].Reachable {
+		if time.Now().After(deadline) {
+			t.Fatal("inbound connection not recorded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if report := srv.NATReport(); len(report.Mappings) != 0 || len(report.Mechanisms) != 0 || report.Interface != "" {
+		t.Fatalf("unexpected report without NAT: %+v", report)
+	}
+}
+
 func listenFakeAddr(network, laddr string, remoteAddr net.Addr) (net.Listener, error) {
 	l, err := net.Listen(network, laddr)
 	if err == nil {
@@ -50,9 +50,10 @@ const (
 // rlpxTransport is the transport used by actual (non-test) connections.
 // It wraps an RLPx connection with locks and read/write deadlines.
 type rlpxTransport struct {
-	rmu, wmu sync.Mutex
-	wbuf     bytes.Buffer
-	conn     *rlpx.Conn
+	rmu, wmu  sync.Mutex
+	wbuf      bytes.Buffer
+	conn      *rlpx.Conn
+	bandwidth *connBandwidth // limits and accounting, set by the server before the handshakes
 }
 
 func newRLPX(conn net.Conn, dialDest *ecdsa.PublicKey) transport {
@@ -60,6 +61,15 @@ func newRLPX(conn net.Conn, dialDest *ecdsa.PublicKey) transport {
 }
 
 func (t *rlpxTransport) ReadMsg() (Msg, error) {
+	msg, err := t.readMsg()
+	if err == nil {
+		// Wait for bandwidth without holding the lock.
+		t.bandwidth.throttleIngress(int(msg.meterSize))
+	}
+	return msg, err
+}
+
+func (t *rlpxTransport) readMsg() (Msg, error) {
 	t.rmu.Lock()
 	defer t.rmu.Unlock()
 
@@ -86,6 +96,15 @@ func (t *rlpxTransport) ReadMsg() (Msg, error) {
 }
 
 func (t *rlpxTransport) WriteMsg(msg Msg) error {
+	// Wait for bandwidth before taking the lock, so that close is not blocked.
+	// The raw size is an upper bound of the wire size.
+	if send, err := t.bandwidth.throttleEgress(int(msg.Size), msg.bulk); err != nil {
+		return err
+	} else if !send {
+		t.bandwidth.traffic.addDropped(msg.meterCap, msg.meterCode)
+		return nil
+	}
+
 	t.wmu.Lock()
 	defer t.wmu.Unlock()
 
@@ -106,16 +125,12 @@ func (t *rlpxTransport) WriteMsg(msg Msg) error {
 
 	// Set metrics.
 	msg.meterSize = size
-	// TODO: use 	"github.com/erigontech/erigon-lib/metrics"
-	//if metrics.Enabled && msg.meterCap.Name != "" { // don't meter non-subprotocol messages
-	//	m := fmt.Sprintf("%s/%s/%d/%#02x", egressMeterName, msg.meterCap.Name, msg.meterCap.Version, msg.meterCode)
-	//	metrics.GetOrRegisterMeter(m, nil).Mark(int64(msg.meterSize))
-	//	metrics.GetOrRegisterMeter(m+"/packets", nil).Mark(1)
-	//}
+	t.bandwidth.egressMsg(msg.meterCap, msg.meterCode, msg.meterSize)
 	return nil
 }
 
 func (t *rlpxTransport) close(err error) {
+	t.bandwidth.close()
 	t.wmu.Lock()
 	defer t.wmu.Unlock()
 
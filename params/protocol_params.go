@@ -44,6 +44,8 @@ const (
 	QuadCoeffDiv          uint64 = 512   // Divisor for the quadratic particle of the memory cost equation.
 	LogDataGas            uint64 = 8     // Per byte in a LOG* operation's data.
 	CallStipend           uint64 = 2300  // Free gas given at beginning of call.
+	ExtCallMinRetainedGas uint64 = 5000  // Minimum gas retained by the caller of EXTCALL, EXTDELEGATECALL and EXTSTATICCALL.
+	ExtCallMinCalleeGas   uint64 = 2300  // Minimum gas the callee of EXTCALL, EXTDELEGATECALL and EXTSTATICCALL must receive.
 
 	Keccak256Gas     uint64 = 30 // Once per KECCAK256 operation.
 	Keccak256WordGas uint64 = 6  // Once per word of the KECCAK256 operation's data.
@@ -0,0 +1,68 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon/core/vm"
+)
+
+// EOFTest checks EOF container validation (EIP-3540 and friends) as exported
+// by execution-spec-tests in the eof_tests fixtures.
+type EOFTest struct {
+	Vectors map[string]eofVector `json:"vectors"`
+}
+
+type eofVector struct {
+	Code          hexutility.Bytes         `json:"code"`
+	ContainerKind string                   `json:"containerKind"`
+	Results       map[string]eofTestResult `json:"results"`
+}
+
+type eofTestResult struct {
+	Result    bool   `json:"result"`
+	Exception string `json:"exception,omitempty"`
+}
+
+// Run validates every vector of the test and compares the outcome with the
+// expected result of each fork that has EOF enabled.
+func (t *EOFTest) Run() error {
+	keys := make([]string, 0, len(t.Vectors))
+	for k := range t.Vectors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		vector := t.Vectors[k]
+		_, err := vm.ParseAndValidateEOF(vector.Code, vector.ContainerKind == "INITCODE")
+		for fork, want := range vector.Results {
+			if _, ok := Forks[fork]; !ok {
+				return UnsupportedForkError{fork}
+			}
+			switch {
+			case want.Result && err != nil:
+				return fmt.Errorf("vector %s (%s): unexpected error: %w", k, fork, err)
+			case !want.Result && err == nil:
+				return fmt.Errorf("vector %s (%s): expected error %s", k, fork, want.Exception)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,355 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package statetransition fuzzes block execution: random transactions are
+// executed serially by the staged sync, then every block is re-executed on
+// top of the historical state and must produce the same receipts and writes.
+package statetransition
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types/accounts"
+
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/stagedsync"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/turbo/rpchelper"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+var (
+	keys = []*ecdsa.PrivateKey{
+		mustKey("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291"),
+		mustKey("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a"),
+		mustKey("49a7b37aa6f6645917e7b807e9d1c00d4fa71f18343b0d4122a4d2df64dd6fee"),
+	}
+
+	storeAddr   = libcommon.HexToAddress("0x5700")
+	counterAddr = libcommon.HexToAddress("0xc000")
+
+	// storeCode stores the second calldata word at the slot given by the first one.
+	storeCode = []byte{
+		byte(vm.PUSH1), 0x20, byte(vm.CALLDATALOAD),
+		byte(vm.PUSH1), 0x00, byte(vm.CALLDATALOAD),
+		byte(vm.SSTORE),
+		byte(vm.STOP),
+	}
+	// counterCode increments slot 0 and logs its new value.
+	counterCode = []byte{
+		byte(vm.PUSH1), 0x00, byte(vm.SLOAD),
+		byte(vm.PUSH1), 0x01, byte(vm.ADD),
+		byte(vm.DUP1), byte(vm.PUSH1), 0x00, byte(vm.SSTORE),
+		byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.LOG1),
+		byte(vm.STOP),
+	}
+
+	gasPrice = uint256.NewInt(10 * params.GWei)
+)
+
+func mustKey(hex string) *ecdsa.PrivateKey {
+	key, err := crypto.HexToECDSA(hex)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// deployCode returns the initcode deploying the given runtime code.
+func deployCode(code []byte) []byte {
+	n := byte(len(code))
+	initCode := []byte{
+		byte(vm.PUSH1), n, byte(vm.PUSH1), 12, byte(vm.PUSH1), 0x00, byte(vm.CODECOPY),
+		byte(vm.PUSH1), n, byte(vm.PUSH1), 0x00, byte(vm.RETURN),
+	}
+	return append(initCode, code...)
+}
+
+// input decodes the fuzzer data, reading zeroes once it's exhausted.
+type input struct {
+	data []byte
+}
+
+func (in *input) next() byte {
+	if len(in.data) == 0 {
+		return 0
+	}
+	b := in.data[0]
+	in.data = in.data[1:]
+	return b
+}
+
+func storeInput(key, value byte) []byte {
+	return append(libcommon.LeftPadBytes([]byte{key}, 32), libcommon.LeftPadBytes([]byte{value}, 32)...)
+}
+
+func TestFuzzer(t *testing.T) {
+	// every transaction is encoded as its kind, the sender and the kind's arguments
+	fuzz(t, []byte{
+		2,                // 3 blocks
+		3,                // 3 transactions
+		0, 0, 1, 0x10, 1, // transfer 1 wei to a fresh account
+		1, 1, 2, 5, 0, // store, runs out of gas
+		3, 2, // create a storage contract
+		2,       // 2 transactions
+		2, 1, 2, // log with 2 wei sent to the counter
+		4, 0, 0, 4, 7, // store in the created contract
+		1,                   // 1 transaction
+		1, 2, 1, 0xff, 0xff, // store
+	})
+}
+
+func Fuzz(f *testing.F) {
+	f.Add([]byte{0, 1, 0, 0, 1, 1, 1})
+	f.Add([]byte{1, 3, 3, 0, 1, 0, 0, 2, 1, 2, 2, 2, 4, 0, 0, 1, 2})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzz(t, data)
+	})
+}
+
+func fuzz(t *testing.T, data []byte) {
+	in := &input{data: data}
+	var (
+		addrs = make([]libcommon.Address, len(keys))
+		alloc = types.GenesisAlloc{
+			storeAddr:   {Code: storeCode, Balance: new(big.Int)},
+			counterAddr: {Code: counterCode, Balance: new(big.Int)},
+		}
+	)
+	for i, key := range keys {
+		addrs[i] = crypto.PubkeyToAddress(key.PublicKey)
+		alloc[addrs[i]] = types.GenesisAccount{Balance: new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(1000))}
+	}
+	gspec := &types.Genesis{Config: params.TestChainConfig, GasLimit: 10_000_000, Alloc: alloc}
+	m := mock.MockWithGenesis(t, gspec, keys[0], false)
+	signer := types.LatestSigner(m.ChainConfig)
+
+	var created []libcommon.Address
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 1+int(in.next()%4), func(i int, b *core.BlockGen) {
+		for n := in.next() % 4; n > 0; n-- {
+			op, from := in.next()%5, int(in.next())%len(keys)
+			nonce := b.TxNonce(addrs[from])
+			var txn types.Transaction
+			switch op {
+			case 0:
+				to := addrs[int(in.next())%len(addrs)]
+				if a := in.next(); a >= 0x10 {
+					to = libcommon.BytesToAddress([]byte{0xf0, a})
+				}
+				txn = types.NewTransaction(nonce, to, uint256.NewInt(uint64(in.next())), params.TxGas, gasPrice, nil)
+			case 1:
+				key, value, gas := in.next(), in.next(), uint64(in.next())
+				txn = types.NewTransaction(nonce, storeAddr, new(uint256.Int), 22_100+gas*200, gasPrice, storeInput(key, value))
+			case 2:
+				txn = types.NewTransaction(nonce, counterAddr, uint256.NewInt(uint64(in.next())), 60_000, gasPrice, nil)
+			case 3:
+				created = append(created, crypto.CreateAddress(addrs[from], nonce))
+				txn = types.NewContractCreation(nonce, new(uint256.Int), 200_000, gasPrice, deployCode(storeCode))
+			case 4:
+				to := storeAddr
+				if len(created) > 0 {
+					to = created[int(in.next())%len(created)]
+				}
+				txn = types.NewTransaction(nonce, to, new(uint256.Int), 60_000, gasPrice, storeInput(in.next(), in.next()))
+			}
+			signed, err := types.SignTx(txn, *signer, keys[from])
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.AddTx(signed)
+		}
+	})
+	if err != nil {
+		t.Fatalf("generate blocks: %v", err)
+	}
+	if err := m.InsertChain(chain); err != nil {
+		t.Fatalf("insert chain: %v", err)
+	}
+
+	tx, err := m.DB.BeginTemporalRo(m.Ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	for i, block := range chain.Blocks {
+		if err := replay(m, tx, block, chain.Receipts[i]); err != nil {
+			t.Fatalf("block %d: %v", block.NumberU64(), err)
+		}
+	}
+}
+
+// replay re-executes the block on top of the historical state, and compares
+// the receipts and the state writes with the ones of the serial execution.
+func replay(m *mock.MockSentry, tx kv.TemporalTx, block *types.Block, receipts types.Receipts) error {
+	ctx := context.Background()
+	txNumsReader := m.BlockReader.TxnumReader(ctx)
+	reader, err := rpchelper.CreateHistoryStateReader(tx, txNumsReader, block.NumberU64(), 0, m.ChainConfig.ChainName)
+	if err != nil {
+		return err
+	}
+	getHeader := func(hash libcommon.Hash, number uint64) *types.Header {
+		h, _ := m.BlockReader.Header(ctx, tx, hash, number)
+		return h
+	}
+	chainReader := stagedsync.ChainReader{Cfg: *m.ChainConfig, Db: tx, BlockReader: m.BlockReader, Logger: log.New()}
+	writer := newRecordingWriter()
+	res, err := core.ExecuteBlockEphemerally(m.ChainConfig, &vm.Config{}, core.GetHashFn(block.Header(), getHeader), m.Engine, block, reader, writer, chainReader, nil, log.New())
+	if err != nil {
+		return err
+	}
+	if err := compareReceipts(res.Receipts, receipts); err != nil {
+		return err
+	}
+
+	// the state after the block is the state before the first txNum of the next one
+	maxTxNum, err := txNumsReader.Max(tx, block.NumberU64())
+	if err != nil {
+		return err
+	}
+	post := state.NewHistoryReaderV3()
+	post.SetTx(tx)
+	post.SetTxNum(maxTxNum + 1)
+	return writer.compare(post)
+}
+
+func compareReceipts(have, want types.Receipts) error {
+	if len(have) != len(want) {
+		return fmt.Errorf("have %d receipts, want %d", len(have), len(want))
+	}
+	for i := range have {
+		h, w := have[i], want[i]
+		if h.Status != w.Status || h.GasUsed != w.GasUsed || h.CumulativeGasUsed != w.CumulativeGasUsed ||
+			h.TxHash != w.TxHash || h.ContractAddress != w.ContractAddress || h.Bloom != w.Bloom {
+			return fmt.Errorf("receipt %d: have %+v, want %+v", i, h, w)
+		}
+		if len(h.Logs) != len(w.Logs) {
+			return fmt.Errorf("receipt %d: have %d logs, want %d", i, len(h.Logs), len(w.Logs))
+		}
+		for j := range h.Logs {
+			hl, wl := h.Logs[j], w.Logs[j]
+			if hl.Address != wl.Address || !bytes.Equal(hl.Data, wl.Data) || fmt.Sprint(hl.Topics) != fmt.Sprint(wl.Topics) {
+				return fmt.Errorf("receipt %d log %d: have %+v, want %+v", i, j, hl, wl)
+			}
+		}
+	}
+	return nil
+}
+
+type storageKey struct {
+	addr libcommon.Address
+	key  libcommon.Hash
+}
+
+// recordingWriter keeps the latest value written to every account, code and
+// storage slot.
+type recordingWriter struct {
+	accounts map[libcommon.Address]*accounts.Account // nil for deleted accounts
+	code     map[libcommon.Address][]byte
+	storage  map[storageKey]uint256.Int
+}
+
+var _ state.WriterWithChangeSets = (*recordingWriter)(nil)
+
+func newRecordingWriter() *recordingWriter {
+	return &recordingWriter{
+		accounts: map[libcommon.Address]*accounts.Account{},
+		code:     map[libcommon.Address][]byte{},
+		storage:  map[storageKey]uint256.Int{},
+	}
+}
+
+func (w *recordingWriter) UpdateAccountData(address libcommon.Address, original, account *accounts.Account) error {
+	acc := new(accounts.Account)
+	acc.Copy(account)
+	w.accounts[address] = acc
+	return nil
+}
+
+func (w *recordingWriter) UpdateAccountCode(address libcommon.Address, incarnation uint64, codeHash libcommon.Hash, code []byte) error {
+	w.code[address] = libcommon.CopyBytes(code)
+	return nil
+}
+
+func (w *recordingWriter) DeleteAccount(address libcommon.Address, original *accounts.Account) error {
+	w.accounts[address] = nil
+	delete(w.code, address)
+	return nil
+}
+
+func (w *recordingWriter) WriteAccountStorage(address libcommon.Address, incarnation uint64, key *libcommon.Hash, original, value *uint256.Int) error {
+	w.storage[storageKey{address, *key}] = *value
+	return nil
+}
+
+func (w *recordingWriter) CreateContract(address libcommon.Address) error { return nil }
+func (w *recordingWriter) WriteChangeSets() error                         { return nil }
+func (w *recordingWriter) WriteHistory() error                            { return nil }
+
+// compare checks that the recorded writes match the state read by r.
+func (w *recordingWriter) compare(r state.StateReader) error {
+	for addr, want := range w.accounts {
+		have, err := r.ReadAccountData(addr)
+		if err != nil {
+			return err
+		}
+		switch {
+		case want == nil && have != nil:
+			return fmt.Errorf("%x: deleted account exists in history: %+v", addr, have)
+		case want == nil:
+		case have == nil:
+			return fmt.Errorf("%x: account missing from history", addr)
+		case have.Nonce != want.Nonce || !have.Balance.Eq(&want.Balance) || have.CodeHash != want.CodeHash:
+			return fmt.Errorf("%x: have account nonce %d balance %d code %x, want nonce %d balance %d code %x",
+				addr, have.Nonce, &have.Balance, have.CodeHash, want.Nonce, &want.Balance, want.CodeHash)
+		}
+	}
+	for addr, want := range w.code {
+		have, err := r.ReadAccountCode(addr, 0)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(have, want) {
+			return fmt.Errorf("%x: have code %x, want %x", addr, have, want)
+		}
+	}
+	for k, want := range w.storage {
+		if acc, ok := w.accounts[k.addr]; ok && acc == nil {
+			continue
+		}
+		enc, err := r.ReadAccountStorage(k.addr, 0, &k.key)
+		if err != nil {
+			return err
+		}
+		if have := new(uint256.Int).SetBytes(enc); !have.Eq(&want) {
+			return fmt.Errorf("%x: have slot %x = %d, want %d", k.addr, k.key, have, &want)
+		}
+	}
+	return nil
+}
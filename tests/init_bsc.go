@@ -0,0 +1,121 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"math/big"
+
+	"github.com/erigontech/erigon-lib/chain"
+
+	"github.com/erigontech/erigon/params"
+)
+
+// bscForks are the BSC hard forks in activation order, along with the chain
+// config fields each of them activates (including the Ethereum forks shipped
+// with them).
+var bscForks = []struct {
+	name   string
+	byTime bool
+	fields func(c *chain.Config) []**big.Int
+}{
+	{"Luban", false, func(c *chain.Config) []**big.Int {
+		return []**big.Int{&c.HomesteadBlock, &c.TangerineWhistleBlock, &c.SpuriousDragonBlock, &c.ByzantiumBlock,
+			&c.ConstantinopleBlock, &c.PetersburgBlock, &c.IstanbulBlock, &c.MuirGlacierBlock, &c.RamanujanBlock,
+			&c.NielsBlock, &c.MirrorSyncBlock, &c.BrunoBlock, &c.EulerBlock, &c.NanoBlock, &c.MoranBlock,
+			&c.GibbsBlock, &c.PlanckBlock, &c.LubanBlock}
+	}},
+	{"Plato", false, func(c *chain.Config) []**big.Int { return []**big.Int{&c.PlatoBlock} }},
+	{"Hertz", false, func(c *chain.Config) []**big.Int {
+		return []**big.Int{&c.BerlinBlock, &c.LondonBlock, &c.HertzBlock, &c.HertzfixBlock}
+	}},
+	{"Feynman", true, func(c *chain.Config) []**big.Int {
+		return []**big.Int{&c.ShanghaiTime, &c.KeplerTime, &c.FeynmanTime, &c.FeynmanFixTime}
+	}},
+	{"Haber", true, func(c *chain.Config) []**big.Int {
+		return []**big.Int{&c.CancunTime, &c.HaberTime, &c.HaberFixTime}
+	}},
+	{"Bohr", true, func(c *chain.Config) []**big.Int { return []**big.Int{&c.BohrTime} }},
+	{"Pascal", true, func(c *chain.Config) []**big.Int { return []**big.Int{&c.PragueTime, &c.PascalTime} }},
+	{"Lorentz", true, func(c *chain.Config) []**big.Int { return []**big.Int{&c.LorentzTime} }},
+	{"Maxwell", true, func(c *chain.Config) []**big.Int { return []**big.Int{&c.MaxwellTime} }},
+}
+
+// Adds the BSC forks, and the transitions between consecutive forks, e.g.
+// "PlatoToHertzAt5" or "BohrToPascalAtTime15k", to the Forks table.
+func init() {
+	for i, fork := range bscForks {
+		Forks[fork.name] = bscConfig(i, nil)
+		if i == 0 {
+			continue
+		}
+		name, at := bscForks[i-1].name+"To"+fork.name+"At5", big.NewInt(5)
+		if fork.byTime {
+			name, at = bscForks[i-1].name+"To"+fork.name+"AtTime15k", big.NewInt(15_000)
+		}
+		Forks[name] = bscConfig(i-1, at)
+	}
+}
+
+// bscConfig returns a Parlia config with the BSC forks up to bscForks[last]
+// active from genesis. If next is not nil, the following fork is scheduled at
+// block (or time) next, along with the system contract upgrades it brings on
+// BSC mainnet.
+func bscConfig(last int, next *big.Int) *chain.Config {
+	mainnet := params.BSCChainConfig
+	c := &chain.Config{
+		ChainID:      big.NewInt(56),
+		Consensus:    chain.ParliaConsensus,
+		BlobSchedule: mainnet.BlobSchedule,
+		Parlia:       &chain.ParliaConfig{BlockAlloc: map[string]interface{}{}},
+	}
+	for _, fork := range bscForks[:last+1] {
+		for _, f := range fork.fields(c) {
+			*f = big.NewInt(0)
+		}
+	}
+	if next == nil || last+1 >= len(bscForks) {
+		return c
+	}
+	fork := bscForks[last+1]
+	mainnetFields := fork.fields(mainnet)
+	for i, f := range fork.fields(c) {
+		*f = next
+		if alloc, ok := mainnet.Parlia.BlockAlloc[(*mainnetFields[i]).String()]; ok {
+			mergeBlockAlloc(c.Parlia.BlockAlloc, next.String(), alloc)
+		}
+	}
+	return c
+}
+
+// mergeBlockAlloc adds the upgrades of alloc to the ones already scheduled at
+// key, the ones of alloc taking precedence.
+func mergeBlockAlloc(blockAlloc map[string]interface{}, key string, alloc interface{}) {
+	prev, ok := blockAlloc[key].(map[string]interface{})
+	upgrades, isMap := alloc.(map[string]interface{})
+	if !ok || !isMap {
+		blockAlloc[key] = alloc
+		return
+	}
+	merged := make(map[string]interface{}, len(prev)+len(upgrades))
+	for addr, account := range prev {
+		merged[addr] = account
+	}
+	for addr, account := range upgrades {
+		merged[addr] = account
+	}
+	blockAlloc[key] = merged
+}
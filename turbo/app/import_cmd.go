@@ -86,7 +86,10 @@ func importChain(cliCtx *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	ethCfg := turboNode.NewEthConfigUrfave(cliCtx, nodeCfg, logger)
+	ethCfg, err := turboNode.NewEthConfigUrfave(cliCtx, nodeCfg, logger)
+	if err != nil {
+		return err
+	}
 
 	stack := makeConfigNode(cliCtx.Context, nodeCfg, logger)
 	defer stack.Close()
@@ -42,6 +42,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/background"
 	"github.com/erigontech/erigon-lib/common/compress"
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/common/dbg"
@@ -49,6 +50,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/disk"
 	"github.com/erigontech/erigon-lib/common/mem"
 	"github.com/erigontech/erigon-lib/config3"
+	diaglib "github.com/erigontech/erigon-lib/diagnostics"
 	"github.com/erigontech/erigon-lib/downloader"
 	"github.com/erigontech/erigon-lib/downloader/snaptype"
 	"github.com/erigontech/erigon-lib/etl"
@@ -81,6 +83,7 @@ import (
 	"github.com/erigontech/erigon/turbo/node"
 	"github.com/erigontech/erigon/turbo/services"
 	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+	"github.com/erigontech/erigon/turbo/snapshotsync/retire"
 	"github.com/spf13/afero"
 )
 
@@ -146,9 +149,10 @@ var snapshotCommand = cli.Command{
 
 				return doRetireCommand(c, dirs)
 			},
-			Usage: "create snapshots from the specified block number",
+			Usage: "create snapshots from the specified block number. Interrupted run continues from last finished job",
 			Flags: joinFlags([]cli.Flag{
 				&utils.DataDirFlag,
+				&SnapshotRetireWorkersFlag,
 			}),
 		},
 		{
@@ -337,6 +341,11 @@ var (
 		Name:  "withoutBsc",
 		Usage: "don't build Bsc snapshots",
 	}
+	SnapshotRetireWorkersFlag = cli.IntFlag{
+		Name:  "retire.workers",
+		Usage: "Workers budget shared by concurrently running retire jobs (blocks, bsc, state)",
+		Value: runtime.NumCPU(),
+	}
 )
 
 func doRmStateSnapshots(cliCtx *cli.Context) error {
@@ -1676,7 +1685,7 @@ func doUnmerge(cliCtx *cli.Context, dirs datadir.Dirs) error {
 }
 
 func doRetireCommand(cliCtx *cli.Context, dirs datadir.Dirs) error {
-	logger, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	logger, metricsMux, _, err := debug.Setup(cliCtx, true /* rootLogger */)
 	if err != nil {
 		return err
 	}
@@ -1705,13 +1714,19 @@ func doRetireCommand(cliCtx *cli.Context, dirs datadir.Dirs) error {
 	defer br.MadvNormal().DisableReadAhead()
 	defer agg.MadvNormal().DisableReadAhead()
 
+	// expose retire progress to `erigon diag retire`
+	if metricsMux != nil {
+		diagMux := diagnostics.SetupDiagnosticsEndpoint(metricsMux, "")
+		if diagClient, err := diaglib.NewDiagnosticClient(ctx, diagMux, dirs.DataDir, false, nil); err == nil {
+			diagClient.Setup()
+			diagnostics.SetupStagesAccess(diagMux, diagClient)
+		} else {
+			logger.Warn("[retire] can't setup diagnostics", "err", err)
+		}
+	}
+
 	blockSnapBuildSema := semaphore.NewWeighted(max(int64(runtime.NumCPU()), int64(dbg.BuildSnapshotAllowance)))
 	agg.SetSnapshotBuildSema(blockSnapBuildSema)
-
-	// `erigon retire` command is designed to maximize resouces utilization. But `Erigon itself` does minimize background impact (because not in rush).
-	agg.SetCollateAndBuildWorkers(estimate.StateV3Collate.Workers())
-	agg.SetMergeWorkers(estimate.AlmostAllCPUs())
-	agg.SetCompressWorkers(estimate.CompressSnapshot.Workers())
 	agg.PeriodicalyPrintProcessSet(ctx)
 
 	if err := br.BuildMissedIndicesIfNeed(ctx, "retire", nil); err != nil {
@@ -1732,6 +1747,7 @@ func doRetireCommand(cliCtx *cli.Context, dirs datadir.Dirs) error {
 	}
 
 	blockReader, _ := br.IO()
+	blocksTarget := to
 
 	blocksInSnapshots := blockReader.FrozenBlocks()
 
@@ -1744,91 +1760,114 @@ func doRetireCommand(cliCtx *cli.Context, dirs datadir.Dirs) error {
 		from, to = from2, to2
 	}
 
-	if err := br.RetireBlocks(ctx, from, to, log.LvlInfo, nil, nil, nil); err != nil {
+	txNumsReader := blockReader.TxnumReader(ctx)
+	var lastTxNum uint64
+	if err := db.View(ctx, func(tx kv.Tx) error {
+		execProgress, _ := stages.GetStageProgress(tx, stages.Execution)
+		lastTxNum, err = txNumsReader.Max(tx, execProgress)
 		return err
-	}
-	if err := br.RemoveOverlaps(); err != nil {
+	}); err != nil {
 		return err
 	}
 
-	deletedBlocks := math.MaxInt // To pass the first iteration
-	allDeletedBlocks := 0
-	for deletedBlocks > 0 { // prune happens by small steps, so need many runs
-		err = db.UpdateNosync(ctx, func(tx kv.RwTx) error {
-			if deletedBlocks, err = br.PruneAncientBlocks(tx, 100); err != nil {
-				return err
-			}
-			return nil
-		})
-		if err != nil {
-			return err
-		}
-
-		allDeletedBlocks += deletedBlocks
+	pipeline, err := retire.NewPipeline(filepath.Join(dirs.Snap, "retire-progress.json"), cliCtx.Int(SnapshotRetireWorkersFlag.Name), logger)
+	if err != nil {
+		return err
 	}
 
-	logger.Info("Pruning has ended", "deleted blocks", allDeletedBlocks)
+	// bsc blobs don't depend on blocks retirement: retire them concurrently
+	br.SkipBsc(chainConfig.Parlia != nil)
 
-	db = temporal.New(db, agg)
+	// `erigon retire` command is designed to maximize resouces utilization. But `Erigon itself` does minimize background impact (because not in rush).
+	// Every job sets workers of the component it drives to what it was granted from the pipeline budget.
+	pipeline.Add(
+		&retire.Job{Type: "blocks", Name: "retire", From: from, To: to, Target: blocksTarget, Workers: estimate.CompressSnapshot.Workers(),
+			Run: func(ctx context.Context, workers int, _ *background.Progress) error {
+				br.SetWorkers(workers)
+				if err := br.RetireBlocks(ctx, from, to, log.LvlInfo, nil, nil, nil); err != nil {
+					return err
+				}
+				return br.RemoveOverlaps()
+			}},
+		&retire.Job{Type: "blocks", Name: "prune", From: from, To: to, Target: blocksTarget, Workers: 1,
+			Run: func(ctx context.Context, _ int, p *background.Progress) error {
+				deletedBlocks := math.MaxInt // To pass the first iteration
+				allDeletedBlocks := 0
+				for deletedBlocks > 0 { // prune happens by small steps, so need many runs
+					if err := db.UpdateNosync(ctx, func(tx kv.RwTx) (err error) {
+						deletedBlocks, err = br.PruneAncientBlocks(tx, 100)
+						return err
+					}); err != nil {
+						return err
+					}
+					allDeletedBlocks += deletedBlocks
+					p.Processed.Add(uint64(deletedBlocks))
+				}
+				logger.Info("Pruning has ended", "deleted blocks", allDeletedBlocks)
+				return nil
+			}},
+	)
 
-	logger.Info("Prune state history")
-	for hasMoreToPrune := true; hasMoreToPrune; {
-		if err := db.Update(ctx, func(tx kv.RwTx) error {
-			err = tx.(kv.TemporalRwTx).Debug().GreedyPruneHistory(ctx, kv.CommitmentDomain)
-			if err != nil {
+	if chainConfig.Parlia != nil {
+		pipeline.Add(&retire.Job{Type: "bsc", Name: "retire", From: blockReader.FrozenBscBlobs(), To: to, Target: blocksTarget, Workers: 1,
+			Run: func(ctx context.Context, workers int, _ *background.Progress) error {
+				br.SetBscWorkers(workers)
+				return br.RetireBscBlocks(ctx, to, log.LvlInfo, nil, nil)
+			}})
+	}
+
+	tdb := temporal.New(db, agg)
+	pruneSmallBatches := func(ctx context.Context, p *background.Progress, greedyCommitment bool) error {
+		for hasMoreToPrune := true; hasMoreToPrune; {
+			if err := tdb.Update(ctx, func(tx kv.RwTx) (err error) {
+				if greedyCommitment {
+					if err = tx.(kv.TemporalRwTx).Debug().GreedyPruneHistory(ctx, kv.CommitmentDomain); err != nil {
+						return err
+					}
+				}
+				hasMoreToPrune, err = tx.(kv.TemporalRwTx).Debug().PruneSmallBatches(ctx, 2*time.Minute)
 				return err
-			}
-			hasMoreToPrune, err = tx.(kv.TemporalRwTx).Debug().PruneSmallBatches(ctx, 2*time.Minute)
-			if err != nil {
+			}); err != nil {
 				return err
 			}
-			return nil
-		}); err != nil {
-			return err
-		}
-	}
-
-	logger.Info("Work on state history snapshots")
-	indexWorkers := estimate.IndexSnapshot.Workers()
-	if err = agg.BuildMissedIndices(ctx, indexWorkers); err != nil {
-		return err
-	}
-
-	txNumsReader := blockReader.TxnumReader(ctx)
-	var lastTxNum uint64
-	if err := db.Update(ctx, func(tx kv.RwTx) error {
-		execProgress, _ := stages.GetStageProgress(tx, stages.Execution)
-		lastTxNum, err = txNumsReader.Max(tx, execProgress)
-		if err != nil {
-			return err
+			p.Processed.Add(1)
 		}
 		return nil
-	}); err != nil {
-		return err
-	}
-
-	logger.Info("Build state history snapshots")
-	if err = agg.BuildFiles(lastTxNum); err != nil {
-		return err
-	}
-
-	for hasMoreToPrune := true; hasMoreToPrune; {
-		if err := db.Update(ctx, func(tx kv.RwTx) error {
-			hasMoreToPrune, err = tx.(kv.TemporalRwTx).Debug().PruneSmallBatches(ctx, 2*time.Minute)
-			return err
-		}); err != nil {
-			return err
-		}
 	}
 
-	if err = agg.MergeLoop(ctx); err != nil {
-		return err
-	}
-	if err = agg.RemoveOverlapsAfterMerge(ctx); err != nil {
-		return err
-	}
+	pipeline.Add(
+		&retire.Job{Type: "state", Name: "prune", To: lastTxNum, Target: lastTxNum, Workers: 1,
+			Run: func(ctx context.Context, _ int, p *background.Progress) error {
+				logger.Info("Prune state history")
+				return pruneSmallBatches(ctx, p, true)
+			}},
+		&retire.Job{Type: "state", Name: "index", To: lastTxNum, Target: lastTxNum, Workers: estimate.IndexSnapshot.Workers(),
+			Run: func(ctx context.Context, workers int, _ *background.Progress) error {
+				logger.Info("Work on state history snapshots")
+				return agg.BuildMissedIndices(ctx, workers)
+			}},
+		&retire.Job{Type: "state", Name: "build", To: lastTxNum, Target: lastTxNum, Workers: estimate.StateV3Collate.Workers(),
+			Run: func(ctx context.Context, workers int, p *background.Progress) error {
+				logger.Info("Build state history snapshots")
+				agg.SetCollateAndBuildWorkers(workers)
+				agg.SetCompressWorkers(workers)
+				if err := agg.BuildFiles(lastTxNum); err != nil {
+					return err
+				}
+				return pruneSmallBatches(ctx, p, false)
+			}},
+		&retire.Job{Type: "state", Name: "merge", To: lastTxNum, Target: lastTxNum, Workers: estimate.AlmostAllCPUs(),
+			Run: func(ctx context.Context, workers int, _ *background.Progress) error {
+				agg.SetMergeWorkers(workers)
+				agg.SetCompressWorkers(workers)
+				if err := agg.MergeLoop(ctx); err != nil {
+					return err
+				}
+				return agg.RemoveOverlapsAfterMerge(ctx)
+			}},
+	)
 
-	return nil
+	return pipeline.Run(ctx)
 }
 
 func doUploaderCommand(cliCtx *cli.Context) error {
@@ -1855,7 +1894,10 @@ func doUploaderCommand(cliCtx *cli.Context) error {
 		return err
 	}
 
-	ethCfg := node.NewEthConfigUrfave(cliCtx, nodeCfg, logger)
+	ethCfg, err := node.NewEthConfigUrfave(cliCtx, nodeCfg, logger)
+	if err != nil {
+		return err
+	}
 
 	ethNode, err := node.New(cliCtx.Context, nodeCfg, ethCfg, logger)
 	if err != nil {
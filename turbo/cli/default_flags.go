@@ -108,6 +108,10 @@ var DefaultFlags = []cli.Flag{
 
 	&utils.SnapKeepBlocksFlag,
 	&utils.SnapStopFlag,
+	&utils.SnapRemoteFlag,
+	&utils.SnapRemoteDiskCacheFlag,
+	&utils.SnapCodecsFlag,
+	&utils.SnapCodecsDictsFlag,
 	&utils.SnapStateStopFlag,
 	&utils.SnapSkipStateSnapshotDownloadFlag,
 	&utils.DbPageSizeFlag,
@@ -125,6 +129,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.P2pProtocolVersionFlag,
 	&utils.P2pProtocolAllowedPorts,
 	&utils.NATFlag,
+	&utils.NATCheckIntervalFlag,
 	&utils.NoDiscoverFlag,
 	&utils.DiscoveryV5Flag,
 	&utils.NetrestrictFlag,
@@ -134,6 +139,12 @@ var DefaultFlags = []cli.Flag{
 	&utils.BootnodesFlag,
 	&utils.StaticPeersFlag,
 	&utils.TrustedPeersFlag,
+	&utils.PriorityPeersFlag,
+	&utils.PriorityValidatorsFlag,
+	&utils.P2pIngressRateFlag,
+	&utils.P2pEgressRateFlag,
+	&utils.P2pPeerIngressRateFlag,
+	&utils.P2pPeerEgressRateFlag,
 	&utils.MaxPeersFlag,
 	&utils.ChainFlag,
 	&utils.DeveloperPeriodFlag,
@@ -243,6 +254,9 @@ var DefaultFlags = []cli.Flag{
 	&SyncLoopBlockLimitFlag,
 	&SyncLoopBreakAfterFlag,
 	&SyncParallelStateFlushing,
+	&SyncParallelExec,
+	&SyncExecPrefetch,
+	&SyncExecPrefetchCPUBudget,
 
 	&utils.ChaosMonkeyFlag,
 
@@ -158,6 +158,24 @@ var (
 		Value: true,
 	}
 
+	SyncParallelExec = cli.BoolFlag{
+		Name:  "sync.parallel-exec",
+		Usage: "Enables parallel execution of block transactions during initial sync (experimental)",
+		Value: false,
+	}
+
+	SyncExecPrefetch = cli.BoolFlag{
+		Name:  "sync.exec-prefetch",
+		Usage: "Warms up the state read by the blocks executed at chain tip, by speculatively executing their transactions",
+		Value: false,
+	}
+
+	SyncExecPrefetchCPUBudget = cli.Float64Flag{
+		Name:  "sync.exec-prefetch.cpu-budget",
+		Usage: "Number of CPU cores the state prefetcher may use, e.g. 0.5 or 2",
+		Value: ethconfig.Defaults.Sync.ExecPrefetchCPUBudget,
+	}
+
 	UploadLocationFlag = cli.StringFlag{
 		Name:  "upload.location",
 		Usage: "Location to upload snapshot segments to",
@@ -324,6 +342,9 @@ func ApplyFlagsForEthConfig(ctx *cli.Context, cfg *ethconfig.Config, logger log.
 		cfg.Sync.LoopBlockLimit = limit
 	}
 	cfg.Sync.ParallelStateFlushing = ctx.Bool(SyncParallelStateFlushing.Name)
+	cfg.Sync.ParallelExec = ctx.Bool(SyncParallelExec.Name)
+	cfg.Sync.ExecPrefetch = ctx.Bool(SyncExecPrefetch.Name)
+	cfg.Sync.ExecPrefetchCPUBudget = ctx.Float64(SyncExecPrefetchCPUBudget.Name)
 
 	if location := ctx.String(UploadLocationFlag.Name); len(location) > 0 {
 		cfg.Sync.UploadLocation = location
@@ -21,8 +21,13 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/erigontech/erigon-lib/common/debug"
+	"github.com/erigontech/erigon-lib/gointerfaces"
 	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon/p2p"
+	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/rpc"
 
 	"github.com/erigontech/erigon/turbo/rpchelper"
 )
@@ -38,6 +43,19 @@ type AdminAPI interface {
 
 	// AddPeer requests connecting to a remote node.
 	AddPeer(ctx context.Context, url string) (bool, error)
+
+	// RemovePeer disconnects from a remote node and removes it from the static nodes.
+	RemovePeer(ctx context.Context, url string) (bool, error)
+
+	// AddTrustedPeer allows a remote node to always connect, even if slots are full.
+	AddTrustedPeer(ctx context.Context, url string) (bool, error)
+
+	// RemoveTrustedPeer removes a remote node from the trusted peer set, but it does not disconnect it.
+	RemoveTrustedPeer(ctx context.Context, url string) (bool, error)
+
+	// PeerEvents creates an RPC subscription which receives peer events (add/drop) of all sentries.
+	// https://geth.ethereum.org/docs/interacting-with-geth/rpc/ns-admin#admin-peerevents
+	PeerEvents(ctx context.Context) (*rpc.Subscription, error)
 }
 
 // AdminAPIImpl data structure to store things needed for admin_* commands.
@@ -79,3 +97,78 @@ func (api *AdminAPIImpl) AddPeer(ctx context.Context, url string) (bool, error)
 	}
 	return result.Success, nil
 }
+
+func (api *AdminAPIImpl) RemovePeer(ctx context.Context, url string) (bool, error) {
+	result, err := api.ethBackend.RemovePeer(ctx, &remote.RemovePeerRequest{Url: url})
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, errors.New("nil removePeer response")
+	}
+	return result.Success, nil
+}
+
+func (api *AdminAPIImpl) AddTrustedPeer(ctx context.Context, url string) (bool, error) {
+	result, err := api.ethBackend.AddTrustedPeer(ctx, &remote.AddTrustedPeerRequest{Url: url})
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, errors.New("nil addTrustedPeer response")
+	}
+	return result.Success, nil
+}
+
+func (api *AdminAPIImpl) RemoveTrustedPeer(ctx context.Context, url string) (bool, error) {
+	result, err := api.ethBackend.RemoveTrustedPeer(ctx, &remote.RemoveTrustedPeerRequest{Url: url})
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, errors.New("nil removeTrustedPeer response")
+	}
+	return result.Success, nil
+}
+
+func (api *AdminAPIImpl) PeerEvents(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	// subscription outlives the request: stream is canceled when subscription is closed
+	streamCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		defer cancel()
+		select {
+		case <-rpcSub.Err():
+		case <-streamCtx.Done():
+		}
+	}()
+	go func() {
+		defer debug.LogPanic()
+		defer cancel()
+		err := api.ethBackend.PeerEvents(streamCtx, func(event *remote.PeerEvent) {
+			if err := notifier.Notify(rpcSub.ID, peerEventToRPC(event)); err != nil {
+				log.Warn("[rpc] error while notifying subscription", "err", err)
+			}
+		})
+		if err != nil && streamCtx.Err() == nil {
+			log.Warn("[rpc] peer events stream failed", "err", err)
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+func peerEventToRPC(event *remote.PeerEvent) *p2p.PeerEvent {
+	eventType := p2p.PeerEventTypeAdd
+	if event.EventId == remote.PeerEvent_Disconnect {
+		eventType = p2p.PeerEventTypeDrop
+	}
+	pubkey := gointerfaces.ConvertH512ToHash(event.PeerId)
+	return &p2p.PeerEvent{Type: eventType, Peer: enode.PubkeyEncoded(pubkey).ID()}
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/direct"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcservices"
+	"github.com/erigontech/erigon/ethdb/privateapi"
+	"github.com/erigontech/erigon/p2p"
+	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/builder"
+	"github.com/erigontech/erigon/turbo/shards"
+)
+
+// adminTestBackend is the node side of the admin peer management calls.
+type adminTestBackend struct {
+	privateapi.EthBackend
+
+	err    error
+	mu     sync.Mutex
+	calls  []string
+	events []*remote.PeerEvent
+	closed chan struct{} // closed when the PeerEvents stream ends
+}
+
+func (b *adminTestBackend) call(name, url string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calls = append(b.calls, name+" "+url)
+	return b.err
+}
+
+func (b *adminTestBackend) RemovePeer(_ context.Context, req *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	if err := b.call("removePeer", req.Url); err != nil {
+		return nil, err
+	}
+	return &remote.RemovePeerReply{Success: true}, nil
+}
+
+func (b *adminTestBackend) AddTrustedPeer(_ context.Context, req *remote.AddTrustedPeerRequest) (*remote.AddTrustedPeerReply, error) {
+	if err := b.call("addTrustedPeer", req.Url); err != nil {
+		return nil, err
+	}
+	return &remote.AddTrustedPeerReply{Success: true}, nil
+}
+
+func (b *adminTestBackend) RemoveTrustedPeer(_ context.Context, req *remote.RemoveTrustedPeerRequest) (*remote.RemoveTrustedPeerReply, error) {
+	if err := b.call("removeTrustedPeer", req.Url); err != nil {
+		return nil, err
+	}
+	return &remote.RemoveTrustedPeerReply{Success: true}, nil
+}
+
+func (b *adminTestBackend) PeerEvents(ctx context.Context, onEvent func(*remote.PeerEvent) error) error {
+	defer close(b.closed)
+	for _, event := range b.events {
+		if err := onEvent(event); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// newAdminTestAPI connects the admin API to the backend the way rpcdaemon does: through the ETHBACKEND server and client.
+func newAdminTestAPI(t *testing.T, backend *adminTestBackend) *AdminAPIImpl {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	server := privateapi.NewEthBackendServer(ctx, backend, nil, shards.NewNotifications(nil), nil, log.New(), builder.NewLatestBlockBuiltStore())
+	return NewAdminAPI(rpcservices.NewRemoteBackend(direct.NewEthBackendClientDirect(server), nil, nil))
+}
+
+func TestAdminPeerManagement(t *testing.T) {
+	const url = "enode://a979fb575495b8d6db44f750317d0f4622bf4c2aa3365d6af7c284339968eef29b69ad0dce72a4d8db5ebb4968de0e3bec910127f134779fbcb0cb6d3331163c@52.16.188.185:30303"
+	calls := []struct {
+		name string
+		call func(api *AdminAPIImpl) (bool, error)
+	}{
+		{"removePeer", func(api *AdminAPIImpl) (bool, error) { return api.RemovePeer(context.Background(), url) }},
+		{"addTrustedPeer", func(api *AdminAPIImpl) (bool, error) { return api.AddTrustedPeer(context.Background(), url) }},
+		{"removeTrustedPeer", func(api *AdminAPIImpl) (bool, error) { return api.RemoveTrustedPeer(context.Background(), url) }},
+	}
+	for _, c := range calls {
+		t.Run(c.name, func(t *testing.T) {
+			backend := &adminTestBackend{}
+			success, err := c.call(newAdminTestAPI(t, backend))
+			require.NoError(t, err)
+			require.True(t, success)
+			require.Equal(t, []string{c.name + " " + url}, backend.calls)
+		})
+		t.Run(c.name+"/error", func(t *testing.T) {
+			errBackend := errors.New("no sentry accepted the peer")
+			success, err := c.call(newAdminTestAPI(t, &adminTestBackend{err: errBackend}))
+			require.ErrorIs(t, err, errBackend)
+			require.False(t, success)
+		})
+	}
+}
+
+func TestAdminPeerEvents(t *testing.T) {
+	var connected, disconnected [64]byte
+	connected[0], disconnected[0] = 1, 2
+	backend := &adminTestBackend{
+		events: []*remote.PeerEvent{
+			{PeerId: gointerfaces.ConvertHashToH512(connected), EventId: remote.PeerEvent_Connect},
+			{PeerId: gointerfaces.ConvertHashToH512(disconnected), EventId: remote.PeerEvent_Disconnect},
+		},
+		closed: make(chan struct{}),
+	}
+
+	logger := log.New()
+	server := rpc.NewServer(50, false /* traceRequests */, false /* debugSingleRequests */, true, logger, 100)
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("admin", newAdminTestAPI(t, backend)))
+	client := rpc.DialInProc(server, logger)
+	defer client.Close()
+
+	events := make(chan *p2p.PeerEvent, len(backend.events))
+	sub, err := client.Subscribe(context.Background(), "admin", events, "peerEvents")
+	require.NoError(t, err)
+
+	want := []*p2p.PeerEvent{
+		{Type: p2p.PeerEventTypeAdd, Peer: enode.PubkeyEncoded(connected).ID()},
+		{Type: p2p.PeerEventTypeDrop, Peer: enode.PubkeyEncoded(disconnected).ID()},
+	}
+	for _, w := range want {
+		select {
+		case event := <-events:
+			require.Equal(t, w, event)
+		case err := <-sub.Err():
+			t.Fatal(err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("timeout waiting for peer event")
+		}
+	}
+
+	// unsubscribing stops the stream on the node side
+	sub.Unsubscribe()
+	select {
+	case <-backend.closed:
+	case <-time.After(10 * time.Second):
+		t.Fatal("peer events stream is not closed after unsubscribe")
+	}
+}
@@ -38,6 +38,7 @@ type BscAPI interface {
 	GetBlobSidecarByTxHash(ctx context.Context, hash libcommon.Hash, fullBlob *bool) (map[string]interface{}, error)
 	GetFinalizedHeader(ctx context.Context, verifiedValidatorNum int64) (map[string]interface{}, error)
 	GetFinalizedBlock(ctx context.Context, verifiedValidatorNum int64, fullTx bool) (map[string]interface{}, error)
+	GetSnapshot(ctx context.Context, blockNr rpc.BlockNumber) (*parlia.Snapshot, error)
 }
 
 type BscImpl struct {
@@ -299,6 +300,27 @@ func (api *BscImpl) GetFinalizedBlock(ctx context.Context, verifiedValidatorNum
 	return api.ethApi.GetBlockByNumber(ctx, rpc.BlockNumber(finalizedBlockNumber), fullTx)
 }
 
+// GetSnapshot returns the parlia snapshot at the given block, stateless
+// verifiers seed their consensus engine with it.
+func (api *BscImpl) GetSnapshot(ctx context.Context, blockNr rpc.BlockNumber) (*parlia.Snapshot, error) {
+	tx, err := api.ethApi.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	chainConfig, err := api.ethApi.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	bsc, err := api.parlia()
+	if err != nil {
+		return nil, err
+	}
+	chain := consensuschain.NewReader(chainConfig, tx, api.ethApi._blockReader, nil)
+	return bsc.API(chain).GetSnapshot(&blockNr)
+}
+
 func (api *BscImpl) getFinalizedNumber(ctx context.Context, verifiedValidatorNum int64) (int64, error) {
 	tx, err := api.ethApi.db.BeginRo(ctx)
 	if err != nil {
@@ -158,6 +158,35 @@ func TestTraceBlockByHash(t *testing.T) {
 	}
 }
 
+func TestProfileBlock(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	ethApi := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+	for _, tt := range debugTraceTransactionTests {
+		tx, err := ethApi.GetTransactionByHash(m.Ctx, common.HexToHash(tt.txHash))
+		if err != nil {
+			t.Fatalf("profileBlock %s: %v", tt.txHash, err)
+		}
+		txcount, err := ethApi.GetBlockTransactionCountByHash(m.Ctx, *tx.BlockHash)
+		if err != nil {
+			t.Fatalf("profileBlock %s: %v", tt.txHash, err)
+		}
+		profile, err := api.ProfileBlock(m.Ctx, rpc.BlockNumberOrHashWithHash(*tx.BlockHash, true))
+		if err != nil {
+			t.Fatalf("profileBlock %s: %v", tt.txHash, err)
+		}
+		if blockNum := tx.BlockNumber.ToInt().Uint64(); profile.FromBlock != blockNum || profile.ToBlock != blockNum {
+			t.Errorf("profileBlock %s: have blocks %d-%d, want %d", tt.txHash, profile.FromBlock, profile.ToBlock, blockNum)
+		}
+		if profile.Calls != uint64(*txcount) {
+			t.Errorf("profileBlock %s: have %d calls, want %d", tt.txHash, profile.Calls, *txcount)
+		}
+		if _, err = json.Marshal(profile); err != nil {
+			t.Fatalf("profileBlock %s: %v", tt.txHash, err)
+		}
+	}
+}
+
 func TestTraceTransaction(t *testing.T) {
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
 	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
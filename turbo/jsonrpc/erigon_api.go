@@ -22,6 +22,7 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/kv"
+	libstate "github.com/erigontech/erigon-lib/state"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/eth/filters"
 	"github.com/erigontech/erigon/p2p"
@@ -51,6 +52,9 @@ type ErigonAPI interface {
 
 	// NodeInfo returns a collection of metadata known about the host.
 	NodeInfo(ctx context.Context) ([]p2p.NodeInfo, error)
+
+	// StorageStats returns disk usage of state per domain/history/inverted index (see ./erigon_storage_stats.go)
+	StorageStats(ctx context.Context) (*libstate.StorageStats, error)
 }
 
 // ErigonImpl is implementation of the ErigonAPI interface
@@ -0,0 +1,44 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+
+	libstate "github.com/erigontech/erigon-lib/state"
+)
+
+// StorageStats implements erigon_storageStats. Returns size of each domain, history and inverted index (files + DB),
+// amount of unmerged steps, pruning lag and simple growth forecast.
+func (api *ErigonImpl) StorageStats(ctx context.Context) (*libstate.StorageStats, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	withAggTx, ok := tx.(libstate.HasAggTx)
+	if !ok {
+		return nil, errors.New("erigon_storageStats: not supported by remote db, start rpcdaemon with --datadir")
+	}
+	aggTx, ok := withAggTx.AggTx().(*libstate.AggregatorRoTx)
+	if !ok {
+		return nil, errors.New("erigon_storageStats: unexpected aggregator tx")
+	}
+	return aggTx.StorageStats(tx)
+}
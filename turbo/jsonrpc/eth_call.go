@@ -135,7 +135,7 @@ func headerByNumberOrHash(ctx context.Context, tx kv.Tx, blockNrOrHash rpc.Block
 }
 
 // EstimateGas implements eth_estimateGas. Returns an estimate of how much gas is necessary to allow the transaction to complete. The transaction will not be added to the blockchain.
-func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *ethapi2.StateOverrides) (hexutil.Uint64, error) {
+func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *ethapi2.StateOverrides, blockOverrides *BlockOverrides) (hexutil.Uint64, error) {
 	var args ethapi2.CallArgs
 	// if we actually get CallArgs here, we use them
 	if argsOrNil != nil {
@@ -148,164 +148,15 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 	}
 	defer dbtx.Rollback()
 
-	// Use latest block by default
-	if blockNrOrHash == nil {
-		blockNrOrHash = &latestNumOrHash
-	}
-
-	chainConfig, err := api.chainConfig(ctx, dbtx)
-	if err != nil {
-		return 0, err
-	}
-	engine := api.engine()
-
-	blockNum, blockHash, isLatest, err := rpchelper.GetCanonicalBlockNumber(ctx, *blockNrOrHash, dbtx, api._blockReader, api.filters) // DoCall cannot be executed on non-canonical blocks
+	env, err := api.newEstimateGasEnv(ctx, dbtx, blockNrOrHash, overrides, blockOverrides)
 	if err != nil {
 		return 0, err
 	}
-
-	block := api.tryBlockFromLru(blockHash)
-
-	// try and get the block from the lru cache first then try DB before failing
-	if block == nil {
-		block, err = api.blockWithSenders(ctx, dbtx, blockHash, blockNum)
-		if err != nil {
-			return 0, err
-		}
-	}
-
-	// try to check if it is a pending block
-	if block == nil {
-		b := api.filters.LastPendingBlock()
-		if b != nil && blockNum == b.NumberU64() {
-			block = b
-		}
-	}
-
-	if block == nil {
-		return 0, errors.New(fmt.Sprintf("could not find the block %s in cache or db", blockNrOrHash.String()))
-	}
-	header := block.HeaderNoCopy()
-
-	stateReader, err := rpchelper.CreateStateReaderFromBlockNumber(ctx, dbtx, api._txNumReader, blockNum, isLatest, 0, api.stateCache, chainConfig.ChainName)
+	gas, err := api.estimateGas(ctx, dbtx, env, args)
 	if err != nil {
 		return 0, err
 	}
-
-	// Binary search the gas requirement, as it may be higher than the amount used
-	var (
-		lo uint64
-		hi uint64
-	)
-	// Use zero address if sender unspecified.
-	if args.From == nil {
-		args.From = new(libcommon.Address)
-	}
-
-	// Determine the highest gas limit can be used during the estimation.
-	if args.Gas != nil && uint64(*args.Gas) >= params.TxGas {
-		hi = uint64(*args.Gas)
-	} else {
-		// Retrieve the block to act as the gas ceiling
-		hi = header.GasLimit
-	}
-	// Recap the highest gas allowance with specified gascap.
-	if hi > api.GasCap {
-		log.Warn("Caller gas above allowance, capping", "requested", hi, "cap", api.GasCap)
-		hi = api.GasCap
-	}
-
-	var feeCap *big.Int
-	if args.GasPrice != nil && (args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil) {
-		return 0, errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
-	} else if args.GasPrice != nil {
-		feeCap = args.GasPrice.ToInt()
-	} else if args.MaxFeePerGas != nil {
-		feeCap = args.MaxFeePerGas.ToInt()
-	} else {
-		feeCap = libcommon.Big0
-	}
-	// Recap the highest gas limit with account's available balance.
-	if feeCap.Sign() != 0 {
-		state := state.New(stateReader)
-		if state == nil {
-			return 0, errors.New("can't get the current state")
-		}
-
-		balance, err := state.GetBalance(*args.From) // from can't be nil
-		if err != nil {
-			return 0, err
-		}
-		available := balance.ToBig()
-		if args.Value != nil {
-			if args.Value.ToInt().Cmp(available) >= 0 {
-				return 0, errors.New("insufficient funds for transfer")
-			}
-			available.Sub(available, args.Value.ToInt())
-		}
-		allowance := new(big.Int).Div(available, feeCap)
-
-		// If the allowance is larger than maximum uint64, skip checking
-		if allowance.IsUint64() && hi > allowance.Uint64() {
-			transfer := args.Value
-			if transfer == nil {
-				transfer = new(hexutil.Big)
-			}
-			log.Warn("Gas estimation capped by limited funds", "original", hi, "balance", balance,
-				"sent", transfer.ToInt(), "maxFeePerGas", feeCap, "fundable", allowance)
-			hi = allowance.Uint64()
-		}
-	}
-
-	caller, err := transactions.NewReusableCaller(engine, stateReader, overrides, header, args, api.GasCap, *blockNrOrHash, dbtx, api._blockReader, chainConfig, api.evmCallTimeout)
-	if err != nil {
-		return 0, err
-	}
-
-	// First try with highest gas possible
-	result, err := caller.DoCallWithNewGas(ctx, hi, engine, overrides)
-	if err != nil || result == nil {
-		return 0, err
-	}
-	if result.Failed() {
-		if !errors.Is(result.Err, vm.ErrOutOfGas) {
-			if len(result.Revert()) > 0 {
-				return 0, ethapi2.NewRevertError(result)
-			}
-			return 0, result.Err
-		}
-		// Otherwise, the specified gas cap is too low
-		return 0, fmt.Errorf("gas required exceeds allowance (%d)", hi)
-	}
-	// Assuming a contract can freely run all the instructions, we have
-	// the true amount of gas it wants to consume to execute fully.
-	// We want to ensure that the gas used doesn't fall below this
-	trueGas := result.UsedGas
-	lo = max(trueGas+result.EvmRefund-1, params.TxGas-1)
-
-	i := 0
-	// Execute the binary search and hone in on an executable gas limit
-	for lo+1 < hi {
-		mid := (hi + lo) / 2
-		if mid < trueGas {
-			lo = mid
-			continue
-		}
-		result, err := caller.DoCallWithNewGas(ctx, mid, engine, overrides)
-		// If the error is not nil(consensus error), it means the provided message
-		// call or transaction will never be accepted no matter how much gas it is
-		// assigened. Return the error directly, don't struggle any more.
-		if err != nil && !errors.Is(err, core.ErrIntrinsicGas) {
-			return 0, err
-		}
-		if errors.Is(err, core.ErrIntrinsicGas) || result.Failed() || result.UsedGas < trueGas {
-			lo = mid
-		} else {
-			hi = mid
-		}
-		i++
-	}
-	return hexutil.Uint64(hi), nil
+	return hexutil.Uint64(gas), nil
 }
 
 // GetProof implements eth_getProof partially; Proofs are available only with the `latest` block tag.
@@ -629,7 +480,7 @@ func (api *BaseAPI) getWitness(ctx context.Context, db kv.RoDB, blockNrOrHash rp
 	}
 
 	// execute block #blockNr ephemerally. This will use TrieStateWriter to record touches of accounts and storage keys.
-	_, err = core.ExecuteBlockEphemerally(chainConfig, &vm.Config{}, store.GetHashFn, engine, block, store.Tds, store.TrieStateWriter, store.ChainReader, nil, logger)
+	_, err = core.ExecuteBlockEphemerallyForEngine(chainConfig, &vm.Config{}, store.GetHashFn, engine, block, store.Tds, store.TrieStateWriter, store.ChainReader, nil, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +87,27 @@ func blockHeaderOverride(blockCtx *evmtypes.BlockContext, blockOverride BlockOve
 	}
 }
 
+// override applies the block overrides to blockCtx, overridden block hashes
+// take precedence over the ones blockCtx.GetHash looks up. Nil overrides leave
+// blockCtx untouched.
+func (o *BlockOverrides) override(blockCtx *evmtypes.BlockContext) {
+	if o == nil {
+		return
+	}
+	overrideBlockHash := make(map[uint64]common.Hash)
+	blockHeaderOverride(blockCtx, *o, overrideBlockHash)
+	if len(overrideBlockHash) == 0 {
+		return
+	}
+	getHash := blockCtx.GetHash
+	blockCtx.GetHash = func(i uint64) common.Hash {
+		if hash, ok := overrideBlockHash[i]; ok {
+			return hash
+		}
+		return getHash(i)
+	}
+}
+
 func (api *APIImpl) CallMany(ctx context.Context, bundles []Bundle, simulateContext StateContext, stateOverride *ethapi.StateOverrides, timeoutMilliSecondsPtr *int64) ([][]map[string]interface{}, error) {
 	var (
 		hash               common.Hash
@@ -42,6 +42,7 @@ import (
 	"github.com/erigontech/erigon/core/rawdb"
 	"github.com/erigontech/erigon/core/state"
 	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/eth/consensuschain"
 	"github.com/erigontech/erigon/eth/ethconfig"
 	"github.com/erigontech/erigon/params"
 	"github.com/erigontech/erigon/rpc"
@@ -49,6 +50,7 @@ import (
 	"github.com/erigontech/erigon/turbo/adapter/ethapi"
 	"github.com/erigontech/erigon/turbo/rpchelper"
 	"github.com/erigontech/erigon/turbo/stages/mock"
+	"github.com/erigontech/erigon/turbo/stateless"
 )
 
 func TestEstimateGas(t *testing.T) {
@@ -63,11 +65,42 @@ func TestEstimateGas(t *testing.T) {
 	if _, err := api.EstimateGas(context.Background(), &ethapi.CallArgs{
 		From: &from,
 		To:   &to,
-	}, nil, nil); err != nil {
+	}, nil, nil, nil); err != nil {
 		t.Errorf("calling EstimateGas: %v", err)
 	}
 }
 
+func TestEstimateGasDetailed(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	stateCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, mock.Mock(t))
+	mining := txpool.NewMiningClient(conn)
+	ff := rpchelper.New(ctx, rpchelper.DefaultFiltersConfig, nil, nil, mining, func() {}, m.Log)
+	api := NewEthAPI(NewBaseApi(ff, stateCache, m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, nil), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	var from = libcommon.HexToAddress("0x71562b71999873db5b286df957af199ec94617f7")
+	var to = libcommon.HexToAddress("0x0d3ab14bbad3d99f4203bd7a11acb94882050e7e")
+	args := ethapi.CallArgs{
+		From:     &from,
+		To:       &to,
+		GasPrice: (*hexutil.Big)(big.NewInt(1)),
+	}
+	gas, err := api.EstimateGas(context.Background(), &args, nil, nil, nil)
+	require.NoError(t, err)
+
+	result, err := api.EstimateGasDetailed(context.Background(), args, nil, nil, nil, &EstimateGasOptions{AccessList: true, Cost: true})
+	require.NoError(t, err)
+	require.Equal(t, gas, result.Gas)
+	require.NotNil(t, result.AccessList)
+	require.NotNil(t, result.GasWithAccessList)
+	require.Equal(t, uint64(gas), result.LegacyCost.ToInt().Uint64())
+
+	// Block overrides are applied like in eth_call: a gas limit below the
+	// intrinsic gas leaves nothing to search in
+	gasLimit := hexutil.Uint(params.TxGas - 1)
+	_, err = api.EstimateGas(context.Background(), &args, nil, nil, &BlockOverrides{GasLimit: &gasLimit})
+	require.Error(t, err)
+}
+
 func TestEthCallNonCanonical(t *testing.T) {
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
 	stateCache := kvcache.New(kvcache.DefaultCoherentConfig)
@@ -229,6 +262,35 @@ func TestGetProof(t *testing.T) {
 	}
 }
 
+// TestGetWitnessExecuteStateless executes the head block, a contract call,
+// on top of nothing but its witness and checks that it ends up with the roots
+// committed to in its header.
+func TestGetWitnessExecuteStateless(t *testing.T) {
+	m, _, _ := chainWithDeployedContract(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	ctx := context.Background()
+	const blockNum = 3
+
+	witness, err := api.GetWitness(ctx, rpc.BlockNumberOrHashWithNumber(blockNum))
+	require.NoError(t, err)
+	require.NotEmpty(t, witness)
+
+	tx, err := m.DB.BeginRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	block, err := m.BlockReader.BlockByNumber(ctx, tx, blockNum)
+	require.NoError(t, err)
+	parent, err := m.BlockReader.HeaderByNumber(ctx, tx, blockNum-1)
+	require.NoError(t, err)
+
+	chainReader := consensuschain.NewReader(m.ChainConfig, tx, m.BlockReader, log.New())
+	res, err := stateless.Execute(m.ChainConfig, m.Engine, chainReader, block, parent, witness, log.New())
+	require.NoError(t, err)
+	require.Equal(t, block.Root(), res.StateRoot)
+	require.Equal(t, block.ReceiptHash(), res.ReceiptRoot)
+	require.NoError(t, res.Verify(block.Header()))
+}
+
 func TestGetBlockByTimestampLatestTime(t *testing.T) {
 	ctx := context.Background()
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
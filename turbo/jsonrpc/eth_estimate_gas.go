@@ -0,0 +1,372 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/tracers/logger"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/rpc"
+	ethapi2 "github.com/erigontech/erigon/turbo/adapter/ethapi"
+	"github.com/erigontech/erigon/turbo/rpchelper"
+	"github.com/erigontech/erigon/turbo/transactions"
+)
+
+// EstimateGasOptions selects the optional parts of the eth_estimateGasDetailed result.
+type EstimateGasOptions struct {
+	// AccessList generates the access list of the transaction and estimates
+	// its gas once more with the access list attached.
+	AccessList bool `json:"accessList"`
+	// Cost prices the estimated gas with both legacy and dynamic fee pricing.
+	Cost bool `json:"cost"`
+}
+
+// estimateGasResult is the result of the `eth_estimateGasDetailed` RPC call.
+type estimateGasResult struct {
+	Gas               hexutil.Uint64    `json:"gas"`
+	AccessList        *types.AccessList `json:"accessList,omitempty"`
+	GasWithAccessList *hexutil.Uint64   `json:"gasWithAccessList,omitempty"`
+	// Costs of the transaction using Gas: the gas price for the legacy one,
+	// base fee plus tip capped by maxFeePerGas for the dynamic fee one. Prices
+	// not given in the call arguments are taken from the gas price oracle.
+	LegacyCost     *hexutil.Big `json:"legacyCost,omitempty"`
+	DynamicFeeCost *hexutil.Big `json:"dynamicFeeCost,omitempty"`
+}
+
+// estimateGasEnv is the block and state a gas estimation executes against.
+type estimateGasEnv struct {
+	chainConfig    *chain.Config
+	engine         consensus.EngineReader
+	header         *types.Header
+	blockNrOrHash  rpc.BlockNumberOrHash
+	stateReader    state.StateReader
+	overrides      *ethapi2.StateOverrides
+	blockOverrides *BlockOverrides
+}
+
+// state returns a fresh state with the overrides applied.
+func (env *estimateGasEnv) state() (*state.IntraBlockState, error) {
+	ibs := state.New(env.stateReader)
+	if env.overrides != nil {
+		if err := env.overrides.Override(ibs); err != nil {
+			return nil, err
+		}
+	}
+	return ibs, nil
+}
+
+func (env *estimateGasEnv) gasLimit() uint64 {
+	if env.blockOverrides != nil && env.blockOverrides.GasLimit != nil {
+		return uint64(*env.blockOverrides.GasLimit)
+	}
+	return env.header.GasLimit
+}
+
+func (env *estimateGasEnv) baseFee() *big.Int {
+	if env.blockOverrides != nil && env.blockOverrides.BaseFee != nil {
+		return env.blockOverrides.BaseFee.ToBig()
+	}
+	return env.header.BaseFee
+}
+
+// EstimateGasDetailed implements eth_estimateGasDetailed. Same as eth_estimateGas, and depending on opts also returns
+// the access list of the transaction with the gas it needs when sent with it, and its cost under legacy and EIP-1559 pricing.
+func (api *APIImpl) EstimateGasDetailed(ctx context.Context, args ethapi2.CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *ethapi2.StateOverrides, blockOverrides *BlockOverrides, opts *EstimateGasOptions) (*estimateGasResult, error) {
+	if opts == nil {
+		opts = &EstimateGasOptions{}
+	}
+	// Use zero address if sender unspecified.
+	if args.From == nil {
+		args.From = new(libcommon.Address)
+	}
+
+	dbtx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer dbtx.Rollback()
+
+	env, err := api.newEstimateGasEnv(ctx, dbtx, blockNrOrHash, overrides, blockOverrides)
+	if err != nil {
+		return nil, err
+	}
+	gas, err := api.estimateGas(ctx, dbtx, env, args)
+	if err != nil {
+		return nil, err
+	}
+	result := &estimateGasResult{Gas: hexutil.Uint64(gas)}
+
+	if opts.AccessList {
+		accessList, err := api.estimateAccessList(dbtx, env, args)
+		if err != nil {
+			return nil, err
+		}
+		args.AccessList = &accessList
+		gasWithAccessList, err := api.estimateGas(ctx, dbtx, env, args)
+		if err != nil {
+			return nil, err
+		}
+		result.AccessList = &accessList
+		result.GasWithAccessList = (*hexutil.Uint64)(&gasWithAccessList)
+	}
+
+	if opts.Cost {
+		var suggestedTip *big.Int
+		if args.GasPrice == nil || args.MaxPriorityFeePerGas == nil {
+			if suggestedTip, err = api.suggestTipCap(ctx, dbtx); err != nil {
+				return nil, err
+			}
+		}
+		gasBig := new(big.Int).SetUint64(gas)
+
+		legacyPrice := new(big.Int)
+		if args.GasPrice != nil {
+			legacyPrice.Set(args.GasPrice.ToInt())
+		} else {
+			legacyPrice.Set(suggestedTip)
+			if baseFee := env.baseFee(); baseFee != nil {
+				legacyPrice.Add(legacyPrice, baseFee)
+			}
+		}
+		result.LegacyCost = (*hexutil.Big)(legacyPrice.Mul(legacyPrice, gasBig))
+
+		// Dynamic fee transactions don't exist before London
+		if baseFee := env.baseFee(); baseFee != nil {
+			tip := suggestedTip
+			if args.MaxPriorityFeePerGas != nil {
+				tip = args.MaxPriorityFeePerGas.ToInt()
+			}
+			price := new(big.Int).Add(baseFee, tip)
+			if args.MaxFeePerGas != nil && price.Cmp(args.MaxFeePerGas.ToInt()) > 0 {
+				price.Set(args.MaxFeePerGas.ToInt())
+			}
+			result.DynamicFeeCost = (*hexutil.Big)(price.Mul(price, gasBig))
+		}
+	}
+	return result, nil
+}
+
+// newEstimateGasEnv looks up the block to estimate on, the latest one by default.
+func (api *APIImpl) newEstimateGasEnv(ctx context.Context, dbtx kv.TemporalTx, blockNrOrHash *rpc.BlockNumberOrHash, overrides *ethapi2.StateOverrides, blockOverrides *BlockOverrides) (*estimateGasEnv, error) {
+	// Use latest block by default
+	if blockNrOrHash == nil {
+		blockNrOrHash = &latestNumOrHash
+	}
+
+	chainConfig, err := api.chainConfig(ctx, dbtx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockNum, blockHash, isLatest, err := rpchelper.GetCanonicalBlockNumber(ctx, *blockNrOrHash, dbtx, api._blockReader, api.filters) // DoCall cannot be executed on non-canonical blocks
+	if err != nil {
+		return nil, err
+	}
+
+	block := api.tryBlockFromLru(blockHash)
+
+	// try and get the block from the lru cache first then try DB before failing
+	if block == nil {
+		block, err = api.blockWithSenders(ctx, dbtx, blockHash, blockNum)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// try to check if it is a pending block
+	if block == nil {
+		b := api.filters.LastPendingBlock()
+		if b != nil && blockNum == b.NumberU64() {
+			block = b
+		}
+	}
+
+	if block == nil {
+		return nil, fmt.Errorf("could not find the block %s in cache or db", blockNrOrHash.String())
+	}
+
+	stateReader, err := rpchelper.CreateStateReaderFromBlockNumber(ctx, dbtx, api._txNumReader, blockNum, isLatest, 0, api.stateCache, chainConfig.ChainName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &estimateGasEnv{
+		chainConfig:    chainConfig,
+		engine:         api.engine(),
+		header:         block.HeaderNoCopy(),
+		blockNrOrHash:  *blockNrOrHash,
+		stateReader:    stateReader,
+		overrides:      overrides,
+		blockOverrides: blockOverrides,
+	}, nil
+}
+
+// estimateGas caps the gas the transaction may use by the call arguments, the
+// block gas limit, the RPC gas cap and the sender's funds, and searches for the
+// lowest gas limit it executes with below that cap.
+func (api *APIImpl) estimateGas(ctx context.Context, dbtx kv.Tx, env *estimateGasEnv, args ethapi2.CallArgs) (uint64, error) {
+	var hi uint64
+	// Use zero address if sender unspecified.
+	if args.From == nil {
+		args.From = new(libcommon.Address)
+	}
+
+	// Determine the highest gas limit can be used during the estimation.
+	if args.Gas != nil && uint64(*args.Gas) >= params.TxGas {
+		hi = uint64(*args.Gas)
+	} else {
+		// Retrieve the block to act as the gas ceiling
+		hi = env.gasLimit()
+	}
+	// Recap the highest gas allowance with specified gascap.
+	if hi > api.GasCap {
+		log.Warn("Caller gas above allowance, capping", "requested", hi, "cap", api.GasCap)
+		hi = api.GasCap
+	}
+
+	var feeCap *big.Int
+	if args.GasPrice != nil && (args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil) {
+		return 0, errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
+	} else if args.GasPrice != nil {
+		feeCap = args.GasPrice.ToInt()
+	} else if args.MaxFeePerGas != nil {
+		feeCap = args.MaxFeePerGas.ToInt()
+	} else {
+		feeCap = libcommon.Big0
+	}
+	// Recap the highest gas limit with account's available balance.
+	if feeCap.Sign() != 0 {
+		ibs, err := env.state()
+		if err != nil {
+			return 0, err
+		}
+
+		balance, err := ibs.GetBalance(*args.From) // from can't be nil
+		if err != nil {
+			return 0, err
+		}
+		available := balance.ToBig()
+		if args.Value != nil {
+			if args.Value.ToInt().Cmp(available) >= 0 {
+				return 0, errors.New("insufficient funds for transfer")
+			}
+			available.Sub(available, args.Value.ToInt())
+		}
+		allowance := new(big.Int).Div(available, feeCap)
+
+		// If the allowance is larger than maximum uint64, skip checking
+		if allowance.IsUint64() && hi > allowance.Uint64() {
+			transfer := args.Value
+			if transfer == nil {
+				transfer = new(hexutil.Big)
+			}
+			log.Warn("Gas estimation capped by limited funds", "original", hi, "balance", balance,
+				"sent", transfer.ToInt(), "maxFeePerGas", feeCap, "fundable", allowance)
+			hi = allowance.Uint64()
+		}
+	}
+
+	caller, err := transactions.NewReusableCaller(env.engine, env.stateReader, env.overrides, env.header, args, api.GasCap, env.blockNrOrHash, dbtx, api._blockReader, env.chainConfig, api.evmCallTimeout, env.blockOverrides.override)
+	if err != nil {
+		return 0, err
+	}
+	return caller.EstimateGas(ctx, hi, env.engine, env.overrides)
+}
+
+// estimateAccessList generates the access list of the transaction the same way
+// eth_createAccessList does, on top of the overridden state and block. Entries
+// of addresses warm anyway are dropped unless their storage slots pay for them.
+func (api *APIImpl) estimateAccessList(dbtx kv.Tx, env *estimateGasEnv, args ethapi2.CallArgs) (types.AccessList, error) {
+	// Retrieve the precompiles since they don't need to be added to the access list
+	precompiles := vm.ActivePrecompiles(env.chainConfig.Rules(env.header.Number.Uint64(), env.header.Time))
+	excl := make(map[libcommon.Address]struct{})
+	for _, pc := range precompiles {
+		excl[pc] = struct{}{}
+	}
+
+	var baseFee *uint256.Int
+	if env.header.BaseFee != nil {
+		baseFee, _ = uint256.FromBig(env.header.BaseFee)
+	}
+
+	// Create an initial tracer
+	prevTracer := logger.NewAccessListTracer(nil, excl, nil)
+	if args.AccessList != nil {
+		prevTracer = logger.NewAccessListTracer(*args.AccessList, excl, nil)
+	}
+	for {
+		ibs, err := env.state()
+		if err != nil {
+			return nil, err
+		}
+		// Retrieve the current access list to expand
+		accessList := prevTracer.AccessList()
+		args.AccessList = &accessList
+
+		msg, err := args.ToMessage(api.GasCap, baseFee)
+		if err != nil {
+			return nil, err
+		}
+
+		// Apply the transaction with the access list tracer
+		tracer := logger.NewAccessListTracer(accessList, excl, ibs)
+		config := vm.Config{Tracer: tracer, Debug: true, NoBaseFee: true}
+		blockCtx := transactions.NewEVMBlockContext(env.engine, env.header, env.blockNrOrHash.RequireCanonical, dbtx, api._blockReader, env.chainConfig)
+		env.blockOverrides.override(&blockCtx)
+		txCtx := core.NewEVMTxContext(msg)
+
+		evm := vm.NewEVM(blockCtx, txCtx, ibs, env.chainConfig, config)
+		gp := new(core.GasPool).AddGas(msg.Gas()).AddBlobGas(msg.BlobGas())
+		if _, err := core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */, env.engine); err != nil {
+			return nil, err
+		}
+		if !tracer.Equal(prevTracer) {
+			prevTracer = tracer
+			continue
+		}
+
+		optimized := &accessListResult{Accesslist: &accessList}
+		optimizeWarmAddrInAccessList(optimized, *args.From)
+		if args.To != nil {
+			optimizeWarmAddrInAccessList(optimized, *args.To)
+		}
+		optimizeWarmAddrInAccessList(optimized, blockCtx.Coinbase)
+		for addr := range tracer.CreatedContracts() {
+			if !tracer.UsedBeforeCreation(addr) {
+				optimizeWarmAddrInAccessList(optimized, addr)
+			}
+		}
+		return *optimized.Accesslist, nil
+	}
+}
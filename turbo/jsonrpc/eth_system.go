@@ -149,18 +149,10 @@ func (api *APIImpl) GasPrice(ctx context.Context) (*hexutil.Big, error) {
 		return nil, err
 	}
 	defer tx.Rollback()
-	oracle := gasprice.NewOracle(NewGasPriceOracleBackend(tx, api.BaseAPI), ethconfig.Defaults.GPO, api.gasCache, api.logger.New("app", "gasPriceOracle"))
-	tipcap, err := oracle.SuggestTipCap(ctx)
-	if err != nil {
-		return nil, err
-	}
-	config, err := api.BaseAPI.chainConfig(ctx, tx)
+	tipcap, err := api.suggestTipCap(ctx, tx)
 	if err != nil {
 		return nil, err
 	}
-	if config.Parlia != nil && tipcap.Cmp(gaspricecfg.BscMinimalAcceptPrice) < 0 {
-		tipcap = new(big.Int).Set(gaspricecfg.BscMinimalAcceptPrice)
-	}
 	gasResult := big.NewInt(0)
 
 	gasResult.Set(tipcap)
@@ -178,6 +170,16 @@ func (api *APIImpl) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, err
 		return nil, err
 	}
 	defer tx.Rollback()
+	tipcap, err := api.suggestTipCap(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(tipcap), err
+}
+
+// suggestTipCap returns the gas price oracle's tip suggestion, raised to the
+// minimal price BSC validators accept on Parlia chains.
+func (api *APIImpl) suggestTipCap(ctx context.Context, tx kv.TemporalTx) (*big.Int, error) {
 	oracle := gasprice.NewOracle(NewGasPriceOracleBackend(tx, api.BaseAPI), ethconfig.Defaults.GPO, api.gasCache, api.logger.New("app", "gasPriceOracle"))
 	tipcap, err := oracle.SuggestTipCap(ctx)
 	if err != nil {
@@ -190,7 +192,7 @@ func (api *APIImpl) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, err
 	if config.Parlia != nil && tipcap.Cmp(gaspricecfg.BscMinimalAcceptPrice) < 0 {
 		tipcap = new(big.Int).Set(gaspricecfg.BscMinimalAcceptPrice)
 	}
-	return (*hexutil.Big)(tipcap), err
+	return tipcap, nil
 }
 
 type feeHistoryResult struct {
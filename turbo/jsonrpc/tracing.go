@@ -37,6 +37,7 @@ import (
 	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/core/vm/evmtypes"
 	tracersConfig "github.com/erigontech/erigon/eth/tracers/config"
+	"github.com/erigontech/erigon/eth/tracers/profiler"
 	bortypes "github.com/erigontech/erigon/polygon/bor/types"
 	polygontracer "github.com/erigontech/erigon/polygon/tracer"
 	"github.com/erigontech/erigon/rpc"
@@ -233,6 +234,78 @@ func (api *PrivateDebugAPIImpl) traceBlock(ctx context.Context, blockNrOrHash rp
 	return nil
 }
 
+// ProfileBlock implements debug_profileBlock. Re-executes the block and returns the invocation
+// counts, gas and wall time of the opcodes and precompiles it ran, in total and per contract.
+func (api *PrivateDebugAPIImpl) ProfileBlock(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*profiler.Profile, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNumber, hash, _, err := rpchelper.GetCanonicalBlockNumber(ctx, blockNrOrHash, tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.blockWithSenders(ctx, tx, hash, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("invalid arguments; block with hash %x not found", hash)
+	}
+
+	// if we've pruned this history away for this block then just return early
+	// to save any red herring errors
+	if err = api.BaseAPI.checkPruneHistory(ctx, tx, block.NumberU64()); err != nil {
+		return nil, err
+	}
+
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	engine := api.engine()
+
+	ibs, blockCtx, _, rules, signer, err := transactions.ComputeBlockContext(ctx, engine, block.HeaderNoCopy(), chainConfig, api._blockReader, api._txNumReader, tx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	prof := profiler.New(0)
+	vmConfig := vm.Config{Debug: true, Tracer: prof.Logger()}
+	for txnIndex, txn := range block.Transactions() {
+		select {
+		default:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		ibs.SetTxContext(txnIndex, block.NumberU64())
+		msg, _ := txn.AsMessage(*signer, block.BaseFee(), rules)
+
+		txCtx := evmtypes.TxContext{
+			TxHash:     txn.Hash(),
+			Origin:     msg.From(),
+			GasPrice:   msg.GasPrice(),
+			BlobHashes: msg.BlobHashes(),
+		}
+		evm := vm.NewEVM(blockCtx, txCtx, ibs, chainConfig, vmConfig)
+		gp := new(core.GasPool).AddGas(msg.Gas()).AddBlobGas(msg.BlobGas())
+		if _, err = core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */, engine); err != nil {
+			return nil, fmt.Errorf("profiling txn %x failed: %w", txn.Hash(), err)
+		}
+		if err = ibs.FinalizeTx(rules, state.NewNoopWriter()); err != nil {
+			return nil, err
+		}
+	}
+
+	if profile := prof.Total(); profile != nil {
+		return profile, nil
+	}
+	// block without transactions
+	return profiler.NewProfile(blockNumber), nil
+}
+
 // TraceTransaction implements debug_traceTransaction. Returns Geth style transaction traces.
 func (api *PrivateDebugAPIImpl) TraceTransaction(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig, stream *jsoniter.Stream) error {
 	tx, err := api.db.BeginTemporalRo(ctx)
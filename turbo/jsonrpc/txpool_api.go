@@ -23,8 +23,10 @@ import (
 
 	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	proto_txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon/core/rawdb"
 	"github.com/erigontech/erigon/core/types"
@@ -35,6 +37,7 @@ import (
 type TxPoolAPI interface {
 	Content(ctx context.Context) (map[string]map[string]map[string]*ethapi.RPCTransaction, error)
 	ContentFrom(ctx context.Context, addr libcommon.Address) (map[string]map[string]*ethapi.RPCTransaction, error)
+	PropagationStats(ctx context.Context, hashes *[]libcommon.Hash) (*PropagationStats, error)
 }
 
 // TxPoolAPIImpl data structure to store things needed for net_ commands
@@ -217,6 +220,112 @@ func (api *TxPoolAPIImpl) Status(ctx context.Context) (map[string]hexutil.Uint,
 	}, nil
 }
 
+// PropagationLatency summarizes the latencies of a propagation stage, in microseconds.
+type PropagationLatency struct {
+	Count hexutil.Uint64 `json:"count"`
+	P50   hexutil.Uint64 `json:"p50"`
+	P90   hexutil.Uint64 `json:"p90"`
+	P99   hexutil.Uint64 `json:"p99"`
+	Max   hexutil.Uint64 `json:"max"`
+}
+
+type PropagationSource struct {
+	PeerID hexutility.Bytes `json:"peerId"`
+	Count  hexutil.Uint64   `json:"count"`
+}
+
+// TxnPropagation is the propagation history of a transaction, times are in unix nanoseconds.
+type TxnPropagation struct {
+	Hash         libcommon.Hash   `json:"hash"`
+	Local        bool             `json:"local"`
+	Source       hexutility.Bytes `json:"source,omitempty"`
+	FirstSeen    *hexutil.Uint64  `json:"firstSeen,omitempty"`
+	Announced    *hexutil.Uint64  `json:"announced,omitempty"`
+	Received     *hexutil.Uint64  `json:"received,omitempty"`
+	Propagated   *hexutil.Uint64  `json:"propagated,omitempty"`
+	PropagatedTo hexutil.Uint64   `json:"propagatedTo"`
+}
+
+type PropagationStats struct {
+	Tracked            hexutil.Uint64      `json:"tracked"`
+	Local              hexutil.Uint64      `json:"local"`
+	Remote             hexutil.Uint64      `json:"remote"`
+	AnnounceToReceive  PropagationLatency  `json:"announceToReceive"`
+	ReceiveToPropagate PropagationLatency  `json:"receiveToPropagate"`
+	LocalToPropagate   PropagationLatency  `json:"localToPropagate"`
+	Sources            []PropagationSource `json:"sources"`
+	Txns               []TxnPropagation    `json:"transactions,omitempty"`
+}
+
+// PropagationStats returns how fast the pool receives and passes on transactions,
+// along with the propagation history of the given transactions.
+func (api *TxPoolAPIImpl) PropagationStats(ctx context.Context, hashes *[]libcommon.Hash) (*PropagationStats, error) {
+	request := &proto_txpool.PropagationStatsRequest{}
+	if hashes != nil {
+		request.Hashes = make([]*typesproto.H256, len(*hashes))
+		for i, hash := range *hashes {
+			request.Hashes[i] = gointerfaces.ConvertHashToH256(hash)
+		}
+	}
+	reply, err := api.pool.PropagationStats(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &PropagationStats{
+		Tracked:            hexutil.Uint64(reply.Tracked),
+		Local:              hexutil.Uint64(reply.Local),
+		Remote:             hexutil.Uint64(reply.Remote),
+		AnnounceToReceive:  propagationLatencyFromProto(reply.AnnounceToReceive),
+		ReceiveToPropagate: propagationLatencyFromProto(reply.ReceiveToPropagate),
+		LocalToPropagate:   propagationLatencyFromProto(reply.LocalToPropagate),
+		Sources:            make([]PropagationSource, len(reply.Sources)),
+	}
+	for i, source := range reply.Sources {
+		peerID := gointerfaces.ConvertH512ToHash(source.PeerId)
+		stats.Sources[i] = PropagationSource{PeerID: peerID[:], Count: hexutil.Uint64(source.Count)}
+	}
+	for _, txn := range reply.Txns {
+		entry := TxnPropagation{
+			Hash:         gointerfaces.ConvertH256ToHash(txn.Hash),
+			Local:        txn.Local,
+			FirstSeen:    unixNanoToHex(txn.FirstSeen),
+			Announced:    unixNanoToHex(txn.Announced),
+			Received:     unixNanoToHex(txn.Received),
+			Propagated:   unixNanoToHex(txn.Propagated),
+			PropagatedTo: hexutil.Uint64(txn.PropagatedTo),
+		}
+		if txn.Source != nil {
+			if source := gointerfaces.ConvertH512ToHash(txn.Source); source != [64]byte{} {
+				entry.Source = source[:]
+			}
+		}
+		stats.Txns = append(stats.Txns, entry)
+	}
+	return stats, nil
+}
+
+func propagationLatencyFromProto(latency *proto_txpool.PropagationLatency) PropagationLatency {
+	if latency == nil {
+		return PropagationLatency{}
+	}
+	return PropagationLatency{
+		Count: hexutil.Uint64(latency.Count),
+		P50:   hexutil.Uint64(latency.P50Us),
+		P90:   hexutil.Uint64(latency.P90Us),
+		P99:   hexutil.Uint64(latency.P99Us),
+		Max:   hexutil.Uint64(latency.MaxUs),
+	}
+}
+
+func unixNanoToHex(t int64) *hexutil.Uint64 {
+	if t <= 0 {
+		return nil
+	}
+	v := hexutil.Uint64(t)
+	return &v
+}
+
 /*
 
 // Inspect retrieves the content of the transaction pool and flattens it into an
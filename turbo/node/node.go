@@ -124,12 +124,14 @@ func NewNodConfigUrfave(ctx *cli.Context, logger log.Logger) (*nodecfg.Config, e
 	erigoncli.ApplyFlagsForNodeConfig(ctx, nodeConfig, logger)
 	return nodeConfig, nil
 }
-func NewEthConfigUrfave(ctx *cli.Context, nodeConfig *nodecfg.Config, logger log.Logger) *ethconfig.Config {
+func NewEthConfigUrfave(ctx *cli.Context, nodeConfig *nodecfg.Config, logger log.Logger) (*ethconfig.Config, error) {
 	ethConfig := ethconfig.Defaults // Needs to be a copy, not pointer
-	utils.SetEthConfig(ctx, nodeConfig, &ethConfig, logger)
+	if err := utils.SetEthConfig(ctx, nodeConfig, &ethConfig, logger); err != nil {
+		return nil, err
+	}
 	erigoncli.ApplyFlagsForEthConfig(ctx, &ethConfig, logger)
 
-	return &ethConfig
+	return &ethConfig, nil
 }
 
 // New creates a new `ErigonNode`.
@@ -0,0 +1,110 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package replay
+
+import (
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/consensus/parlia"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+)
+
+// RecordBlock executes block on top of stateReader, which must read the state
+// as of the beginning of the block, and records what the execution reads. A
+// failing execution is recorded too, its error is kept in the recording: the
+// blocks worth replaying are usually the ones which fail.
+func RecordBlock(chainConfig *chain.Config, engine consensus.Engine, chainReader consensus.ChainReader, block *types.Block, stateReader state.StateReader, logger log.Logger) (*BlockRecording, error) {
+	rec := &BlockRecording{
+		Block:       block,
+		BlockHashes: map[uint64]libcommon.Hash{},
+	}
+	if p, ok := engine.(*parlia.Parlia); ok {
+		snap, err := p.API(chainReader).GetSnapshotAtHash(block.ParentHash())
+		if err != nil {
+			return nil, fmt.Errorf("parlia snapshot at %x: %w", block.ParentHash(), err)
+		}
+		rec.ParliaSnapshot = snap
+	}
+
+	reader := state.NewRecordingReader(stateReader)
+	headers := &recordingChain{ChainReader: chainReader, headers: map[libcommon.Hash]*types.Header{}}
+	// The ancestors walked to resolve BLOCKHASH are not recorded as headers,
+	// their hashes are all the replay needs.
+	getHashFn := core.GetHashFn(block.Header(), chainReader.GetHeader)
+	getHash := func(n uint64) libcommon.Hash {
+		h := getHashFn(n)
+		rec.BlockHashes[n] = h
+		return h
+	}
+
+	vmConfig := vm.Config{}
+	if _, err := core.ExecuteBlockEphemerallyForEngine(chainConfig, &vmConfig, getHash, engine, block, reader, state.NewNoopWriter(), headers, nil, logger); err != nil {
+		rec.Err = err.Error()
+	}
+	rec.Reads = reader.Reads()
+	for _, h := range headers.headers {
+		rec.Headers = append(rec.Headers, h)
+	}
+	return rec, nil
+}
+
+// recordingChain collects the headers the consensus engine looks up.
+type recordingChain struct {
+	consensus.ChainReader
+	headers map[libcommon.Hash]*types.Header
+}
+
+func (c *recordingChain) record(h *types.Header) *types.Header {
+	if h != nil {
+		c.headers[h.Hash()] = h
+	}
+	return h
+}
+
+func (c *recordingChain) CurrentHeader() *types.Header {
+	return c.record(c.ChainReader.CurrentHeader())
+}
+func (c *recordingChain) CurrentFinalizedHeader() *types.Header {
+	return c.record(c.ChainReader.CurrentFinalizedHeader())
+}
+func (c *recordingChain) CurrentSafeHeader() *types.Header {
+	return c.record(c.ChainReader.CurrentSafeHeader())
+}
+func (c *recordingChain) GetHeader(hash libcommon.Hash, number uint64) *types.Header {
+	return c.record(c.ChainReader.GetHeader(hash, number))
+}
+func (c *recordingChain) GetHeaderByNumber(number uint64) *types.Header {
+	return c.record(c.ChainReader.GetHeaderByNumber(number))
+}
+func (c *recordingChain) GetHeaderByHash(hash libcommon.Hash) *types.Header {
+	return c.record(c.ChainReader.GetHeaderByHash(hash))
+}
+func (c *recordingChain) GetBlock(hash libcommon.Hash, number uint64) *types.Block {
+	b := c.ChainReader.GetBlock(hash, number)
+	if b != nil {
+		c.record(b.Header())
+	}
+	return b
+}
@@ -0,0 +1,272 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package replay records everything the execution of a block reads, the
+// pre-state, block hashes, ancestor headers and consensus snapshot, so that the
+// block can be re-executed later, on another machine, without the datadir.
+package replay
+
+import (
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types/accounts"
+
+	"github.com/erigontech/erigon/consensus/parlia"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/types"
+)
+
+// recordingVersion is bumped on every incompatible change of the file layout.
+const recordingVersion = 1
+
+// Recording holds the inputs of the execution of a range of blocks.
+type Recording struct {
+	ChainConfig *chain.Config
+	Blocks      []*BlockRecording
+}
+
+// BlockRecording holds the inputs of the execution of a single block. The
+// blocks of a range are recorded independently, each against its own
+// pre-state, so any of them can be replayed on its own.
+type BlockRecording struct {
+	Block       *types.Block
+	Reads       *state.StateReads
+	BlockHashes map[uint64]libcommon.Hash
+	// Headers the consensus engine looked up while executing the block.
+	Headers []*types.Header
+	// ParliaSnapshot is the validator set at the parent of the block, BSC only.
+	ParliaSnapshot *parlia.Snapshot
+	// Err is the error the execution failed with when it was recorded.
+	Err string
+}
+
+type encRecording struct {
+	Version     uint64
+	ChainConfig []byte
+	Blocks      []*encBlock
+}
+
+type encBlock struct {
+	Block          *types.Block
+	Accounts       []encAccount
+	Storage        []encStorage
+	Code           []encCode
+	Incarnations   []encIncarnation
+	BlockHashes    []encBlockHash
+	Headers        []*types.Header
+	ParliaSnapshot []byte
+	Err            string
+}
+
+type encAccount struct {
+	Address libcommon.Address
+	Account []byte // storage encoding, empty for absent accounts
+}
+
+type encStorage struct {
+	Address     libcommon.Address
+	Incarnation uint64
+	Key         libcommon.Hash
+	Value       []byte
+}
+
+type encCode struct {
+	Address     libcommon.Address
+	Incarnation uint64
+	Code        []byte
+}
+
+type encIncarnation struct {
+	Address     libcommon.Address
+	Incarnation uint64
+}
+
+type encBlockHash struct {
+	Number uint64
+	Hash   libcommon.Hash
+}
+
+// Write encodes the recording into w. Entries are sorted so that recording
+// the same block twice yields the same file.
+func Write(w io.Writer, rec *Recording) error {
+	cc, err := json.Marshal(rec.ChainConfig)
+	if err != nil {
+		return err
+	}
+	enc := &encRecording{Version: recordingVersion, ChainConfig: cc}
+	for _, b := range rec.Blocks {
+		eb, err := encodeBlock(b)
+		if err != nil {
+			return fmt.Errorf("block %d: %w", b.Block.NumberU64(), err)
+		}
+		enc.Blocks = append(enc.Blocks, eb)
+	}
+	zw := gzip.NewWriter(w)
+	if err := rlp.Encode(zw, enc); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// Read decodes a recording written by Write.
+func Read(r io.Reader) (*Recording, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	var enc encRecording
+	if err := rlp.Decode(zr, &enc); err != nil {
+		return nil, err
+	}
+	if enc.Version != recordingVersion {
+		return nil, fmt.Errorf("unsupported recording version %d, expected %d", enc.Version, recordingVersion)
+	}
+	rec := &Recording{ChainConfig: new(chain.Config)}
+	if err := json.Unmarshal(enc.ChainConfig, rec.ChainConfig); err != nil {
+		return nil, err
+	}
+	for _, eb := range enc.Blocks {
+		b, err := decodeBlock(eb)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", eb.Block.NumberU64(), err)
+		}
+		rec.Blocks = append(rec.Blocks, b)
+	}
+	return rec, nil
+}
+
+func WriteFile(path string, rec *Recording) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, rec); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func ReadFile(path string) (*Recording, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+func encodeBlock(b *BlockRecording) (*encBlock, error) {
+	eb := &encBlock{Block: b.Block, Headers: b.Headers, Err: b.Err}
+	for addr, acc := range b.Reads.Accounts {
+		var v []byte
+		if acc != nil {
+			v = make([]byte, acc.EncodingLengthForStorage())
+			acc.EncodeForStorage(v)
+		}
+		eb.Accounts = append(eb.Accounts, encAccount{Address: addr, Account: v})
+	}
+	for slot, v := range b.Reads.Storage {
+		eb.Storage = append(eb.Storage, encStorage{Address: slot.Address, Incarnation: slot.Incarnation, Key: slot.Key, Value: v})
+	}
+	for k, c := range b.Reads.Code {
+		eb.Code = append(eb.Code, encCode{Address: k.Address, Incarnation: k.Incarnation, Code: c})
+	}
+	for addr, inc := range b.Reads.Incarnations {
+		eb.Incarnations = append(eb.Incarnations, encIncarnation{Address: addr, Incarnation: inc})
+	}
+	for n, h := range b.BlockHashes {
+		eb.BlockHashes = append(eb.BlockHashes, encBlockHash{Number: n, Hash: h})
+	}
+	slices.SortFunc(eb.Accounts, func(a, b encAccount) int { return bytes.Compare(a.Address[:], b.Address[:]) })
+	slices.SortFunc(eb.Storage, func(a, b encStorage) int {
+		if c := bytes.Compare(a.Address[:], b.Address[:]); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Incarnation, b.Incarnation); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.Key[:], b.Key[:])
+	})
+	slices.SortFunc(eb.Code, func(a, b encCode) int {
+		if c := bytes.Compare(a.Address[:], b.Address[:]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Incarnation, b.Incarnation)
+	})
+	slices.SortFunc(eb.Incarnations, func(a, b encIncarnation) int { return bytes.Compare(a.Address[:], b.Address[:]) })
+	slices.SortFunc(eb.BlockHashes, func(a, b encBlockHash) int { return cmp.Compare(a.Number, b.Number) })
+	slices.SortFunc(eb.Headers, func(a, b *types.Header) int { return a.Number.Cmp(b.Number) })
+
+	if b.ParliaSnapshot != nil {
+		snap, err := json.Marshal(b.ParliaSnapshot)
+		if err != nil {
+			return nil, err
+		}
+		eb.ParliaSnapshot = snap
+	}
+	return eb, nil
+}
+
+func decodeBlock(eb *encBlock) (*BlockRecording, error) {
+	b := &BlockRecording{
+		Block:       eb.Block,
+		Reads:       state.NewStateReads(),
+		BlockHashes: make(map[uint64]libcommon.Hash, len(eb.BlockHashes)),
+		Headers:     eb.Headers,
+		Err:         eb.Err,
+	}
+	for _, a := range eb.Accounts {
+		var acc *accounts.Account
+		if len(a.Account) > 0 {
+			acc = new(accounts.Account)
+			if err := acc.DecodeForStorage(a.Account); err != nil {
+				return nil, fmt.Errorf("account %x: %w", a.Address, err)
+			}
+		}
+		b.Reads.Accounts[a.Address] = acc
+	}
+	for _, s := range eb.Storage {
+		b.Reads.Storage[state.StorageSlot{Address: s.Address, Incarnation: s.Incarnation, Key: s.Key}] = s.Value
+	}
+	for _, c := range eb.Code {
+		b.Reads.Code[state.CodeKey{Address: c.Address, Incarnation: c.Incarnation}] = c.Code
+	}
+	for _, i := range eb.Incarnations {
+		b.Reads.Incarnations[i.Address] = i.Incarnation
+	}
+	for _, h := range eb.BlockHashes {
+		b.BlockHashes[h.Number] = h.Hash
+	}
+	if len(eb.ParliaSnapshot) > 0 {
+		b.ParliaSnapshot = new(parlia.Snapshot)
+		if err := json.Unmarshal(eb.ParliaSnapshot, b.ParliaSnapshot); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package replay
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
+
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/consensus/ethash"
+	"github.com/erigontech/erigon/consensus/merge"
+	"github.com/erigontech/erigon/consensus/parlia"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+)
+
+// ReplayBlock re-executes a recorded block reading nothing but the recording.
+// getTracer, if not nil, provides the tracer of every transaction. Reads the
+// recording can not serve fail the execution with state.ErrNotRecorded.
+func ReplayBlock(chainConfig *chain.Config, rec *BlockRecording, getTracer func(txIndex int, txHash libcommon.Hash) (vm.EVMLogger, error), logger log.Logger) (*core.EphemeralExecResult, error) {
+	engine, err := newEngine(chainConfig, rec, logger)
+	if err != nil {
+		return nil, err
+	}
+	defer engine.Close()
+
+	var missingHash *uint64
+	getHash := func(n uint64) libcommon.Hash {
+		h, ok := rec.BlockHashes[n]
+		if !ok && missingHash == nil {
+			missingHash = &n
+		}
+		return h
+	}
+
+	vmConfig := vm.Config{Debug: getTracer != nil}
+	res, err := core.ExecuteBlockEphemerallyForEngine(chainConfig, &vmConfig, getHash, engine, rec.Block, state.NewReplayReader(rec.Reads), state.NewNoopWriter(), newReplayChain(chainConfig, rec), getTracer, logger)
+	if missingHash != nil {
+		return nil, fmt.Errorf("%w: hash of block %d", state.ErrNotRecorded, *missingHash)
+	}
+	return res, err
+}
+
+// newEngine creates the consensus engine of the chain, parlia is seeded with
+// the recorded snapshot as there is no consensus db to load it from.
+func newEngine(chainConfig *chain.Config, rec *BlockRecording, logger log.Logger) (consensus.Engine, error) {
+	switch {
+	case chainConfig.Parlia != nil:
+		if rec.ParliaSnapshot == nil {
+			return nil, fmt.Errorf("block %d: no parlia snapshot recorded", rec.Block.NumberU64())
+		}
+		engine := parlia.New(chainConfig, memdb.New(os.TempDir(), kv.ConsensusDB), nil /* blobStore */, nil /* blockReader */, logger)
+		if err := engine.ImportSnapshot(rec.ParliaSnapshot); err != nil {
+			return nil, err
+		}
+		return engine, nil
+	case chainConfig.Bor != nil, chainConfig.Aura != nil:
+		return nil, fmt.Errorf("replay is not supported for chain %s", chainConfig.ChainName)
+	default:
+		var engine consensus.Engine = ethash.NewFaker()
+		if chainConfig.TerminalTotalDifficulty != nil {
+			engine = merge.New(engine)
+		}
+		return engine, nil
+	}
+}
+
+// replayChain serves the recorded headers to the consensus engine.
+type replayChain struct {
+	config   *chain.Config
+	block    *types.Block
+	byHash   map[libcommon.Hash]*types.Header
+	byNumber map[uint64]*types.Header
+	current  *types.Header
+}
+
+func newReplayChain(config *chain.Config, rec *BlockRecording) *replayChain {
+	c := &replayChain{
+		config:   config,
+		block:    rec.Block,
+		byHash:   map[libcommon.Hash]*types.Header{},
+		byNumber: map[uint64]*types.Header{},
+		current:  rec.Block.Header(),
+	}
+	for _, h := range rec.Headers {
+		c.byHash[h.Hash()] = h
+		c.byNumber[h.Number.Uint64()] = h
+		// The head of the chain at recording time is not known, the highest
+		// header the engine asked for is the best guess.
+		if h.Number.Cmp(c.current.Number) > 0 {
+			c.current = h
+		}
+	}
+	return c
+}
+
+func (c *replayChain) Config() *chain.Config                    { return c.config }
+func (c *replayChain) CurrentHeader() *types.Header             { return c.current }
+func (c *replayChain) CurrentFinalizedHeader() *types.Header    { return nil }
+func (c *replayChain) CurrentSafeHeader() *types.Header         { return nil }
+func (c *replayChain) GetHeaderByNumber(n uint64) *types.Header { return c.byNumber[n] }
+func (c *replayChain) GetHeaderByHash(hash libcommon.Hash) *types.Header {
+	return c.byHash[hash]
+}
+func (c *replayChain) GetHeader(hash libcommon.Hash, number uint64) *types.Header {
+	if h := c.byHash[hash]; h != nil && h.Number.Uint64() == number {
+		return h
+	}
+	return nil
+}
+func (c *replayChain) GetTd(hash libcommon.Hash, number uint64) *big.Int { return nil }
+func (c *replayChain) FrozenBlocks() uint64                              { return 0 }
+func (c *replayChain) FrozenBorBlocks() uint64                           { return 0 }
+func (c *replayChain) GetBlock(hash libcommon.Hash, number uint64) *types.Block {
+	if c.block.Hash() == hash && c.block.NumberU64() == number {
+		return c.block
+	}
+	return nil
+}
+func (c *replayChain) HasBlock(hash libcommon.Hash, number uint64) bool {
+	return c.GetBlock(hash, number) != nil
+}
+func (c *replayChain) BorEventsByBlock(hash libcommon.Hash, number uint64) []rlp.RawValue {
+	return nil
+}
+func (c *replayChain) BorStartEventId(hash libcommon.Hash, number uint64) uint64 { return 0 }
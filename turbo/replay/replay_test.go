@@ -0,0 +1,111 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package replay
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/stagedsync"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+var (
+	// blockHashCode stores the hash of the parent block at slot 0.
+	blockHashCode = []byte{
+		byte(vm.PUSH1), 0x01, byte(vm.NUMBER), byte(vm.SUB), byte(vm.BLOCKHASH),
+		byte(vm.PUSH1), 0x00, byte(vm.SSTORE), byte(vm.STOP),
+	}
+	blockHashAddr = libcommon.HexToAddress("0xb10c")
+)
+
+func TestRecordReplay(t *testing.T) {
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	gspec := &types.Genesis{
+		Config:   params.TestChainConfig,
+		GasLimit: 10_000_000,
+		Alloc: types.GenesisAlloc{
+			addr:          {Balance: new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(1000))},
+			blockHashAddr: {Code: blockHashCode, Balance: new(big.Int)},
+		},
+	}
+	m := mock.MockWithGenesis(t, gspec, key, false)
+	signer := types.LatestSigner(m.ChainConfig)
+	gasPrice := uint256.NewInt(params.InitialBaseFee)
+
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 3, func(i int, b *core.BlockGen) {
+		transfer, err := types.SignTx(types.NewTransaction(b.TxNonce(addr), libcommon.HexToAddress("0xdead"), uint256.NewInt(1000), params.TxGas, gasPrice, nil), *signer, key)
+		require.NoError(t, err)
+		b.AddTx(transfer)
+		call, err := types.SignTx(types.NewTransaction(b.TxNonce(addr), blockHashAddr, new(uint256.Int), 100_000, gasPrice, nil), *signer, key)
+		require.NoError(t, err)
+		b.AddTx(call)
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chain))
+
+	tx, err := m.DB.BeginTemporalRo(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	block := chain.Blocks[2]
+	chainReader := stagedsync.ChainReader{Cfg: *m.ChainConfig, Db: tx, BlockReader: m.BlockReader, Logger: log.New()}
+	rec, err := RecordBlock(m.ChainConfig, m.Engine, chainReader, block, m.NewHistoryStateReader(block.NumberU64(), tx), log.New())
+	require.NoError(t, err)
+	require.Empty(t, rec.Err)
+	require.Equal(t, chain.Blocks[1].Hash(), rec.BlockHashes[block.NumberU64()-1])
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, &Recording{ChainConfig: m.ChainConfig, Blocks: []*BlockRecording{rec}}))
+	recording, err := Read(&buf)
+	require.NoError(t, err)
+	require.Len(t, recording.Blocks, 1)
+	replayed := recording.Blocks[0]
+
+	var traced int
+	getTracer := func(txIndex int, txHash libcommon.Hash) (vm.EVMLogger, error) {
+		return vm.NewHooksLogger(&tracing.Hooks{
+			OnTxStart: func(*tracing.VMContext, types.Transaction, libcommon.Address) { traced++ },
+		}), nil
+	}
+	res, err := ReplayBlock(recording.ChainConfig, replayed, getTracer, log.New())
+	require.NoError(t, err)
+	require.Equal(t, block.ReceiptHash(), res.ReceiptRoot)
+	require.Equal(t, block.GasUsed(), uint64(res.GasUsed))
+	require.Equal(t, len(block.Transactions()), traced)
+
+	// A recording missing a read the execution needs must not be silently
+	// served as absent state.
+	delete(replayed.Reads.Accounts, addr)
+	_, err = ReplayBlock(recording.ChainConfig, replayed, nil, log.New())
+	require.ErrorContains(t, err, state.ErrNotRecorded.Error())
+}
@@ -165,6 +165,11 @@ type BlockRetire struct {
 
 	heimdallStore heimdall.Store
 	bridgeStore   bridge.Store
+
+	// bsc blobs retired by separated `RetireBscBlocks` call
+	skipBsc bool
+	// workers of `RetireBscBlocks`, which may run concurrently with blocks retirement. 0 - same as `workers`
+	bscWorkers int
 }
 
 func NewBlockRetire(
@@ -200,8 +205,9 @@ func NewBlockRetire(
 	}
 }
 
-func (br *BlockRetire) SetWorkers(workers int) { br.workers = workers }
-func (br *BlockRetire) GetWorkers() int        { return br.workers }
+func (br *BlockRetire) SetWorkers(workers int)    { br.workers = workers }
+func (br *BlockRetire) GetWorkers() int           { return br.workers }
+func (br *BlockRetire) SetBscWorkers(workers int) { br.bscWorkers = workers }
 
 func (br *BlockRetire) IO() (services.FullBlockReader, *blockio.BlockWriter) {
 	return br.blockReader, br.blockWriter
@@ -428,7 +434,7 @@ func (br *BlockRetire) RetireBlocks(ctx context.Context, requestedMinBlockNum ui
 		br.maxScheduledBlock.Store(requestedMaxBlockNum)
 	}
 	includeBor := br.chainConfig.Bor != nil
-	includeBsc := br.chainConfig.Parlia != nil
+	includeBsc := br.chainConfig.Parlia != nil && !br.skipBsc
 
 	if err := br.BuildMissedIndicesIfNeed(ctx, "RetireBlocks", br.notifier); err != nil {
 		return err
@@ -490,6 +496,9 @@ func (br *BlockRetire) RetireBlocks(ctx context.Context, requestedMinBlockNum ui
 	return nil
 }
 
+// SkipBsc - exclude bsc blobs from `RetireBlocks`. Then they must be retired by `RetireBscBlocks`
+func (br *BlockRetire) SkipBsc(v bool) { br.skipBsc = v }
+
 func (br *BlockRetire) BuildMissedIndicesIfNeed(ctx context.Context, logPrefix string, notifier services.DBEventNotifier) error {
 	if err := br.snapshots().BuildMissedIndices(ctx, logPrefix, notifier, br.dirs, br.chainConfig, br.logger); err != nil {
 		return err
@@ -601,7 +610,7 @@ var BlockCompressCfg = seg.Cfg{
 func dumpRange(ctx context.Context, f snaptype.FileInfo, dumper dumpFunc, firstKey firstKeyGetter, chainDB kv.RoDB, chainConfig *chain.Config, tmpDir string, workers int, lvl log.Lvl, logger log.Logger) (uint64, error) {
 	var lastKeyValue uint64
 
-	compressCfg := BlockCompressCfg
+	compressCfg := snaptype.CompressCfg(f.Type, BlockCompressCfg)
 	compressCfg.Workers = workers
 	sn, err := seg.NewCompressor(ctx, "Snapshot "+f.Type.Name(), f.Path, tmpDir, compressCfg, log.LvlTrace, logger)
 	if err != nil {
@@ -43,7 +43,7 @@ func (br *BlockRetire) retireBscBlocks(ctx context.Context, minBlockNum uint64,
 
 	startTime := time.Now()
 	snapshots := br.bscSnapshots()
-	notifier, logger, blockReader, tmpDir, db, workers := br.notifier, br.logger, br.blockReader, br.tmpDir, br.db, br.workers
+	notifier, logger, blockReader, tmpDir, db, workers := br.notifier, br.logger, br.blockReader, br.tmpDir, br.db, br.getBscWorkers()
 
 	var minimumBlob uint64
 	if br.chainConfig.ChainName == networkname.BSC {
@@ -145,9 +145,25 @@ func (br *BlockRetire) retireBscBlocks(ctx context.Context, minBlockNum uint64,
 	return blocksRetired || merged, err
 }
 
+// RetireBscBlocks - retire and merge bsc blobs up to `maxBlockNum`. Works independently of blocks retirement
+func (br *BlockRetire) RetireBscBlocks(ctx context.Context, maxBlockNum uint64, lvl log.Lvl, seedNewSnapshots func(downloadRequest []snapshotsync.DownloadRequest) error, onDelete func(l []string) error) error {
+	if br.chainConfig.Parlia == nil {
+		return nil
+	}
+	for {
+		ok, err := br.retireBscBlocks(ctx, br.blockReader.FrozenBscBlobs(), maxBlockNum, lvl, seedNewSnapshots, onDelete)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+}
+
 func (br *BlockRetire) MergeBscBlocks(ctx context.Context, lvl log.Lvl, seedNewSnapshots func(downloadRequest []snapshotsync.DownloadRequest) error, onDelete func(l []string) error) (mergedBlocks bool, err error) {
 	startTime := time.Now()
-	notifier, logger, _, tmpDir, db, workers := br.notifier, br.logger, br.blockReader, br.tmpDir, br.db, br.workers
+	notifier, logger, _, tmpDir, db, workers := br.notifier, br.logger, br.blockReader, br.tmpDir, br.db, br.getBscWorkers()
 	snapshots := br.bscSnapshots()
 	merger := snapshotsync.NewMerger(tmpDir, workers, lvl, db, br.chainConfig, logger)
 	rangesToMerge := merger.FindMergeRanges(snapshots.Ranges(), snapshots.BlocksAvailable())
@@ -306,7 +322,7 @@ func (v *BscView) BlobSidecarsSegment(blockNum uint64) (*snapshotsync.VisibleSeg
 func dumpBlobsRange(ctx context.Context, blockFrom, blockTo uint64, tmpDir, snapDir string, chainDB kv.RoDB, blobStore services.BlobStorage, blockReader services.FullBlockReader, chainConfig *chain.Config, workers int, lvl log.Lvl, logger log.Logger) (err error) {
 	startTime := time.Now()
 	f := coresnaptype.BlobSidecars.FileInfo(snapDir, blockFrom, blockTo)
-	sn, err := seg.NewCompressor(ctx, "Snapshot "+f.Type.Name(), f.Path, tmpDir, seg.DefaultCfg, log.LvlTrace, logger)
+	sn, err := seg.NewCompressor(ctx, "Snapshot "+f.Type.Name(), f.Path, tmpDir, snaptype.CompressCfg(f.Type, seg.DefaultCfg), log.LvlTrace, logger)
 	if err != nil {
 		return err
 	}
@@ -445,3 +461,10 @@ func (s *BscRoSnapshots) ReadBlobSidecars(blockNum uint64) ([]*types.BlobSidecar
 
 	return sidecars, nil
 }
+
+func (br *BlockRetire) getBscWorkers() int {
+	if br.bscWorkers > 0 {
+		return br.bscWorkers
+	}
+	return br.workers
+}
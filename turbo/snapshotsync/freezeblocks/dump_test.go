@@ -31,10 +31,14 @@ import (
 	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/math"
 	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/downloader/snaptype"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/seg"
 	"github.com/erigontech/erigon/core"
+	coresnaptype "github.com/erigontech/erigon/core/snaptype"
 	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/eth/ethconfig"
 	"github.com/erigontech/erigon/ethdb/prune"
 	"github.com/erigontech/erigon/params"
 	"github.com/erigontech/erigon/polygon/bor/borcfg"
@@ -264,6 +268,57 @@ func TestDump(t *testing.T) {
 	}
 }
 
+// TestDumpBlocksCodecs - block files dumped by configured codecs are read back by BlockReader
+func TestDumpBlocksCodecs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fix me on win")
+	}
+	require := require.New(t)
+	zc, err := seg.NewZstdCodec(nil)
+	require.NoError(err)
+	snaptype.SetCodecs(seg.CodecsCfg{coresnaptype.Headers.Name(): zc, coresnaptype.Bodies.Name(): seg.RawCodec})
+	t.Cleanup(func() { snaptype.SetCodecs(nil) })
+
+	const chainSize = 1000
+	m := createDumpTestKV(t, params.TestChainConfig, chainSize)
+	logger := log.New()
+	tmpDir, snapDir := t.TempDir(), t.TempDir()
+	require.NoError(freezeblocks.DumpBlocks(m.Ctx, 0, chainSize, m.ChainConfig, tmpDir, snapDir, m.DB, 1, log.LvlInfo, logger, m.BlockReader))
+
+	for _, tt := range []struct {
+		typ   snaptype.Type
+		codec seg.CodecID
+	}{{coresnaptype.Headers, seg.CodecIDZstd}, {coresnaptype.Bodies, seg.CodecIDRaw}, {coresnaptype.Transactions, seg.CodecIDPattern}} {
+		d, err := seg.NewDecompressor(tt.typ.FileInfo(snapDir, 0, chainSize).Path)
+		require.NoError(err)
+		require.Equal(tt.codec, d.Codec().ID(), tt.typ.Name())
+		d.Close()
+	}
+
+	snapshots := freezeblocks.NewRoSnapshots(ethconfig.BlocksFreezing{ChainName: networkname.Mainnet}, snapDir, 0, logger)
+	defer snapshots.Close()
+	require.NoError(snapshots.OpenFolder())
+	blockReader := freezeblocks.NewBlockReader(snapshots, nil, nil, nil, nil)
+	require.EqualValues(chainSize-1, blockReader.FrozenBlocks())
+
+	tx, err := m.DB.BeginRo(m.Ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	for n := uint64(0); n < chainSize; n++ {
+		block, err := m.BlockReader.BlockByNumber(m.Ctx, tx, n)
+		require.NoError(err)
+		h, err := blockReader.HeaderByNumber(m.Ctx, nil, n) // nil tx: read from files
+		require.NoError(err)
+		require.Equal(block.Hash(), h.Hash(), n)
+		body, err := blockReader.BodyWithTransactions(m.Ctx, nil, block.Hash(), n)
+		require.NoError(err)
+		require.Len(body.Transactions, len(block.Transactions()), n)
+		for i, txn := range block.Transactions() {
+			require.Equal(txn.Hash(), body.Transactions[i].Hash(), n)
+		}
+	}
+}
+
 func createDumpTestKV(t *testing.T, chainConfig *chain.Config, chainSize int) *mock.MockSentry {
 	var (
 		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
@@ -269,7 +269,7 @@ func (m *Merger) merge(ctx context.Context, v *View, toMerge []*DirtySegment, ta
 		expectedTotal += d.Count()
 	}
 
-	compresCfg := seg.DefaultCfg
+	compresCfg := snaptype.CompressCfg(targetFile.Type, seg.DefaultCfg)
 	compresCfg.Workers = m.compressWorkers
 	f, err := seg.NewCompressor(ctx, "Snapshots merge", targetFile.Path, m.tmpDir, compresCfg, log.LvlTrace, m.logger)
 	if err != nil {
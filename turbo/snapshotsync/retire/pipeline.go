@@ -0,0 +1,298 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package retire - runs `retire` work (blocks/bsc/state snapshots building, merging and pruning) as set of jobs.
+//
+//   - jobs of the same type run sequentially, jobs of different types run concurrently
+//   - all jobs share one workers budget
+//   - finished jobs are checkpointed on disk: after restart they are skipped
+//   - progress is reported to logs and to diagnostics (`erigon diag retire`)
+package retire
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/erigontech/erigon-lib/common/background"
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/diagnostics"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// Job - one piece of retire work: some snapshot type over some range
+type Job struct {
+	Type     string // `blocks`, `bsc`, `state`, ... jobs of same type are executed sequentially in order of adding
+	Name     string // `retire`, `prune`, `merge`, ...
+	From, To uint64 // blocks or txNums - depends on type
+	// Target - what the whole retire run brings snapshots up to (stage progress, last txNum). Unlike From/To
+	// it doesn't depend on already frozen files, so it stays the same when a partially finished run is resumed.
+	Target  uint64
+	Workers int // amount of workers taken from pipeline budget while job is running
+
+	// Run - `workers` is the amount granted from the budget, the job must not use more
+	Run func(ctx context.Context, workers int, p *background.Progress) error
+
+	progress  background.Progress
+	status    string
+	startedAt time.Time
+	elapsed   time.Duration
+	err       error
+}
+
+// Key - identifies job in the checkpoint
+func (j *Job) Key() string { return fmt.Sprintf("%s/%s/%d", j.Type, j.Name, j.Target) }
+
+// Pipeline - executes jobs. Not thread-safe: jobs must be added before `Run`
+type Pipeline struct {
+	checkpointPath string
+	workers        int
+	sema           *semaphore.Weighted
+	logger         log.Logger
+
+	jobs  []*Job
+	lock  sync.Mutex
+	done  map[string]time.Time // job key -> finish time. persisted in checkpoint file
+	types []string             // in order of adding
+
+	checkpointLock sync.Mutex // jobs of different types finish concurrently
+}
+
+// NewPipeline - `checkpointPath` is file where finished jobs are stored, `workers` - budget shared by all jobs
+func NewPipeline(checkpointPath string, workers int, logger log.Logger) (*Pipeline, error) {
+	workers = max(workers, 1)
+	p := &Pipeline{
+		checkpointPath: checkpointPath,
+		workers:        workers,
+		sema:           semaphore.NewWeighted(int64(workers)),
+		logger:         logger,
+		done:           map[string]time.Time{},
+	}
+	if err := p.loadCheckpoint(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Pipeline) Add(jobs ...*Job) {
+	for _, j := range jobs {
+		j.Workers = min(max(j.Workers, 1), p.workers)
+		j.status = diagnostics.RetireJobPending
+		if _, ok := p.done[j.Key()]; ok {
+			j.status = diagnostics.RetireJobSkipped
+		}
+		known := false
+		for _, t := range p.jobs {
+			if t.Type == j.Type {
+				known = true
+				break
+			}
+		}
+		if !known {
+			p.types = append(p.types, j.Type)
+		}
+		p.jobs = append(p.jobs, j)
+	}
+}
+
+// Run - executes all not finished jobs. On success checkpoint file is removed, on failure - it keeps all finished jobs.
+func (p *Pipeline) Run(ctx context.Context) error {
+	for _, j := range p.jobs {
+		p.report(j)
+	}
+
+	logCtx, logCancel := context.WithCancel(ctx)
+	defer logCancel()
+	go p.logProgress(logCtx, 30*time.Second)
+
+	g, gctx := errgroup.WithContext(ctx)
+	for _, t := range p.types {
+		g.Go(func() error {
+			for _, j := range p.jobs {
+				if j.Type != t {
+					continue
+				}
+				if err := p.runJob(gctx, j); err != nil {
+					return fmt.Errorf("%s: %w", j.Key(), err)
+				}
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	if err := os.Remove(p.checkpointPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (p *Pipeline) runJob(ctx context.Context, j *Job) error {
+	if j.status == diagnostics.RetireJobSkipped {
+		p.logger.Info("[retire] skip job: finished by previous run", "job", j.Key())
+		return nil
+	}
+	if err := p.sema.Acquire(ctx, int64(j.Workers)); err != nil {
+		return err
+	}
+	defer p.sema.Release(int64(j.Workers))
+
+	p.lock.Lock()
+	j.status, j.startedAt = diagnostics.RetireJobRunning, time.Now()
+	p.lock.Unlock()
+	p.report(j)
+	p.logger.Info("[retire] start job", "job", j.Key(), "workers", j.Workers)
+
+	err := j.Run(ctx, j.Workers, &j.progress)
+
+	p.lock.Lock()
+	j.elapsed = time.Since(j.startedAt)
+	if err != nil {
+		j.status, j.err = diagnostics.RetireJobFailed, err
+	} else {
+		j.status = diagnostics.RetireJobDone
+		p.done[j.Key()] = time.Now()
+	}
+	p.lock.Unlock()
+	p.report(j)
+
+	if err != nil {
+		return err
+	}
+	p.logger.Info("[retire] finished job", "job", j.Key(), "took", j.elapsed)
+	return p.saveCheckpoint()
+}
+
+func (p *Pipeline) logProgress(ctx context.Context, every time.Duration) {
+	logEvery := time.NewTicker(every)
+	defer logEvery.Stop()
+	reportEvery := time.NewTicker(5 * time.Second)
+	defer reportEvery.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reportEvery.C:
+			for _, j := range p.jobs {
+				if p.jobStatus(j) == diagnostics.RetireJobRunning {
+					p.report(j)
+				}
+			}
+		case <-logEvery.C:
+			var args []interface{}
+			for _, j := range p.jobs {
+				if p.jobStatus(j) != diagnostics.RetireJobRunning {
+					continue
+				}
+				processed, total := j.progress.Processed.Load(), j.progress.Total.Load()
+				if total > 0 {
+					args = append(args, j.Key(), fmt.Sprintf("%d/%d", processed, total))
+				} else {
+					args = append(args, j.Key(), "running")
+				}
+			}
+			if len(args) > 0 {
+				p.logger.Info("[retire] progress", args...)
+			}
+		}
+	}
+}
+
+func (p *Pipeline) jobStatus(j *Job) string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return j.status
+}
+
+func (p *Pipeline) report(j *Job) {
+	diagnostics.Send(diagnostics.SnapshotRetireJobUpdate{Job: p.diagnosticsJob(j)})
+}
+
+func (p *Pipeline) diagnosticsJob(j *Job) diagnostics.SnapshotRetireJob {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	elapsed := j.elapsed
+	if j.status == diagnostics.RetireJobRunning {
+		elapsed = time.Since(j.startedAt)
+	}
+	dj := diagnostics.SnapshotRetireJob{
+		Type: j.Type, Name: j.Name, From: j.From, To: j.To,
+		Status:      j.status,
+		Processed:   j.progress.Processed.Load(),
+		Total:       j.progress.Total.Load(),
+		TimeElapsed: elapsed.Seconds(),
+	}
+	if j.err != nil {
+		dj.Err = j.err.Error()
+	}
+	return dj
+}
+
+// Jobs - state of all jobs, in order of adding
+func (p *Pipeline) Jobs() []diagnostics.SnapshotRetireJob {
+	res := make([]diagnostics.SnapshotRetireJob, 0, len(p.jobs))
+	for _, j := range p.jobs {
+		res = append(res, p.diagnosticsJob(j))
+	}
+	return res
+}
+
+type checkpoint struct {
+	Done map[string]time.Time `json:"done"`
+}
+
+func (p *Pipeline) loadCheckpoint() error {
+	data, err := os.ReadFile(p.checkpointPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	var c checkpoint
+	if err := json.Unmarshal(data, &c); err != nil {
+		p.logger.Warn("[retire] ignoring broken checkpoint", "file", p.checkpointPath, "err", err)
+		return nil
+	}
+	for k, v := range c.Done {
+		p.done[k] = v
+	}
+	return nil
+}
+
+func (p *Pipeline) saveCheckpoint() error {
+	p.checkpointLock.Lock()
+	defer p.checkpointLock.Unlock()
+	p.lock.Lock()
+	data, err := json.Marshal(checkpoint{Done: p.done})
+	p.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	tmpPath := p.checkpointPath + ".tmp"
+	if err := dir.WriteFileWithFsync(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, p.checkpointPath)
+}
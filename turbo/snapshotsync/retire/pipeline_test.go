@@ -0,0 +1,114 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package retire
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/background"
+	"github.com/erigontech/erigon-lib/diagnostics"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestPipelineResume(t *testing.T) {
+	t.Parallel()
+
+	ctx, logger := context.Background(), log.New()
+	checkpointPath := filepath.Join(t.TempDir(), "retire.json")
+
+	var lock sync.Mutex
+	var executed []string
+	failMerge := true
+	// `from` is derived from already frozen files: it moves after a partial run
+	newJobs := func(from uint64) []*Job {
+		run := func(name string) func(ctx context.Context, workers int, p *background.Progress) error {
+			return func(ctx context.Context, workers int, p *background.Progress) error {
+				lock.Lock()
+				defer lock.Unlock()
+				if name == "state/merge" && failMerge {
+					return errors.New("interrupted")
+				}
+				executed = append(executed, name)
+				return nil
+			}
+		}
+		return []*Job{
+			{Type: "blocks", Name: "retire", From: from, To: 1000, Target: 1000, Run: run("blocks/retire")},
+			{Type: "blocks", Name: "prune", From: from, To: 1000, Target: 1000, Run: run("blocks/prune")},
+			{Type: "state", Name: "build", From: from, To: 100, Target: 100, Run: run("state/build")},
+			{Type: "state", Name: "merge", From: from, To: 100, Target: 100, Run: run("state/merge")},
+		}
+	}
+
+	p, err := NewPipeline(checkpointPath, 2, logger)
+	require.NoError(t, err)
+	p.Add(newJobs(0)...)
+	require.ErrorContains(t, p.Run(ctx), "state/merge/100: interrupted")
+	require.Contains(t, executed, "state/build")
+	require.FileExists(t, checkpointPath)
+	firstRun := executed
+
+	executed, failMerge = nil, false
+	p, err = NewPipeline(checkpointPath, 2, logger)
+	require.NoError(t, err)
+	p.Add(newJobs(50)...)
+	require.NoError(t, p.Run(ctx))
+	require.Contains(t, executed, "state/merge")
+	require.NotContains(t, executed, "state/build")
+	require.ElementsMatch(t, []string{"blocks/retire", "blocks/prune", "state/build", "state/merge"}, append(firstRun, executed...))
+	require.NoFileExists(t, checkpointPath)
+
+	jobs := p.Jobs()
+	require.Len(t, jobs, 4)
+	require.Equal(t, diagnostics.RetireJobSkipped, jobs[2].Status)
+	require.Equal(t, diagnostics.RetireJobDone, jobs[3].Status)
+}
+
+func TestPipelineWorkersBudget(t *testing.T) {
+	t.Parallel()
+
+	var running, maxRunning atomic.Int64
+	job := func(typ string, workers int) *Job {
+		j := &Job{Type: typ, Name: "retire", Workers: workers}
+		j.Run = func(ctx context.Context, granted int, p *background.Progress) error {
+			require.LessOrEqual(t, granted, 3)
+			n := running.Add(int64(granted))
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			running.Add(-int64(granted))
+			return nil
+		}
+		return j
+	}
+
+	p, err := NewPipeline(filepath.Join(t.TempDir(), "retire.json"), 3, log.New())
+	require.NoError(t, err)
+	p.Add(job("blocks", 2), job("bsc", 2), job("state", 10))
+	require.NoError(t, p.Run(context.Background()))
+	require.LessOrEqual(t, maxRunning.Load(), int64(3))
+}
@@ -0,0 +1,190 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stateless
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
+
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// headerCacheSize covers the ancestors the consensus engines look up while
+// finalizing a block, e.g. the parlia snapshot of the previous epoch.
+const headerCacheSize = 2048
+
+// RPCWitnessSource fetches witnesses with eth_getWitness.
+type RPCWitnessSource struct {
+	client *rpc.Client
+}
+
+func NewRPCWitnessSource(client *rpc.Client) *RPCWitnessSource {
+	return &RPCWitnessSource{client: client}
+}
+
+func (s *RPCWitnessSource) Witness(ctx context.Context, number uint64) ([]byte, error) {
+	var witness hexutility.Bytes
+	if err := s.client.CallContext(ctx, &witness, "eth_getWitness", hexutil.Uint64(number)); err != nil {
+		return nil, fmt.Errorf("eth_getWitness(%d): %w", number, err)
+	}
+	if len(witness) == 0 {
+		return nil, fmt.Errorf("eth_getWitness(%d): %w", number, errNotFound)
+	}
+	return witness, nil
+}
+
+// RPCChain is a Chain backed by the header chain and blocks of a full node,
+// read over JSON-RPC. It is what a verifier without any database of its own
+// runs on. Headers are cached as the engines keep looking up the same recent
+// ancestors.
+type RPCChain struct {
+	ctx     context.Context
+	config  *chain.Config
+	client  *rpc.Client
+	headers *lru.Cache[libcommon.Hash, *types.Header]
+	hashes  *lru.Cache[uint64, libcommon.Hash]
+	logger  log.Logger
+}
+
+func NewRPCChain(ctx context.Context, config *chain.Config, client *rpc.Client, logger log.Logger) *RPCChain {
+	headers, err := lru.New[libcommon.Hash, *types.Header](headerCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	hashes, err := lru.New[uint64, libcommon.Hash](headerCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	return &RPCChain{ctx: ctx, config: config, client: client, headers: headers, hashes: hashes, logger: logger}
+}
+
+func (c *RPCChain) HeadNumber(ctx context.Context) (uint64, error) {
+	var head hexutil.Uint64
+	if err := c.client.CallContext(ctx, &head, "eth_blockNumber"); err != nil {
+		return 0, err
+	}
+	return uint64(head), nil
+}
+
+func (c *RPCChain) Block(ctx context.Context, number uint64) (*types.Block, error) {
+	var raw hexutility.Bytes
+	if err := c.client.CallContext(ctx, &raw, "debug_getRawBlock", hexutil.Uint64(number)); err != nil {
+		return nil, fmt.Errorf("debug_getRawBlock(%d): %w", number, err)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("debug_getRawBlock(%d): %w", number, errNotFound)
+	}
+	block := new(types.Block)
+	if err := rlp.DecodeBytes(raw, block); err != nil {
+		return nil, fmt.Errorf("decoding block %d: %w", number, err)
+	}
+	c.addHeader(block.Header())
+	return block, nil
+}
+
+// View runs f with the chain itself, the engines read headers on demand.
+func (c *RPCChain) View(ctx context.Context, f func(consensus.ChainReader) error) error {
+	return f(c)
+}
+
+func (c *RPCChain) addHeader(header *types.Header) {
+	hash := header.Hash()
+	c.headers.Add(hash, header)
+	c.hashes.Add(header.Number.Uint64(), hash)
+}
+
+// header requests the header by number or hash, arg is passed as is to
+// debug_getRawHeader.
+func (c *RPCChain) header(arg interface{}) *types.Header {
+	var raw hexutility.Bytes
+	if err := c.client.CallContext(c.ctx, &raw, "debug_getRawHeader", arg); err != nil {
+		c.logger.Warn("[stateless] debug_getRawHeader failed", "block", arg, "err", err)
+		return nil
+	}
+	if len(raw) == 0 {
+		return nil
+	}
+	header := new(types.Header)
+	if err := rlp.DecodeBytes(raw, header); err != nil {
+		c.logger.Warn("[stateless] decoding header failed", "block", arg, "err", err)
+		return nil
+	}
+	c.addHeader(header)
+	return header
+}
+
+func (c *RPCChain) Config() *chain.Config { return c.config }
+
+func (c *RPCChain) CurrentHeader() *types.Header {
+	return c.header(rpc.LatestBlockNumber)
+}
+func (c *RPCChain) CurrentFinalizedHeader() *types.Header {
+	return c.header(rpc.FinalizedBlockNumber)
+}
+func (c *RPCChain) CurrentSafeHeader() *types.Header {
+	return c.header(rpc.SafeBlockNumber)
+}
+func (c *RPCChain) GetHeader(hash libcommon.Hash, number uint64) *types.Header {
+	h := c.GetHeaderByHash(hash)
+	if h == nil || h.Number.Uint64() != number {
+		return nil
+	}
+	return h
+}
+func (c *RPCChain) GetHeaderByNumber(number uint64) *types.Header {
+	if hash, ok := c.hashes.Get(number); ok {
+		if h, ok := c.headers.Get(hash); ok {
+			return h
+		}
+	}
+	return c.header(hexutil.Uint64(number))
+}
+func (c *RPCChain) GetHeaderByHash(hash libcommon.Hash) *types.Header {
+	if h, ok := c.headers.Get(hash); ok {
+		return h
+	}
+	return c.header(hash)
+}
+
+// GetTd is not served, no engine run by the verifier needs it.
+func (c *RPCChain) GetTd(hash libcommon.Hash, number uint64) *big.Int { return nil }
+func (c *RPCChain) FrozenBlocks() uint64                              { return 0 }
+func (c *RPCChain) FrozenBorBlocks() uint64                           { return 0 }
+func (c *RPCChain) GetBlock(hash libcommon.Hash, number uint64) *types.Block {
+	block, err := c.Block(c.ctx, number)
+	if err != nil || block.Hash() != hash {
+		return nil
+	}
+	return block
+}
+func (c *RPCChain) HasBlock(hash libcommon.Hash, number uint64) bool {
+	return c.GetBlock(hash, number) != nil
+}
+func (c *RPCChain) BorEventsByBlock(hash libcommon.Hash, number uint64) []rlp.RawValue { return nil }
+func (c *RPCChain) BorStartEventId(hash libcommon.Hash, number uint64) uint64          { return 0 }
@@ -0,0 +1,103 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package stateless validates blocks without a state database: every block is
+// executed on top of the witness of its pre-state, and the state root, receipts
+// root and gas used it produces are compared with the ones in the header.
+package stateless
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/trie"
+
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+)
+
+// Result is the outcome of the stateless execution of a block.
+type Result struct {
+	Number      uint64
+	Hash        libcommon.Hash
+	StateRoot   libcommon.Hash
+	ReceiptRoot libcommon.Hash
+	GasUsed     uint64
+	Took        time.Duration
+}
+
+// Verify compares the result with the roots and gas used committed to in header.
+func (r *Result) Verify(header *types.Header) error {
+	if r.GasUsed != header.GasUsed {
+		return fmt.Errorf("block %d: gas used mismatch: executed %d, header %d", r.Number, r.GasUsed, header.GasUsed)
+	}
+	if r.ReceiptRoot != header.ReceiptHash {
+		return fmt.Errorf("block %d: receipts root mismatch: executed %x, header %x", r.Number, r.ReceiptRoot, header.ReceiptHash)
+	}
+	if r.StateRoot != header.Root {
+		return fmt.Errorf("block %d: state root mismatch: executed %x, header %x", r.Number, r.StateRoot, header.Root)
+	}
+	return nil
+}
+
+// Execute runs block on top of the pre-state described by witness, which must
+// hash to the state root of parent. Nothing but the witness is read, so the
+// witness has to cover every account, storage slot and code the block touches,
+// including what the consensus engine reads and writes when finalizing it.
+func Execute(chainConfig *chain.Config, engine consensus.Engine, chainReader consensus.ChainReader, block *types.Block, parent *types.Header, witness []byte, logger log.Logger) (*Result, error) {
+	start := time.Now()
+	if block.ParentHash() != parent.Hash() {
+		return nil, fmt.Errorf("block %d: parent hash %x does not match %x", block.NumberU64(), block.ParentHash(), parent.Hash())
+	}
+	w, err := trie.NewWitnessFromReader(bytes.NewReader(witness), false /* trace */)
+	if err != nil {
+		return nil, fmt.Errorf("block %d: decoding witness: %w", block.NumberU64(), err)
+	}
+	// NewStateless rebuilds the trie from the witness and refuses it unless it
+	// hashes to the parent state root.
+	ibs, err := state.NewStateless(parent.Root, w, parent.Number.Uint64(), false /* trace */, false /* isBinary */)
+	if err != nil {
+		return nil, fmt.Errorf("block %d: %w", block.NumberU64(), err)
+	}
+
+	// StatelessExec leaves the comparison with the header to Verify, so that a
+	// bad block reports every root instead of the first mismatch.
+	vmConfig := vm.Config{StatelessExec: true}
+	getHash := core.GetHashFn(block.Header(), chainReader.GetHeader)
+	execResult, err := core.ExecuteBlockEphemerallyForEngine(chainConfig, &vmConfig, getHash, engine, block, ibs, ibs, chainReader, nil, logger)
+	if err != nil {
+		return nil, fmt.Errorf("block %d: %w", block.NumberU64(), err)
+	}
+	if len(execResult.Rejected) > 0 {
+		rejected := execResult.Rejected[0]
+		return nil, fmt.Errorf("block %d: tx %d rejected: %s", block.NumberU64(), rejected.Index, rejected.Err)
+	}
+	return &Result{
+		Number:      block.NumberU64(),
+		Hash:        block.Hash(),
+		StateRoot:   ibs.Finalize(),
+		ReceiptRoot: execResult.ReceiptRoot,
+		GasUsed:     uint64(execResult.GasUsed),
+		Took:        time.Since(start),
+	}, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stateless
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/params"
+)
+
+func TestResultVerify(t *testing.T) {
+	header := &types.Header{
+		Number:      big.NewInt(10),
+		Root:        libcommon.HexToHash("0x01"),
+		ReceiptHash: libcommon.HexToHash("0x02"),
+		GasUsed:     21000,
+	}
+	res := Result{Number: 10, StateRoot: header.Root, ReceiptRoot: header.ReceiptHash, GasUsed: header.GasUsed}
+	require.NoError(t, res.Verify(header))
+
+	bad := res
+	bad.GasUsed++
+	require.ErrorContains(t, bad.Verify(header), "gas used mismatch")
+
+	bad = res
+	bad.ReceiptRoot = libcommon.Hash{}
+	require.ErrorContains(t, bad.Verify(header), "receipts root mismatch")
+
+	bad = res
+	bad.StateRoot = libcommon.Hash{}
+	require.ErrorContains(t, bad.Verify(header), "state root mismatch")
+}
+
+func TestExecuteWrongParent(t *testing.T) {
+	parent := &types.Header{Number: big.NewInt(9)}
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(10), ParentHash: libcommon.HexToHash("0x03")})
+	_, err := Execute(params.TestChainConfig, nil, nil, block, parent, nil, log.New())
+	require.ErrorContains(t, err, "parent hash")
+}
@@ -0,0 +1,251 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stateless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
+
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/eth/consensuschain"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/turbo/services"
+)
+
+var (
+	blocksVerified = metrics.GetOrCreateCounter("stateless_blocks_verified")
+	blocksInvalid  = metrics.GetOrCreateCounter("stateless_blocks_invalid")
+	fetchErrors    = metrics.GetOrCreateCounter("stateless_fetch_errors")
+	verifiedHead   = metrics.GetOrCreateGauge("stateless_verified_block")
+
+	errNotFound = errors.New("not found")
+)
+
+// Chain provides the verifier with the blocks to verify and the header chain
+// the consensus engine reads while executing them.
+type Chain interface {
+	HeadNumber(ctx context.Context) (uint64, error)
+	Block(ctx context.Context, number uint64) (*types.Block, error)
+	View(ctx context.Context, f func(consensus.ChainReader) error) error
+}
+
+// WitnessSource provides the witness of the pre-state of a block.
+type WitnessSource interface {
+	Witness(ctx context.Context, number uint64) ([]byte, error)
+}
+
+// DBChain is a Chain backed by a node database, as opened by the rpcdaemon.
+type DBChain struct {
+	db          kv.RoDB
+	config      *chain.Config
+	blockReader services.FullBlockReader
+	logger      log.Logger
+}
+
+func NewDBChain(db kv.RoDB, config *chain.Config, blockReader services.FullBlockReader, logger log.Logger) *DBChain {
+	return &DBChain{db: db, config: config, blockReader: blockReader, logger: logger}
+}
+
+func (c *DBChain) HeadNumber(ctx context.Context) (head uint64, err error) {
+	err = c.db.View(ctx, func(tx kv.Tx) error {
+		head, err = stages.GetStageProgress(tx, stages.Bodies)
+		return err
+	})
+	return head, err
+}
+
+func (c *DBChain) Block(ctx context.Context, number uint64) (block *types.Block, err error) {
+	err = c.db.View(ctx, func(tx kv.Tx) error {
+		block, err = c.blockReader.BlockByNumber(ctx, tx, number)
+		return err
+	})
+	if err == nil && block == nil {
+		err = fmt.Errorf("block %d: %w", number, errNotFound)
+	}
+	return block, err
+}
+
+func (c *DBChain) View(ctx context.Context, f func(consensus.ChainReader) error) error {
+	return c.db.View(ctx, func(tx kv.Tx) error {
+		return f(consensuschain.NewReader(c.config, tx, c.blockReader, c.logger))
+	})
+}
+
+// Verifier follows a chain and validates every block statelessly. Blocks which
+// fail validation are logged and counted, the verifier carries on with the next
+// one: it monitors the chain, it does not follow its own fork.
+type Verifier struct {
+	config       *chain.Config
+	engine       consensus.EngineReader
+	chain        Chain
+	witnesses    WitnessSource
+	pollInterval time.Duration
+	logger       log.Logger
+}
+
+func NewVerifier(config *chain.Config, engine consensus.EngineReader, chain Chain, witnesses WitnessSource, pollInterval time.Duration, logger log.Logger) *Verifier {
+	return &Verifier{
+		config:       config,
+		engine:       engine,
+		chain:        chain,
+		witnesses:    witnesses,
+		pollInterval: pollInterval,
+		logger:       logger,
+	}
+}
+
+// Run verifies the blocks from..to, to == 0 follows the head of the chain
+// until ctx is cancelled, from == 0 starts at the current head.
+func (v *Verifier) Run(ctx context.Context, from, to uint64) error {
+	next := from
+	if next == 0 {
+		head, err := v.chain.HeadNumber(ctx)
+		if err != nil {
+			return err
+		}
+		next = max(head, 1)
+	}
+	v.logger.Info("[stateless] Starting verification", "from", next, "to", to)
+
+	logEvery := time.NewTicker(30 * time.Second)
+	defer logEvery.Stop()
+	var verified, invalid uint64
+	for to == 0 || next <= to {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-logEvery.C:
+			v.logger.Info("[stateless] Progress", "block", next, "verified", verified, "invalid", invalid)
+		default:
+		}
+
+		head, err := v.chain.HeadNumber(ctx)
+		if err != nil || head < next {
+			if err != nil {
+				fetchErrors.Inc()
+				v.logger.Warn("[stateless] Reading chain head failed", "err", err)
+			}
+			if err := v.wait(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+
+		engine, err := v.consensusEngine()
+		if err != nil {
+			v.logger.Warn("[stateless] Consensus engine not ready", "err", err)
+			if err := v.wait(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+
+		block, witness, err := v.fetch(ctx, next)
+		if err != nil {
+			fetchErrors.Inc()
+			v.logger.Warn("[stateless] Fetching block failed", "block", next, "err", err)
+			if err := v.wait(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+
+		res, err := v.verify(ctx, engine, block, witness)
+		if err != nil {
+			invalid++
+			blocksInvalid.Inc()
+			v.logger.Error("[stateless] Block verification failed", "block", next, "hash", block.Hash(), "err", err)
+		} else {
+			verified++
+			blocksVerified.Inc()
+			verifiedHead.SetUint64(next)
+			v.logger.Debug("[stateless] Block verified", "block", next, "hash", res.Hash, "root", res.StateRoot, "took", res.Took)
+		}
+		next++
+	}
+	v.logger.Info("[stateless] Verification done", "verified", verified, "invalid", invalid)
+	if invalid > 0 {
+		return fmt.Errorf("%d blocks failed verification", invalid)
+	}
+	return nil
+}
+
+func (v *Verifier) fetch(ctx context.Context, number uint64) (*types.Block, []byte, error) {
+	block, err := v.chain.Block(ctx, number)
+	if err != nil {
+		return nil, nil, err
+	}
+	witness, err := v.witnesses.Witness(ctx, number)
+	if err != nil {
+		return nil, nil, err
+	}
+	return block, witness, nil
+}
+
+// verify executes block on top of witness and checks the outcome against the
+// block header.
+func (v *Verifier) verify(ctx context.Context, engine consensus.Engine, block *types.Block, witness []byte) (res *Result, err error) {
+	err = v.chain.View(ctx, func(chainReader consensus.ChainReader) error {
+		parent := chainReader.GetHeader(block.ParentHash(), block.NumberU64()-1)
+		if parent == nil {
+			return fmt.Errorf("parent of block %d not found: %x", block.NumberU64(), block.ParentHash())
+		}
+		if res, err = Execute(v.config, engine, chainReader, block, parent, witness, v.logger); err != nil {
+			return err
+		}
+		return res.Verify(block.Header())
+	})
+	return res, err
+}
+
+func (v *Verifier) wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(v.pollInterval):
+		return nil
+	}
+}
+
+// consensusEngine unwraps the engine of the rpcdaemon, which is only known once
+// the remote node is reachable.
+func (v *Verifier) consensusEngine() (consensus.Engine, error) {
+	type lazy interface {
+		HasEngine() bool
+		Engine() consensus.EngineReader
+	}
+
+	switch engine := v.engine.(type) {
+	case lazy:
+		if engine.HasEngine() {
+			if e, ok := engine.Engine().(consensus.Engine); ok {
+				return e, nil
+			}
+		}
+	case consensus.Engine:
+		return engine, nil
+	}
+	return nil, fmt.Errorf("unknown or invalid consensus engine: %T", v.engine)
+}
@@ -158,6 +158,18 @@ func (r *ReusableCaller) DoCallWithNewGas(
 	newGas uint64,
 	engine consensus.EngineReader,
 	overrides *ethapi2.StateOverrides,
+) (*evmtypes.ExecutionResult, error) {
+	return r.doCall(ctx, newGas, engine, overrides, nil)
+}
+
+// doCall executes the message with newGas on top of a fresh state, tracer is
+// kept in the EVM config until the next call with a different one.
+func (r *ReusableCaller) doCall(
+	ctx context.Context,
+	newGas uint64,
+	engine consensus.EngineReader,
+	overrides *ethapi2.StateOverrides,
+	tracer vm.EVMLogger,
 ) (*evmtypes.ExecutionResult, error) {
 	var cancel context.CancelFunc
 	if r.callTimeout > 0 {
@@ -172,13 +184,23 @@ func (r *ReusableCaller) DoCallWithNewGas(
 
 	r.message.ChangeGas(r.gasCap, newGas)
 
-	// reset the EVM so that we can continue to use it with the new context
+	// reset the EVM so that we can continue to use it with the new context,
+	// every call starts from the overridden state rather than from the
+	// leftovers of the previous one
 	txCtx := core.NewEVMTxContext(r.message)
-	if overrides == nil {
-		r.intraBlockState = state.New(r.stateReader)
+	r.intraBlockState = state.New(r.stateReader)
+	if overrides != nil {
+		if err := overrides.Override(r.intraBlockState); err != nil {
+			return nil, err
+		}
 	}
 
-	r.evm.Reset(txCtx, r.intraBlockState)
+	if r.evm.Config().Tracer != tracer {
+		vmConfig := vm.Config{NoBaseFee: true, Tracer: tracer, Debug: tracer != nil}
+		r.evm.ResetBetweenBlocks(r.evm.Context, txCtx, r.intraBlockState, vmConfig, r.evm.ChainRules())
+	} else {
+		r.evm.Reset(txCtx, r.intraBlockState)
+	}
 
 	timedOut := false
 	go func() {
@@ -213,6 +235,7 @@ func NewReusableCaller(
 	headerReader services.HeaderReader,
 	chainConfig *chain.Config,
 	callTimeout time.Duration,
+	blockOverrides func(*evmtypes.BlockContext),
 ) (*ReusableCaller, error) {
 	ibs := state.New(stateReader)
 
@@ -237,6 +260,9 @@ func NewReusableCaller(
 	}
 
 	blockCtx := NewEVMBlockContext(engine, header, blockNrOrHash.RequireCanonical, tx, headerReader, chainConfig)
+	if blockOverrides != nil {
+		blockOverrides(&blockCtx)
+	}
 	txCtx := core.NewEVMTxContext(msg)
 
 	evm := vm.NewEVM(blockCtx, txCtx, ibs, chainConfig, vm.Config{NoBaseFee: true})
@@ -0,0 +1,219 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/params"
+	ethapi2 "github.com/erigontech/erigon/turbo/adapter/ethapi"
+)
+
+// estimateGasErrorRatio is the relative distance between the bounds of the
+// search at which the upper bound is considered good enough to be returned.
+// Chasing the exact limit costs several more executions and wallets pad the
+// estimate anyway.
+const estimateGasErrorRatio = 0.015
+
+// EstimateGas returns the lowest gas limit, up to hi, with which the message
+// executes successfully.
+//
+// The message is first executed with hi gas under a tracer which works out
+// how much gas every call frame needed, including what had to be held back
+// for the 63/64 rule and the refund. The binary search then only covers the
+// window between the gas actually used and that requirement, which in most
+// cases is settled by a single confirming execution.
+func (r *ReusableCaller) EstimateGas(ctx context.Context, hi uint64, engine consensus.EngineReader, overrides *ethapi2.StateOverrides) (uint64, error) {
+	tracer := &gasRequirementTracer{}
+	result, err := r.doCall(ctx, hi, engine, overrides, tracer)
+	if err != nil || result == nil {
+		return 0, err
+	}
+	if result.Failed() {
+		if !errors.Is(result.Err, vm.ErrOutOfGas) {
+			if len(result.Revert()) > 0 {
+				return 0, ethapi2.NewRevertError(result)
+			}
+			return 0, result.Err
+		}
+		// Otherwise, the specified gas cap is too low
+		return 0, fmt.Errorf("gas required exceeds allowance (%d)", hi)
+	}
+	// Assuming a contract can freely run all the instructions, we have
+	// the true amount of gas it wants to consume to execute fully.
+	// We want to ensure that the gas used doesn't fall below this
+	trueGas := result.UsedGas
+	lo := max(trueGas+result.EvmRefund-1, params.TxGas-1)
+
+	// Try the requirement reported by the tracer first, it is exact unless the
+	// execution path depends on the gas left (GAS opcode, SSTORE sentry, etc).
+	if optimistic := tracer.requiredGas(hi); optimistic > lo && optimistic < hi {
+		ok, err := r.executable(ctx, optimistic, trueGas, engine, overrides)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			hi = optimistic
+		} else {
+			lo = optimistic
+		}
+	}
+
+	// Execute the binary search and hone in on an executable gas limit
+	for lo+1 < hi {
+		if float64(hi-lo)/float64(hi) < estimateGasErrorRatio {
+			break
+		}
+		mid := (hi + lo) / 2
+		if mid > lo*2 {
+			// Most transactions don't need anywhere near the block gas limit,
+			// so skew the first probes towards the lower bound.
+			mid = lo * 2
+		}
+		ok, err := r.executable(ctx, mid, trueGas, engine, overrides)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi, nil
+}
+
+// executable reports whether the message succeeds with the given gas limit and
+// takes the same path as with the highest limit, i.e. uses at least trueGas.
+func (r *ReusableCaller) executable(ctx context.Context, gas, trueGas uint64, engine consensus.EngineReader, overrides *ethapi2.StateOverrides) (bool, error) {
+	result, err := r.doCall(ctx, gas, engine, overrides, nil)
+	// If the error is not nil(consensus error), it means the provided message
+	// call or transaction will never be accepted no matter how much gas it is
+	// assigened. Return the error directly, don't struggle any more.
+	if err != nil {
+		if errors.Is(err, core.ErrIntrinsicGas) {
+			return false, nil
+		}
+		return false, err
+	}
+	return !result.Failed() && result.UsedGas >= trueGas, nil
+}
+
+// gasRequirementTracer works out in a single execution the smallest gas limit
+// under which every call frame still gets the gas it consumed. Since EIP-150
+// a frame can forward at most 63/64 of its remaining gas, so a transaction
+// with nested calls needs more than the gas it ends up using.
+type gasRequirementTracer struct {
+	frames   []gasFrame
+	startGas uint64 // gas available to the top-level frame
+	required uint64 // gas the top-level frame needs to execute the same way
+}
+
+type gasFrame struct {
+	gas       uint64 // gas available on entry, stipend included
+	stipend   uint64 // gas granted for free to the callee of a value transfer
+	available uint64 // gas the caller held when it applied the 63/64 rule
+	left      uint64 // gas left once the last opcode was charged
+	required  uint64 // smallest entry gas with which all sub-calls get what they used
+}
+
+// requiredGas returns the gas limit the traced message needs, given the limit
+// it was traced with.
+func (t *gasRequirementTracer) requiredGas(gasLimit uint64) uint64 {
+	if t.startGas > gasLimit || t.required > t.startGas {
+		return gasLimit
+	}
+	return gasLimit - t.startGas + t.required
+}
+
+// onCallExit accounts a successful callee which needed need gas to the frame
+// which called it.
+func (f *gasFrame) onCallExit(callee gasFrame, need uint64) {
+	need -= min(need, callee.stipend)
+	// The caller passes on all but one 64th of the gas it holds, the smallest
+	// amount which leaves the callee `need` is need + (need-1)/63.
+	needed := need
+	if need > 0 {
+		needed += (need - 1) / 63
+	}
+	required := f.gas - min(callee.available, f.gas) + needed
+	f.required = max(f.required, min(required, f.gas))
+}
+
+func (t *gasRequirementTracer) CaptureTxStart(gasLimit uint64) {}
+
+func (t *gasRequirementTracer) CaptureTxEnd(restGas uint64) {}
+
+func (t *gasRequirementTracer) CaptureStart(env *vm.EVM, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.frames = append(t.frames[:0], gasFrame{gas: gas})
+	t.startGas, t.required = gas, 0
+}
+
+func (t *gasRequirementTracer) CaptureEnd(output []byte, usedGas uint64, err error) {
+	if len(t.frames) == 0 {
+		return
+	}
+	t.required = max(t.frames[0].required, usedGas)
+	t.frames = t.frames[:0]
+}
+
+func (t *gasRequirementTracer) CaptureEnter(typ vm.OpCode, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	var stipend uint64
+	if (typ == vm.CALL || typ == vm.CALLCODE) && value != nil && !value.IsZero() {
+		stipend = min(gas, params.CallStipend)
+	}
+	// CALL and friends charge the forwarded gas as part of their cost, CREATE
+	// takes it out of what is left afterwards.
+	available := t.frames[len(t.frames)-1].left
+	if !create {
+		available += gas - stipend
+	}
+	t.frames = append(t.frames, gasFrame{gas: gas, stipend: stipend, available: available})
+}
+
+func (t *gasRequirementTracer) CaptureExit(output []byte, usedGas uint64, err error) {
+	n := len(t.frames)
+	if n < 2 {
+		return
+	}
+	callee := t.frames[n-1]
+	t.frames = t.frames[:n-1]
+	// A failed callee fails with less gas just the same, what it burnt is
+	// already part of the caller's own usage.
+	if err != nil {
+		return
+	}
+	t.frames[n-2].onCallExit(callee, max(callee.required, usedGas))
+}
+
+func (t *gasRequirementTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if len(t.frames) > 0 && cost <= gas {
+		t.frames[len(t.frames)-1].left = gas - cost
+	}
+}
+
+func (t *gasRequirementTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
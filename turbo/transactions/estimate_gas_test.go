@@ -0,0 +1,234 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package transactions
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon-lib/log/v3"
+	libstate "github.com/erigontech/erigon-lib/state"
+
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/params"
+	ethapi2 "github.com/erigontech/erigon/turbo/adapter/ethapi"
+)
+
+const estimateGasCap = 30_000_000
+
+var (
+	estimateSender = libcommon.HexToAddress("0x5e4de7")
+	estimateA      = libcommon.HexToAddress("0xaaaa")
+	estimateB      = libcommon.HexToAddress("0xbbbb")
+	estimateC      = libcommon.HexToAddress("0xcccc")
+	estimateEOA    = libcommon.HexToAddress("0xe0a")
+)
+
+// callOrRevert calls to with all the gas left and reverts if the call fails, so
+// that the whole message fails if the callee doesn't get the gas it needs.
+func callOrRevert(to libcommon.Address, value byte) []byte {
+	code := []byte{
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, // retSize, retOffset, argsSize, argsOffset
+		byte(vm.PUSH1), value, byte(vm.PUSH20),
+	}
+	code = append(code, to[:]...)
+	return append(code,
+		byte(vm.GAS), byte(vm.CALL), byte(vm.ISZERO), byte(vm.PUSH1), 38, byte(vm.JUMPI), byte(vm.STOP),
+		byte(vm.JUMPDEST), byte(vm.PUSH1), 0, byte(vm.DUP1), byte(vm.REVERT), // 38
+	)
+}
+
+var (
+	sstoreOne  = []byte{byte(vm.PUSH1), 1, byte(vm.PUSH1), 0, byte(vm.SSTORE), byte(vm.STOP)}
+	sstoreZero = []byte{byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.SSTORE), byte(vm.STOP)}
+	logZero    = []byte{byte(vm.PUSH1), 0, byte(vm.DUP1), byte(vm.LOG0), byte(vm.STOP)}
+)
+
+func estimateAccount(code []byte, slot0 uint64) ethapi2.Account {
+	c := hexutility.Bytes(code)
+	balance := (*hexutil.Big)(big.NewInt(params.Ether))
+	acc := ethapi2.Account{Code: &c, Balance: &balance}
+	if slot0 != 0 {
+		storage := map[libcommon.Hash]libcommon.Hash{{}: libcommon.BigToHash(new(big.Int).SetUint64(slot0))}
+		acc.State = &storage
+	}
+	return acc
+}
+
+func newEstimateCaller(t *testing.T, to libcommon.Address) *ReusableCaller {
+	t.Helper()
+	db, _ := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	tx, err := db.BeginTemporalRw(context.Background()) //nolint:gocritic
+	require.NoError(t, err)
+	t.Cleanup(tx.Rollback)
+	domains, err := libstate.NewSharedDomains(tx, log.New())
+	require.NoError(t, err)
+	t.Cleanup(domains.Close)
+	stateReader := state.NewReaderV3(domains)
+
+	args := ethapi2.CallArgs{From: &estimateSender, To: &to}
+	msg, err := args.ToMessage(estimateGasCap, nil)
+	require.NoError(t, err)
+	blockCtx := evmtypes.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    consensus.Transfer,
+		GetHash:     func(uint64) libcommon.Hash { return libcommon.Hash{} },
+		BlockNumber: 1,
+		Time:        1,
+		Difficulty:  new(big.Int),
+		GasLimit:    estimateGasCap,
+		BlobBaseFee: new(uint256.Int),
+	}
+	ibs := state.New(stateReader)
+	evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), ibs, params.AllProtocolChanges, vm.Config{NoBaseFee: true})
+	return &ReusableCaller{
+		evm:             evm,
+		intraBlockState: ibs,
+		gasCap:          estimateGasCap,
+		stateReader:     stateReader,
+		message:         msg,
+	}
+}
+
+// TestGasRequirementTracer checks that the gas required by the traced
+// execution is the lowest limit found by a plain binary search.
+func TestGasRequirementTracer(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		to       libcommon.Address
+		accounts ethapi2.StateOverrides
+	}{
+		{
+			name: "transfer",
+			to:   estimateEOA,
+		},
+		{
+			name:     "sstore",
+			to:       estimateA,
+			accounts: ethapi2.StateOverrides{estimateA: estimateAccount(sstoreOne, 0)},
+		},
+		{
+			name:     "sstore refund",
+			to:       estimateA,
+			accounts: ethapi2.StateOverrides{estimateA: estimateAccount(sstoreZero, 1)},
+		},
+		{
+			name: "call",
+			to:   estimateA,
+			accounts: ethapi2.StateOverrides{
+				estimateA: estimateAccount(callOrRevert(estimateB, 0), 0),
+				estimateB: estimateAccount(sstoreOne, 0),
+			},
+		},
+		{
+			name: "nested calls",
+			to:   estimateA,
+			accounts: ethapi2.StateOverrides{
+				estimateA: estimateAccount(callOrRevert(estimateB, 0), 0),
+				estimateB: estimateAccount(callOrRevert(estimateC, 0), 0),
+				estimateC: estimateAccount(sstoreOne, 0),
+			},
+		},
+		{
+			name: "nested calls with refund",
+			to:   estimateA,
+			accounts: ethapi2.StateOverrides{
+				estimateA: estimateAccount(callOrRevert(estimateB, 0), 0),
+				estimateB: estimateAccount(callOrRevert(estimateC, 0), 0),
+				estimateC: estimateAccount(sstoreZero, 1),
+			},
+		},
+		{
+			name:     "value transfer to eoa",
+			to:       estimateA,
+			accounts: ethapi2.StateOverrides{estimateA: estimateAccount(callOrRevert(estimateEOA, 1), 0)},
+		},
+		{
+			name: "value transfer within the stipend",
+			to:   estimateA,
+			accounts: ethapi2.StateOverrides{
+				estimateA: estimateAccount(callOrRevert(estimateB, 1), 0),
+				estimateB: estimateAccount(logZero, 0),
+			},
+		},
+		{
+			name: "value transfer beyond the stipend",
+			to:   estimateA,
+			accounts: ethapi2.StateOverrides{
+				estimateA: estimateAccount(callOrRevert(estimateB, 1), 0),
+				estimateB: estimateAccount(sstoreOne, 0),
+			},
+		},
+		{
+			name: "nested value transfers",
+			to:   estimateA,
+			accounts: ethapi2.StateOverrides{
+				estimateA: estimateAccount(callOrRevert(estimateB, 1), 0),
+				estimateB: estimateAccount(callOrRevert(estimateC, 1), 0),
+				estimateC: estimateAccount(sstoreZero, 1),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require := require.New(t)
+			ctx := context.Background()
+			r := newEstimateCaller(t, tt.to)
+			overrides := &tt.accounts
+			const hi = 1_000_000
+
+			tracer := &gasRequirementTracer{}
+			result, err := r.doCall(ctx, hi, nil, overrides, tracer)
+			require.NoError(err)
+			require.NoError(result.Err)
+
+			lo, want := uint64(params.TxGas-1), uint64(hi)
+			for lo+1 < want {
+				mid := (lo + want) / 2
+				result, err := r.doCall(ctx, mid, nil, overrides, nil)
+				if err != nil && !errors.Is(err, core.ErrIntrinsicGas) {
+					require.NoError(err)
+				}
+				if err == nil && !result.Failed() {
+					want = mid
+				} else {
+					lo = mid
+				}
+			}
+			require.Equal(want, tracer.requiredGas(hi))
+
+			estimate, err := r.EstimateGas(ctx, hi, nil, overrides)
+			require.NoError(err)
+			require.Equal(want, estimate)
+		})
+	}
+}
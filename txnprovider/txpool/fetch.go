@@ -51,6 +51,7 @@ type Fetch struct {
 	sentryClients            []sentry.SentryClient // sentry clients that will be used for accessing the network
 	stateChangesParseCtxLock sync.Mutex
 	pooledTxnsParseCtxLock   sync.Mutex
+	propagation              *PropagationTracker // records announcement and receipt times, may be nil
 	logger                   log.Logger
 }
 
@@ -81,6 +82,7 @@ func NewFetch(
 		stateChangesParseCtx: NewTxnParseContext(chainID).ChainIDRequired(), //TODO: change ctx if rules changed
 		pooledTxnsParseCtx:   NewTxnParseContext(chainID).ChainIDRequired(),
 		wg:                   options.p2pFetcherWg,
+		propagation:          options.propagationTracker,
 		logger:               logger,
 	}
 	f.pooledTxnsParseCtx.ValidateRLP(f.pool.ValidateSerializedTxn)
@@ -195,6 +197,15 @@ func (f *Fetch) receiveMessage(ctx context.Context, sentryClient sentry.SentryCl
 				continue
 			}
 			f.logger.Debug("[txpool.fetch] Handling incoming message", "reqID", req.Id.String(), "err", err)
+			if errors.Is(err, ErrParseTxn) {
+				// Invalid transactions lower the peer reputation, sentry kicks the peer once it drops too low
+				if _, err := sentryClient.PenalizePeer(streamCtx, &sentry.PenalizePeerRequest{
+					PeerId:  req.PeerId,
+					Penalty: sentry.PenaltyKind_InvalidTx,
+				}); err != nil {
+					f.logger.Debug("[txpool.fetch] Could not send penalty", "err", err)
+				}
+			}
 		}
 		if f.wg != nil {
 			f.wg.Done()
@@ -234,6 +245,7 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentry.InboundMes
 		if err != nil {
 			return err
 		}
+		f.propagation.OnAnnounced(unknownHashes, req.PeerId)
 		if len(unknownHashes) > 0 {
 			var encodedRequest []byte
 			var messageID sentry.MessageId
@@ -258,6 +270,7 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentry.InboundMes
 			return err
 		}
 
+		f.propagation.OnAnnounced(unknownHashes, req.PeerId)
 		if len(unknownHashes) > 0 {
 			var encodedRequest []byte
 			var messageID sentry.MessageId
@@ -372,6 +385,7 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentry.InboundMes
 		if len(txns.Txns) == 0 {
 			return nil
 		}
+		f.propagation.OnReceived(txns, req.PeerId)
 		f.pool.AddRemoteTxns(ctx, txns)
 	default:
 		defer f.logger.Trace("[txpool] dropped p2p message", "id", req.Id)
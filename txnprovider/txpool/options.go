@@ -48,11 +48,20 @@ func WithP2PSenderWg(wg *sync.WaitGroup) Option {
 	}
 }
 
+// WithPropagationTracker shares the tracker of transaction propagation timings,
+// by default the pool creates its own one.
+func WithPropagationTracker(tracker *PropagationTracker) Option {
+	return func(o *options) {
+		o.propagationTracker = tracker
+	}
+}
+
 type options struct {
-	feeCalculator     FeeCalculator
-	poolDBInitializer poolDBInitializer
-	p2pSenderWg       *sync.WaitGroup
-	p2pFetcherWg      *sync.WaitGroup
+	feeCalculator      FeeCalculator
+	poolDBInitializer  poolDBInitializer
+	p2pSenderWg        *sync.WaitGroup
+	p2pFetcherWg       *sync.WaitGroup
+	propagationTracker *PropagationTracker
 }
 
 func applyOpts(opts ...Option) options {
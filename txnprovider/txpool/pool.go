@@ -150,6 +150,7 @@ type TxPool struct {
 	feeCalculator           FeeCalculator
 	p2pFetcher              *Fetch
 	p2pSender               *Send
+	propagation             *PropagationTracker
 	newSlotsStreams         *NewSlotsStreams
 	builderNotifyNewTxns    func()
 	logger                  log.Logger
@@ -278,6 +279,12 @@ func New(
 		res.pragueTime = &pragueTimeU64
 	}
 
+	res.propagation = options.propagationTracker
+	if res.propagation == nil {
+		res.propagation = NewPropagationTracker()
+		opts = append(opts, WithPropagationTracker(res.propagation))
+	}
+
 	res.p2pFetcher = NewFetch(ctx, sentryClients, res, stateChangesClient, poolDB, chainID, logger, opts...)
 	res.p2pSender = NewSend(ctx, sentryClients, logger, opts...)
 	return res, nil
@@ -631,6 +638,11 @@ func (p *TxPool) IdHashKnown(tx kv.Tx, hash []byte) (bool, error) {
 	return p.idHashKnown(tx, hash, hashS)
 }
 
+// PropagationStats returns the propagation timings of the tracked transactions and of the given ones.
+func (p *TxPool) PropagationStats(hashes []common.Hash) PropagationStats {
+	return p.propagation.Stats(hashes)
+}
+
 func (p *TxPool) FilterKnownIdHashes(tx kv.Tx, hashes Hashes) (unknownHashes Hashes, err error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -1270,6 +1282,7 @@ func (p *TxPool) AddLocalTxns(ctx context.Context, newTxns TxnSlots) ([]txpoolcf
 				p.logger.Info(fmt.Sprintf("TX TRACING: AddLocalTxns promotes idHash=%x, senderId=%d", txn.IDHash, txn.SenderID))
 			}
 			p.promoted.Append(txn.Type, txn.Size, txn.IDHash[:])
+			p.propagation.OnLocal(txn.IDHash[:])
 		}
 	}
 	if p.promoted.Len() > 0 {
@@ -1974,6 +1987,7 @@ func (p *TxPool) Run(ctx context.Context) error {
 				var remoteTxnHashes Hashes
 				var remoteTxnRlps [][]byte
 				var broadcastHashes Hashes
+				var remoteBroadcastHashes Hashes
 				slotsRlp := make([][]byte, 0, announcements.Len())
 
 				if err := p.poolDB.View(ctx, func(tx kv.Tx) error {
@@ -2012,6 +2026,7 @@ func (p *TxPool) Run(ctx context.Context) error {
 							// "Nodes MUST NOT automatically broadcast blob transactions to their peers" - EIP-4844
 							if t != BlobTxnType && len(slotRlp) < txMaxBroadcastSize {
 								remoteTxnRlps = append(remoteTxnRlps, slotRlp)
+								remoteBroadcastHashes = append(remoteBroadcastHashes, hash...)
 							}
 						}
 					}
@@ -2035,11 +2050,15 @@ func (p *TxPool) Run(ctx context.Context) error {
 					hash := localTxnHashes.At(i)
 					p.logger.Trace("Local txn announced", "txHash", hex.EncodeToString(hash), "to peer", hashSentTo[i], "baseFee", p.pendingBaseFee.Load())
 				}
+				p.propagation.OnPropagated(broadcastHashes, txnSentTo)
+				p.propagation.OnPropagated(localTxnHashes, hashSentTo)
 
 				// broadcast remote transactions
 				const remoteTxnsBroadcastMaxPeers uint64 = 3
-				p.p2pSender.BroadcastPooledTxns(remoteTxnRlps, remoteTxnsBroadcastMaxPeers)
-				p.p2pSender.AnnouncePooledTxns(remoteTxnTypes, remoteTxnSizes, remoteTxnHashes, remoteTxnsBroadcastMaxPeers*2)
+				txnSentTo = p.p2pSender.BroadcastPooledTxns(remoteTxnRlps, remoteTxnsBroadcastMaxPeers)
+				hashSentTo = p.p2pSender.AnnouncePooledTxns(remoteTxnTypes, remoteTxnSizes, remoteTxnHashes, remoteTxnsBroadcastMaxPeers*2)
+				p.propagation.OnPropagated(remoteBroadcastHashes, txnSentTo)
+				p.propagation.OnPropagated(remoteTxnHashes, hashSentTo)
 			}()
 		case <-syncToNewPeersEvery.C: // new peer
 			newPeers := p.recentlyConnectedPeers.GetAndClean()
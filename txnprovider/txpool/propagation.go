@@ -0,0 +1,265 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+// propagationTrackerSize is the number of most recently seen transactions whose propagation is tracked.
+const propagationTrackerSize = 100_000
+
+// maxPropagationSources is the number of top source peers returned by Stats.
+const maxPropagationSources = 16
+
+var (
+	propagationBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+	announceToReceiveHist  = metrics.NewHistogram(`txpool_propagation{stage="announce_to_receive"}`, propagationBuckets)
+	receiveToPropagateHist = metrics.NewHistogram(`txpool_propagation{stage="receive_to_propagate"}`, propagationBuckets)
+	localToPropagateHist   = metrics.NewHistogram(`txpool_propagation{stage="local_to_propagate"}`, propagationBuckets)
+)
+
+// TxnPropagation is the propagation history of a transaction. Zero times mean that the event didn't happen.
+type TxnPropagation struct {
+	Hash         common.Hash
+	Local        bool
+	Source       [64]byte // first peer which announced or sent the transaction, zero for local ones
+	FirstSeen    time.Time
+	Announced    time.Time // first announcement by a peer
+	Received     time.Time // received from a peer or submitted locally
+	Propagated   time.Time // first sent or announced to peers
+	PropagatedTo uint64    // number of peers the transaction was sent or announced to
+}
+
+// PropagationLatency summarizes the latencies of a propagation stage.
+type PropagationLatency struct {
+	Count uint64
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+// PropagationSource is a peer with the number of tracked transactions first seen from it.
+type PropagationSource struct {
+	PeerID [64]byte
+	Count  uint64
+}
+
+type PropagationStats struct {
+	Tracked            uint64
+	Local              uint64
+	Remote             uint64
+	AnnounceToReceive  PropagationLatency
+	ReceiveToPropagate PropagationLatency
+	LocalToPropagate   PropagationLatency
+	Sources            []PropagationSource // most frequent first
+	Txns               []TxnPropagation    // requested transactions which are tracked
+}
+
+// PropagationTracker records when transactions were first seen, where they came from
+// and how long it took to pass them on. It keeps a bounded number of the most recent
+// transactions. A nil *PropagationTracker discards everything.
+type PropagationTracker struct {
+	lock sync.Mutex
+	txns *simplelru.LRU[common.Hash, *TxnPropagation]
+	now  func() time.Time
+}
+
+func NewPropagationTracker() *PropagationTracker {
+	txns, err := simplelru.NewLRU[common.Hash, *TxnPropagation](propagationTrackerSize, nil)
+	if err != nil {
+		panic(err)
+	}
+	return &PropagationTracker{txns: txns, now: time.Now}
+}
+
+// get must be called with t.lock held.
+func (t *PropagationTracker) get(hash []byte, now time.Time, peerID PeerID) *TxnPropagation {
+	h := common.BytesToHash(hash)
+	if txn, ok := t.txns.Get(h); ok {
+		return txn
+	}
+	txn := &TxnPropagation{Hash: h, FirstSeen: now}
+	if peerID != nil {
+		txn.Source = gointerfaces.ConvertH512ToHash(peerID)
+	}
+	t.txns.Add(h, txn)
+	return txn
+}
+
+// OnAnnounced records hashes announced by a peer.
+func (t *PropagationTracker) OnAnnounced(hashes Hashes, peerID PeerID) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.now()
+	for i := 0; i < hashes.Len(); i++ {
+		txn := t.get(hashes.At(i), now, peerID)
+		if txn.Announced.IsZero() && txn.Received.IsZero() {
+			txn.Announced = now
+		}
+	}
+}
+
+// OnReceived records transactions received from a peer.
+func (t *PropagationTracker) OnReceived(txns TxnSlots, peerID PeerID) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.now()
+	for _, slot := range txns.Txns {
+		txn := t.get(slot.IDHash[:], now, peerID)
+		if !txn.Received.IsZero() {
+			continue
+		}
+		txn.Received = now
+		if !txn.Announced.IsZero() {
+			announceToReceiveHist.Observe(now.Sub(txn.Announced).Seconds())
+		}
+	}
+}
+
+// OnLocal records a locally submitted transaction.
+func (t *PropagationTracker) OnLocal(hash []byte) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.now()
+	txn := t.get(hash, now, nil)
+	txn.Local = true
+	if txn.Received.IsZero() {
+		txn.Received = now
+	}
+}
+
+// OnPropagated records transactions sent or announced to sentTo[i] peers.
+func (t *PropagationTracker) OnPropagated(hashes Hashes, sentTo []int) {
+	if t == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.now()
+	for i := 0; i < hashes.Len() && i < len(sentTo); i++ {
+		if sentTo[i] == 0 {
+			continue
+		}
+		txn, ok := t.txns.Peek(common.BytesToHash(hashes.At(i)))
+		if !ok {
+			continue
+		}
+		txn.PropagatedTo += uint64(sentTo[i])
+		if !txn.Propagated.IsZero() || txn.Received.IsZero() {
+			continue
+		}
+		txn.Propagated = now
+		if txn.Local {
+			localToPropagateHist.Observe(now.Sub(txn.Received).Seconds())
+		} else {
+			receiveToPropagateHist.Observe(now.Sub(txn.Received).Seconds())
+		}
+	}
+}
+
+func propagationLatency(latencies []time.Duration) PropagationLatency {
+	if len(latencies) == 0 {
+		return PropagationLatency{}
+	}
+	slices.Sort(latencies)
+	quantile := func(q float64) time.Duration {
+		return latencies[int(q*float64(len(latencies)-1))]
+	}
+	return PropagationLatency{
+		Count: uint64(len(latencies)),
+		P50:   quantile(0.5),
+		P90:   quantile(0.9),
+		P99:   quantile(0.99),
+		Max:   latencies[len(latencies)-1],
+	}
+}
+
+// Stats summarizes the tracked transactions and returns the history of the given ones.
+func (t *PropagationTracker) Stats(hashes []common.Hash) PropagationStats {
+	var stats PropagationStats
+	if t == nil {
+		return stats
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var announceToReceive, receiveToPropagate, localToPropagate []time.Duration
+	sources := make(map[[64]byte]uint64)
+	for _, h := range t.txns.Keys() {
+		txn, _ := t.txns.Peek(h)
+		stats.Tracked++
+		if txn.Local {
+			stats.Local++
+		} else {
+			stats.Remote++
+			sources[txn.Source]++
+		}
+		if !txn.Announced.IsZero() && !txn.Received.IsZero() {
+			announceToReceive = append(announceToReceive, txn.Received.Sub(txn.Announced))
+		}
+		if !txn.Propagated.IsZero() {
+			if txn.Local {
+				localToPropagate = append(localToPropagate, txn.Propagated.Sub(txn.Received))
+			} else {
+				receiveToPropagate = append(receiveToPropagate, txn.Propagated.Sub(txn.Received))
+			}
+		}
+	}
+	stats.AnnounceToReceive = propagationLatency(announceToReceive)
+	stats.ReceiveToPropagate = propagationLatency(receiveToPropagate)
+	stats.LocalToPropagate = propagationLatency(localToPropagate)
+
+	for peerID, count := range sources {
+		stats.Sources = append(stats.Sources, PropagationSource{PeerID: peerID, Count: count})
+	}
+	slices.SortFunc(stats.Sources, func(a, b PropagationSource) int {
+		if a.Count != b.Count {
+			return cmp.Compare(b.Count, a.Count)
+		}
+		return bytes.Compare(a.PeerID[:], b.PeerID[:])
+	})
+	stats.Sources = stats.Sources[:min(len(stats.Sources), maxPropagationSources)]
+
+	for _, h := range hashes {
+		if txn, ok := t.txns.Peek(h); ok {
+			stats.Txns = append(stats.Txns, *txn)
+		}
+	}
+	return stats
+}
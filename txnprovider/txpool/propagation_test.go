@@ -0,0 +1,107 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+)
+
+func TestPropagationTracker(t *testing.T) {
+	require := require.New(t)
+
+	tracker := NewPropagationTracker()
+	start := time.Unix(1_700_000_000, 0)
+	now := start
+	tracker.now = func() time.Time { return now }
+
+	peer1 := [64]byte{1}
+	peer2 := [64]byte{2}
+	h1, h2, h3 := common.Hash{1}, common.Hash{2}, common.Hash{3}
+
+	// h1 and h2 are announced by peer1, h2 is announced again by peer2
+	var announced Hashes
+	announced = append(announced, h1[:]...)
+	announced = append(announced, h2[:]...)
+	tracker.OnAnnounced(announced, gointerfaces.ConvertHashToH512(peer1))
+	now = now.Add(100 * time.Millisecond)
+	tracker.OnAnnounced(h2[:], gointerfaces.ConvertHashToH512(peer2))
+
+	// h1 and h2 arrive 200ms after the first announcement
+	now = now.Add(100 * time.Millisecond)
+	var received TxnSlots
+	received.Resize(2)
+	received.Txns[0] = &TxnSlot{IDHash: h1}
+	received.Txns[1] = &TxnSlot{IDHash: h2}
+	tracker.OnReceived(received, gointerfaces.ConvertHashToH512(peer2))
+
+	// h3 is submitted locally
+	tracker.OnLocal(h3[:])
+
+	// all are propagated 50ms later, h2 to nobody
+	now = now.Add(50 * time.Millisecond)
+	var propagated Hashes
+	propagated = append(propagated, h1[:]...)
+	propagated = append(propagated, h2[:]...)
+	propagated = append(propagated, h3[:]...)
+	tracker.OnPropagated(propagated, []int{3, 0, 10})
+	now = now.Add(50 * time.Millisecond)
+	tracker.OnPropagated(h1[:], []int{2})
+
+	stats := tracker.Stats([]common.Hash{h1, h3, {4}})
+	require.Equal(uint64(3), stats.Tracked)
+	require.Equal(uint64(1), stats.Local)
+	require.Equal(uint64(2), stats.Remote)
+	require.Equal(PropagationLatency{Count: 2, P50: 200 * time.Millisecond, P90: 200 * time.Millisecond, P99: 200 * time.Millisecond, Max: 200 * time.Millisecond}, stats.AnnounceToReceive)
+	require.Equal(uint64(1), stats.ReceiveToPropagate.Count)
+	require.Equal(50*time.Millisecond, stats.ReceiveToPropagate.Max)
+	require.Equal(uint64(1), stats.LocalToPropagate.Count)
+	require.Equal(50*time.Millisecond, stats.LocalToPropagate.Max)
+	require.Equal([]PropagationSource{{PeerID: peer1, Count: 2}}, stats.Sources)
+
+	require.Len(stats.Txns, 2)
+	require.Equal(TxnPropagation{
+		Hash:         h1,
+		Source:       peer1,
+		FirstSeen:    start,
+		Announced:    start,
+		Received:     start.Add(200 * time.Millisecond),
+		Propagated:   start.Add(250 * time.Millisecond),
+		PropagatedTo: 5,
+	}, stats.Txns[0])
+	require.Equal(TxnPropagation{
+		Hash:         h3,
+		Local:        true,
+		FirstSeen:    start.Add(200 * time.Millisecond),
+		Received:     start.Add(200 * time.Millisecond),
+		Propagated:   start.Add(250 * time.Millisecond),
+		PropagatedTo: 10,
+	}, stats.Txns[1])
+}
+
+func TestPropagationTrackerNil(t *testing.T) {
+	var tracker *PropagationTracker
+	tracker.OnAnnounced(make(Hashes, 32), nil)
+	tracker.OnLocal(make([]byte, 32))
+	tracker.OnPropagated(make(Hashes, 32), []int{1})
+	require.Equal(t, PropagationStats{}, tracker.Stats([]common.Hash{{}}))
+}
@@ -60,6 +60,7 @@ type txPool interface {
 	IdHashKnown(tx kv.Tx, hash []byte) (bool, error)
 	NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool)
 	GetBlobs(blobhashes []common.Hash) (blobs [][]byte, proofs [][]byte)
+	PropagationStats(hashes []common.Hash) PropagationStats
 }
 
 var _ txpool_proto.TxpoolServer = (*GrpcServer)(nil)   // compile-time interface check
@@ -98,6 +99,9 @@ func (*GrpcDisabled) Status(ctx context.Context, request *txpool_proto.StatusReq
 func (*GrpcDisabled) Nonce(ctx context.Context, request *txpool_proto.NonceRequest) (*txpool_proto.NonceReply, error) {
 	return nil, ErrPoolDisabled
 }
+func (*GrpcDisabled) PropagationStats(ctx context.Context, request *txpool_proto.PropagationStatsRequest) (*txpool_proto.PropagationStatsReply, error) {
+	return nil, ErrPoolDisabled
+}
 
 type GrpcServer struct {
 	txpool_proto.UnimplementedTxpoolServer
@@ -318,6 +322,61 @@ func (s *GrpcServer) Nonce(ctx context.Context, in *txpool_proto.NonceRequest) (
 	}, nil
 }
 
+func (s *GrpcServer) PropagationStats(_ context.Context, in *txpool_proto.PropagationStatsRequest) (*txpool_proto.PropagationStatsReply, error) {
+	hashes := make([]common.Hash, len(in.Hashes))
+	for i := range in.Hashes {
+		hashes[i] = gointerfaces.ConvertH256ToHash(in.Hashes[i])
+	}
+	stats := s.txPool.PropagationStats(hashes)
+
+	reply := &txpool_proto.PropagationStatsReply{
+		Tracked:            stats.Tracked,
+		Local:              stats.Local,
+		Remote:             stats.Remote,
+		AnnounceToReceive:  mapPropagationLatencyToProto(stats.AnnounceToReceive),
+		ReceiveToPropagate: mapPropagationLatencyToProto(stats.ReceiveToPropagate),
+		LocalToPropagate:   mapPropagationLatencyToProto(stats.LocalToPropagate),
+		Sources:            make([]*txpool_proto.PropagationSource, len(stats.Sources)),
+		Txns:               make([]*txpool_proto.TxnPropagation, len(stats.Txns)),
+	}
+	for i, source := range stats.Sources {
+		reply.Sources[i] = &txpool_proto.PropagationSource{
+			PeerId: gointerfaces.ConvertHashToH512(source.PeerID),
+			Count:  source.Count,
+		}
+	}
+	for i, txn := range stats.Txns {
+		reply.Txns[i] = &txpool_proto.TxnPropagation{
+			Hash:         gointerfaces.ConvertHashToH256(txn.Hash),
+			Local:        txn.Local,
+			Source:       gointerfaces.ConvertHashToH512(txn.Source),
+			FirstSeen:    unixNanoOrZero(txn.FirstSeen),
+			Announced:    unixNanoOrZero(txn.Announced),
+			Received:     unixNanoOrZero(txn.Received),
+			Propagated:   unixNanoOrZero(txn.Propagated),
+			PropagatedTo: txn.PropagatedTo,
+		}
+	}
+	return reply, nil
+}
+
+func mapPropagationLatencyToProto(latency PropagationLatency) *txpool_proto.PropagationLatency {
+	return &txpool_proto.PropagationLatency{
+		Count: latency.Count,
+		P50Us: uint64(latency.P50.Microseconds()),
+		P90Us: uint64(latency.P90.Microseconds()),
+		P99Us: uint64(latency.P99.Microseconds()),
+		MaxUs: uint64(latency.Max.Microseconds()),
+	}
+}
+
+func unixNanoOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
 // NewSlotsStreams - it's safe to use this class as non-pointer
 type NewSlotsStreams struct {
 	chans map[uint]txpool_proto.Txpool_OnAddServer